@@ -0,0 +1,328 @@
+package apis
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/client"
+)
+
+// batchCall is a line of the file given to `api call --from-file`.
+type batchCall struct {
+	// Service is the name of the service, as in `taskcluster api <service>`.
+	Service string `json:"service"`
+	// Method is the name of the method, or one of its former names.
+	Method string `json:"method"`
+	// Args are the URL arguments, by name or in order.
+	Args json.RawMessage `json:"args"`
+	// Query are the query-string parameters.
+	Query map[string]string `json:"query"`
+	// Payload is the request payload, for the methods which take one.
+	Payload json.RawMessage `json:"payload"`
+}
+
+// batchResult is the outcome of a batchCall, as printed.
+type batchResult struct {
+	Line       int             `json:"line"`
+	Service    string          `json:"service,omitempty"`
+	Method     string          `json:"method,omitempty"`
+	Status     string          `json:"status"`
+	Result     json.RawMessage `json:"result,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	StatusCode int             `json:"statusCode,omitempty"`
+	Code       string          `json:"code,omitempty"`
+	RequestID  string          `json:"requestId,omitempty"`
+}
+
+func init() {
+	Command.AddCommand(newBatchCommand())
+}
+
+// newBatchCommand returns the `api call` command, with its flags.
+func newBatchCommand() *cobra.Command {
+	batchCmd := &cobra.Command{
+		Use:   "call --from-file <file>",
+		Short: "Call many API methods, listed in a file, concurrently.",
+		Long: `Call many API methods, listed in a file, concurrently.
+
+Each line of the file, or of stdin with --from-file -, is a JSON object giving
+a call: the "service" and "method", as in "taskcluster api <service>
+<method>", the URL "args", as an object or a list in the order of the
+method's arguments, and the "query" and "payload", if any.  For example:
+
+  {"service": "queue", "method": "task", "args": {"taskId": "fN1SbArXTPSVFNUvaOlinQ"}}
+  {"service": "auth", "method": "expandScopes", "payload": {"scopes": ["assume:x"]}}
+
+The calls are independent, and up to --concurrency run at a time.  Their
+results are printed in the order of the lines, as a JSON list in which each
+result has the line of the call, and its "status": "succeeded" with the
+response as its "result", or "failed" with the "error" (and the statusCode,
+code and requestId of errors from the services).  The exit status is not 0 if
+any call failed.  Methods transferring the content of artifacts cannot be
+called in batches.`,
+		Args: cobra.NoArgs,
+		RunE: runBatch,
+	}
+	batchCmd.Flags().String("from-file", "", "File listing the calls, one JSON object per line, or - for stdin")
+	err := batchCmd.MarkFlagFilename("from-file", "jsonl", "json")
+	if err != nil {
+		panic(err)
+	}
+	batchCmd.Flags().IntP("concurrency", "j", 10, "Maximum number of calls to run at a time")
+	batchCmd.Flags().Bool("no-validate", false, "Send the payloads without validating them against the methods' schemas")
+	return batchCmd
+}
+
+func runBatch(cmd *cobra.Command, args []string) error {
+	source, _ := cmd.Flags().GetString("from-file")
+	if source == "" {
+		return errors.New("call requires --from-file")
+	}
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	if concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d, at least one call must run at a time", concurrency)
+	}
+	noValidate, _ := cmd.Flags().GetBool("no-validate")
+
+	var input io.Reader = cmd.InOrStdin()
+	if source != "-" {
+		f, err := os.Open(source)
+		if err != nil {
+			return fmt.Errorf("Failed to open input file, error: %s", err)
+		}
+		defer f.Close()
+		input = f
+	}
+	lines, err := readBatchLines(input)
+	if err != nil {
+		return fmt.Errorf("Failed to read input file, error: %s", err)
+	}
+
+	var output = cmd.OutOrStdout()
+	if flag := cmd.Flags().Lookup("output"); flag != nil && flag.Changed {
+		f, err := os.Create(flag.Value.String())
+		if err != nil {
+			return fmt.Errorf("Failed to open output file, error: %s", err)
+		}
+		defer f.Close()
+		output = f
+	}
+	format := "json"
+	if flag := cmd.Flags().Lookup("format"); flag != nil && flag.Value.String() != "" {
+		format = flag.Value.String()
+	}
+	if !isOutputFormat(format) {
+		return fmt.Errorf("unsupported output format '%s'", format)
+	}
+
+	results := runBatchCalls(lines, concurrency, !noValidate)
+	data, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	if err := writeResult(output, format, data); err != nil {
+		return fmt.Errorf("Failed to print results: %s", err)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Status != "succeeded" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d calls failed", failed, len(results))
+	}
+	return nil
+}
+
+// batchLine is a non-empty line of the input, with its number.
+type batchLine struct {
+	number int
+	text   string
+}
+
+// readBatchLines reads the lines of the input which are not blank.
+func readBatchLines(input io.Reader) ([]batchLine, error) {
+	var lines []batchLine
+	scanner := bufio.NewScanner(input)
+	// payloads, such as task definitions, can be long
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for number := 1; scanner.Scan(); number++ {
+		if text := strings.TrimSpace(scanner.Text()); text != "" {
+			lines = append(lines, batchLine{number: number, text: text})
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// runBatchCalls makes the calls of the given lines, running at most
+// concurrency at a time, and returns their results in the same order.
+func runBatchCalls(lines []batchLine, concurrency int, validate bool) []batchResult {
+	results := make([]batchResult, len(lines))
+	var wg sync.WaitGroup
+	// a slot is taken by each running call
+	slots := make(chan struct{}, concurrency)
+
+	for i, line := range lines {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, line batchLine) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			results[i] = runBatchCall(line, validate)
+		}(i, line)
+	}
+	wg.Wait()
+	return results
+}
+
+// runBatchCall makes the call of a line, and returns its result.
+func runBatchCall(line batchLine, validate bool) batchResult {
+	result := batchResult{Line: line.number}
+	fail := func(err error) batchResult {
+		result.Status = "failed"
+		result.Error = err.Error()
+		var apiErr *client.APICallError
+		if errors.As(err, &apiErr) {
+			result.StatusCode = apiErr.StatusCode
+			result.Code = apiErr.Code
+			result.RequestID = apiErr.RequestID
+		}
+		return result
+	}
+
+	var c batchCall
+	decoder := json.NewDecoder(strings.NewReader(line.text))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&c); err != nil {
+		return fail(fmt.Errorf("invalid call: %s", err))
+	}
+	result.Service, result.Method = c.Service, c.Method
+
+	service, entry, err := lookupCommand(c.Service, c.Method)
+	if err != nil {
+		return fail(err)
+	}
+	if entry.Download || entry.Upload {
+		return fail(fmt.Errorf("%s transfers the content of an artifact, which cannot be called in a batch", entry.Name))
+	}
+	args, err := batchArgs(entry, c.Args)
+	if err != nil {
+		return fail(err)
+	}
+	for name := range c.Query {
+		if !contains(entry.Query, name) {
+			return fail(fmt.Errorf("%s has no query-string parameter '%s'", entry.Name, name))
+		}
+	}
+
+	var payload []byte
+	switch {
+	case entry.Input == "" && len(c.Payload) > 0:
+		return fail(fmt.Errorf("%s takes no payload", entry.Name))
+	case entry.Input != "" && len(c.Payload) == 0:
+		return fail(fmt.Errorf("%s requires a payload", entry.Name))
+	case entry.Input != "":
+		payload = c.Payload
+		if validate {
+			if err := validateBody(service.ServiceName, entry.Input, payload); err != nil {
+				return fail(err)
+			}
+		}
+	}
+
+	body, err := execute(context.Background(), nil, service.ServiceName, service.APIVersion, entry, args, c.Query, bytes.NewReader(payload))
+	if err != nil {
+		return fail(err)
+	}
+	result.Status = "succeeded"
+	result.Result = json.RawMessage(body)
+	if !json.Valid(body) {
+		// keep the output valid JSON, whatever the response
+		quoted, _ := json.Marshal(string(body))
+		result.Result = quoted
+	}
+	return result
+}
+
+// lookupCommand finds the service and entry called by `taskcluster api
+// <service> <method>`, where method may also be a former name of the entry.
+func lookupCommand(serviceName, method string) (definitions.Service, *definitions.Entry, error) {
+	for name, service := range services {
+		if strings.ToLower(name[0:1])+name[1:] != serviceName {
+			continue
+		}
+		for i, entry := range service.Entries {
+			if entry.Name == method || contains(entry.Aliases, method) {
+				return service, &service.Entries[i], nil
+			}
+		}
+		return definitions.Service{}, nil, fmt.Errorf("unknown method '%s' of service '%s'", method, serviceName)
+	}
+	return definitions.Service{}, nil, fmt.Errorf("unknown service '%s'", serviceName)
+}
+
+// batchArgs returns the URL arguments of a call of entry, given as an object
+// of them by name, or as a list in the order of the entry's arguments.  Numbers
+// are accepted for arguments such as runId.
+func batchArgs(entry *definitions.Entry, raw json.RawMessage) (map[string]string, error) {
+	args := make(map[string]string, len(entry.Args))
+	if len(raw) == 0 || string(raw) == "null" {
+		raw = nil
+	}
+
+	var values map[string]interface{}
+	var list []interface{}
+	if err := json.Unmarshal(raw, &list); raw != nil && err == nil {
+		if len(list) != len(entry.Args) {
+			return nil, fmt.Errorf("%s takes %d arguments (%s), not %d", entry.Name, len(entry.Args), strings.Join(entry.Args, ", "), len(list))
+		}
+		values = make(map[string]interface{}, len(list))
+		for i, value := range list {
+			values[entry.Args[i]] = value
+		}
+	} else if err := json.Unmarshal(raw, &values); raw != nil && err != nil {
+		return nil, errors.New("args must be an object or a list")
+	}
+
+	for name, value := range values {
+		if !contains(entry.Args, name) {
+			return nil, fmt.Errorf("%s has no argument '%s'", entry.Name, name)
+		}
+		switch v := value.(type) {
+		case string:
+			args[name] = v
+		case float64:
+			args[name] = fmt.Sprint(v)
+		default:
+			return nil, fmt.Errorf("argument '%s' must be a string or a number", name)
+		}
+	}
+	for _, name := range entry.Args {
+		if _, ok := args[name]; !ok {
+			return nil, fmt.Errorf("%s requires the argument '%s'", entry.Name, name)
+		}
+	}
+	return args, nil
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+func runBatchCommand(t *testing.T, input string, args ...string) ([]map[string]interface{}, error) {
+	cmd := newBatchCommand()
+	buf := &bytes.Buffer{}
+	cmd.SetOutput(buf)
+	// only the results are printed
+	cmd.SilenceErrors, cmd.SilenceUsage = true, true
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetArgs(append([]string{"--from-file", "-"}, args...))
+	err := cmd.Execute()
+	var results []map[string]interface{}
+	if buf.Len() > 0 {
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &results), buf.String())
+	}
+	return results, err
+}
+
+func TestBatchCalls(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/queue/v1/task/", func(w http.ResponseWriter, r *http.Request) {
+		taskID := strings.TrimPrefix(r.URL.Path, "/api/queue/v1/task/")
+		if taskID == "missing" {
+			w.Header().Set("X-For-Request-Id", "req")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"code": "ResourceNotFound", "message": "No such task", "requestInfo": {}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"taskId": "`+taskID+`"}`)
+	})
+	handler.HandleFunc("/api/queue/v1/task-group/tg/list", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"limit": "`+r.URL.Query().Get("limit")+`"}`)
+	})
+	handler.HandleFunc("/api/auth/v1/scopes/expand", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	config.SetRootURL(server.URL)
+
+	input := strings.Join([]string{
+		`{"service": "queue", "method": "task", "args": {"taskId": "abc"}}`,
+		``,
+		`{"service": "queue", "method": "task", "args": ["missing"]}`,
+		`{"service": "queue", "method": "listTaskGroup", "args": ["tg"], "query": {"limit": "5"}}`,
+		`{"service": "auth", "method": "expandScopes", "payload": {"scopes": ["a"]}}`,
+		`{"service": "nope", "method": "task"}`,
+		`not json`,
+	}, "\n")
+	results, err := runBatchCommand(t, input)
+	assert.EqualError(err, "3 of 6 calls failed")
+	assert.Len(results, 6)
+
+	// the results are in the order of the lines, whichever completes first
+	assert.Equal(map[string]interface{}{
+		"line": 1.0, "service": "queue", "method": "task", "status": "succeeded",
+		"result": map[string]interface{}{"taskId": "abc"},
+	}, results[0])
+	assert.Equal(map[string]interface{}{
+		"line": 3.0, "service": "queue", "method": "task", "status": "failed",
+		"error": "ResourceNotFound: No such task", "statusCode": 404.0, "code": "ResourceNotFound", "requestId": "req",
+	}, results[1])
+	assert.Equal(map[string]interface{}{"limit": "5"}, results[2]["result"])
+	assert.Equal(map[string]interface{}{"scopes": []interface{}{"a"}}, results[3]["result"])
+	assert.Equal("unknown service 'nope'", results[4]["error"])
+	assert.Equal(7.0, results[5]["line"])
+	assert.Contains(results[5]["error"], "invalid call")
+
+	// without failures, the command succeeds
+	results, err = runBatchCommand(t, `{"service": "queue", "method": "task", "args": {"taskId": "abc"}}`)
+	assert.NoError(err)
+	assert.Len(results, 1)
+}
+
+func TestBatchConcurrency(t *testing.T) {
+	assert := assert.New(t)
+
+	var running, maxRunning int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer server.Close()
+	config.SetRootURL(server.URL)
+
+	input := strings.Repeat(`{"service": "queue", "method": "task", "args": {"taskId": "abc"}}`+"\n", 8)
+	results, err := runBatchCommand(t, input, "--concurrency", "2")
+	assert.NoError(err)
+	assert.Len(results, 8)
+	assert.Equal(int32(2), atomic.LoadInt32(&maxRunning))
+
+	_, err = runBatchCommand(t, input, "-j", "0")
+	assert.EqualError(err, "invalid concurrency 0, at least one call must run at a time")
+}
+
+func TestBatchCallErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	for call, message := range map[string]string{
+		`{"service": "queue", "method": "nope"}`:                                         "unknown method 'nope' of service 'queue'",
+		`{"service": "queue", "method": "task"}`:                                         "task requires the argument 'taskId'",
+		`{"service": "queue", "method": "task", "args": ["a", "b"]}`:                     "task takes 1 arguments (taskId), not 2",
+		`{"service": "queue", "method": "task", "args": {"taskID": "a"}}`:                "task has no argument 'taskID'",
+		`{"service": "queue", "method": "task", "args": {"taskId": true}}`:               "argument 'taskId' must be a string or a number",
+		`{"service": "queue", "method": "task", "args": "abc"}`:                          "args must be an object or a list",
+		`{"service": "queue", "method": "task", "args": ["a"], "query": {"x": "1"}}`:     "task has no query-string parameter 'x'",
+		`{"service": "queue", "method": "task", "args": ["a"], "payload": {}}`:           "task takes no payload",
+		`{"service": "auth", "method": "expandScopes"}`:                                  "expandScopes requires a payload",
+		`{"service": "queue", "method": "getLatestArtifact", "args": ["a", "public/x"]}`: "getLatestArtifact transfers the content of an artifact, which cannot be called in a batch",
+		`{"service": "queue", "method": "task", "arguments": {}}`:                        `invalid call: json: unknown field "arguments"`,
+	} {
+		result := runBatchCall(batchLine{number: 1, text: call}, true)
+		assert.Equal("failed", result.Status, call)
+		assert.Equal(message, result.Error, call)
+	}
+
+	// numbers are accepted as arguments
+	args, err := batchArgs(&definitions.Entry{Name: "getArtifact", Args: []string{"taskId", "runId"}}, json.RawMessage(`["abc", 0]`))
+	assert.NoError(err)
+	assert.Equal(map[string]string{"taskId": "abc", "runId": "0"}, args)
+
+	// payloads are validated against the schema of the method
+	result := runBatchCall(batchLine{number: 1, text: `{"service": "auth", "method": "expandScopes", "payload": {"scopes": "a"}}`}, true)
+	assert.Contains(result.Error, "Payload does not match the schema")
+}
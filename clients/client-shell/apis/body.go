@@ -0,0 +1,64 @@
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// readBody reads a request payload given as the value of `--body`: `-` reads
+// it from stdin, `@<filename>` reads it from a file, and anything else is the
+// payload itself.  The payload is checked to be valid JSON.
+func readBody(value string, stdin io.Reader) ([]byte, error) {
+	var data []byte
+	var err error
+	source := "body"
+	switch {
+	case value == "-":
+		source = "stdin"
+		data, err = ioutil.ReadAll(stdin)
+	case strings.HasPrefix(value, "@"):
+		source = value[1:]
+		data, err = ioutil.ReadFile(source)
+	default:
+		data = []byte(value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read input, error: %s", err)
+	}
+
+	if err := validateJSON(data); err != nil {
+		return nil, fmt.Errorf("Invalid JSON in %s: %s", source, err)
+	}
+	return data, nil
+}
+
+// validateJSON checks that data holds a single JSON value, describing the
+// position of any syntax error.
+func validateJSON(data []byte) error {
+	var value interface{}
+	err := json.Unmarshal(data, &value)
+	if serr, ok := err.(*json.SyntaxError); ok {
+		line, column := position(data, serr.Offset)
+		return fmt.Errorf("line %d, column %d: %s", line, column, serr)
+	}
+	return err
+}
+
+// position converts a 1-based byte offset, as found in json.SyntaxError, into
+// a 1-based line and column.
+func position(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data))+1 {
+		offset = int64(len(data)) + 1
+	}
+	if offset < 1 {
+		return 1, 1
+	}
+	before := data[:offset-1]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
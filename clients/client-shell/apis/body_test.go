@@ -0,0 +1,52 @@
+package apis
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestReadBody(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "body")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "payload.json")
+	assert.NoError(ioutil.WriteFile(filename, []byte(`{"from": "file"}`), 0644))
+
+	body, err := readBody("-", strings.NewReader(`{"from": "stdin"}`))
+	assert.NoError(err)
+	assert.Equal(`{"from": "stdin"}`, string(body))
+
+	body, err = readBody("@"+filename, nil)
+	assert.NoError(err)
+	assert.Equal(`{"from": "file"}`, string(body))
+
+	body, err = readBody(`{"from": "flag"}`, nil)
+	assert.NoError(err)
+	assert.Equal(`{"from": "flag"}`, string(body))
+
+	_, err = readBody("@"+filepath.Join(dir, "missing.json"), nil)
+	assert.Error(err)
+}
+
+func TestReadBodyInvalidJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := readBody("-", strings.NewReader("{\n  \"a\": 1,\n  \"b\": x\n}"))
+	assert.EqualError(err, "Invalid JSON in stdin: line 3, column 8: invalid character 'x' looking for beginning of value")
+
+	_, err = readBody(`{"a": 1`, nil)
+	assert.EqualError(err, "Invalid JSON in body: line 1, column 7: unexpected end of JSON input")
+
+	_, err = readBody("{}\n{}", nil)
+	assert.EqualError(err, "Invalid JSON in body: line 2, column 1: invalid character '{' after top-level value")
+
+	_, err = readBody("", nil)
+	assert.Error(err)
+}
@@ -0,0 +1,85 @@
+package apis
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// call calls the named entry of a service in services, as the service
+// clients (such as the one returned by NewQueue) do, with the root URL and
+// credentials of conn, returning the response body.  Cancelling ctx aborts the
+// request.
+func call(ctx context.Context, conn *connection, serviceName, entryName string, args, query map[string]string, payload []byte) ([]byte, error) {
+	service, entry, err := lookupEntry(serviceName, entryName)
+	if err != nil {
+		return nil, err
+	}
+	return execute(ctx, conn, service.ServiceName, service.APIVersion, entry, args, query, bytes.NewReader(payload))
+}
+
+// callTyped calls the named entry of a service in services, as the typed
+// service clients (such as the one returned by NewTypedQueue, when generated
+// with -typed-payloads) do, marshalling payload to JSON unless it is nil, and
+// unmarshalling the response body into result unless it is nil.
+func callTyped(ctx context.Context, conn *connection, serviceName, entryName string, args, query map[string]string, payload, result interface{}) error {
+	var body []byte
+	if payload != nil {
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("could not marshal the payload of %s: %s", entryName, err)
+		}
+	}
+	response, err := call(ctx, conn, serviceName, entryName, args, query, body)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(response, result); err != nil {
+		return fmt.Errorf("could not unmarshal the response of %s: %s", entryName, err)
+	}
+	return nil
+}
+
+// callStream calls the named entry of a service in services, as the Stream
+// methods of the service clients do, streaming body (for Upload entries) and
+// the response body, which the caller must close.
+func callStream(ctx context.Context, conn *connection, serviceName, entryName string, args, query map[string]string, body io.Reader) (io.ReadCloser, error) {
+	service, entry, err := lookupEntry(serviceName, entryName)
+	if err != nil {
+		return nil, err
+	}
+	return executeStream(ctx, conn, service.ServiceName, service.APIVersion, entry, args, query, body)
+}
+
+// signURL returns a signed URL for the named entry of a service in services,
+// as the SignURL methods of the service clients do.
+func signURL(conn *connection, serviceName, entryName string, duration time.Duration, args, query map[string]string) (string, error) {
+	service, entry, err := lookupEntry(serviceName, entryName)
+	if err != nil {
+		return "", err
+	}
+	return signEntryURL(conn, service.ServiceName, service.APIVersion, entry, args, query, duration)
+}
+
+// lookupEntry finds the named entry of a service in services.
+func lookupEntry(serviceName, entryName string) (definitions.Service, *definitions.Entry, error) {
+	service, ok := services[serviceName]
+	if !ok {
+		return definitions.Service{}, nil, fmt.Errorf("unknown service '%s'", serviceName)
+	}
+	for i := range service.Entries {
+		if service.Entries[i].Name == entryName {
+			return service, &service.Entries[i], nil
+		}
+	}
+	return definitions.Service{}, nil, fmt.Errorf("unknown method '%s' of service '%s'", entryName, serviceName)
+}
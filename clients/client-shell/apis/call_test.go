@@ -0,0 +1,220 @@
+package apis
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/client"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+// the service clients implement the service interfaces
+var (
+	_ Queue = queueClient{}
+	_ Auth  = authClient{}
+)
+
+// fakeQueue shows how code depending on the Queue interface can be tested.
+type fakeQueue struct {
+	Queue
+}
+
+func (fakeQueue) Task(taskID string) ([]byte, error) {
+	return []byte(`{"taskId": "` + taskID + `"}`), nil
+}
+
+func TestServiceClients(t *testing.T) {
+	assert := assert.New(t)
+
+	var q interface{} = NewQueue()
+	_, ok := q.(queueClient)
+	assert.True(ok, "NewQueue returned %T", q)
+
+	var fake Queue = fakeQueue{}
+	task, err := fake.Task("abc")
+	assert.NoError(err)
+	assert.Equal(`{"taskId": "abc"}`, string(task))
+}
+
+func TestServiceClientCall(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/queue/v1/task-group/tg/list", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"limit": "`+r.URL.Query().Get("limit")+`"}`)
+	})
+	handler.HandleFunc("/api/queue/v1/task/abc", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	config.SetRootURL(server.URL)
+
+	res, err := NewQueue().ListTaskGroup("tg", map[string]string{"limit": "5"})
+	assert.NoError(err)
+	assert.Equal(`{"limit": "5"}`, string(res))
+
+	res, err = NewQueue().CreateTask("abc", []byte(`{"payload": {}}`))
+	assert.NoError(err)
+	assert.Equal(`{"payload": {}}`, string(res))
+}
+
+func TestCallTyped(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/auth/v1/scopes/expand", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	})
+	handler.HandleFunc("/api/queue/v1/task/abc/status", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `not json`)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	config.SetRootURL(server.URL)
+
+	// the payload is marshalled, and the response unmarshalled into result
+	type scopeset struct {
+		Scopes []string `json:"scopes"`
+	}
+	var result scopeset
+	err := callTyped(context.Background(), nil, "Auth", "expandScopes", nil, nil, &scopeset{Scopes: []string{"a"}}, &result)
+	assert.NoError(err)
+	assert.Equal(scopeset{Scopes: []string{"a"}}, result)
+
+	// without a result, the response is ignored
+	assert.NoError(callTyped(context.Background(), nil, "Queue", "status", map[string]string{"taskId": "abc"}, nil, nil, nil))
+
+	err = callTyped(context.Background(), nil, "Queue", "status", map[string]string{"taskId": "abc"}, nil, nil, &result)
+	assert.Error(err)
+	assert.Contains(err.Error(), "could not unmarshal the response of status")
+}
+
+func TestServiceClientCallCancelled(t *testing.T) {
+	assert := assert.New(t)
+
+	// the server does not respond until the test is over
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+	config.SetRootURL(server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := call(ctx, nil, "Queue", "task", map[string]string{"taskId": "abc"}, nil, nil)
+	assert.Error(err)
+	assert.Contains(err.Error(), context.Canceled.Error())
+	assert.True(time.Since(start) < 5*time.Second, "the request was not aborted")
+}
+
+func TestServiceClientSignURL(t *testing.T) {
+	assert := assert.New(t)
+
+	config.SetRootURL("https://tc.example.com")
+	defer func(creds *client.Credentials) { config.Credentials = creds }(config.Credentials)
+	config.Credentials = &client.Credentials{ClientID: "tester", AccessToken: "no-secret"}
+
+	signed, err := NewQueue().GetArtifactSignURL(time.Hour, "abc", "0", "private/build.zip")
+	assert.NoError(err)
+	assert.True(strings.HasPrefix(signed, "https://tc.example.com/api/queue/v1/task/abc/runs/0/artifacts/private%2Fbuild.zip?bewit="), signed)
+}
+
+// setEnv sets the given environment variables, unsetting those given as "",
+// until the returned function is called.
+func setEnv(values map[string]string) func() {
+	set := func(name, value string) {
+		if value == "" {
+			os.Unsetenv(name)
+		} else {
+			os.Setenv(name, value)
+		}
+	}
+	old := map[string]string{}
+	for name, value := range values {
+		old[name] = os.Getenv(name)
+		set(name, value)
+	}
+	return func() {
+		for name, value := range old {
+			set(name, value)
+		}
+	}
+}
+
+func TestServiceClientFromEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_, _ = io.WriteString(w, `{"taskId": "abc"}`)
+	}))
+	defer server.Close()
+
+	// the configuration is not used
+	config.SetRootURL("https://tc.example.com")
+	defer func(creds *client.Credentials) { config.Credentials = creds }(config.Credentials)
+	config.Credentials = nil
+
+	defer setEnv(map[string]string{
+		"TASKCLUSTER_ROOT_URL":     server.URL + "/",
+		"TASKCLUSTER_CLIENT_ID":    "tester",
+		"TASKCLUSTER_ACCESS_TOKEN": "no-secret",
+		"TASKCLUSTER_CERTIFICATE":  "",
+	})()
+	q, err := NewQueueFromEnv()
+	assert.NoError(err)
+	conn := q.(queueClient).conn
+	assert.Equal(server.URL, conn.RootURL())
+	assert.Equal(&client.Credentials{ClientID: "tester", AccessToken: "no-secret"}, conn.Credentials())
+
+	res, err := q.Task("abc")
+	assert.NoError(err)
+	assert.Equal(`{"taskId": "abc"}`, string(res))
+	assert.Contains(authorization, `Hawk id="tester"`)
+
+	signed, err := q.GetArtifactSignURL(time.Hour, "abc", "0", "private/build.zip")
+	assert.NoError(err)
+	assert.True(strings.HasPrefix(signed, server.URL+"/api/queue/v1/task/abc/runs/0/artifacts/private%2Fbuild.zip?bewit="), signed)
+
+	// temporary credentials have a certificate
+	defer setEnv(map[string]string{"TASKCLUSTER_CERTIFICATE": `{"version": 1}`})()
+	q, err = NewQueueFromEnv()
+	assert.NoError(err)
+	assert.Equal(`{"version": 1}`, q.(queueClient).conn.Credentials().Certificate)
+}
+
+func TestServiceClientFromEnvMissing(t *testing.T) {
+	assert := assert.New(t)
+
+	defer setEnv(map[string]string{
+		"TASKCLUSTER_ROOT_URL":     "https://tc.example.com",
+		"TASKCLUSTER_CLIENT_ID":    "",
+		"TASKCLUSTER_ACCESS_TOKEN": "",
+	})()
+	_, err := NewAuthFromEnv()
+	assert.EqualError(err, "missing environment variables: TASKCLUSTER_CLIENT_ID, TASKCLUSTER_ACCESS_TOKEN")
+
+	defer setEnv(map[string]string{
+		"TASKCLUSTER_ROOT_URL":     "tc.example.com",
+		"TASKCLUSTER_CLIENT_ID":    "tester",
+		"TASKCLUSTER_ACCESS_TOKEN": "no-secret",
+	})()
+	_, err = NewAuthFromEnv()
+	assert.EqualError(err, "TASKCLUSTER_ROOT_URL: invalid root URL 'tc.example.com': must be an absolute http or https URL")
+}
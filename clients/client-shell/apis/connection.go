@@ -0,0 +1,67 @@
+package apis
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/client"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+// connection is the root URL and credentials with which a service client
+// calls API methods.  A nil connection uses the configured ones, as the
+// commands do.
+type connection struct {
+	rootURL     string
+	credentials *client.Credentials
+}
+
+// RootURL returns the root URL of the connection.
+func (conn *connection) RootURL() string {
+	if conn == nil {
+		return config.RootURL()
+	}
+	return conn.rootURL
+}
+
+// Credentials returns the credentials of the connection, if any.
+func (conn *connection) Credentials() *client.Credentials {
+	if conn == nil {
+		return config.Credentials
+	}
+	return conn.credentials
+}
+
+// connectionFromEnv returns a connection using the root URL and credentials
+// given by TASKCLUSTER_ROOT_URL, TASKCLUSTER_CLIENT_ID,
+// TASKCLUSTER_ACCESS_TOKEN and, for temporary credentials,
+// TASKCLUSTER_CERTIFICATE.  All but the certificate are required.
+func connectionFromEnv() (*connection, error) {
+	var missing []string
+	lookup := func(name string) string {
+		value := os.Getenv(name)
+		if value == "" {
+			missing = append(missing, name)
+		}
+		return value
+	}
+	rootURL := lookup("TASKCLUSTER_ROOT_URL")
+	clientID := lookup("TASKCLUSTER_CLIENT_ID")
+	accessToken := lookup("TASKCLUSTER_ACCESS_TOKEN")
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing environment variables: %s", strings.Join(missing, ", "))
+	}
+	if err := config.ValidateRootURL(rootURL); err != nil {
+		return nil, fmt.Errorf("TASKCLUSTER_ROOT_URL: %s", err)
+	}
+
+	return &connection{
+		rootURL: strings.TrimRight(rootURL, "/"),
+		credentials: &client.Credentials{
+			ClientID:    clientID,
+			AccessToken: accessToken,
+			Certificate: os.Getenv("TASKCLUSTER_CERTIFICATE"),
+		},
+	}, nil
+}
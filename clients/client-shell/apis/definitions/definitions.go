@@ -1,4 +1,5 @@
-// Package definitions implements the definition of the Service and Entry structs.
+// Package definitions implements the definition of the Service and Entry
+// structs, and of the Exchanges of services.
 
 package definitions
 
@@ -22,4 +23,29 @@ type Entry struct {
 	Args        []string `json:"args"`
 	Query       []string `json:"query"`
 	Input       string   `json:"input"`
+	Output      string   `json:"output"`
+	// Paginated is set by the generator for entries which accept a
+	// `continuationToken` query parameter.
+	Paginated bool `json:"-"`
+	// SignedURL is set by the generator for GET entries which require
+	// scopes, and so can be called with a signed URL instead of an
+	// Authorization header.
+	SignedURL bool `json:"-"`
+	// Download is set by the generator for entries returning the content of
+	// an artifact, rather than JSON, and Upload for entries taking it as the
+	// request body; both are streamed, rather than held in memory.
+	Download bool `json:"-"`
+	Upload   bool `json:"-"`
+	// PutURL is set by the generator for entries whose response may have a
+	// `putUrl`, to which the content of an artifact is then uploaded, such as
+	// queue's createArtifact.
+	PutURL bool `json:"-"`
+	// Usage and Example are set by the generator, and shown in the help of
+	// the entry's command.  In the usage, required parameters are given in
+	// angle brackets and optional ones in square brackets.
+	Usage   string `json:"-"`
+	Example string `json:"-"`
+	// Aliases is set by the generator to the former names of the entry, if
+	// it was renamed; commands with these names are deprecated.
+	Aliases []string `json:"-"`
 }
@@ -0,0 +1,108 @@
+package definitions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Exchanges definition, describing the Pulse exchanges of a service.
+type Exchanges struct {
+	ExchangePrefix string     `json:"exchangePrefix"`
+	ServiceName    string     `json:"serviceName"`
+	APIVersion     string     `json:"apiVersion"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Entries        []Exchange `json:"entries"`
+}
+
+// Exchange definition for exchanges.
+type Exchange struct {
+	Name        string              `json:"name"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Exchange    string              `json:"exchange"`
+	RoutingKey  []RoutingKeyElement `json:"routingKey"`
+	Schema      string              `json:"schema"`
+}
+
+// RoutingKeyElement definition, describing one of the dot-separated
+// components of the routing keys of an exchange's messages.
+type RoutingKeyElement struct {
+	Name          string `json:"name"`
+	Summary       string `json:"summary"`
+	Constant      string `json:"constant"`
+	MultipleWords bool   `json:"multipleWords"`
+	Required      bool   `json:"required"`
+}
+
+// Binding is an exchange and a routing-key pattern to bind a Pulse queue to
+// that exchange with.
+type Binding struct {
+	Exchange          string
+	RoutingKeyPattern string
+}
+
+// Binding returns the binding matching the messages of exchange x whose
+// routing keys have the given values.  Components without a value match any
+// word (`*`), or any number of words (`#`) for multiple-word components;
+// constant components always have their constant value.
+func (x Exchanges) Binding(name string, values map[string]string) (Binding, error) {
+	for _, exchange := range x.Entries {
+		if exchange.Name == name {
+			pattern, err := exchange.RoutingKeyPattern(values)
+			if err != nil {
+				return Binding{}, err
+			}
+			return Binding{
+				Exchange:          x.ExchangePrefix + exchange.Exchange,
+				RoutingKeyPattern: pattern,
+			}, nil
+		}
+	}
+	return Binding{}, fmt.Errorf("unknown exchange '%s'", name)
+}
+
+// RoutingKeyPattern builds a routing-key pattern from the values of some of
+// the exchange's routing-key components, as described for Exchanges.Binding.
+func (e Exchange) RoutingKeyPattern(values map[string]string) (string, error) {
+	for name := range values {
+		if !e.hasComponent(name) {
+			return "", fmt.Errorf("exchange '%s' has no routing-key component '%s'", e.Name, name)
+		}
+	}
+
+	words := make([]string, 0, len(e.RoutingKey))
+	for _, element := range e.RoutingKey {
+		value, ok := values[element.Name]
+		switch {
+		case element.Constant != "":
+			if ok && value != element.Constant {
+				return "", fmt.Errorf(
+					"routing-key component '%s' of exchange '%s' is always '%s'",
+					element.Name, e.Name, element.Constant,
+				)
+			}
+			value = element.Constant
+		case !ok && element.MultipleWords:
+			value = "#"
+		case !ok:
+			value = "*"
+		case !element.MultipleWords && strings.Contains(value, "."):
+			return "", fmt.Errorf(
+				"routing-key component '%s' of exchange '%s' is a single word, and cannot contain '.'",
+				element.Name, e.Name,
+			)
+		}
+		words = append(words, value)
+	}
+	return strings.Join(words, "."), nil
+}
+
+func (e Exchange) hasComponent(name string) bool {
+	for _, element := range e.RoutingKey {
+		if element.Name == name {
+			return true
+		}
+	}
+	return false
+}
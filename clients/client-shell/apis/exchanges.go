@@ -0,0 +1,38 @@
+package apis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// ListenFor returns the binding for the messages of the named exchange of a
+// service whose routing keys have the given values for some of their
+// components, such as `{"taskId": "..."}`.  The service is named as in
+// `taskcluster api`, e.g. `queue`, and the exchange by its name in the
+// service's exchanges reference, e.g. `taskCompleted`.
+func ListenFor(serviceName, exchangeName string, values map[string]string) (definitions.Binding, error) {
+	if serviceName == "" {
+		return definitions.Binding{}, fmt.Errorf("no service given")
+	}
+	x, ok := exchanges[strings.ToUpper(serviceName[:1])+serviceName[1:]]
+	if !ok {
+		return definitions.Binding{}, fmt.Errorf("service '%s' has no exchanges", serviceName)
+	}
+	return x.Binding(exchangeName, values)
+}
+
+// bindingFor is the implementation of the generated binding functions of the
+// exchanges, such as QueueTaskCompleted: it returns the binding for the named
+// exchange of a service, by Go name, with the routing-key components given as
+// "" matching any value.
+func bindingFor(name, exchangeName string, values map[string]string) (definitions.Binding, error) {
+	given := make(map[string]string, len(values))
+	for component, value := range values {
+		if value != "" {
+			given[component] = value
+		}
+	}
+	return exchanges[name].Binding(exchangeName, given)
+}
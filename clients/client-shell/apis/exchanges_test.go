@@ -0,0 +1,39 @@
+package apis
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestListenFor(t *testing.T) {
+	assert := assert.New(t)
+
+	binding, err := ListenFor("queue", "taskCompleted", map[string]string{"taskId": "abc"})
+	assert.NoError(err)
+	assert.Equal("exchange/taskcluster-queue/v1/task-completed", binding.Exchange)
+	assert.Equal("primary.abc.*.*.*.*.*.*.*.#", binding.RoutingKeyPattern)
+
+	_, err = ListenFor("secrets", "secretCreated", nil)
+	assert.EqualError(err, "service 'secrets' has no exchanges")
+}
+
+func TestBindingFunctions(t *testing.T) {
+	assert := assert.New(t)
+
+	// the generated functions take every routing-key component that is not
+	// constant, matching any value when given ""
+	binding, err := QueueTaskCompleted("abc", "", "", "", "", "", "", "", "")
+	assert.NoError(err)
+	assert.Equal("exchange/taskcluster-queue/v1/task-completed", binding.Exchange)
+	assert.Equal("primary.abc.*.*.*.*.*.*.*.#", binding.RoutingKeyPattern)
+
+	binding, err = QueueTaskGroupResolved("tg", "", "")
+	assert.NoError(err)
+	assert.Equal("exchange/taskcluster-queue/v1/task-group-resolved", binding.Exchange)
+	assert.Equal("primary.tg.*.#", binding.RoutingKeyPattern)
+
+	// single-word components cannot match several words
+	_, err = QueueTaskCompleted("a.b", "", "", "", "", "", "", "", "")
+	assert.Error(err)
+}
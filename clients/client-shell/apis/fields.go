@@ -0,0 +1,158 @@
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// fieldTree is the set of paths given to `--fields`, merged into a tree: the
+// children of a node are the fields to keep of an object, or `[*]` for the
+// items of a list.  A node without children keeps the whole value.
+type fieldTree map[string]fieldTree
+
+// fieldPath is one of the paths given to `--fields`.
+type fieldPath struct {
+	path     string
+	segments []querySegment
+}
+
+// parseFields parses the comma-separated paths of `--fields`, such as
+// `taskId,status.state` or `tasks[*].status.taskId`, returning them and their
+// tree.  The paths are those of `--query`, except that list indexes are not
+// allowed.
+func parseFields(fields string) (fieldTree, []fieldPath, error) {
+	tree := fieldTree{}
+	var paths []fieldPath
+	for _, path := range strings.Split(fields, ",") {
+		path = strings.TrimSpace(path)
+		segments, err := parseQuery(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(segments) == 0 {
+			return nil, nil, fmt.Errorf("invalid fields '%s': empty path", fields)
+		}
+
+		node := tree
+	segments:
+		for i, segment := range segments {
+			if segment.isIndex {
+				return nil, nil, fmt.Errorf("invalid field '%s': list indexes are not allowed, use [*]", path)
+			}
+			key := segment.field
+			if segment.wildcard {
+				key = "[*]"
+			}
+			child, ok := node[key]
+			switch {
+			case ok && child == nil:
+				// a shorter path keeps all of this value already
+				break segments
+			case i == len(segments)-1:
+				node[key] = nil
+			case !ok:
+				child = fieldTree{}
+				node[key] = child
+			}
+			node = child
+		}
+		paths = append(paths, fieldPath{path: path, segments: segments})
+	}
+	return tree, paths, nil
+}
+
+// pruneFields returns value, keeping only the parts of it in tree: objects
+// keep only the fields given, and lists have all of their items pruned.
+func pruneFields(value interface{}, tree fieldTree) interface{} {
+	if tree == nil {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		pruned := make(map[string]interface{}, len(tree))
+		for name, subtree := range tree {
+			if field, ok := v[name]; ok && name != "[*]" {
+				pruned[name] = pruneFields(field, subtree)
+			}
+		}
+		return pruned
+	case []interface{}:
+		subtree, ok := tree["[*]"]
+		if !ok {
+			return []interface{}{}
+		}
+		pruned := make([]interface{}, len(v))
+		for i, item := range v {
+			pruned[i] = pruneFields(item, subtree)
+		}
+		return pruned
+	}
+	return value
+}
+
+// hasPath returns true if value has a path given to `--fields`.  A path
+// through an empty list is present, as are those present in any of the items
+// of a list.
+func hasPath(value interface{}, segments []querySegment) bool {
+	if len(segments) == 0 {
+		return true
+	}
+	segment := segments[0]
+	if segment.wildcard {
+		list, ok := value.([]interface{})
+		if !ok {
+			return false
+		}
+		if len(list) == 0 {
+			return true
+		}
+		for _, item := range list {
+			if hasPath(item, segments[1:]) {
+				return true
+			}
+		}
+		return false
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	field, ok := object[segment.field]
+	return ok && hasPath(field, segments[1:])
+}
+
+// selectFields returns the JSON response body keeping only the paths given
+// to `--fields`, as compact JSON.  It is an error if any of the paths is not
+// in the response, unless ignoreMissing is set.
+func selectFields(body []byte, fields string, ignoreMissing bool) ([]byte, error) {
+	tree, paths, err := parseFields(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep numbers, and characters such as '<', exactly as received
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("response is not valid JSON: %s", err)
+	}
+
+	if !ignoreMissing {
+		for _, path := range paths {
+			if !hasPath(value, path.segments) {
+				return nil, fmt.Errorf("field '%s' is not in the response (use --ignore-missing to omit it)", path.path)
+			}
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(pruneFields(value, tree)); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(buf.Bytes()), nil
+}
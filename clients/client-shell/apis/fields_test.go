@@ -0,0 +1,137 @@
+package apis
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+func fieldsResult(t *testing.T, fields string, ignoreMissing bool, body string) string {
+	result, err := selectFields([]byte(body), fields, ignoreMissing)
+	assert.NoError(t, err)
+	return string(result)
+}
+
+func TestSelectFields(t *testing.T) {
+	assert := assert.New(t)
+
+	status := `{"status": {"taskId": "abc", "state": "completed", "runs": [{"runId": 0, "state": "completed"}]}, "extra": 10000000}`
+	assert.JSONEq(`{"status": {"state": "completed"}}`, fieldsResult(t, "status.state", false, status))
+	assert.JSONEq(`{"status": {"taskId": "abc", "state": "completed"}, "extra": 10000000}`,
+		fieldsResult(t, "status.taskId, status.state,extra", false, status))
+
+	// numbers and characters such as '<' are kept as received
+	assert.Equal(`{"extra":10000000}`, fieldsResult(t, "extra", false, status))
+	assert.Equal(`{"s":"<a & b>"}`, fieldsResult(t, "s", false, `{"s": "<a & b>", "t": 1}`))
+
+	// the items of lists are pruned alike
+	assert.JSONEq(`{"status": {"runs": [{"state": "completed"}]}}`, fieldsResult(t, "status.runs[*].state", false, status))
+	assert.JSONEq(`{"things": [{"name": "a"}, {"name": "bb", "count": 10000000}]}`,
+		fieldsResult(t, "things[*].name,things[*].count", true, `{"things": [{"name": "a"}, {"name": "bb", "count": 10000000}], "continuationToken": "tok"}`))
+
+	// shorter paths keep everything below them, whichever comes first
+	assert.JSONEq(`{"status": {"taskId": "abc", "state": "completed", "runs": [{"runId": 0, "state": "completed"}]}}`,
+		fieldsResult(t, "status.state,status", false, status))
+	assert.JSONEq(`{"status": {"taskId": "abc", "state": "completed", "runs": [{"runId": 0, "state": "completed"}]}}`,
+		fieldsResult(t, "status,status.state", false, status))
+
+	// paths through empty lists are not missing
+	assert.JSONEq(`{"things": []}`, fieldsResult(t, "things[*].name", false, `{"things": []}`))
+}
+
+func TestSelectFieldsMissing(t *testing.T) {
+	assert := assert.New(t)
+
+	body := `{"status": {"state": "completed"}, "things": [{"name": "a"}]}`
+	for _, missing := range []string{"taskId", "status.taskId", "status.state.x", "things[*].count", "status[*]"} {
+		_, err := selectFields([]byte(body), "status.state,"+missing, false)
+		assert.EqualError(err, "field '"+missing+"' is not in the response (use --ignore-missing to omit it)")
+
+		// unless they are ignored
+		_, err = selectFields([]byte(body), "status.state,"+missing, true)
+		assert.NoError(err)
+	}
+	assert.JSONEq(`{"status": {"state": "completed"}}`, fieldsResult(t, "status.state,taskId,status.taskId", true, body))
+}
+
+func TestParseFields(t *testing.T) {
+	assert := assert.New(t)
+
+	tree, paths, err := parseFields("a.b,a.c,d[*].e")
+	assert.NoError(err)
+	assert.Equal(fieldTree{"a": {"b": nil, "c": nil}, "d": {"[*]": {"e": nil}}}, tree)
+	assert.Len(paths, 3)
+	assert.Equal("d[*].e", paths[2].path)
+
+	for _, invalid := range []string{"", "a,,b", "a[0]", "a..b", "a,"} {
+		_, _, err := parseFields(invalid)
+		assert.Error(err, invalid)
+	}
+}
+
+func TestCommandFields(t *testing.T) {
+	assert := assert.New(t)
+
+	// two pages of things, merged with --all before the fields are selected
+	pages := map[string]string{
+		"":  `{"things": [{"name": "a", "count": 1, "tags": ["x"]}], "continuationToken": "b"}`,
+		"b": `{"things": [{"name": "bb", "count": 2, "tags": []}]}`,
+	}
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = io.WriteString(w, pages[r.URL.Query().Get("continuationToken")])
+	}))
+	defer server.Close()
+	config.SetRootURL(server.URL)
+
+	cmd := makeCmdFromDefinition("Test", definitions.Service{
+		ServiceName: "test",
+		APIVersion:  "v1",
+		Entries: []definitions.Entry{
+			definitions.Entry{Name: "listThings", Method: "get", Route: "/things", Query: []string{"continuationToken"}, Paginated: true},
+		},
+	})
+	cmd.PersistentFlags().StringP("format", "f", "", "Output format")
+	cmd.PersistentFlags().String("query", "", "Query")
+	cmd.PersistentFlags().String("fields", "", "Fields")
+	cmd.PersistentFlags().Bool("ignore-missing", false, "Ignore missing fields")
+	buf := &bytes.Buffer{}
+	cmd.SetOutput(buf)
+
+	// by default, the result is printed as JSON
+	cmd.SetArgs([]string{"listThings", "--all", "--fields", "things[*].name"})
+	assert.NoError(cmd.Execute())
+	assert.Equal("{\n  \"things\": [\n    {\n      \"name\": \"a\"\n    },\n    {\n      \"name\": \"bb\"\n    }\n  ]\n}\n", buf.String())
+
+	// and composes with the other formats, and with --query
+	buf.Reset()
+	cmd.SetArgs([]string{"listThings", "--all", "--fields", "things[*].name,things[*].count", "--format", "table"})
+	assert.NoError(cmd.Execute())
+	assert.Equal("COUNT  NAME\n1      a\n2      bb\n", buf.String())
+
+	buf.Reset()
+	cmd.SetArgs([]string{"listThings", "--all", "--fields", "things[*].name", "--format", "", "--query", "things[*].name"})
+	assert.NoError(cmd.Execute())
+	assert.Equal("[\n  \"a\",\n  \"bb\"\n]\n", buf.String())
+
+	// invalid fields are reported without calling the method
+	calls = 0
+	cmd.SetArgs([]string{"listThings", "--fields", "things[0]", "--query", ""})
+	assert.Error(cmd.Execute())
+	assert.Equal(0, calls)
+
+	// missing fields are errors, unless ignored
+	cmd.SetArgs([]string{"listThings", "--fields", "things[*].name,taskId"})
+	assert.EqualError(cmd.Execute(), "field 'taskId' is not in the response (use --ignore-missing to omit it)")
+	buf.Reset()
+	cmd.SetArgs([]string{"listThings", "--fields", "things[*].name,taskId", "--ignore-missing"})
+	assert.NoError(cmd.Execute())
+	assert.Contains(buf.String(), `"name": "a"`)
+}
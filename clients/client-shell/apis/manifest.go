@@ -0,0 +1,144 @@
+package apis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// ManifestVersion is the version of the structure printed by
+// `taskcluster api --list-json`.  Fields may be added without changing it,
+// but it changes if any field is removed or changes meaning.
+const ManifestVersion = 1
+
+// manifest describes every command of the api tree.
+type manifest struct {
+	Version int `json:"version"`
+	// ReferencesVersion identifies the references the commands were
+	// generated from.
+	ReferencesVersion string            `json:"referencesVersion"`
+	Services          []manifestService `json:"services"`
+}
+
+type manifestService struct {
+	// Command is the name of the service's command, such as `purgeCache`.
+	Command     string           `json:"command"`
+	ServiceName string           `json:"serviceName"`
+	APIVersion  string           `json:"apiVersion"`
+	Title       string           `json:"title"`
+	Methods     []manifestMethod `json:"methods"`
+}
+
+type manifestMethod struct {
+	// Command is the name of the method's command, which is also the name of
+	// the method.
+	Command    string              `json:"command"`
+	Title      string              `json:"title"`
+	Stability  string              `json:"stability"`
+	Method     string              `json:"method"`
+	Route      string              `json:"route"`
+	Parameters []manifestParameter `json:"parameters"`
+	// Input and Output are the ids of the schemas of the payload and of the
+	// response, or empty if they are not JSON.
+	Input     string   `json:"input"`
+	Output    string   `json:"output"`
+	Paginated bool     `json:"paginated"`
+	SignedURL bool     `json:"signedUrl"`
+	Streamed  bool     `json:"streamed"`
+	Aliases   []string `json:"aliases"`
+}
+
+type manifestParameter struct {
+	Name string `json:"name"`
+	// In is `path` for positional arguments, `query` for query-string
+	// options, and `body` for the payload.
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+}
+
+func runAPI(cmd *cobra.Command, args []string) error {
+	if listJSON, _ := cmd.Flags().GetBool("list-json"); !listJSON {
+		return cmd.Help()
+	}
+
+	var output = cmd.OutOrStdout()
+	if flag := cmd.Flags().Lookup("output"); flag != nil && flag.Changed {
+		f, err := os.Create(flag.Value.String())
+		if err != nil {
+			return fmt.Errorf("Failed to open output file, error: %s", err)
+		}
+		defer f.Close()
+		output = f
+	}
+	return writeManifest(output, services)
+}
+
+// writeManifest writes the manifest of the commands of services, as indented
+// JSON.
+func writeManifest(w io.Writer, services map[string]definitions.Service) error {
+	m := manifest{
+		Version:           ManifestVersion,
+		ReferencesVersion: ReferencesVersion,
+		Services:          make([]manifestService, 0, len(services)),
+	}
+	for name, service := range services {
+		m.Services = append(m.Services, makeManifestService(name, service))
+	}
+	sort.Slice(m.Services, func(i, j int) bool {
+		return m.Services[i].Command < m.Services[j].Command
+	})
+
+	// routes contain angle brackets, which are left as they are
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(m)
+}
+
+func makeManifestService(name string, service definitions.Service) manifestService {
+	s := manifestService{
+		Command:     strings.ToLower(name[0:1]) + name[1:],
+		ServiceName: service.ServiceName,
+		APIVersion:  service.APIVersion,
+		Title:       service.Title,
+		Methods:     make([]manifestMethod, 0, len(service.Entries)),
+	}
+	for _, entry := range service.Entries {
+		m := manifestMethod{
+			Command:    entry.Name,
+			Title:      entry.Title,
+			Stability:  entry.Stability,
+			Method:     strings.ToUpper(entry.Method),
+			Route:      entry.Route,
+			Parameters: []manifestParameter{},
+			Paginated:  entry.Paginated,
+			SignedURL:  entry.SignedURL,
+			Streamed:   entry.Download || entry.Upload,
+			Aliases:    entry.Aliases,
+		}
+		if m.Aliases == nil {
+			m.Aliases = []string{}
+		}
+		for _, arg := range entry.Args {
+			m.Parameters = append(m.Parameters, manifestParameter{Name: arg, In: "path", Required: true})
+		}
+		for _, q := range entry.Query {
+			m.Parameters = append(m.Parameters, manifestParameter{Name: q, In: "query"})
+		}
+		if entry.Input != "" {
+			m.Parameters = append(m.Parameters, manifestParameter{Name: "payload", In: "body", Required: true})
+			m.Input = schemaPath(service.ServiceName, entry.Input) + "#"
+		}
+		if entry.Output != "" {
+			m.Output = schemaPath(service.ServiceName, entry.Output) + "#"
+		}
+		s.Methods = append(s.Methods, m)
+	}
+	return s
+}
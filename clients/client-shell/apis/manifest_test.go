@@ -0,0 +1,86 @@
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+func TestWriteManifest(t *testing.T) {
+	assert := assert.New(t)
+
+	def := servicesTest["Test"]
+	entry := def.Entries[0]
+	entry.Args = []string{"thingId"}
+	entry.Route = "/things/<thingId>"
+	entry.Input = "v1/thing.json#"
+	entry.Paginated = true
+	entry.Aliases = []string{"oldTest"}
+	buf := &bytes.Buffer{}
+	assert.NoError(writeManifest(buf, map[string]definitions.Service{
+		"PurgeTest": {ServiceName: "purge-test", APIVersion: "v1", Title: "Test API", Entries: []definitions.Entry{entry}},
+	}))
+
+	// the structure is versioned, so it is checked in full
+	assert.JSONEq(`{
+		"version": 1,
+		"referencesVersion": "`+ReferencesVersion+`",
+		"services": [{
+			"command": "purgeTest",
+			"serviceName": "purge-test",
+			"apiVersion": "v1",
+			"title": "Test API",
+			"methods": [{
+				"command": "test",
+				"title": "Do a test",
+				"stability": "stable",
+				"method": "GET",
+				"route": "/things/<thingId>",
+				"parameters": [
+					{"name": "thingId", "in": "path", "required": true},
+					{"name": "key", "in": "query", "required": false},
+					{"name": "payload", "in": "body", "required": true}
+				],
+				"input": "/schemas/purge-test/v1/thing.json#",
+				"output": "",
+				"paginated": true,
+				"signedUrl": false,
+				"streamed": false,
+				"aliases": ["oldTest"]
+			}]
+		}]
+	}`, buf.String())
+	assert.Contains(buf.String(), `"/things/<thingId>"`)
+}
+
+func TestCommandListJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	// Command is a subcommand of the root, so runAPI is run directly
+	buf := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("list-json", true, "")
+	cmd.SetOutput(buf)
+	assert.NoError(runAPI(cmd, nil))
+
+	var m manifest
+	assert.NoError(json.Unmarshal(buf.Bytes(), &m))
+	assert.Equal(ManifestVersion, m.Version)
+	assert.Len(m.Services, len(services))
+
+	// the schema ids are those of the embedded schemas
+	for _, service := range m.Services {
+		for _, method := range service.Methods {
+			for _, id := range []string{method.Input, method.Output} {
+				if id != "" {
+					_, ok := schemas[id[:len(id)-1]]
+					assert.True(ok, "%s.%s: unknown schema %s", service.Command, method.Command, id)
+				}
+			}
+		}
+	}
+}
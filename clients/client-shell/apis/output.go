@@ -0,0 +1,138 @@
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// isOutputFormat returns true if format is a supported value of `--format`.
+// The empty format writes responses exactly as they were received.
+func isOutputFormat(format string) bool {
+	switch format {
+	case "", "json", "yaml", "table":
+		return true
+	}
+	return false
+}
+
+// writeResult writes the JSON response body to output, in the given format.
+func writeResult(output io.Writer, format string, body []byte) error {
+	if format == "" {
+		_, err := output.Write(body)
+		return err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("response is not valid JSON: %s", err)
+	}
+
+	switch format {
+	case "yaml":
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+		_, err = output.Write(data)
+		return err
+	case "table":
+		if rows, ok := tableRows(value); ok {
+			return writeTable(output, rows)
+		}
+		// not tabular, so fall back to JSON
+	}
+
+	buf := &bytes.Buffer{}
+	if err := json.Indent(buf, body, "", "  "); err != nil {
+		return err
+	}
+	buf.WriteString("\n")
+	_, err := buf.WriteTo(output)
+	return err
+}
+
+// tableRows finds the rows of a tabular response: a list of objects, an
+// object with a single list of objects (such as most `list..` responses), or
+// a single object.
+func tableRows(value interface{}) ([]map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		rows := make([]map[string]interface{}, 0, len(v))
+		for _, item := range v {
+			row, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			rows = append(rows, row)
+		}
+		return rows, true
+	case map[string]interface{}:
+		var list []interface{}
+		for _, field := range v {
+			if l, ok := field.([]interface{}); ok {
+				if list != nil {
+					// more than one list; we can't pick one
+					return nil, false
+				}
+				list = l
+			}
+		}
+		if list != nil {
+			return tableRows(list)
+		}
+		return []map[string]interface{}{v}, true
+	}
+	return nil, false
+}
+
+// writeTable prints rows as aligned columns, one for each key present in any
+// of the rows.
+func writeTable(output io.Writer, rows []map[string]interface{}) error {
+	keys := map[string]bool{}
+	for _, row := range rows {
+		for key := range row {
+			keys[key] = true
+		}
+	}
+	columns := make([]string, 0, len(keys))
+	for key := range keys {
+		columns = append(columns, key)
+	}
+	sort.Strings(columns)
+
+	w := tabwriter.NewWriter(output, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.ToUpper(strings.Join(columns, "\t")))
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = tableCell(row[column])
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
+
+// tableCell renders a single value in a table; nested values are shown as
+// compact JSON.
+func tableCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.NewReplacer("\t", " ", "\n", " ").Replace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+	return fmt.Sprint(value)
+}
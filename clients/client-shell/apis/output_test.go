@@ -0,0 +1,52 @@
+package apis
+
+import (
+	"bytes"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+const listResponse = `{"things":[{"name":"a","count":1,"tags":["x"]},{"name":"bb","count":10000000}],"continuationToken":"tok"}`
+
+func formatResult(t *testing.T, format, body string) string {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, writeResult(buf, format, []byte(body)))
+	return buf.String()
+}
+
+func TestWriteResultRaw(t *testing.T) {
+	assert.Equal(t, listResponse, formatResult(t, "", listResponse))
+}
+
+func TestWriteResultJSON(t *testing.T) {
+	assert.Equal(t, "{\n  \"a\": [\n    1\n  ]\n}\n", formatResult(t, "json", `{"a":[1]}`))
+}
+
+func TestWriteResultYAML(t *testing.T) {
+	assert.Equal(t, "a:\n- 1\nb: c\n", formatResult(t, "yaml", `{"b":"c","a":[1]}`))
+}
+
+func TestWriteResultTable(t *testing.T) {
+	assert := assert.New(t)
+
+	// the single list in the response is used for rows
+	assert.Equal(
+		"COUNT     NAME  TAGS\n"+
+			"1         a     [\"x\"]\n"+
+			"10000000  bb    \n",
+		formatResult(t, "table", listResponse),
+	)
+
+	// a single object is a single row
+	assert.Equal("A  B\n1  two\n", formatResult(t, "table", `{"a":1,"b":"two"}`))
+}
+
+func TestWriteResultTableFallback(t *testing.T) {
+	// two lists, so not tabular
+	assert.Equal(t, "{\n  \"a\": [],\n  \"b\": []\n}\n", formatResult(t, "table", `{"a":[],"b":[]}`))
+}
+
+func TestWriteResultInvalidJSON(t *testing.T) {
+	assert.Error(t, writeResult(&bytes.Buffer{}, "yaml", []byte("nope")))
+}
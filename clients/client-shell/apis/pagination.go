@@ -0,0 +1,229 @@
+package apis
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// executeAll calls a paginated API method repeatedly, passing back the
+// `continuationToken` of each response until there is none, and returns a
+// single response in which the arrays of all the pages are concatenated and
+// the other properties are those of the last page.
+func executeAll(
+	ctx context.Context, conn *connection, serviceName string, apiVersion string, entry *definitions.Entry, args, query map[string]string,
+	payload io.Reader,
+) ([]byte, error) {
+	// copy the query, so that the caller's is not modified
+	pageQuery := make(map[string]string, len(query))
+	for k, v := range query {
+		pageQuery[k] = v
+	}
+
+	// the same payload is sent with every page
+	var input []byte
+	if entry.Input != "" {
+		data, err := ioutil.ReadAll(payload)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read input, error: %s", err)
+		}
+		input = data
+	}
+
+	arrays := arrayFields(serviceName, entry)
+	var merged map[string]interface{}
+	for {
+		body, err := execute(ctx, conn, serviceName, apiVersion, entry, args, pageQuery, bytes.NewReader(input))
+		if err != nil {
+			return nil, err
+		}
+
+		var page map[string]interface{}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("Failed to parse paginated response, error: %s", err)
+		}
+
+		token, _ := page["continuationToken"].(string)
+		delete(page, "continuationToken")
+		if merged, err = mergePage(merged, page, arrays); err != nil {
+			return nil, err
+		}
+
+		if token == "" {
+			break
+		}
+		pageQuery["continuationToken"] = token
+	}
+
+	return json.Marshal(merged)
+}
+
+// arrayFields returns, for the top-level properties of the response of entry
+// whose type its output schema declares, whether that type is an array, such
+// as `tasks` for queue's listTaskGroup.  Properties given by `$ref`, `oneOf`
+// or `anyOf` are resolved against the embedded schemas; those whose type
+// remains unknown are left out.  It returns nil if the schema is not known.
+// Properties left out are merged if they are arrays in the responses.
+func arrayFields(serviceName string, entry *definitions.Entry) map[string]bool {
+	if entry.Output == "" {
+		return nil
+	}
+	file := schemaPath(serviceName, entry.Output)
+	schema, ok := schemas[file]
+	if !ok {
+		return nil
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(schema), &doc); err != nil {
+		return nil
+	}
+
+	fields := make(map[string]bool)
+	properties, _ := doc["properties"].(map[string]interface{})
+	for name, property := range properties {
+		if isArray, known := isArraySchema(file, property, 0); known {
+			fields[name] = isArray
+		}
+	}
+	return fields
+}
+
+// maxSchemaDepth bounds the resolution of schemas which refer to themselves.
+const maxSchemaDepth = 10
+
+// isArraySchema returns whether the given schema, which is part of the
+// embedded schema file, describes an array, and whether that is known.  A
+// `oneOf` or `anyOf` is known to be an array only if all its alternatives
+// are, and known not to be only if none are.
+func isArraySchema(file string, value interface{}, depth int) (isArray bool, known bool) {
+	schema, ok := value.(map[string]interface{})
+	if !ok || depth > maxSchemaDepth {
+		return false, false
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		refFile, target, ok := resolveSchemaRef(file, ref)
+		if !ok {
+			return false, false
+		}
+		return isArraySchema(refFile, target, depth+1)
+	}
+
+	switch t := schema["type"].(type) {
+	case string:
+		return t == "array", true
+	case []interface{}:
+		// a list of types, such as ["array", "null"]
+		for _, each := range t {
+			if each == "array" {
+				return true, true
+			}
+		}
+		return false, true
+	}
+
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		alternatives, ok := schema[keyword].([]interface{})
+		if !ok || len(alternatives) == 0 {
+			continue
+		}
+		arrays := 0
+		for _, alternative := range alternatives {
+			isArray, known := isArraySchema(file, alternative, depth+1)
+			if !known {
+				return false, false
+			}
+			if isArray {
+				arrays++
+			}
+		}
+		switch arrays {
+		case len(alternatives):
+			return true, true
+		case 0:
+			return false, true
+		}
+		return false, false
+	}
+	return false, false
+}
+
+// resolveSchemaRef returns the embedded schema file a `$ref` found in file
+// refers to, and the part of it given by the ref's JSON pointer, if any.
+// Refs are relative to file, such as `purge-cache-requests.json#`, or
+// absolute, such as `/schemas/common/metaschema.json#`.
+func resolveSchemaRef(file, ref string) (string, interface{}, bool) {
+	parts := strings.SplitN(ref, "#", 2)
+	refFile := file
+	switch {
+	case parts[0] == "":
+	case strings.HasPrefix(parts[0], "/"):
+		refFile = parts[0]
+	default:
+		refFile = path.Join(path.Dir(file), parts[0])
+	}
+	schema, ok := schemas[refFile]
+	if !ok {
+		return "", nil, false
+	}
+	var target interface{}
+	if err := json.Unmarshal([]byte(schema), &target); err != nil {
+		return "", nil, false
+	}
+
+	if len(parts) == 2 && parts[1] != "" {
+		if !strings.HasPrefix(parts[1], "/") {
+			return "", nil, false
+		}
+		for _, token := range strings.Split(parts[1][1:], "/") {
+			token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+			object, ok := target.(map[string]interface{})
+			if !ok {
+				return "", nil, false
+			}
+			if target, ok = object[token]; !ok {
+				return "", nil, false
+			}
+		}
+	}
+	return refFile, target, true
+}
+
+// mergePage adds page to the results so far, appending to the array fields
+// and taking the value of the latest page for any other properties.  The
+// properties which are not in arrays (all of them if arrays is nil) are
+// appended if they are arrays in the page.
+func mergePage(merged, page map[string]interface{}, arrays map[string]bool) (map[string]interface{}, error) {
+	if merged == nil {
+		merged = make(map[string]interface{}, len(page))
+	}
+	for k, v := range page {
+		items, isArray := v.([]interface{})
+		array, declared := arrays[k]
+		if declared && !array {
+			merged[k] = v
+			continue
+		}
+		if !declared && !isArray {
+			merged[k] = v
+			continue
+		}
+		if v == nil {
+			// an absent page of items adds nothing
+			continue
+		}
+		if !isArray {
+			return nil, fmt.Errorf("Failed to merge paginated response: expected an array for '%s'", k)
+		}
+		existing, _ := merged[k].([]interface{})
+		merged[k] = append(existing, items...)
+	}
+	return merged, nil
+}
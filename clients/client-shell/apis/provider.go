@@ -2,17 +2,18 @@ package apis
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"hash"
 	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	got "github.com/taskcluster/go-got"
 
 	tcurls "github.com/taskcluster/taskcluster-lib-urls"
 	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
@@ -26,6 +27,13 @@ var (
 	Command = &cobra.Command{
 		Use:   "api",
 		Short: "Direct access to Taskcluster APIs.",
+		Long: `Direct access to Taskcluster APIs.
+
+With --list-json, prints a description of every service and method command as
+JSON, for tools building on them; its "version" changes only if fields are
+removed or change meaning.`,
+		Args: cobra.NoArgs,
+		RunE: runAPI,
 	}
 )
 
@@ -43,6 +51,11 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	fs.StringP("format", "f", "", "Output format: json, yaml or table [default: the response, as received]")
+	fs.String("query", "", "Print only the part of the response at this path, such as status.state or tasks[*].taskId")
+	fs.String("fields", "", "Print the response with only these comma-separated paths, such as taskId,status.state or tasks[*].status.taskId")
+	fs.Bool("ignore-missing", false, "Omit the paths given to --fields which are not in the response, rather than failing")
+	Command.Flags().Bool("list-json", false, "Print the services and methods of the api commands as JSON")
 
 	root.Command.AddCommand(Command)
 }
@@ -58,29 +71,88 @@ func makeCmdFromDefinition(name string, service definitions.Service) *cobra.Comm
 		Long:  service.Description,
 	}
 
-	// one subcommand for every function of the service
+	// one subcommand for every function of the service, and for each of its
+	// former names
 	for _, entry := range service.Entries {
-		usage := entry.Name
+		cmd.AddCommand(makeEntryCmd(service, entry, entry.Name))
+		for _, alias := range entry.Aliases {
+			cmd.AddCommand(makeEntryCmd(service, entry, alias))
+		}
+	}
+
+	return cmd
+}
+
+// makeEntryCmd returns the command calling the API method described by entry,
+// named name: the entry's name, or one of its aliases.
+func makeEntryCmd(service definitions.Service, entry definitions.Entry, name string) *cobra.Command {
+	usage := entry.Usage
+	if usage == "" {
+		usage = entry.Name
 		for _, arg := range entry.Args {
 			usage += " <" + arg + ">"
 		}
-
-		subCmd := &cobra.Command{
-			Use:   usage,
-			Short: entry.Title,
-			Long:  buildHelp(&entry),
-			RunE:  buildExecutor(service, entry),
+	}
+	usage = name + strings.TrimPrefix(usage, entry.Name)
+
+	subCmd := &cobra.Command{
+		Use:     usage,
+		Short:   entry.Title,
+		Long:    buildHelp(&entry),
+		Example: entry.Example,
+		RunE:    buildExecutor(service, entry),
+	}
+	if name != entry.Name {
+		// aliases are not listed, and warn on stderr (cobra's Deprecated
+		// would warn on the command's output, which may be stdout)
+		deprecation := fmt.Sprintf("Command %q is deprecated, use '%s' instead", name, entry.Name)
+		subCmd.Hidden = true
+		subCmd.Long = deprecation + ".\n\n" + subCmd.Long
+		run := subCmd.RunE
+		subCmd.RunE = func(cmd *cobra.Command, args []string) error {
+			root.Report(cmd.ErrOrStderr(), logrus.WarnLevel, nil, deprecation)
+			return run(cmd, args)
 		}
+	}
 
-		fs := subCmd.Flags()
-		for _, q := range entry.Query {
-			fs.String(q, "", "Specify the '"+q+"' query-string parameter")
+	fs := subCmd.Flags()
+	for _, q := range entry.Query {
+		fs.String(q, "", "Specify the '"+q+"' query-string parameter")
+	}
+	if entry.Input != "" {
+		fs.String("body", "-", "Request payload: JSON, @<filename>, or - for stdin")
+		err := subCmd.MarkFlagFilename("body", "json")
+		if err != nil {
+			panic(err)
+		}
+		fs.Bool("no-validate", false, "Send the payload without validating it against the method's schema")
+	}
+	if entry.Paginated {
+		fs.Bool("all", false, "Fetch all pages of results, following the continuationToken")
+	}
+	if entry.Upload {
+		fs.String("input", "-", "Content of the artifact: a file, or - for stdin")
+		err := subCmd.MarkFlagFilename("input")
+		if err != nil {
+			panic(err)
+		}
+	}
+	if entry.PutURL {
+		fs.String("input", "", "File to upload to the putUrl of the response, detecting its contentType if the payload has none")
+		err := subCmd.MarkFlagFilename("input")
+		if err != nil {
+			panic(err)
 		}
-
-		cmd.AddCommand(subCmd)
+	}
+	if entry.Input != "" || entry.Output != "" {
+		fs.String("schema", "", "Print the JSON schema of the payload (--schema=input, the default) or of the response (--schema=output), instead of calling the method")
+		fs.Lookup("schema").NoOptDefVal = "input"
+	}
+	if entry.SignedURL {
+		fs.Duration("sign-url", 0, "Print a URL signed with the credentials and valid for the given duration (e.g. 15m), instead of calling the method")
 	}
 
-	return cmd
+	return subCmd
 }
 
 func buildHelp(entry *definitions.Entry) string {
@@ -95,6 +167,31 @@ func buildHelp(entry *definitions.Entry) string {
 	fmt.Fprintf(buf, "Path:       %s\n", entry.Route)
 	fmt.Fprintf(buf, "Stability:  %s\n", entry.Stability)
 	fmt.Fprintf(buf, "JSON Input: %s\n", jsonInput)
+	if entry.Input != "" {
+		fmt.Fprintln(buf, "")
+		fmt.Fprintln(buf, "The payload is read from stdin, or given with --body as JSON or as")
+		fmt.Fprintln(buf, "@<filename>; --schema prints the JSON schema it must match.")
+	}
+	if entry.Output != "" {
+		fmt.Fprintln(buf, "")
+		fmt.Fprintln(buf, "--schema=output prints the JSON schema of the response.")
+	}
+	if entry.Download {
+		fmt.Fprintln(buf, "")
+		fmt.Fprintln(buf, "The content of the artifact is written to stdout, or to --output, as it")
+		fmt.Fprintln(buf, "is received.")
+	}
+	if entry.Upload {
+		fmt.Fprintln(buf, "")
+		fmt.Fprintln(buf, "The content of the artifact is read from stdin, or from the file given")
+		fmt.Fprintln(buf, "with --input, as it is sent.")
+	}
+	if entry.PutURL {
+		fmt.Fprintln(buf, "")
+		fmt.Fprintln(buf, "With --input <file>, the file is then uploaded to the putUrl of the")
+		fmt.Fprintln(buf, "response; if the payload of an s3 artifact has no contentType, that of")
+		fmt.Fprintln(buf, "the file is detected from its extension or its content.")
+	}
 	fmt.Fprintln(buf, "")
 	fmt.Fprint(buf, entry.Description)
 
@@ -103,6 +200,17 @@ func buildHelp(entry *definitions.Entry) string {
 
 func buildExecutor(service definitions.Service, entry definitions.Entry) func(*cobra.Command, []string) error {
 	return func(cmd *cobra.Command, args []string) error {
+		// Print a schema instead of calling the method, if asked to; no
+		// arguments are needed
+		if flag := cmd.Flags().Lookup("schema"); flag != nil && flag.Changed {
+			schema, err := entrySchema(service.ServiceName, &entry, flag.Value.String())
+			if err != nil {
+				return err
+			}
+			_, err = cmd.OutOrStdout().Write(schema)
+			return err
+		}
+
 		// validate that we have as much arguments as in the definition
 		if len(args) < len(entry.Args) {
 			return errors.New("Insufficient arguments given")
@@ -128,11 +236,56 @@ func buildExecutor(service definitions.Service, entry definitions.Entry) func(*c
 			}
 		}
 
-		// Read payload if present
-		var input io.Reader = os.Stdin
-		if payload, ok := argmap["payload"]; ok {
-			if payload != "-" {
-				input = bytes.NewBufferString(payload)
+		// The file to upload to the putUrl of the response, if any
+		upload := ""
+		if entry.PutURL {
+			upload, _ = fs.GetString("input")
+		}
+
+		// Read and validate the payload, if the method takes one; by default
+		// it is read from stdin
+		var input io.Reader
+		var payload []byte
+		if entry.Input != "" {
+			source := "-"
+			if payload, ok := argmap["payload"]; ok {
+				source = payload
+			}
+			if flag := cmd.Flags().Lookup("body"); flag != nil && flag.Changed {
+				source = flag.Value.String()
+			}
+			body, err := readBody(source, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			if upload != "" {
+				// complete the payload before validating it
+				if body, err = withContentType(body, upload); err != nil {
+					return err
+				}
+			}
+			if noValidate, _ := cmd.Flags().GetBool("no-validate"); !noValidate {
+				if err := validateBody(service.ServiceName, entry.Input, body); err != nil {
+					return err
+				}
+			}
+			input = bytes.NewReader(body)
+			payload = body
+		}
+
+		// Print a signed URL instead of calling the method, if asked to
+		if entry.SignedURL {
+			if flag := fs.Lookup("sign-url"); flag != nil && flag.Changed {
+				duration, err := fs.GetDuration("sign-url")
+				if err != nil {
+					return err
+				}
+				signed, err := signEntryURL(nil, service.ServiceName, service.APIVersion, &entry, argmap, query, duration)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), signed)
+				return nil
 			}
 		}
 
@@ -148,24 +301,196 @@ func buildExecutor(service definitions.Service, entry definitions.Entry) func(*c
 			output = f
 		}
 
-		return execute(service.ServiceName, service.APIVersion, &entry, argmap, query, input, output)
+		// Select the output format
+		format := ""
+		if flag := cmd.Flags().Lookup("format"); flag != nil {
+			format = flag.Value.String()
+		}
+		if !isOutputFormat(format) {
+			return fmt.Errorf("unsupported output format '%s'", format)
+		}
+
+		// Check the query, before making the call
+		selection := ""
+		if flag := cmd.Flags().Lookup("query"); flag != nil {
+			selection = flag.Value.String()
+		}
+		if selection != "" {
+			if _, err := parseQuery(selection); err != nil {
+				return err
+			}
+		}
+
+		// Check the fields, likewise
+		fields := ""
+		if flag := cmd.Flags().Lookup("fields"); flag != nil {
+			fields = flag.Value.String()
+		}
+		if fields != "" {
+			if _, _, err := parseFields(fields); err != nil {
+				return err
+			}
+		}
+
+		// Stream the content of artifacts, rather than holding it in memory
+		if entry.Download || entry.Upload {
+			if format != "" || selection != "" || fields != "" {
+				return fmt.Errorf("--format, --query and --fields cannot be used with %s, whose response is streamed as received", entry.Name)
+			}
+			return runStream(cmd, service, &entry, argmap, query, output)
+		}
+
+		// Follow the continuationToken, if asked to
+		run := execute
+		if entry.Paginated {
+			all, err := fs.GetBool("all")
+			if err != nil {
+				return err
+			}
+			if all {
+				run = executeAll
+			}
+		}
+
+		var result []byte
+		var err error
+		if upload != "" {
+			result, err = executeUpload(context.Background(), nil, service.ServiceName, service.APIVersion, &entry, argmap, query, payload, upload)
+		} else {
+			result, err = run(context.Background(), nil, service.ServiceName, service.APIVersion, &entry, argmap, query, input)
+		}
+		if err != nil {
+			reportAPICallError(cmd, err)
+			return err
+		}
+
+		// Keep only the given fields, of all pages if --all was given; the
+		// result is printed as JSON by default, as for --query
+		if fields != "" {
+			ignoreMissing, _ := cmd.Flags().GetBool("ignore-missing")
+			if result, err = selectFields(result, fields, ignoreMissing); err != nil {
+				return err
+			}
+			if format == "" && selection == "" {
+				format = "json"
+			}
+		}
+
+		// Print the response, or the selected part of it, to whatever output
+		if selection != "" {
+			return writeQueryResult(output, format, selection, result)
+		}
+		err = writeResult(output, format, result)
+		if err != nil {
+			return fmt.Errorf("Failed to print response: %s", err)
+		}
+
+		return nil
 	}
 }
 
-func execute(
-	serviceName string, apiVersion string, entry *definitions.Entry, args, query map[string]string,
-	payload io.Reader, output io.Writer,
+// runStream calls the API method described by entry with executeStream,
+// copying the response body to output.
+func runStream(
+	cmd *cobra.Command, service definitions.Service, entry *definitions.Entry, args, query map[string]string,
+	output io.Writer,
 ) error {
+	var body io.Reader
+	if entry.Upload {
+		source, err := cmd.Flags().GetString("input")
+		if err != nil {
+			return err
+		}
+		if source == "-" {
+			body = cmd.InOrStdin()
+		} else {
+			f, err := os.Open(source)
+			if err != nil {
+				return fmt.Errorf("Failed to open input file, error: %s", err)
+			}
+			defer f.Close()
+			body = f
+		}
+	}
+
+	res, err := executeStream(context.Background(), nil, service.ServiceName, service.APIVersion, entry, args, query, body)
+	if err != nil {
+		reportAPICallError(cmd, err)
+		return err
+	}
+	defer res.Close()
+
+	if _, err := io.Copy(output, res); err != nil {
+		return fmt.Errorf("Failed to write response: %s", err)
+	}
+	return nil
+}
+
+// reportAPICallError prints errors from the service as just `code: message`,
+// without cobra's prefix and usage, or as a log entry with their requestId and
+// statusCode with `--log-format json`.
+func reportAPICallError(cmd *cobra.Command, err error) {
+	if apiErr, ok := err.(*client.APICallError); ok {
+		root.Report(cmd.ErrOrStderr(), logrus.ErrorLevel, root.ErrorFields(apiErr), apiErr.Error())
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+	}
+}
+
+// execute calls the API method described by entry, with the root URL and
+// credentials of conn, returning the response body.  Cancelling ctx aborts the
+// request, as does exceeding the configured timeout.
+func execute(
+	ctx context.Context, conn *connection, serviceName string, apiVersion string, entry *definitions.Entry, args, query map[string]string,
+	payload io.Reader,
+) ([]byte, error) {
 	var input []byte
 	// Read all input
 	if entry.Input != "" {
 		data, err := ioutil.ReadAll(payload)
 		if err != nil {
-			return fmt.Errorf("Failed to read input, error: %s", err)
+			return nil, fmt.Errorf("Failed to read input, error: %s", err)
 		}
 		input = data
 	}
 
+	method := strings.ToUpper(entry.Method)
+	url := entryURL(conn, serviceName, apiVersion, entry, args, query)
+
+	// Bound the request, including its retries, by the configured timeout
+	reqCtx, cancel, timeout := withTimeout(ctx, entry)
+	defer cancel()
+
+	// Send the request, retrying transient failures of idempotent requests
+	c := client.New(conn.Credentials())
+	c.Logger = root.Logger
+	c.Limiter = requestLimiter()
+	c.TraceID = config.TraceID
+	res, err := c.Request(reqCtx, method, url, input)
+	if err != nil {
+		return nil, requestError(ctx, reqCtx, timeout, err)
+	}
+
+	return res.Body, nil
+}
+
+// requestError describes the error of a request sent with reqCtx, derived
+// from ctx by withTimeout.
+func requestError(ctx, reqCtx context.Context, timeout time.Duration, err error) error {
+	// errors from the service are returned as they are, so that the exit
+	// status can reflect them
+	if _, ok := err.(*client.APICallError); ok {
+		return err
+	}
+	if reqCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		return fmt.Errorf("Request timed out after %s (see --timeout and --io-timeout)", timeout)
+	}
+	return fmt.Errorf("Request failed: %s", err)
+}
+
+// entryURL builds the URL of a call to the API method described by entry, at
+// the root URL of conn.
+func entryURL(conn *connection, serviceName string, apiVersion string, entry *definitions.Entry, args, query map[string]string) string {
 	// Parameterize the route
 	route := entry.Route
 	for k, v := range args {
@@ -183,51 +508,22 @@ func execute(
 		q = "?" + q
 	}
 
-	// Construct parameters
-	method := strings.ToUpper(entry.Method)
-	url := tcurls.API(config.RootURL(), serviceName, apiVersion, route+q)
-
-	// Try to make the request up to 5 times using go-got
-	// Allow unlimited responses.
-	g := got.New()
-	g.Retries = 5
-	g.MaxSize = 0
-
-	req := g.NewRequest(method, url, input)
-
-	// If there is a body, we set a content-type
-	if len(input) != 0 {
-		req.Header.Set("Content-Type", "application/json")
-	}
-
-	// Sign request if credentials are available
-	if config.Credentials != nil {
-		var h hash.Hash
-		// Create payload hash if there is any
-		if len(input) != 0 {
-			h = client.PayloadHash("application/json")
-			_, err := h.Write(input)
-			if err != nil {
-				return fmt.Errorf("Failed to write hash, error: %s", err)
-			}
-		}
-		err := config.Credentials.SignGotRequest(req, h)
-		if err != nil {
-			return fmt.Errorf("Failed to sign request, error: %s", err)
-		}
-	}
+	return tcurls.API(conn.RootURL(), serviceName, apiVersion, route+q)
+}
 
-	res, err := req.Send()
-	if err != nil {
-		return fmt.Errorf("Request failed: %s", err)
+// signEntryURL returns a URL for the API method described by entry, signed
+// with the credentials of conn and valid for the given duration.
+func signEntryURL(
+	conn *connection, serviceName string, apiVersion string, entry *definitions.Entry, args, query map[string]string,
+	duration time.Duration,
+) (string, error) {
+	credentials := conn.Credentials()
+	if credentials == nil {
+		return "", errors.New("Signing a URL requires credentials")
 	}
-
-	// Print the request to whatever output
-	_, err = output.Write(res.Body)
+	signed, err := credentials.SignURL(entryURL(conn, serviceName, apiVersion, entry, args, query), duration)
 	if err != nil {
-		return fmt.Errorf("Failed to print response: %s", err)
+		return "", fmt.Errorf("Failed to sign URL, error: %s", err)
 	}
-
-	// Exit
-	return nil
+	return signed, nil
 }
@@ -6,10 +6,13 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	assert "github.com/stretchr/testify/require"
 	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/client"
 	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
 )
 
@@ -106,3 +109,389 @@ func apiHandler(w http.ResponseWriter, r *http.Request) {
 		_, _ = io.WriteString(w, "true")
 	}
 }
+
+func TestPaginatedCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	// serve three pages of results, checking that each request passes back
+	// the previous page's continuationToken
+	pages := map[string]string{
+		"":  `{"things": [1, 2], "continuationToken": "a"}`,
+		"a": `{"things": [3], "continuationToken": "b"}`,
+		"b": `{"things": [], "extra": true}`,
+	}
+	requests := 0
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/test/v1/things", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal("10", r.URL.Query().Get("limit"))
+		_, _ = io.WriteString(w, pages[r.URL.Query().Get("continuationToken")])
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	config.SetRootURL(server.URL)
+
+	cmd := makeCmdFromDefinition("Test", definitions.Service{
+		ServiceName: "test",
+		APIVersion:  "v1",
+		Entries: []definitions.Entry{
+			definitions.Entry{
+				Name:      "listThings",
+				Method:    "get",
+				Route:     "/things",
+				Args:      []string{},
+				Query:     []string{"continuationToken", "limit"},
+				Paginated: true,
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+	cmd.SetOutput(buf)
+
+	// without --all, only the first page is returned
+	cmd.SetArgs([]string{"listThings", "--limit", "10"})
+	assert.NoError(cmd.Execute())
+	assert.Equal(pages[""], buf.String())
+	assert.Equal(1, requests)
+
+	// with --all, the pages are fetched and concatenated
+	requests = 0
+	buf.Reset()
+	cmd.SetArgs([]string{"listThings", "--limit", "10", "--all"})
+	assert.NoError(cmd.Execute())
+	assert.JSONEq(`{"things": [1, 2, 3], "extra": true}`, buf.String())
+	assert.Equal(3, requests)
+}
+
+func TestPaginatedCommandSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	// serve pages of queue's listTaskGroup, whose output schema says that
+	// only tasks is an array; the scalars change from page to page
+	pages := map[string]string{
+		"":  `{"taskGroupId": "first", "tasks": [{"n": 1}, {"n": 2}], "continuationToken": "a"}`,
+		"a": `{"taskGroupId": "second", "tasks": [], "continuationToken": "b"}`,
+		"b": `{"taskGroupId": "last", "tasks": [{"n": 3}]}`,
+	}
+	requests := 0
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/queue/v1/task-group/abc/list", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = io.WriteString(w, pages[r.URL.Query().Get("continuationToken")])
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	config.SetRootURL(server.URL)
+
+	cmd := makeCmdFromDefinition("Queue", definitions.Service{
+		ServiceName: "queue",
+		APIVersion:  "v1",
+		Entries: []definitions.Entry{
+			definitions.Entry{
+				Name:      "listTaskGroup",
+				Method:    "get",
+				Route:     "/task-group/<taskGroupId>/list",
+				Args:      []string{"taskGroupId"},
+				Query:     []string{"continuationToken", "limit"},
+				Output:    "v1/list-task-group-response.json#",
+				Paginated: true,
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+	cmd.SetOutput(buf)
+
+	cmd.SetArgs([]string{"listTaskGroup", "abc", "--all"})
+	assert.NoError(cmd.Execute())
+	assert.JSONEq(`{"taskGroupId": "last", "tasks": [{"n": 1}, {"n": 2}, {"n": 3}]}`, buf.String())
+	assert.Equal(3, requests)
+
+	// a page whose tasks are not an array cannot be merged
+	pages["b"] = `{"taskGroupId": "last", "tasks": "oops"}`
+	buf.Reset()
+	cmd.SetArgs([]string{"listTaskGroup", "abc", "--all"})
+	err := cmd.Execute()
+	assert.Error(err)
+	assert.Contains(err.Error(), "expected an array for 'tasks'")
+}
+
+func TestPaginatedCommandSchemaRef(t *testing.T) {
+	assert := assert.New(t)
+
+	// the requests of purge-cache's allPurgeRequests are an array given by a
+	// $ref to another schema
+	pages := map[string]string{
+		"":  `{"requests": [{"cacheName": "a"}], "continuationToken": "a"}`,
+		"a": `{"requests": [{"cacheName": "b"}, {"cacheName": "c"}]}`,
+	}
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/purge-cache/v1/purge-cache/list", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, pages[r.URL.Query().Get("continuationToken")])
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	config.SetRootURL(server.URL)
+
+	cmd := makeCmdFromDefinition("PurgeCache", definitions.Service{
+		ServiceName: "purge-cache",
+		APIVersion:  "v1",
+		Entries: []definitions.Entry{
+			definitions.Entry{
+				Name:      "allPurgeRequests",
+				Method:    "get",
+				Route:     "/purge-cache/list",
+				Args:      []string{},
+				Query:     []string{"continuationToken", "limit"},
+				Output:    "v1/all-purge-cache-request-list.json#",
+				Paginated: true,
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+	cmd.SetOutput(buf)
+
+	cmd.SetArgs([]string{"allPurgeRequests", "--all"})
+	assert.NoError(cmd.Execute())
+	assert.JSONEq(`{"requests": [{"cacheName": "a"}, {"cacheName": "b"}, {"cacheName": "c"}]}`, buf.String())
+}
+
+func TestArrayFields(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(
+		map[string]bool{"continuationToken": false, "taskGroupId": false, "tasks": true},
+		arrayFields("queue", &definitions.Entry{Output: "v1/list-task-group-response.json#"}),
+	)
+	assert.Nil(arrayFields("queue", &definitions.Entry{}))
+	assert.Nil(arrayFields("queue", &definitions.Entry{Output: "v1/no-such-schema.json#"}))
+
+	// $refs are resolved
+	assert.Equal(
+		map[string]bool{"continuationToken": false, "requests": true},
+		arrayFields("purge-cache", &definitions.Entry{Output: "v1/all-purge-cache-request-list.json#"}),
+	)
+
+	defer func() {
+		delete(schemas, "/schemas/test/v1/list.json")
+		delete(schemas, "/schemas/test/v1/items.json")
+	}()
+	schemas["/schemas/test/v1/items.json"] = `{
+		"definitions": {"item": {"type": "object"}, "items": {"type": "array"}},
+		"type": "array"
+	}`
+	schemas["/schemas/test/v1/list.json"] = `{"properties": {
+		"relative": {"$ref": "items.json#"},
+		"absolute": {"$ref": "/schemas/test/v1/items.json"},
+		"pointer": {"$ref": "items.json#/definitions/item"},
+		"local": {"$ref": "#/properties/relative"},
+		"oneOf": {"oneOf": [{"type": "array"}, {"$ref": "items.json#/definitions/items"}]},
+		"anyOf": {"anyOf": [{"type": "string"}, {"type": "number"}]},
+		"mixed": {"oneOf": [{"type": "array"}, {"type": "null"}]},
+		"missing": {"$ref": "no-such-schema.json#"},
+		"untyped": {"description": "anything"}
+	}}`
+	// those whose type is not known are left out, and merged if they are
+	// arrays in the responses
+	assert.Equal(
+		map[string]bool{"relative": true, "absolute": true, "pointer": false, "local": true, "oneOf": true, "anyOf": false},
+		arrayFields("test", &definitions.Entry{Output: "v1/list.json#"}),
+	)
+}
+
+func TestMergePage(t *testing.T) {
+	assert := assert.New(t)
+
+	// without a schema, arrays are appended and the last page's other values
+	// are kept
+	merged, err := mergePage(nil, map[string]interface{}{"a": []interface{}{1}, "b": "first"}, nil)
+	assert.NoError(err)
+	merged, err = mergePage(merged, map[string]interface{}{"a": []interface{}{2}, "b": "second", "c": []interface{}{3}}, nil)
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{
+		"a": []interface{}{1, 2},
+		"b": "second",
+		"c": []interface{}{3},
+	}, merged)
+
+	// with a schema, only its array fields are appended
+	arrays := map[string]bool{"a": true, "b": false}
+	merged, err = mergePage(nil, map[string]interface{}{"a": []interface{}{1}, "b": []interface{}{"first"}}, arrays)
+	assert.NoError(err)
+	merged, err = mergePage(merged, map[string]interface{}{"a": nil, "b": []interface{}{"second"}}, arrays)
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{
+		"a": []interface{}{1},
+		"b": []interface{}{"second"},
+	}, merged)
+
+	_, err = mergePage(merged, map[string]interface{}{"a": "x"}, arrays)
+	assert.Error(err)
+
+	// properties whose type is not known are appended if they are arrays
+	merged, err = mergePage(nil, map[string]interface{}{"c": []interface{}{1}, "d": "first"}, arrays)
+	assert.NoError(err)
+	merged, err = mergePage(merged, map[string]interface{}{"c": []interface{}{2}, "d": "second"}, arrays)
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{"c": []interface{}{1, 2}, "d": "second"}, merged)
+}
+
+func TestCommandBody(t *testing.T) {
+	assert := assert.New(t)
+
+	// echo the request body back
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/test/v1/things", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(w, r.Body)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	config.SetRootURL(server.URL)
+
+	cmd := makeCmdFromDefinition("Test", definitions.Service{
+		ServiceName: "test",
+		APIVersion:  "v1",
+		Entries: []definitions.Entry{
+			definitions.Entry{
+				Name:   "createThing",
+				Method: "put",
+				Route:  "/things",
+				Args:   []string{},
+				Query:  []string{},
+				Input:  "v1/create-thing-request.json#",
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+	cmd.SetOutput(buf)
+
+	// from stdin, by default
+	cmd.SetIn(bytes.NewBufferString(`{"from": "stdin"}`))
+	cmd.SetArgs([]string{"createThing"})
+	assert.NoError(cmd.Execute())
+	assert.Equal(`{"from": "stdin"}`, buf.String())
+
+	// from --body
+	buf.Reset()
+	cmd.SetArgs([]string{"createThing", "--body", `{"from": "flag"}`})
+	assert.NoError(cmd.Execute())
+	assert.Equal(`{"from": "flag"}`, buf.String())
+
+	// invalid JSON is not sent
+	buf.Reset()
+	cmd.SetArgs([]string{"createThing", "--body", `{"from": `})
+	assert.Error(cmd.Execute())
+}
+
+func TestCommandAPICallError(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.NewServeMux()
+	var traceID string
+	handler.HandleFunc("/api/test/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		traceID = r.Header.Get(client.TraceHeader)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"code": "ResourceNotFound", "message": "No such thing", "requestInfo": {}}`)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	config.SetRootURL(server.URL)
+	config.TraceID = "trace"
+	defer func() { config.TraceID = "" }()
+
+	cmd := makeCmdFromDefinition("Test", servicesTest["Test"])
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+
+	// the error is printed as `code: message`, without usage
+	cmd.SetArgs([]string{"test"})
+	err := cmd.Execute()
+	apiErr, ok := err.(*client.APICallError)
+	assert.True(ok, "expected an *client.APICallError, got %T", err)
+	assert.Equal(http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal("ResourceNotFound: No such thing\n", stderr.String())
+
+	// the request carries the trace ID, as does the error
+	assert.Equal("trace", traceID)
+	assert.Equal("trace", apiErr.TraceID)
+	assert.Equal("", stdout.String())
+}
+
+func TestCommandSignURL(t *testing.T) {
+	assert := assert.New(t)
+
+	config.SetRootURL("https://tc.example.com")
+	defer func(creds *client.Credentials) { config.Credentials = creds }(config.Credentials)
+	config.Credentials = &client.Credentials{ClientID: "tester", AccessToken: "no-secret"}
+
+	cmd := makeCmdFromDefinition("Test", definitions.Service{
+		ServiceName: "test",
+		APIVersion:  "v1",
+		Entries: []definitions.Entry{
+			definitions.Entry{
+				Name:      "getThing",
+				Method:    "get",
+				Route:     "/things/<thingId>",
+				Args:      []string{"thingId"},
+				Query:     []string{"format"},
+				SignedURL: true,
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+	cmd.SetOutput(buf)
+
+	// the URL is printed, and no request is made
+	cmd.SetArgs([]string{"getThing", "abc", "--format", "zip", "--sign-url", "15m"})
+	assert.NoError(cmd.Execute())
+	signed, err := url.Parse(strings.TrimSpace(buf.String()))
+	assert.NoError(err)
+	assert.Equal("https://tc.example.com/api/test/v1/things/abc", signed.Scheme+"://"+signed.Host+signed.Path)
+	assert.Equal("zip", signed.Query().Get("format"))
+	assert.NotEmpty(signed.Query().Get("bewit"))
+
+	// signing requires credentials
+	config.Credentials = nil
+	cmd.SetArgs([]string{"getThing", "abc", "--sign-url", "15m"})
+	assert.Error(cmd.Execute())
+}
+
+func TestCommandAlias(t *testing.T) {
+	assert := assert.New(t)
+
+	providerServer := apiServer()
+	config.SetRootURL(providerServer.URL)
+	defer providerServer.Close()
+
+	def := servicesTest["Test"]
+	entry := def.Entries[0]
+	entry.Aliases = []string{"oldTest"}
+	cmd := makeCmdFromDefinition("Test", definitions.Service{
+		ServiceName: def.ServiceName,
+		APIVersion:  def.APIVersion,
+		Entries:     []definitions.Entry{entry},
+	})
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+
+	// the alias calls the same method, with the same flags
+	alias, _, err := cmd.Find([]string{"oldTest"})
+	assert.NoError(err)
+	assert.Equal("oldTest", alias.Name())
+	assert.True(alias.Hidden, "the alias should not be listed")
+
+	val := "kept"
+	cmd.SetArgs([]string{"oldTest", "--key", val})
+	assert.NoError(cmd.Execute())
+	assert.Equal(val, stdout.String())
+	assert.Contains(stderr.String(), `Command "oldTest" is deprecated, use 'test' instead`)
+
+	// the current name does not warn
+	stdout.Reset()
+	stderr.Reset()
+	cmd.SetArgs([]string{"test", "--key", val})
+	assert.NoError(cmd.Execute())
+	assert.Equal(val, stdout.String())
+	assert.Empty(stderr.String())
+}
@@ -0,0 +1,141 @@
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// querySegment is one step of a `--query` path: a field of an object, an
+// index into a list, or a wildcard (`[*]`) matching every item of a list.
+type querySegment struct {
+	field    string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// parseQuery parses a `--query` path, such as `status.state`,
+// `tasks[0].status` or `$.tasks[*].status.taskId`: fields separated by dots,
+// and list indexes or wildcards in square brackets, optionally preceded by
+// `$`, as in JSONPath.
+func parseQuery(query string) ([]querySegment, error) {
+	rest := strings.TrimPrefix(query, "$")
+	var segments []querySegment
+	for rest != "" {
+		switch {
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid query '%s': missing ']'", query)
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+			if inner == "*" {
+				segments = append(segments, querySegment{wildcard: true})
+				continue
+			}
+			index, err := strconv.Atoi(inner)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid query '%s': '[%s]' is not a list index or [*]", query, inner)
+			}
+			segments = append(segments, querySegment{index: index, isIndex: true})
+		default:
+			// fields follow a dot, except at the start of the query
+			if rest[0] == '.' {
+				rest = rest[1:]
+			} else if len(segments) > 0 {
+				return nil, fmt.Errorf("invalid query '%s': expected '.' or '[' before '%s'", query, rest)
+			}
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid query '%s': empty field name", query)
+			}
+			segments = append(segments, querySegment{field: rest[:end]})
+			rest = rest[end:]
+		}
+	}
+	return segments, nil
+}
+
+// selectQuery returns the part of value selected by a `--query` path.  With
+// a wildcard, the result is the list of everything matched.  It is an error
+// if the path matches nothing.
+func selectQuery(value interface{}, query string) (interface{}, error) {
+	segments, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []interface{}{value}
+	wildcard := false
+	for _, segment := range segments {
+		var next []interface{}
+		for _, match := range matches {
+			switch {
+			case segment.wildcard:
+				if list, ok := match.([]interface{}); ok {
+					next = append(next, list...)
+				}
+			case segment.isIndex:
+				if list, ok := match.([]interface{}); ok && segment.index < len(list) {
+					next = append(next, list[segment.index])
+				}
+			default:
+				if object, ok := match.(map[string]interface{}); ok {
+					if field, ok := object[segment.field]; ok {
+						next = append(next, field)
+					}
+				}
+			}
+		}
+		matches = next
+		wildcard = wildcard || segment.wildcard
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("query '%s' matches nothing in the response", query)
+	}
+	if wildcard {
+		return matches, nil
+	}
+	return matches[0], nil
+}
+
+// writeQueryResult writes the part of the JSON response body selected by a
+// `--query` path to output, in the given format.  Without a format, strings
+// are written as they are, for use in scripts, and anything else as JSON.
+func writeQueryResult(output io.Writer, format, query string, body []byte) error {
+	// keep numbers, and characters such as '<', exactly as received
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("response is not valid JSON: %s", err)
+	}
+	selected, err := selectQuery(value, query)
+	if err != nil {
+		return err
+	}
+
+	if s, ok := selected.(string); ok && format == "" {
+		_, err = fmt.Fprintln(output, s)
+		return err
+	}
+	if format == "" {
+		format = "json"
+	}
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(selected); err != nil {
+		return err
+	}
+	return writeResult(output, format, bytes.TrimSpace(buf.Bytes()))
+}
@@ -0,0 +1,96 @@
+package apis
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+func queryResult(t *testing.T, format, query, body string) string {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, writeQueryResult(buf, format, query, []byte(body)))
+	return buf.String()
+}
+
+func TestWriteQueryResult(t *testing.T) {
+	assert := assert.New(t)
+
+	// strings are printed as they are, for scripts
+	assert.Equal("tok\n", queryResult(t, "", "continuationToken", listResponse))
+	assert.Equal("bb\n", queryResult(t, "", "things[1].name", listResponse))
+	assert.Equal("bb\n", queryResult(t, "", "$.things[1].name", listResponse))
+
+	// anything else is JSON, with numbers as received
+	assert.Equal("10000000\n", queryResult(t, "", "things[1].count", listResponse))
+	assert.Equal("[\n  \"x\"\n]\n", queryResult(t, "", "things[0].tags", listResponse))
+	assert.Equal("\"<a & b>\"\n", queryResult(t, "json", "s", `{"s": "<a & b>"}`))
+
+	// wildcards select a list
+	assert.Equal("[\n  \"a\",\n  \"bb\"\n]\n", queryResult(t, "", "things[*].name", listResponse))
+	assert.Equal("- a\n- bb\n", queryResult(t, "yaml", "things[*].name", listResponse))
+	assert.Equal("[\n  \"x\"\n]\n", queryResult(t, "json", "things[*].tags[*]", listResponse))
+
+	// and compose with tables
+	assert.Equal("COUNT     NAME\n1         a\n10000000  bb\n", queryResult(t, "table", "things[*]",
+		`{"things":[{"name":"a","count":1},{"name":"bb","count":10000000}]}`))
+}
+
+func TestWriteQueryResultNoMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, query := range []string{"status.state", "things[2]", "things.name", "things[*].missing", "continuationToken[*]"} {
+		err := writeQueryResult(&bytes.Buffer{}, "", query, []byte(listResponse))
+		assert.EqualError(err, "query '"+query+"' matches nothing in the response")
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	segments, err := parseQuery("tasks[*].status.runs[0]")
+	assert.NoError(err)
+	assert.Equal([]querySegment{
+		{field: "tasks"},
+		{wildcard: true},
+		{field: "status"},
+		{field: "runs"},
+		{index: 0, isIndex: true},
+	}, segments)
+
+	for _, invalid := range []string{"a..b", "a[", "a[x]", "a[-1]", "a[0]b", ".", "a."} {
+		_, err := parseQuery(invalid)
+		assert.Error(err, invalid)
+	}
+}
+
+func TestCommandQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = io.WriteString(w, `{"status": {"state": "completed"}}`)
+	}))
+	defer server.Close()
+	config.SetRootURL(server.URL)
+
+	cmd := makeCmdFromDefinition("Test", servicesTest["Test"])
+	cmd.PersistentFlags().StringP("format", "f", "", "Output format")
+	cmd.PersistentFlags().String("query", "", "Query")
+	buf := &bytes.Buffer{}
+	cmd.SetOutput(buf)
+
+	cmd.SetArgs([]string{"test", "--query", "status.state"})
+	assert.NoError(cmd.Execute())
+	assert.Equal("completed\n", buf.String())
+
+	// invalid queries are reported without calling the method
+	cmd.SetArgs([]string{"test", "--query", "status[x]"})
+	assert.Error(cmd.Execute())
+	assert.Equal(1, calls)
+}
@@ -0,0 +1,30 @@
+package apis
+
+import (
+	"sync"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/client"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+var (
+	limiterMutex sync.Mutex
+	limiter      *client.RateLimiter
+)
+
+// requestLimiter returns the rate limiter shared by all the API calls of the
+// process, whether made by commands or by the methods of the service clients,
+// as configured by config.RateLimit and config.RateBurst; it is nil if there
+// is no limit.
+func requestLimiter() *client.RateLimiter {
+	limiterMutex.Lock()
+	defer limiterMutex.Unlock()
+
+	if config.RateLimit <= 0 {
+		return nil
+	}
+	if limiter == nil || limiter.Rate() != config.RateLimit || limiter.Burst() != config.RateBurst {
+		limiter = client.NewRateLimiter(config.RateLimit, config.RateBurst)
+	}
+	return limiter
+}
@@ -0,0 +1,29 @@
+package apis
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+func TestRequestLimiter(t *testing.T) {
+	assert := assert.New(t)
+	defer func() { config.RateLimit, config.RateBurst = 0, config.DefaultRateBurst }()
+
+	assert.Nil(requestLimiter(), "requests are not limited by default")
+
+	// all calls share the same limiter
+	config.RateLimit = 5
+	first := requestLimiter()
+	assert.NotNil(first)
+	assert.Equal(5.0, first.Rate())
+	assert.Equal(config.DefaultRateBurst, first.Burst())
+	assert.True(first == requestLimiter(), "the limiter is not shared")
+
+	// until the configuration changes
+	config.RateBurst = 3
+	second := requestLimiter()
+	assert.True(first != second, "the limiter was not reconfigured")
+	assert.Equal(3, second.Burst())
+}
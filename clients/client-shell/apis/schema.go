@@ -0,0 +1,50 @@
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// schemaPath returns the path in schemas of a schema of a service, given as
+// in its reference, such as `v1/create-task-request.json#`.
+func schemaPath(serviceName, ref string) string {
+	return "/schemas/" + serviceName + "/" + strings.SplitN(ref, "#", 2)[0]
+}
+
+// entrySchema returns the input or output schema of the API method described
+// by entry, indented.  Schemas it refers to are not included.
+func entrySchema(serviceName string, entry *definitions.Entry, which string) ([]byte, error) {
+	var ref string
+	switch which {
+	case "input":
+		ref = entry.Input
+		if ref == "" && entry.Output != "" {
+			return nil, fmt.Errorf("%s takes no payload, and so has no input schema; see --schema=output for that of its response", entry.Name)
+		}
+		if ref == "" {
+			return nil, fmt.Errorf("%s takes no payload, and so has no input schema", entry.Name)
+		}
+	case "output":
+		ref = entry.Output
+		if ref == "" {
+			return nil, fmt.Errorf("the response of %s is not JSON, and so has no output schema", entry.Name)
+		}
+	default:
+		return nil, fmt.Errorf("unknown schema '%s', expected input or output", which)
+	}
+
+	schema, ok := schemas[schemaPath(serviceName, ref)]
+	if !ok {
+		return nil, fmt.Errorf("the schema %s of %s is not known", ref, entry.Name)
+	}
+	buf := &bytes.Buffer{}
+	if err := json.Indent(buf, []byte(schema), "", "  "); err != nil {
+		return nil, fmt.Errorf("Failed to print schema %s, error: %s", ref, err)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
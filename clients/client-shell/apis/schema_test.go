@@ -0,0 +1,87 @@
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+func TestEntrySchema(t *testing.T) {
+	assert := assert.New(t)
+
+	service, entry, err := lookupEntry("Queue", "createTask")
+	assert.NoError(err)
+
+	schema, err := entrySchema(service.ServiceName, entry, "input")
+	assert.NoError(err)
+	var doc map[string]interface{}
+	assert.NoError(json.Unmarshal(schema, &doc))
+	assert.Equal("/schemas/queue/v1/create-task-request.json#", doc["$id"])
+	assert.Contains(string(schema), "\n  \"$id\": ")
+
+	schema, err = entrySchema(service.ServiceName, entry, "output")
+	assert.NoError(err)
+	assert.Contains(string(schema), `"$id": "/schemas/queue/v1/task-status-response.json#"`)
+
+	_, err = entrySchema(service.ServiceName, entry, "payload")
+	assert.EqualError(err, "unknown schema 'payload', expected input or output")
+
+	_, entry, err = lookupEntry("Queue", "status")
+	assert.NoError(err)
+	_, err = entrySchema(service.ServiceName, entry, "input")
+	assert.EqualError(err, "status takes no payload, and so has no input schema; see --schema=output for that of its response")
+}
+
+// Every schema named by an entry is embedded.
+func TestEntrySchemaAllEmbedded(t *testing.T) {
+	for _, service := range services {
+		for i := range service.Entries {
+			entry := &service.Entries[i]
+			if entry.Input != "" {
+				if _, err := entrySchema(service.ServiceName, entry, "input"); err != nil {
+					t.Errorf("%s.%s: %s", service.ServiceName, entry.Name, err)
+				}
+			}
+			if entry.Output != "" {
+				if _, err := entrySchema(service.ServiceName, entry, "output"); err != nil {
+					t.Errorf("%s.%s: %s", service.ServiceName, entry.Name, err)
+				}
+			}
+		}
+	}
+}
+
+func TestCommandSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	service := services["Queue"]
+	cmd := makeCmdFromDefinition("Queue", definitions.Service{
+		ServiceName: service.ServiceName,
+		APIVersion:  service.APIVersion,
+		Entries: []definitions.Entry{
+			{
+				Name:   "createTask",
+				Method: "put",
+				Route:  "/task/<taskId>",
+				Args:   []string{"taskId"},
+				Input:  "v1/create-task-request.json#",
+				Output: "v1/task-status-response.json#",
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+	cmd.SetOutput(buf)
+
+	// no arguments or payload are needed, and no request is made
+	cmd.SetArgs([]string{"createTask", "--schema"})
+	assert.NoError(cmd.Execute())
+	assert.Contains(buf.String(), `"$id": "/schemas/queue/v1/create-task-request.json#"`)
+
+	buf.Reset()
+	cmd.SetArgs([]string{"createTask", "--schema=output"})
+	assert.NoError(cmd.Execute())
+	assert.Contains(buf.String(), `"$id": "/schemas/queue/v1/task-status-response.json#"`)
+}
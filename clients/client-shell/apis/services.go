@@ -1,8 +1,21 @@
 //go:generate go run ../codegen/cmd/gen-services
 // Code generated by `go generate ./apis`; DO NOT EDIT
+
 package apis
 
-import "github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// ReferencesVersion identifies the API references this file was generated
+// from.  It is a hash of the generated definitions, so it changes only when
+// the generated code does; there is deliberately no generation timestamp,
+// which would change every time.
+const ReferencesVersion = "sha256:db0dd36713fe4585b9e7ab30296706b82fcc6a09be2aea629c74f910913166f4"
 
 var services = map[string]definitions.Service{
 	"Auth": definitions.Service{
@@ -11,32 +24,247 @@ var services = map[string]definitions.Service{
 		Title:       "Authentication API",
 		Description: "Authentication related API end-points for Taskcluster and related\nservices. These API end-points are of interest if you wish to:\n  * Authorize a request signed with Taskcluster credentials,\n  * Manage clients and roles,\n  * Inspect or audit clients and roles,\n  * Gain access to various services guarded by this API.\n",
 		Entries: []definitions.Entry{
+			// authenticateHawk: Authenticate Hawk Request
+			//
+			// Validate the request signature given on input and return list of scopes
+			// that the authenticating client has.
+			//
+			// This method is used by other services that wish rely on Taskcluster
+			// credentials for authentication. This way we can use Hawk without having
+			// the secret credentials leave this service.
 			definitions.Entry{
-				Name:        "ping",
-				Title:       "Ping Server",
-				Description: "Respond without doing anything.\nThis endpoint is used to check that the service is up.",
+				Name:        "authenticateHawk",
+				Title:       "Authenticate Hawk Request",
+				Description: "Validate the request signature given on input and return list of scopes\nthat the authenticating client has.\n\nThis method is used by other services that wish rely on Taskcluster\ncredentials for authentication. This way we can use Hawk without having\nthe secret credentials leave this service.",
+				Stability:   "stable",
+				Method:      "post",
+				Route:       "/authenticate-hawk",
+				Args:        []string{},
+				Query:       []string{},
+				Input:       "v1/authenticate-hawk-request.json#",
+				Output:      "v1/authenticate-hawk-response.json#",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "authenticateHawk [--body <payload>]",
+				Example:     "  taskcluster api auth authenticateHawk --body @authenticate-hawk-request.json",
+				Aliases:     []string(nil),
+			},
+			// awsS3Credentials: Get Temporary Read/Write Credentials S3
+			//
+			// Get temporary AWS credentials for `read-write` or `read-only` access to
+			// a given `bucket` and `prefix` within that bucket.
+			// The `level` parameter can be `read-write` or `read-only` and determines
+			// which type of credentials are returned. Please note that the `level`
+			// parameter is required in the scope guarding access.  The bucket name must
+			// not contain `.`, as recommended by Amazon.
+			//
+			// This method can only allow access to a whitelisted set of buckets, as
+			// configured
+			// in the Taskcluster deployment
+			//
+			// The credentials are set to expire after an hour, but this behavior is
+			// subject to change. Hence, you should always read the `expires` property
+			// from the response, if you intend to maintain active credentials in your
+			// application.
+			//
+			// Please note that your `prefix` may not start with slash `/`. Such a prefix
+			// is allowed on S3, but we forbid it here to discourage bad behavior.
+			//
+			// Also note that if your `prefix` doesn't end in a slash `/`, the STS
+			// credentials may allow access to unexpected keys, as S3 does not treat
+			// slashes specially.  For example, a prefix of `my-folder` will allow
+			// access to `my-folder/file.txt` as expected, but also to `my-folder.txt`,
+			// which may not be intended.
+			//
+			// Finally, note that the `PutObjectAcl` call is not allowed.  Passing a canned
+			// ACL other than `private` to `PutObject` is treated as a `PutObjectAcl` call,
+			// and
+			// will result in an access-denied error from AWS.  This limitation is due to a
+			// security flaw in Amazon S3 which might otherwise allow indefinite access to
+			// uploaded objects.
+			//
+			// **EC2 metadata compatibility**, if the querystring parameter
+			// `?format=iam-role-compat` is given, the response will be compatible
+			// with the JSON exposed by the EC2 metadata service. This aims to ease
+			// compatibility for libraries and tools built to auto-refresh credentials.
+			// For details on the format returned by EC2 metadata service see:
+			// [EC2 User
+			// Guide](http://docs.aws.amazon.com/AWSEC2/latest/UserGuide/iam-roles-for-amazon-ec2.html#instance-metadata-security-credentials).
+			definitions.Entry{
+				Name:        "awsS3Credentials",
+				Title:       "Get Temporary Read/Write Credentials S3",
+				Description: "Get temporary AWS credentials for `read-write` or `read-only` access to\na given `bucket` and `prefix` within that bucket.\nThe `level` parameter can be `read-write` or `read-only` and determines\nwhich type of credentials are returned. Please note that the `level`\nparameter is required in the scope guarding access.  The bucket name must\nnot contain `.`, as recommended by Amazon.\n\nThis method can only allow access to a whitelisted set of buckets, as configured\nin the Taskcluster deployment\n\nThe credentials are set to expire after an hour, but this behavior is\nsubject to change. Hence, you should always read the `expires` property\nfrom the response, if you intend to maintain active credentials in your\napplication.\n\nPlease note that your `prefix` may not start with slash `/`. Such a prefix\nis allowed on S3, but we forbid it here to discourage bad behavior.\n\nAlso note that if your `prefix` doesn't end in a slash `/`, the STS\ncredentials may allow access to unexpected keys, as S3 does not treat\nslashes specially.  For example, a prefix of `my-folder` will allow\naccess to `my-folder/file.txt` as expected, but also to `my-folder.txt`,\nwhich may not be intended.\n\nFinally, note that the `PutObjectAcl` call is not allowed.  Passing a canned\nACL other than `private` to `PutObject` is treated as a `PutObjectAcl` call, and\nwill result in an access-denied error from AWS.  This limitation is due to a\nsecurity flaw in Amazon S3 which might otherwise allow indefinite access to\nuploaded objects.\n\n**EC2 metadata compatibility**, if the querystring parameter\n`?format=iam-role-compat` is given, the response will be compatible\nwith the JSON exposed by the EC2 metadata service. This aims to ease\ncompatibility for libraries and tools built to auto-refresh credentials.\nFor details on the format returned by EC2 metadata service see:\n[EC2 User Guide](http://docs.aws.amazon.com/AWSEC2/latest/UserGuide/iam-roles-for-amazon-ec2.html#instance-metadata-security-credentials).",
 				Stability:   "stable",
 				Method:      "get",
-				Route:       "/ping",
+				Route:       "/aws/s3/<level>/<bucket>/<prefix>",
+				Args: []string{
+					"level",
+					"bucket",
+					"prefix",
+				},
+				Query: []string{
+					"format",
+				},
+				Input:     "",
+				Output:    "v1/aws-s3-credentials-response.json#",
+				Paginated: false,
+				SignedURL: true,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "awsS3Credentials <level> <bucket> <prefix> [--format <format>] [--sign-url <duration>]",
+				Example:   "  taskcluster api auth awsS3Credentials <level> <bucket> <prefix>",
+				Aliases:   []string(nil),
+			},
+			// azureAccounts: List Accounts Managed by Auth
+			//
+			// Retrieve a list of all Azure accounts managed by Taskcluster Auth.
+			definitions.Entry{
+				Name:        "azureAccounts",
+				Title:       "List Accounts Managed by Auth",
+				Description: "Retrieve a list of all Azure accounts managed by Taskcluster Auth.",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/azure/accounts",
 				Args:        []string{},
 				Query:       []string{},
 				Input:       "",
+				Output:      "v1/azure-account-list-response.json#",
+				Paginated:   false,
+				SignedURL:   true,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "azureAccounts [--sign-url <duration>]",
+				Example:     "  taskcluster api auth azureAccounts",
+				Aliases:     []string(nil),
 			},
+			// azureContainerSAS: Get Shared-Access-Signature for Azure Container
+			//
+			// Get a shared access signature (SAS) string for use with a specific Azure
+			// Blob Storage container.
+			//
+			// The `level` parameter can be `read-write` or `read-only` and determines
+			// which type of credentials are returned. If level is read-write, it will
+			// create the
+			// container if it doesn't already exist.
 			definitions.Entry{
-				Name:        "listClients",
-				Title:       "List Clients",
-				Description: "Get a list of all clients.  With `prefix`, only clients for which\nit is a prefix of the clientId are returned.\n\nBy default this end-point will try to return up to 1000 clients in one\nrequest. But it **may return less, even none**.\nIt may also return a `continuationToken` even though there are no more\nresults. However, you can only be sure to have seen all results if you\nkeep calling `listClients` with the last `continuationToken` until you\nget a result without a `continuationToken`.",
+				Name:        "azureContainerSAS",
+				Title:       "Get Shared-Access-Signature for Azure Container",
+				Description: "Get a shared access signature (SAS) string for use with a specific Azure\nBlob Storage container.\n\nThe `level` parameter can be `read-write` or `read-only` and determines\nwhich type of credentials are returned.  If level is read-write, it will create the\ncontainer if it doesn't already exist.",
 				Stability:   "stable",
 				Method:      "get",
-				Route:       "/clients/",
-				Args:        []string{},
+				Route:       "/azure/<account>/containers/<container>/<level>",
+				Args: []string{
+					"account",
+					"container",
+					"level",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/azure-container-response.json#",
+				Paginated: false,
+				SignedURL: true,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "azureContainerSAS <account> <container> <level> [--sign-url <duration>]",
+				Example:   "  taskcluster api auth azureContainerSAS <account> <container> <level>",
+				Aliases:   []string(nil),
+			},
+			// azureContainers: List containers in an Account Managed by Auth
+			//
+			// Retrieve a list of all containers in an account.
+			definitions.Entry{
+				Name:        "azureContainers",
+				Title:       "List containers in an Account Managed by Auth",
+				Description: "Retrieve a list of all containers in an account.",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/azure/<account>/containers",
+				Args: []string{
+					"account",
+				},
+				Query: []string{
+					"continuationToken",
+				},
+				Input:     "",
+				Output:    "v1/azure-container-list-response.json#",
+				Paginated: true,
+				SignedURL: true,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "azureContainers <account> [--continuationToken <continuationToken>] [--all] [--sign-url <duration>]",
+				Example:   "  taskcluster api auth azureContainers <account>",
+				Aliases:   []string(nil),
+			},
+			// azureTableSAS: Get Shared-Access-Signature for Azure Table
+			//
+			// Get a shared access signature (SAS) string for use with a specific Azure
+			// Table Storage table.
+			//
+			// The `level` parameter can be `read-write` or `read-only` and determines
+			// which type of credentials are returned. If level is read-write, it will
+			// create the
+			// table if it doesn't already exist.
+			definitions.Entry{
+				Name:        "azureTableSAS",
+				Title:       "Get Shared-Access-Signature for Azure Table",
+				Description: "Get a shared access signature (SAS) string for use with a specific Azure\nTable Storage table.\n\nThe `level` parameter can be `read-write` or `read-only` and determines\nwhich type of credentials are returned.  If level is read-write, it will create the\ntable if it doesn't already exist.",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/azure/<account>/table/<table>/<level>",
+				Args: []string{
+					"account",
+					"table",
+					"level",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/azure-table-access-response.json#",
+				Paginated: false,
+				SignedURL: true,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "azureTableSAS <account> <table> <level> [--sign-url <duration>]",
+				Example:   "  taskcluster api auth azureTableSAS <account> <table> <level>",
+				Aliases:   []string(nil),
+			},
+			// azureTables: List Tables in an Account Managed by Auth
+			//
+			// Retrieve a list of all tables in an account.
+			definitions.Entry{
+				Name:        "azureTables",
+				Title:       "List Tables in an Account Managed by Auth",
+				Description: "Retrieve a list of all tables in an account.",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/azure/<account>/tables",
+				Args: []string{
+					"account",
+				},
 				Query: []string{
-					"prefix",
 					"continuationToken",
-					"limit",
 				},
-				Input: "",
+				Input:     "",
+				Output:    "v1/azure-table-list-response.json#",
+				Paginated: true,
+				SignedURL: true,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "azureTables <account> [--continuationToken <continuationToken>] [--all] [--sign-url <duration>]",
+				Example:   "  taskcluster api auth azureTables <account>",
+				Aliases:   []string(nil),
 			},
+			// client: Get Client
+			//
+			// Get information about a single client.
 			definitions.Entry{
 				Name:        "client",
 				Title:       "Get Client",
@@ -47,9 +275,32 @@ var services = map[string]definitions.Service{
 				Args: []string{
 					"clientId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/get-client-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "client <clientId>",
+				Example:   "  taskcluster api auth client <clientId>",
+				Aliases:   []string(nil),
 			},
+			// createClient: Create Client
+			//
+			// Create a new client and get the `accessToken` for this client.
+			// You should store the `accessToken` from this API call as there is no
+			// other way to retrieve it.
+			//
+			// If you loose the `accessToken` you can call `resetAccessToken` to reset
+			// it, and a new `accessToken` will be returned, but you cannot retrieve the
+			// current `accessToken`.
+			//
+			// If a client with the same `clientId` already exists this operation will
+			// fail. Use `updateClient` if you wish to update an existing client.
+			//
+			// The caller's scopes must satisfy `scopes`.
 			definitions.Entry{
 				Name:        "createClient",
 				Title:       "Create Client",
@@ -60,48 +311,136 @@ var services = map[string]definitions.Service{
 				Args: []string{
 					"clientId",
 				},
-				Query: []string{},
-				Input: "v1/create-client-request.json#",
+				Query:     []string{},
+				Input:     "v1/create-client-request.json#",
+				Output:    "v1/create-client-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "createClient <clientId> [--body <payload>]",
+				Example:   "  taskcluster api auth createClient <clientId> --body @create-client-request.json",
+				Aliases:   []string(nil),
 			},
+			// createRole: Create Role
+			//
+			// Create a new role.
+			//
+			// The caller's scopes must satisfy the new role's scopes.
+			//
+			// If there already exists a role with the same `roleId` this operation
+			// will fail. Use `updateRole` to modify an existing role.
+			//
+			// Creation of a role that will generate an infinite expansion will result
+			// in an error response.
 			definitions.Entry{
-				Name:        "resetAccessToken",
-				Title:       "Reset `accessToken`",
-				Description: "Reset a clients `accessToken`, this will revoke the existing\n`accessToken`, generate a new `accessToken` and return it from this\ncall.\n\nThere is no way to retrieve an existing `accessToken`, so if you loose it\nyou must reset the accessToken to acquire it again.",
+				Name:        "createRole",
+				Title:       "Create Role",
+				Description: "Create a new role.\n\nThe caller's scopes must satisfy the new role's scopes.\n\nIf there already exists a role with the same `roleId` this operation\nwill fail. Use `updateRole` to modify an existing role.\n\nCreation of a role that will generate an infinite expansion will result\nin an error response.",
 				Stability:   "stable",
-				Method:      "post",
-				Route:       "/clients/<clientId>/reset",
+				Method:      "put",
+				Route:       "/roles/<roleId>",
 				Args: []string{
-					"clientId",
+					"roleId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "v1/create-role-request.json#",
+				Output:    "v1/get-role-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "createRole <roleId> [--body <payload>]",
+				Example:   "  taskcluster api auth createRole <roleId> --body @create-role-request.json",
+				Aliases:   []string(nil),
 			},
+			// currentScopes: Get Current Scopes
+			//
+			// Return the expanded scopes available in the request, taking into account all
+			// sources
+			// of scopes and scope restrictions (temporary credentials, assumeScopes, client
+			// scopes,
+			// and roles).
 			definitions.Entry{
-				Name:        "updateClient",
-				Title:       "Update Client",
-				Description: "Update an exisiting client. The `clientId` and `accessToken` cannot be\nupdated, but `scopes` can be modified.  The caller's scopes must\nsatisfy all scopes being added to the client in the update operation.\nIf no scopes are given in the request, the client's scopes remain\nunchanged",
+				Name:        "currentScopes",
+				Title:       "Get Current Scopes",
+				Description: "Return the expanded scopes available in the request, taking into account all sources\nof scopes and scope restrictions (temporary credentials, assumeScopes, client scopes,\nand roles).",
 				Stability:   "stable",
-				Method:      "post",
+				Method:      "get",
+				Route:       "/scopes/current",
+				Args:        []string{},
+				Query:       []string{},
+				Input:       "",
+				Output:      "v1/scopeset.json#",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "currentScopes",
+				Example:     "  taskcluster api auth currentScopes",
+				Aliases:     []string(nil),
+			},
+			// deleteClient: Delete Client
+			//
+			// Delete a client, please note that any roles related to this client must
+			// be deleted independently.
+			definitions.Entry{
+				Name:        "deleteClient",
+				Title:       "Delete Client",
+				Description: "Delete a client, please note that any roles related to this client must\nbe deleted independently.",
+				Stability:   "stable",
+				Method:      "delete",
 				Route:       "/clients/<clientId>",
 				Args: []string{
 					"clientId",
 				},
-				Query: []string{},
-				Input: "v1/create-client-request.json#",
+				Query:     []string{},
+				Input:     "",
+				Output:    "",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "deleteClient <clientId>",
+				Example:   "  taskcluster api auth deleteClient <clientId>",
+				Aliases:   []string(nil),
 			},
+			// deleteRole: Delete Role
+			//
+			// Delete a role. This operation will succeed regardless of whether or not
+			// the role exists.
 			definitions.Entry{
-				Name:        "enableClient",
-				Title:       "Enable Client",
-				Description: "Enable a client that was disabled with `disableClient`.  If the client\nis already enabled, this does nothing.\n\nThis is typically used by identity providers to re-enable clients that\nhad been disabled when the corresponding identity's scopes changed.",
+				Name:        "deleteRole",
+				Title:       "Delete Role",
+				Description: "Delete a role. This operation will succeed regardless of whether or not\nthe role exists.",
 				Stability:   "stable",
-				Method:      "post",
-				Route:       "/clients/<clientId>/enable",
+				Method:      "delete",
+				Route:       "/roles/<roleId>",
 				Args: []string{
-					"clientId",
+					"roleId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "deleteRole <roleId>",
+				Example:   "  taskcluster api auth deleteRole <roleId>",
+				Aliases:   []string(nil),
 			},
+			// disableClient: Disable Client
+			//
+			// Disable a client.  If the client is already disabled, this does nothing.
+			//
+			// This is typically used by identity providers to disable clients when the
+			// corresponding identity's scopes no longer satisfy the client's scopes.
 			definitions.Entry{
 				Name:        "disableClient",
 				Title:       "Disable Client",
@@ -112,47 +451,149 @@ var services = map[string]definitions.Service{
 				Args: []string{
 					"clientId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/get-client-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "disableClient <clientId>",
+				Example:   "  taskcluster api auth disableClient <clientId>",
+				Aliases:   []string(nil),
 			},
+			// enableClient: Enable Client
+			//
+			// Enable a client that was disabled with `disableClient`.  If the client
+			// is already enabled, this does nothing.
+			//
+			// This is typically used by identity providers to re-enable clients that
+			// had been disabled when the corresponding identity's scopes changed.
 			definitions.Entry{
-				Name:        "deleteClient",
-				Title:       "Delete Client",
-				Description: "Delete a client, please note that any roles related to this client must\nbe deleted independently.",
+				Name:        "enableClient",
+				Title:       "Enable Client",
+				Description: "Enable a client that was disabled with `disableClient`.  If the client\nis already enabled, this does nothing.\n\nThis is typically used by identity providers to re-enable clients that\nhad been disabled when the corresponding identity's scopes changed.",
 				Stability:   "stable",
-				Method:      "delete",
-				Route:       "/clients/<clientId>",
+				Method:      "post",
+				Route:       "/clients/<clientId>/enable",
 				Args: []string{
 					"clientId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/get-client-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "enableClient <clientId>",
+				Example:   "  taskcluster api auth enableClient <clientId>",
+				Aliases:   []string(nil),
 			},
+			// expandScopes: Expand Scopes
+			//
+			// Return an expanded copy of the given scopeset, with scopes implied by any
+			// roles included.
 			definitions.Entry{
-				Name:        "listRoles",
-				Title:       "List Roles (no pagination)",
-				Description: "Get a list of all roles. Each role object also includes the list of\nscopes it expands to.  This always returns all roles in a single HTTP\nrequest.\n\nTo get paginated results, use `listRoles2`.",
+				Name:        "expandScopes",
+				Title:       "Expand Scopes",
+				Description: "Return an expanded copy of the given scopeset, with scopes implied by any\nroles included.",
 				Stability:   "stable",
-				Method:      "get",
-				Route:       "/roles/",
+				Method:      "post",
+				Route:       "/scopes/expand",
 				Args:        []string{},
 				Query:       []string{},
-				Input:       "",
+				Input:       "v1/scopeset.json#",
+				Output:      "v1/scopeset.json#",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "expandScopes [--body <payload>]",
+				Example:     "  taskcluster api auth expandScopes --body @scopeset.json",
+				Aliases:     []string(nil),
 			},
+			// gcpCredentials: Get Temporary GCP Credentials
+			//
+			// Get temporary GCP credentials for the given serviceAccount in the given
+			// project.
+			//
+			// Only preconfigured projects and serviceAccounts are allowed, as defined in
+			// the
+			// deployment of the Taskcluster services.
+			//
+			// The credentials are set to expire after an hour, but this behavior is
+			// subject to change. Hence, you should always read the `expires` property
+			// from the response, if you intend to maintain active credentials in your
+			// application.
 			definitions.Entry{
-				Name:        "listRoles2",
-				Title:       "List Roles",
-				Description: "Get a list of all roles. Each role object also includes the list of\nscopes it expands to.  This is similar to `listRoles` but differs in the\nformat of the response.\n\nIf no limit is given, all roles are returned. Since this\nlist may become long, callers can use the `limit` and `continuationToken`\nquery arguments to page through the responses.",
+				Name:        "gcpCredentials",
+				Title:       "Get Temporary GCP Credentials",
+				Description: "Get temporary GCP credentials for the given serviceAccount in the given project.\n\nOnly preconfigured projects and serviceAccounts are allowed, as defined in the\ndeployment of the Taskcluster services.\n\nThe credentials are set to expire after an hour, but this behavior is\nsubject to change. Hence, you should always read the `expires` property\nfrom the response, if you intend to maintain active credentials in your\napplication.",
 				Stability:   "stable",
 				Method:      "get",
-				Route:       "/roles2/",
+				Route:       "/gcp/credentials/<projectId>/<serviceAccount>",
+				Args: []string{
+					"projectId",
+					"serviceAccount",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/gcp-credentials-response.json#",
+				Paginated: false,
+				SignedURL: true,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "gcpCredentials <projectId> <serviceAccount> [--sign-url <duration>]",
+				Example:   "  taskcluster api auth gcpCredentials <projectId> <serviceAccount>",
+				Aliases:   []string(nil),
+			},
+			// listClients: List Clients
+			//
+			// Get a list of all clients.  With `prefix`, only clients for which
+			// it is a prefix of the clientId are returned.
+			//
+			// By default this end-point will try to return up to 1000 clients in one
+			// request. But it **may return less, even none**.
+			// It may also return a `continuationToken` even though there are no more
+			// results. However, you can only be sure to have seen all results if you
+			// keep calling `listClients` with the last `continuationToken` until you
+			// get a result without a `continuationToken`.
+			definitions.Entry{
+				Name:        "listClients",
+				Title:       "List Clients",
+				Description: "Get a list of all clients.  With `prefix`, only clients for which\nit is a prefix of the clientId are returned.\n\nBy default this end-point will try to return up to 1000 clients in one\nrequest. But it **may return less, even none**.\nIt may also return a `continuationToken` even though there are no more\nresults. However, you can only be sure to have seen all results if you\nkeep calling `listClients` with the last `continuationToken` until you\nget a result without a `continuationToken`.",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/clients/",
 				Args:        []string{},
 				Query: []string{
+					"prefix",
 					"continuationToken",
 					"limit",
 				},
-				Input: "",
+				Input:     "",
+				Output:    "v1/list-clients-response.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listClients [--prefix <prefix>] [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api auth listClients",
+				Aliases:   []string(nil),
 			},
+			// listRoleIds: List Role IDs
+			//
+			// Get a list of all role IDs.
+			//
+			// If no limit is given, the roleIds of all roles are returned. Since this
+			// list may become long, callers can use the `limit` and `continuationToken`
+			// query arguments to page through the responses.
 			definitions.Entry{
 				Name:        "listRoleIds",
 				Title:       "List Role IDs",
@@ -165,183 +606,330 @@ var services = map[string]definitions.Service{
 					"continuationToken",
 					"limit",
 				},
-				Input: "",
+				Input:     "",
+				Output:    "v1/list-role-ids-response.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listRoleIds [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api auth listRoleIds",
+				Aliases:   []string(nil),
 			},
+			// listRoles: List Roles (no pagination)
+			//
+			// Get a list of all roles. Each role object also includes the list of
+			// scopes it expands to.  This always returns all roles in a single HTTP
+			// request.
+			//
+			// To get paginated results, use `listRoles2`.
 			definitions.Entry{
-				Name:        "role",
-				Title:       "Get Role",
-				Description: "Get information about a single role, including the set of scopes that the\nrole expands to.",
+				Name:        "listRoles",
+				Title:       "List Roles (no pagination)",
+				Description: "Get a list of all roles. Each role object also includes the list of\nscopes it expands to.  This always returns all roles in a single HTTP\nrequest.\n\nTo get paginated results, use `listRoles2`.",
 				Stability:   "stable",
 				Method:      "get",
-				Route:       "/roles/<roleId>",
-				Args: []string{
-					"roleId",
+				Route:       "/roles/",
+				Args:        []string{},
+				Query:       []string{},
+				Input:       "",
+				Output:      "v1/list-roles-response.json#",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "listRoles",
+				Example:     "  taskcluster api auth listRoles",
+				Aliases:     []string(nil),
+			},
+			// listRoles2: List Roles
+			//
+			// Get a list of all roles. Each role object also includes the list of
+			// scopes it expands to.  This is similar to `listRoles` but differs in the
+			// format of the response.
+			//
+			// If no limit is given, all roles are returned. Since this
+			// list may become long, callers can use the `limit` and `continuationToken`
+			// query arguments to page through the responses.
+			definitions.Entry{
+				Name:        "listRoles2",
+				Title:       "List Roles",
+				Description: "Get a list of all roles. Each role object also includes the list of\nscopes it expands to.  This is similar to `listRoles` but differs in the\nformat of the response.\n\nIf no limit is given, all roles are returned. Since this\nlist may become long, callers can use the `limit` and `continuationToken`\nquery arguments to page through the responses.",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/roles2/",
+				Args:        []string{},
+				Query: []string{
+					"continuationToken",
+					"limit",
 				},
-				Query: []string{},
-				Input: "",
+				Input:     "",
+				Output:    "v1/list-roles2-response.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listRoles2 [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api auth listRoles2",
+				Aliases:   []string(nil),
 			},
+			// ping: Ping Server
+			//
+			// Respond without doing anything.
+			// This endpoint is used to check that the service is up.
 			definitions.Entry{
-				Name:        "createRole",
-				Title:       "Create Role",
-				Description: "Create a new role.\n\nThe caller's scopes must satisfy the new role's scopes.\n\nIf there already exists a role with the same `roleId` this operation\nwill fail. Use `updateRole` to modify an existing role.\n\nCreation of a role that will generate an infinite expansion will result\nin an error response.",
+				Name:        "ping",
+				Title:       "Ping Server",
+				Description: "Respond without doing anything.\nThis endpoint is used to check that the service is up.",
 				Stability:   "stable",
-				Method:      "put",
-				Route:       "/roles/<roleId>",
+				Method:      "get",
+				Route:       "/ping",
+				Args:        []string{},
+				Query:       []string{},
+				Input:       "",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "ping",
+				Example:     "  taskcluster api auth ping",
+				Aliases:     []string(nil),
+			},
+			// resetAccessToken: Reset `accessToken`
+			//
+			// Reset a clients `accessToken`, this will revoke the existing
+			// `accessToken`, generate a new `accessToken` and return it from this
+			// call.
+			//
+			// There is no way to retrieve an existing `accessToken`, so if you loose it
+			// you must reset the accessToken to acquire it again.
+			definitions.Entry{
+				Name:        "resetAccessToken",
+				Title:       "Reset `accessToken`",
+				Description: "Reset a clients `accessToken`, this will revoke the existing\n`accessToken`, generate a new `accessToken` and return it from this\ncall.\n\nThere is no way to retrieve an existing `accessToken`, so if you loose it\nyou must reset the accessToken to acquire it again.",
+				Stability:   "stable",
+				Method:      "post",
+				Route:       "/clients/<clientId>/reset",
 				Args: []string{
-					"roleId",
+					"clientId",
 				},
-				Query: []string{},
-				Input: "v1/create-role-request.json#",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/create-client-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "resetAccessToken <clientId>",
+				Example:   "  taskcluster api auth resetAccessToken <clientId>",
+				Aliases:   []string(nil),
 			},
+			// role: Get Role
+			//
+			// Get information about a single role, including the set of scopes that the
+			// role expands to.
 			definitions.Entry{
-				Name:        "updateRole",
-				Title:       "Update Role",
-				Description: "Update an existing role.\n\nThe caller's scopes must satisfy all of the new scopes being added, but\nneed not satisfy all of the role's existing scopes.\n\nAn update of a role that will generate an infinite expansion will result\nin an error response.",
+				Name:        "role",
+				Title:       "Get Role",
+				Description: "Get information about a single role, including the set of scopes that the\nrole expands to.",
 				Stability:   "stable",
-				Method:      "post",
+				Method:      "get",
 				Route:       "/roles/<roleId>",
 				Args: []string{
 					"roleId",
 				},
-				Query: []string{},
-				Input: "v1/create-role-request.json#",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/get-role-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "role <roleId>",
+				Example:   "  taskcluster api auth role <roleId>",
+				Aliases:   []string(nil),
 			},
+			// sentryDSN: Get DSN for Sentry Project
+			//
+			// Get temporary DSN (access credentials) for a sentry project.
+			// The credentials returned can be used with any Sentry client for up to
+			// 24 hours, after which the credentials will be automatically disabled.
+			//
+			// If the project doesn't exist it will be created, and assigned to the
+			// initial team configured for this component. Contact a Sentry admin
+			// to have the project transferred to a team you have access to if needed
 			definitions.Entry{
-				Name:        "deleteRole",
-				Title:       "Delete Role",
-				Description: "Delete a role. This operation will succeed regardless of whether or not\nthe role exists.",
+				Name:        "sentryDSN",
+				Title:       "Get DSN for Sentry Project",
+				Description: "Get temporary DSN (access credentials) for a sentry project.\nThe credentials returned can be used with any Sentry client for up to\n24 hours, after which the credentials will be automatically disabled.\n\nIf the project doesn't exist it will be created, and assigned to the\ninitial team configured for this component. Contact a Sentry admin\nto have the project transferred to a team you have access to if needed",
 				Stability:   "stable",
-				Method:      "delete",
-				Route:       "/roles/<roleId>",
+				Method:      "get",
+				Route:       "/sentry/<project>/dsn",
 				Args: []string{
-					"roleId",
+					"project",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/sentry-dsn-response.json#",
+				Paginated: false,
+				SignedURL: true,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "sentryDSN <project> [--sign-url <duration>]",
+				Example:   "  taskcluster api auth sentryDSN <project>",
+				Aliases:   []string(nil),
 			},
+			// testAuthenticate: Test Authentication
+			//
+			// Utility method to test client implementations of Taskcluster
+			// authentication.
+			//
+			// Rather than using real credentials, this endpoint accepts requests with
+			// clientId `tester` and accessToken `no-secret`. That client's scopes are
+			// based on `clientScopes` in the request body.
+			//
+			// The request is validated, with any certificate, authorizedScopes, etc.
+			// applied, and the resulting scopes are checked against `requiredScopes`
+			// from the request body. On success, the response contains the clientId
+			// and scopes as seen by the API method.
 			definitions.Entry{
-				Name:        "expandScopes",
-				Title:       "Expand Scopes",
-				Description: "Return an expanded copy of the given scopeset, with scopes implied by any\nroles included.",
+				Name:        "testAuthenticate",
+				Title:       "Test Authentication",
+				Description: "Utility method to test client implementations of Taskcluster\nauthentication.\n\nRather than using real credentials, this endpoint accepts requests with\nclientId `tester` and accessToken `no-secret`. That client's scopes are\nbased on `clientScopes` in the request body.\n\nThe request is validated, with any certificate, authorizedScopes, etc.\napplied, and the resulting scopes are checked against `requiredScopes`\nfrom the request body. On success, the response contains the clientId\nand scopes as seen by the API method.",
 				Stability:   "stable",
 				Method:      "post",
-				Route:       "/scopes/expand",
+				Route:       "/test-authenticate",
 				Args:        []string{},
 				Query:       []string{},
-				Input:       "v1/scopeset.json#",
+				Input:       "v1/test-authenticate-request.json#",
+				Output:      "v1/test-authenticate-response.json#",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "testAuthenticate [--body <payload>]",
+				Example:     "  taskcluster api auth testAuthenticate --body @test-authenticate-request.json",
+				Aliases:     []string(nil),
 			},
+			// testAuthenticateGet: Test Authentication (GET)
+			//
+			// Utility method similar to `testAuthenticate`, but with the GET method,
+			// so it can be used with signed URLs (bewits).
+			//
+			// Rather than using real credentials, this endpoint accepts requests with
+			// clientId `tester` and accessToken `no-secret`. That client's scopes are
+			// `['test:*', 'auth:create-client:test:*']`.  The call fails if the
+			// `test:authenticate-get` scope is not available.
+			//
+			// The request is validated, with any certificate, authorizedScopes, etc.
+			// applied, and the resulting scopes are checked, just like any API call.
+			// On success, the response contains the clientId and scopes as seen by
+			// the API method.
+			//
+			// This method may later be extended to allow specification of client and
+			// required scopes via query arguments.
 			definitions.Entry{
-				Name:        "currentScopes",
-				Title:       "Get Current Scopes",
-				Description: "Return the expanded scopes available in the request, taking into account all sources\nof scopes and scope restrictions (temporary credentials, assumeScopes, client scopes,\nand roles).",
-				Stability:   "stable",
-				Method:      "get",
-				Route:       "/scopes/current",
-				Args:        []string{},
-				Query:       []string{},
-				Input:       "",
-			},
-			definitions.Entry{
-				Name:        "awsS3Credentials",
-				Title:       "Get Temporary Read/Write Credentials S3",
-				Description: "Get temporary AWS credentials for `read-write` or `read-only` access to\na given `bucket` and `prefix` within that bucket.\nThe `level` parameter can be `read-write` or `read-only` and determines\nwhich type of credentials are returned. Please note that the `level`\nparameter is required in the scope guarding access.  The bucket name must\nnot contain `.`, as recommended by Amazon.\n\nThis method can only allow access to a whitelisted set of buckets, as configured\nin the Taskcluster deployment\n\nThe credentials are set to expire after an hour, but this behavior is\nsubject to change. Hence, you should always read the `expires` property\nfrom the response, if you intend to maintain active credentials in your\napplication.\n\nPlease note that your `prefix` may not start with slash `/`. Such a prefix\nis allowed on S3, but we forbid it here to discourage bad behavior.\n\nAlso note that if your `prefix` doesn't end in a slash `/`, the STS\ncredentials may allow access to unexpected keys, as S3 does not treat\nslashes specially.  For example, a prefix of `my-folder` will allow\naccess to `my-folder/file.txt` as expected, but also to `my-folder.txt`,\nwhich may not be intended.\n\nFinally, note that the `PutObjectAcl` call is not allowed.  Passing a canned\nACL other than `private` to `PutObject` is treated as a `PutObjectAcl` call, and\nwill result in an access-denied error from AWS.  This limitation is due to a\nsecurity flaw in Amazon S3 which might otherwise allow indefinite access to\nuploaded objects.\n\n**EC2 metadata compatibility**, if the querystring parameter\n`?format=iam-role-compat` is given, the response will be compatible\nwith the JSON exposed by the EC2 metadata service. This aims to ease\ncompatibility for libraries and tools built to auto-refresh credentials.\nFor details on the format returned by EC2 metadata service see:\n[EC2 User Guide](http://docs.aws.amazon.com/AWSEC2/latest/UserGuide/iam-roles-for-amazon-ec2.html#instance-metadata-security-credentials).",
-				Stability:   "stable",
-				Method:      "get",
-				Route:       "/aws/s3/<level>/<bucket>/<prefix>",
-				Args: []string{
-					"level",
-					"bucket",
-					"prefix",
-				},
-				Query: []string{
-					"format",
-				},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "azureAccounts",
-				Title:       "List Accounts Managed by Auth",
-				Description: "Retrieve a list of all Azure accounts managed by Taskcluster Auth.",
+				Name:        "testAuthenticateGet",
+				Title:       "Test Authentication (GET)",
+				Description: "Utility method similar to `testAuthenticate`, but with the GET method,\nso it can be used with signed URLs (bewits).\n\nRather than using real credentials, this endpoint accepts requests with\nclientId `tester` and accessToken `no-secret`. That client's scopes are\n`['test:*', 'auth:create-client:test:*']`.  The call fails if the \n`test:authenticate-get` scope is not available.\n\nThe request is validated, with any certificate, authorizedScopes, etc.\napplied, and the resulting scopes are checked, just like any API call.\nOn success, the response contains the clientId and scopes as seen by\nthe API method.\n\nThis method may later be extended to allow specification of client and\nrequired scopes via query arguments.",
 				Stability:   "stable",
 				Method:      "get",
-				Route:       "/azure/accounts",
+				Route:       "/test-authenticate-get/",
 				Args:        []string{},
 				Query:       []string{},
 				Input:       "",
+				Output:      "v1/test-authenticate-response.json#",
+				Paginated:   false,
+				SignedURL:   true,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "testAuthenticateGet [--sign-url <duration>]",
+				Example:     "  taskcluster api auth testAuthenticateGet",
+				Aliases:     []string(nil),
 			},
+			// updateClient: Update Client
+			//
+			// Update an exisiting client. The `clientId` and `accessToken` cannot be
+			// updated, but `scopes` can be modified.  The caller's scopes must
+			// satisfy all scopes being added to the client in the update operation.
+			// If no scopes are given in the request, the client's scopes remain
+			// unchanged
 			definitions.Entry{
-				Name:        "azureTables",
-				Title:       "List Tables in an Account Managed by Auth",
-				Description: "Retrieve a list of all tables in an account.",
-				Stability:   "stable",
-				Method:      "get",
-				Route:       "/azure/<account>/tables",
-				Args: []string{
-					"account",
-				},
-				Query: []string{
-					"continuationToken",
-				},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "azureTableSAS",
-				Title:       "Get Shared-Access-Signature for Azure Table",
-				Description: "Get a shared access signature (SAS) string for use with a specific Azure\nTable Storage table.\n\nThe `level` parameter can be `read-write` or `read-only` and determines\nwhich type of credentials are returned.  If level is read-write, it will create the\ntable if it doesn't already exist.",
-				Stability:   "stable",
-				Method:      "get",
-				Route:       "/azure/<account>/table/<table>/<level>",
-				Args: []string{
-					"account",
-					"table",
-					"level",
-				},
-				Query: []string{},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "azureContainers",
-				Title:       "List containers in an Account Managed by Auth",
-				Description: "Retrieve a list of all containers in an account.",
-				Stability:   "stable",
-				Method:      "get",
-				Route:       "/azure/<account>/containers",
-				Args: []string{
-					"account",
-				},
-				Query: []string{
-					"continuationToken",
-				},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "azureContainerSAS",
-				Title:       "Get Shared-Access-Signature for Azure Container",
-				Description: "Get a shared access signature (SAS) string for use with a specific Azure\nBlob Storage container.\n\nThe `level` parameter can be `read-write` or `read-only` and determines\nwhich type of credentials are returned.  If level is read-write, it will create the\ncontainer if it doesn't already exist.",
+				Name:        "updateClient",
+				Title:       "Update Client",
+				Description: "Update an exisiting client. The `clientId` and `accessToken` cannot be\nupdated, but `scopes` can be modified.  The caller's scopes must\nsatisfy all scopes being added to the client in the update operation.\nIf no scopes are given in the request, the client's scopes remain\nunchanged",
 				Stability:   "stable",
-				Method:      "get",
-				Route:       "/azure/<account>/containers/<container>/<level>",
+				Method:      "post",
+				Route:       "/clients/<clientId>",
 				Args: []string{
-					"account",
-					"container",
-					"level",
+					"clientId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "v1/create-client-request.json#",
+				Output:    "v1/get-client-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "updateClient <clientId> [--body <payload>]",
+				Example:   "  taskcluster api auth updateClient <clientId> --body @create-client-request.json",
+				Aliases:   []string(nil),
 			},
+			// updateRole: Update Role
+			//
+			// Update an existing role.
+			//
+			// The caller's scopes must satisfy all of the new scopes being added, but
+			// need not satisfy all of the role's existing scopes.
+			//
+			// An update of a role that will generate an infinite expansion will result
+			// in an error response.
 			definitions.Entry{
-				Name:        "sentryDSN",
-				Title:       "Get DSN for Sentry Project",
-				Description: "Get temporary DSN (access credentials) for a sentry project.\nThe credentials returned can be used with any Sentry client for up to\n24 hours, after which the credentials will be automatically disabled.\n\nIf the project doesn't exist it will be created, and assigned to the\ninitial team configured for this component. Contact a Sentry admin\nto have the project transferred to a team you have access to if needed",
+				Name:        "updateRole",
+				Title:       "Update Role",
+				Description: "Update an existing role.\n\nThe caller's scopes must satisfy all of the new scopes being added, but\nneed not satisfy all of the role's existing scopes.\n\nAn update of a role that will generate an infinite expansion will result\nin an error response.",
 				Stability:   "stable",
-				Method:      "get",
-				Route:       "/sentry/<project>/dsn",
+				Method:      "post",
+				Route:       "/roles/<roleId>",
 				Args: []string{
-					"project",
+					"roleId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "v1/create-role-request.json#",
+				Output:    "v1/get-role-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "updateRole <roleId> [--body <payload>]",
+				Example:   "  taskcluster api auth updateRole <roleId> --body @create-role-request.json",
+				Aliases:   []string(nil),
 			},
+			// websocktunnelToken: Get a client token for the Websocktunnel service
+			//
+			// Get a temporary token suitable for use connecting to a
+			// [websocktunnel](https://github.com/taskcluster/taskcluster/tree/master/tools/websocktunnel)
+			// server.
+			//
+			// The resulting token will only be accepted by servers with a matching audience
+			// value.  Reaching such a server is the callers responsibility.  In general,
+			// a server URL or set of URLs should be provided to the caller as configuration
+			// along with the audience value.
+			//
+			// The token is valid for a limited time (on the scale of hours). Callers should
+			// refresh it before expiration.
 			definitions.Entry{
 				Name:        "websocktunnelToken",
 				Title:       "Get a client token for the Websocktunnel service",
@@ -353,55 +941,17 @@ var services = map[string]definitions.Service{
 					"wstAudience",
 					"wstClient",
 				},
-				Query: []string{},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "gcpCredentials",
-				Title:       "Get Temporary GCP Credentials",
-				Description: "Get temporary GCP credentials for the given serviceAccount in the given project.\n\nOnly preconfigured projects and serviceAccounts are allowed, as defined in the\ndeployment of the Taskcluster services.\n\nThe credentials are set to expire after an hour, but this behavior is\nsubject to change. Hence, you should always read the `expires` property\nfrom the response, if you intend to maintain active credentials in your\napplication.",
-				Stability:   "stable",
-				Method:      "get",
-				Route:       "/gcp/credentials/<projectId>/<serviceAccount>",
-				Args: []string{
-					"projectId",
-					"serviceAccount",
-				},
-				Query: []string{},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "authenticateHawk",
-				Title:       "Authenticate Hawk Request",
-				Description: "Validate the request signature given on input and return list of scopes\nthat the authenticating client has.\n\nThis method is used by other services that wish rely on Taskcluster\ncredentials for authentication. This way we can use Hawk without having\nthe secret credentials leave this service.",
-				Stability:   "stable",
-				Method:      "post",
-				Route:       "/authenticate-hawk",
-				Args:        []string{},
-				Query:       []string{},
-				Input:       "v1/authenticate-hawk-request.json#",
-			},
-			definitions.Entry{
-				Name:        "testAuthenticate",
-				Title:       "Test Authentication",
-				Description: "Utility method to test client implementations of Taskcluster\nauthentication.\n\nRather than using real credentials, this endpoint accepts requests with\nclientId `tester` and accessToken `no-secret`. That client's scopes are\nbased on `clientScopes` in the request body.\n\nThe request is validated, with any certificate, authorizedScopes, etc.\napplied, and the resulting scopes are checked against `requiredScopes`\nfrom the request body. On success, the response contains the clientId\nand scopes as seen by the API method.",
-				Stability:   "stable",
-				Method:      "post",
-				Route:       "/test-authenticate",
-				Args:        []string{},
-				Query:       []string{},
-				Input:       "v1/test-authenticate-request.json#",
-			},
-			definitions.Entry{
-				Name:        "testAuthenticateGet",
-				Title:       "Test Authentication (GET)",
-				Description: "Utility method similar to `testAuthenticate`, but with the GET method,\nso it can be used with signed URLs (bewits).\n\nRather than using real credentials, this endpoint accepts requests with\nclientId `tester` and accessToken `no-secret`. That client's scopes are\n`['test:*', 'auth:create-client:test:*']`.  The call fails if the \n`test:authenticate-get` scope is not available.\n\nThe request is validated, with any certificate, authorizedScopes, etc.\napplied, and the resulting scopes are checked, just like any API call.\nOn success, the response contains the clientId and scopes as seen by\nthe API method.\n\nThis method may later be extended to allow specification of client and\nrequired scopes via query arguments.",
-				Stability:   "stable",
-				Method:      "get",
-				Route:       "/test-authenticate-get/",
-				Args:        []string{},
-				Query:       []string{},
-				Input:       "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/websocktunnel-token-response.json#",
+				Paginated: false,
+				SignedURL: true,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "websocktunnelToken <wstAudience> <wstClient> [--sign-url <duration>]",
+				Example:   "  taskcluster api auth websocktunnelToken <wstAudience> <wstClient>",
+				Aliases:   []string(nil),
 			},
 		},
 	},
@@ -411,28 +961,39 @@ var services = map[string]definitions.Service{
 		Title:       "Taskcluster GitHub API Documentation",
 		Description: "The github service is responsible for creating tasks in reposnse\nto GitHub events, and posting results to the GitHub UI.\n\nThis document describes the API end-point for consuming GitHub\nweb hooks, as well as some useful consumer APIs.\n\nWhen Github forbids an action, this service returns an HTTP 403\nwith code ForbiddenByGithub.",
 		Entries: []definitions.Entry{
+			// badge: Latest Build Status Badge
+			//
+			// Checks the status of the latest build of a given branch
+			// and returns corresponding badge svg.
 			definitions.Entry{
-				Name:        "ping",
-				Title:       "Ping Server",
-				Description: "Respond without doing anything.\nThis endpoint is used to check that the service is up.",
-				Stability:   "stable",
+				Name:        "badge",
+				Title:       "Latest Build Status Badge",
+				Description: "Checks the status of the latest build of a given branch\nand returns corresponding badge svg.",
+				Stability:   "experimental",
 				Method:      "get",
-				Route:       "/ping",
-				Args:        []string{},
-				Query:       []string{},
-				Input:       "",
-			},
-			definitions.Entry{
-				Name:        "githubWebHookConsumer",
-				Title:       "Consume GitHub WebHook",
-				Description: "Capture a GitHub event and publish it via pulse, if it's a push,\nrelease or pull request.",
-				Stability:   "stable",
-				Method:      "post",
-				Route:       "/github",
-				Args:        []string{},
-				Query:       []string{},
-				Input:       "",
+				Route:       "/repository/<owner>/<repo>/<branch>/badge.svg",
+				Args: []string{
+					"owner",
+					"repo",
+					"branch",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "badge <owner> <repo> <branch>",
+				Example:   "  taskcluster api github badge <owner> <repo> <branch>",
+				Aliases:   []string(nil),
 			},
+			// builds: List of Builds
+			//
+			// A paginated list of builds that have been run in
+			// Taskcluster. Can be filtered on various git-specific
+			// fields.
 			definitions.Entry{
 				Name:        "builds",
 				Title:       "List of Builds",
@@ -448,37 +1009,107 @@ var services = map[string]definitions.Service{
 					"repository",
 					"sha",
 				},
-				Input: "",
+				Input:     "",
+				Output:    "v1/build-list.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "builds [--continuationToken <continuationToken>] [--limit <limit>] [--organization <organization>] [--repository <repository>] [--sha <sha>] [--all]",
+				Example:   "  taskcluster api github builds",
+				Aliases:   []string(nil),
 			},
+			// createComment: Post a comment on a given GitHub Issue or Pull Request
+			//
+			// For a given Issue or Pull Request of a repository, this will write a new
+			// message.
 			definitions.Entry{
-				Name:        "badge",
-				Title:       "Latest Build Status Badge",
-				Description: "Checks the status of the latest build of a given branch\nand returns corresponding badge svg.",
-				Stability:   "experimental",
-				Method:      "get",
-				Route:       "/repository/<owner>/<repo>/<branch>/badge.svg",
+				Name:        "createComment",
+				Title:       "Post a comment on a given GitHub Issue or Pull Request",
+				Description: "For a given Issue or Pull Request of a repository, this will write a new message.",
+				Stability:   "stable",
+				Method:      "post",
+				Route:       "/repository/<owner>/<repo>/issues/<number>/comments",
 				Args: []string{
 					"owner",
 					"repo",
-					"branch",
+					"number",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "v1/create-comment.json#",
+				Output:    "",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "createComment <owner> <repo> <number> [--body <payload>]",
+				Example:   "  taskcluster api github createComment <owner> <repo> <number> --body @create-comment.json",
+				Aliases:   []string(nil),
 			},
+			// createStatus: Post a status against a given changeset
+			//
+			// For a given changeset (SHA) of a repository, this will attach a "commit
+			// status"
+			// on github. These statuses are links displayed next to each revision.
+			// The status is either OK (green check) or FAILURE (red cross),
+			// made of a custom title and link.
 			definitions.Entry{
-				Name:        "repository",
-				Title:       "Get Repository Info",
-				Description: "Returns any repository metadata that is\nuseful within Taskcluster related services.",
+				Name:        "createStatus",
+				Title:       "Post a status against a given changeset",
+				Description: "For a given changeset (SHA) of a repository, this will attach a \"commit status\"\non github. These statuses are links displayed next to each revision.\nThe status is either OK (green check) or FAILURE (red cross), \nmade of a custom title and link.",
 				Stability:   "experimental",
-				Method:      "get",
-				Route:       "/repository/<owner>/<repo>",
+				Method:      "post",
+				Route:       "/repository/<owner>/<repo>/statuses/<sha>",
 				Args: []string{
 					"owner",
 					"repo",
+					"sha",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "v1/create-status.json#",
+				Output:    "",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "createStatus <owner> <repo> <sha> [--body <payload>]",
+				Example:   "  taskcluster api github createStatus <owner> <repo> <sha> --body @create-status.json",
+				Aliases:   []string(nil),
+			},
+			// githubWebHookConsumer: Consume GitHub WebHook
+			//
+			// Capture a GitHub event and publish it via pulse, if it's a push,
+			// release or pull request.
+			definitions.Entry{
+				Name:        "githubWebHookConsumer",
+				Title:       "Consume GitHub WebHook",
+				Description: "Capture a GitHub event and publish it via pulse, if it's a push,\nrelease or pull request.",
+				Stability:   "stable",
+				Method:      "post",
+				Route:       "/github",
+				Args:        []string{},
+				Query:       []string{},
+				Input:       "",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "githubWebHookConsumer",
+				Example:     "  taskcluster api github githubWebHookConsumer",
+				Aliases:     []string(nil),
 			},
+			// latest: Latest Status for Branch
+			//
+			// For a given branch of a repository, this will always point
+			// to a status page for the most recent task triggered by that
+			// branch.
+			//
+			// Note: This is a redirect rather than a direct link.
 			definitions.Entry{
 				Name:        "latest",
 				Title:       "Latest Status for Branch",
@@ -491,38 +1122,68 @@ var services = map[string]definitions.Service{
 					"repo",
 					"branch",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "latest <owner> <repo> <branch>",
+				Example:   "  taskcluster api github latest <owner> <repo> <branch>",
+				Aliases:   []string(nil),
 			},
+			// ping: Ping Server
+			//
+			// Respond without doing anything.
+			// This endpoint is used to check that the service is up.
 			definitions.Entry{
-				Name:        "createStatus",
-				Title:       "Post a status against a given changeset",
-				Description: "For a given changeset (SHA) of a repository, this will attach a \"commit status\"\non github. These statuses are links displayed next to each revision.\nThe status is either OK (green check) or FAILURE (red cross), \nmade of a custom title and link.",
-				Stability:   "experimental",
-				Method:      "post",
-				Route:       "/repository/<owner>/<repo>/statuses/<sha>",
-				Args: []string{
-					"owner",
-					"repo",
-					"sha",
-				},
-				Query: []string{},
-				Input: "v1/create-status.json#",
+				Name:        "ping",
+				Title:       "Ping Server",
+				Description: "Respond without doing anything.\nThis endpoint is used to check that the service is up.",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/ping",
+				Args:        []string{},
+				Query:       []string{},
+				Input:       "",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "ping",
+				Example:     "  taskcluster api github ping",
+				Aliases:     []string(nil),
 			},
+			// repository: Get Repository Info
+			//
+			// Returns any repository metadata that is
+			// useful within Taskcluster related services.
 			definitions.Entry{
-				Name:        "createComment",
-				Title:       "Post a comment on a given GitHub Issue or Pull Request",
-				Description: "For a given Issue or Pull Request of a repository, this will write a new message.",
-				Stability:   "stable",
-				Method:      "post",
-				Route:       "/repository/<owner>/<repo>/issues/<number>/comments",
+				Name:        "repository",
+				Title:       "Get Repository Info",
+				Description: "Returns any repository metadata that is\nuseful within Taskcluster related services.",
+				Stability:   "experimental",
+				Method:      "get",
+				Route:       "/repository/<owner>/<repo>",
 				Args: []string{
 					"owner",
 					"repo",
-					"number",
 				},
-				Query: []string{},
-				Input: "v1/create-comment.json#",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/repository.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "repository <owner> <repo>",
+				Example:   "  taskcluster api github repository <owner> <repo>",
+				Aliases:   []string(nil),
 			},
 		},
 	},
@@ -532,41 +1193,96 @@ var services = map[string]definitions.Service{
 		Title:       "Hooks API Documentation",
 		Description: "The hooks service provides a mechanism for creating tasks in response to events.\n",
 		Entries: []definitions.Entry{
+			// createHook: Create a hook
+			//
+			// This endpoint will create a new hook.
+			//
+			// The caller's credentials must include the role that will be used to
+			// create the task.  That role must satisfy task.scopes as well as the
+			// necessary scopes to add the task to the queue.
 			definitions.Entry{
-				Name:        "ping",
-				Title:       "Ping Server",
-				Description: "Respond without doing anything.\nThis endpoint is used to check that the service is up.",
+				Name:        "createHook",
+				Title:       "Create a hook",
+				Description: "This endpoint will create a new hook.\n\nThe caller's credentials must include the role that will be used to\ncreate the task.  That role must satisfy task.scopes as well as the\nnecessary scopes to add the task to the queue.",
 				Stability:   "stable",
-				Method:      "get",
-				Route:       "/ping",
-				Args:        []string{},
-				Query:       []string{},
-				Input:       "",
+				Method:      "put",
+				Route:       "/hooks/<hookGroupId>/<hookId>",
+				Args: []string{
+					"hookGroupId",
+					"hookId",
+				},
+				Query:     []string{},
+				Input:     "v1/create-hook-request.json#",
+				Output:    "v1/hook-definition.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "createHook <hookGroupId> <hookId> [--body <payload>]",
+				Example:   "  taskcluster api hooks createHook <hookGroupId> <hookId> --body @create-hook-request.json",
+				Aliases:   []string(nil),
 			},
+			// getHookStatus: Get hook status
+			//
+			// This endpoint will return the current status of the hook.  This represents a
+			// snapshot in time and may vary from one call to the next.
+			//
+			// This method is deprecated in favor of listLastFires.
 			definitions.Entry{
-				Name:        "listHookGroups",
-				Title:       "List hook groups",
-				Description: "This endpoint will return a list of all hook groups with at least one hook.",
-				Stability:   "stable",
+				Name:        "getHookStatus",
+				Title:       "Get hook status",
+				Description: "This endpoint will return the current status of the hook.  This represents a\nsnapshot in time and may vary from one call to the next.\n\nThis method is deprecated in favor of listLastFires.",
+				Stability:   "deprecated",
 				Method:      "get",
-				Route:       "/hooks",
-				Args:        []string{},
-				Query:       []string{},
-				Input:       "",
+				Route:       "/hooks/<hookGroupId>/<hookId>/status",
+				Args: []string{
+					"hookGroupId",
+					"hookId",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/hook-status.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "getHookStatus <hookGroupId> <hookId>",
+				Example:   "  taskcluster api hooks getHookStatus <hookGroupId> <hookId>",
+				Aliases:   []string(nil),
 			},
+			// getTriggerToken: Get a trigger token
+			//
+			// Retrieve a unique secret token for triggering the specified hook. This
+			// token can be deactivated with `resetTriggerToken`.
 			definitions.Entry{
-				Name:        "listHooks",
-				Title:       "List hooks in a given group",
-				Description: "This endpoint will return a list of all the hook definitions within a\ngiven hook group.",
+				Name:        "getTriggerToken",
+				Title:       "Get a trigger token",
+				Description: "Retrieve a unique secret token for triggering the specified hook. This\ntoken can be deactivated with `resetTriggerToken`.",
 				Stability:   "stable",
 				Method:      "get",
-				Route:       "/hooks/<hookGroupId>",
+				Route:       "/hooks/<hookGroupId>/<hookId>/token",
 				Args: []string{
 					"hookGroupId",
+					"hookId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/trigger-token-response.json#",
+				Paginated: false,
+				SignedURL: true,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "getTriggerToken <hookGroupId> <hookId> [--sign-url <duration>]",
+				Example:   "  taskcluster api hooks getTriggerToken <hookGroupId> <hookId>",
+				Aliases:   []string(nil),
 			},
+			// hook: Get hook definition
+			//
+			// This endpoint will return the hook definition for the given `hookGroupId`
+			// and hookId.
 			definitions.Entry{
 				Name:        "hook",
 				Title:       "Get hook definition",
@@ -578,51 +1294,122 @@ var services = map[string]definitions.Service{
 					"hookGroupId",
 					"hookId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/hook-definition.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "hook <hookGroupId> <hookId>",
+				Example:   "  taskcluster api hooks hook <hookGroupId> <hookId>",
+				Aliases:   []string(nil),
 			},
+			// listHookGroups: List hook groups
+			//
+			// This endpoint will return a list of all hook groups with at least one hook.
 			definitions.Entry{
-				Name:        "getHookStatus",
-				Title:       "Get hook status",
-				Description: "This endpoint will return the current status of the hook.  This represents a\nsnapshot in time and may vary from one call to the next.\n\nThis method is deprecated in favor of listLastFires.",
-				Stability:   "deprecated",
+				Name:        "listHookGroups",
+				Title:       "List hook groups",
+				Description: "This endpoint will return a list of all hook groups with at least one hook.",
+				Stability:   "stable",
 				Method:      "get",
-				Route:       "/hooks/<hookGroupId>/<hookId>/status",
-				Args: []string{
-					"hookGroupId",
-					"hookId",
-				},
-				Query: []string{},
-				Input: "",
+				Route:       "/hooks",
+				Args:        []string{},
+				Query:       []string{},
+				Input:       "",
+				Output:      "v1/list-hook-groups-response.json#",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "listHookGroups",
+				Example:     "  taskcluster api hooks listHookGroups",
+				Aliases:     []string(nil),
 			},
+			// listHooks: List hooks in a given group
+			//
+			// This endpoint will return a list of all the hook definitions within a
+			// given hook group.
 			definitions.Entry{
-				Name:        "createHook",
-				Title:       "Create a hook",
-				Description: "This endpoint will create a new hook.\n\nThe caller's credentials must include the role that will be used to\ncreate the task.  That role must satisfy task.scopes as well as the\nnecessary scopes to add the task to the queue.",
+				Name:        "listHooks",
+				Title:       "List hooks in a given group",
+				Description: "This endpoint will return a list of all the hook definitions within a\ngiven hook group.",
 				Stability:   "stable",
-				Method:      "put",
-				Route:       "/hooks/<hookGroupId>/<hookId>",
+				Method:      "get",
+				Route:       "/hooks/<hookGroupId>",
 				Args: []string{
 					"hookGroupId",
-					"hookId",
 				},
-				Query: []string{},
-				Input: "v1/create-hook-request.json#",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/list-hooks-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listHooks <hookGroupId>",
+				Example:   "  taskcluster api hooks listHooks <hookGroupId>",
+				Aliases:   []string(nil),
 			},
+			// listLastFires: Get information about recent hook fires
+			//
+			// This endpoint will return information about the the last few times this hook
+			// has been
+			// fired, including whether the hook was fired successfully or not
 			definitions.Entry{
-				Name:        "updateHook",
-				Title:       "Update a hook",
-				Description: "This endpoint will update an existing hook.  All fields except\n`hookGroupId` and `hookId` can be modified.",
+				Name:        "listLastFires",
+				Title:       "Get information about recent hook fires",
+				Description: "This endpoint will return information about the the last few times this hook has been\nfired, including whether the hook was fired successfully or not",
 				Stability:   "stable",
-				Method:      "post",
-				Route:       "/hooks/<hookGroupId>/<hookId>",
+				Method:      "get",
+				Route:       "/hooks/<hookGroupId>/<hookId>/last-fires",
 				Args: []string{
 					"hookGroupId",
 					"hookId",
 				},
-				Query: []string{},
-				Input: "v1/create-hook-request.json#",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/list-lastFires-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listLastFires <hookGroupId> <hookId>",
+				Example:   "  taskcluster api hooks listLastFires <hookGroupId> <hookId>",
+				Aliases:   []string(nil),
+			},
+			// ping: Ping Server
+			//
+			// Respond without doing anything.
+			// This endpoint is used to check that the service is up.
+			definitions.Entry{
+				Name:        "ping",
+				Title:       "Ping Server",
+				Description: "Respond without doing anything.\nThis endpoint is used to check that the service is up.",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/ping",
+				Args:        []string{},
+				Query:       []string{},
+				Input:       "",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "ping",
+				Example:     "  taskcluster api hooks ping",
+				Aliases:     []string(nil),
 			},
+			// removeHook: Delete a hook
+			//
+			// This endpoint will remove a hook definition.
 			definitions.Entry{
 				Name:        "removeHook",
 				Title:       "Delete a hook",
@@ -634,51 +1421,82 @@ var services = map[string]definitions.Service{
 					"hookGroupId",
 					"hookId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "removeHook <hookGroupId> <hookId>",
+				Example:   "  taskcluster api hooks removeHook <hookGroupId> <hookId>",
+				Aliases:   []string(nil),
 			},
+			// resetTriggerToken: Reset a trigger token
+			//
+			// Reset the token for triggering a given hook. This invalidates token that
+			// may have been issued via getTriggerToken with a new token.
 			definitions.Entry{
-				Name:        "triggerHook",
-				Title:       "Trigger a hook",
-				Description: "This endpoint will trigger the creation of a task from a hook definition.\n\nThe HTTP payload must match the hooks `triggerSchema`.  If it does, it is\nprovided as the `payload` property of the JSON-e context used to render the\ntask template.",
+				Name:        "resetTriggerToken",
+				Title:       "Reset a trigger token",
+				Description: "Reset the token for triggering a given hook. This invalidates token that\nmay have been issued via getTriggerToken with a new token.",
 				Stability:   "stable",
 				Method:      "post",
-				Route:       "/hooks/<hookGroupId>/<hookId>/trigger",
-				Args: []string{
-					"hookGroupId",
-					"hookId",
-				},
-				Query: []string{},
-				Input: "v1/trigger-hook.json#",
-			},
-			definitions.Entry{
-				Name:        "getTriggerToken",
-				Title:       "Get a trigger token",
-				Description: "Retrieve a unique secret token for triggering the specified hook. This\ntoken can be deactivated with `resetTriggerToken`.",
-				Stability:   "stable",
-				Method:      "get",
 				Route:       "/hooks/<hookGroupId>/<hookId>/token",
 				Args: []string{
 					"hookGroupId",
 					"hookId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/trigger-token-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "resetTriggerToken <hookGroupId> <hookId>",
+				Example:   "  taskcluster api hooks resetTriggerToken <hookGroupId> <hookId>",
+				Aliases:   []string(nil),
 			},
+			// triggerHook: Trigger a hook
+			//
+			// This endpoint will trigger the creation of a task from a hook definition.
+			//
+			// The HTTP payload must match the hooks `triggerSchema`.  If it does, it is
+			// provided as the `payload` property of the JSON-e context used to render the
+			// task template.
 			definitions.Entry{
-				Name:        "resetTriggerToken",
-				Title:       "Reset a trigger token",
-				Description: "Reset the token for triggering a given hook. This invalidates token that\nmay have been issued via getTriggerToken with a new token.",
+				Name:        "triggerHook",
+				Title:       "Trigger a hook",
+				Description: "This endpoint will trigger the creation of a task from a hook definition.\n\nThe HTTP payload must match the hooks `triggerSchema`.  If it does, it is\nprovided as the `payload` property of the JSON-e context used to render the\ntask template.",
 				Stability:   "stable",
 				Method:      "post",
-				Route:       "/hooks/<hookGroupId>/<hookId>/token",
+				Route:       "/hooks/<hookGroupId>/<hookId>/trigger",
 				Args: []string{
 					"hookGroupId",
 					"hookId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "v1/trigger-hook.json#",
+				Output:    "v1/trigger-hook-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "triggerHook <hookGroupId> <hookId> [--body <payload>]",
+				Example:   "  taskcluster api hooks triggerHook <hookGroupId> <hookId> --body @trigger-hook.json",
+				Aliases:   []string(nil),
 			},
+			// triggerHookWithToken: Trigger a hook with a token
+			//
+			// This endpoint triggers a defined hook with a valid token.
+			//
+			// The HTTP payload must match the hooks `triggerSchema`.  If it does, it is
+			// provided as the `payload` property of the JSON-e context used to render the
+			// task template.
 			definitions.Entry{
 				Name:        "triggerHookWithToken",
 				Title:       "Trigger a hook with a token",
@@ -691,22 +1509,44 @@ var services = map[string]definitions.Service{
 					"hookId",
 					"token",
 				},
-				Query: []string{},
-				Input: "v1/trigger-hook.json#",
+				Query:     []string{},
+				Input:     "v1/trigger-hook.json#",
+				Output:    "v1/trigger-hook-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "triggerHookWithToken <hookGroupId> <hookId> <token> [--body <payload>]",
+				Example:   "  taskcluster api hooks triggerHookWithToken <hookGroupId> <hookId> <token> --body @trigger-hook.json",
+				Aliases:   []string(nil),
 			},
+			// updateHook: Update a hook
+			//
+			// This endpoint will update an existing hook.  All fields except
+			// `hookGroupId` and `hookId` can be modified.
 			definitions.Entry{
-				Name:        "listLastFires",
-				Title:       "Get information about recent hook fires",
-				Description: "This endpoint will return information about the the last few times this hook has been\nfired, including whether the hook was fired successfully or not",
+				Name:        "updateHook",
+				Title:       "Update a hook",
+				Description: "This endpoint will update an existing hook.  All fields except\n`hookGroupId` and `hookId` can be modified.",
 				Stability:   "stable",
-				Method:      "get",
-				Route:       "/hooks/<hookGroupId>/<hookId>/last-fires",
+				Method:      "post",
+				Route:       "/hooks/<hookGroupId>/<hookId>",
 				Args: []string{
 					"hookGroupId",
 					"hookId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "v1/create-hook-request.json#",
+				Output:    "v1/hook-definition.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "updateHook <hookGroupId> <hookId> [--body <payload>]",
+				Example:   "  taskcluster api hooks updateHook <hookGroupId> <hookId> --body @create-hook-request.json",
+				Aliases:   []string(nil),
 			},
 		},
 	},
@@ -716,17 +1556,59 @@ var services = map[string]definitions.Service{
 		Title:       "Task Index API Documentation",
 		Description: "The index service is responsible for indexing tasks. The service ensures that\ntasks can be located by user-defined names.\n\nAs described in the service documentation, tasks are typically indexed via Pulse\nmessages, so the most common use of API methods is to read from the index.",
 		Entries: []definitions.Entry{
+			// findArtifactFromTask: Get Artifact From Indexed Task
+			//
+			// Find a task by index path and redirect to the artifact on the most recent
+			// run with the given `name`.
+			//
+			// Note that multiple calls to this endpoint may return artifacts from differen
+			// tasks
+			// if a new task is inserted into the index between calls. Avoid using this
+			// method as
+			// a stable link to multiple, connected files if the index path does not contain
+			// a
+			// unique identifier. For example, the following two links may return unrelated
+			// files:
+			// *
+			// https://tc.example.com/api/index/v1/task/some-app.win64.latest.installer/artifacts/public/installer.exe`
+			// *
+			// https://tc.example.com/api/index/v1/task/some-app.win64.latest.installer/artifacts/public/debug-symbols.zip`
+			//
+			// This problem be remedied by including the revision in the index path or by
+			// bundling both
+			// installer and debug symbols into a single artifact.
+			//
+			// If no task exists for the given index path, this API end-point responds with
+			// 404.
 			definitions.Entry{
-				Name:        "ping",
-				Title:       "Ping Server",
-				Description: "Respond without doing anything.\nThis endpoint is used to check that the service is up.",
+				Name:        "findArtifactFromTask",
+				Title:       "Get Artifact From Indexed Task",
+				Description: "Find a task by index path and redirect to the artifact on the most recent\nrun with the given `name`.\n\nNote that multiple calls to this endpoint may return artifacts from differen tasks\nif a new task is inserted into the index between calls. Avoid using this method as\na stable link to multiple, connected files if the index path does not contain a\nunique identifier.  For example, the following two links may return unrelated files:\n* https://tc.example.com/api/index/v1/task/some-app.win64.latest.installer/artifacts/public/installer.exe`\n* https://tc.example.com/api/index/v1/task/some-app.win64.latest.installer/artifacts/public/debug-symbols.zip`\n\nThis problem be remedied by including the revision in the index path or by bundling both\ninstaller and debug symbols into a single artifact.\n\nIf no task exists for the given index path, this API end-point responds with 404.",
 				Stability:   "stable",
 				Method:      "get",
-				Route:       "/ping",
-				Args:        []string{},
-				Query:       []string{},
-				Input:       "",
+				Route:       "/task/<indexPath>/artifacts/<name>",
+				Args: []string{
+					"indexPath",
+					"name",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "",
+				Paginated: false,
+				SignedURL: true,
+				Download:  true,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "findArtifactFromTask <indexPath> <name> [--sign-url <duration>]",
+				Example:   "  taskcluster api index findArtifactFromTask <indexPath> <name> --output <file>",
+				Aliases:   []string(nil),
 			},
+			// findTask: Find Indexed Task
+			//
+			// Find a task by index path, returning the highest-rank task with that path. If
+			// no
+			// task exists for the given path, this API end-point will respond with a 404
+			// status.
 			definitions.Entry{
 				Name:        "findTask",
 				Title:       "Find Indexed Task",
@@ -737,9 +1619,58 @@ var services = map[string]definitions.Service{
 				Args: []string{
 					"indexPath",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/indexed-task-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "findTask <indexPath>",
+				Example:   "  taskcluster api index findTask <indexPath>",
+				Aliases:   []string(nil),
+			},
+			// insertTask: Insert Task into Index
+			//
+			// Insert a task into the index.  If the new rank is less than the existing rank
+			// at the given index path, the task is not indexed but the response is still
+			// 200 OK.
+			//
+			// Please see the introduction above for information
+			// about indexing successfully completed tasks automatically using custom
+			// routes.
+			definitions.Entry{
+				Name:        "insertTask",
+				Title:       "Insert Task into Index",
+				Description: "Insert a task into the index.  If the new rank is less than the existing rank\nat the given index path, the task is not indexed but the response is still 200 OK.\n\nPlease see the introduction above for information\nabout indexing successfully completed tasks automatically using custom routes.",
+				Stability:   "stable",
+				Method:      "put",
+				Route:       "/task/<namespace>",
+				Args: []string{
+					"namespace",
+				},
+				Query:     []string{},
+				Input:     "v1/insert-task-request.json#",
+				Output:    "v1/indexed-task-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "insertTask <namespace> [--body <payload>]",
+				Example:   "  taskcluster api index insertTask <namespace> --body @insert-task-request.json",
+				Aliases:   []string(nil),
 			},
+			// listNamespaces: List Namespaces
+			//
+			// List the namespaces immediately under a given namespace.
+			//
+			// This endpoint
+			// lists up to 1000 namespaces. If more namespaces are present, a
+			// `continuationToken` will be returned, which can be given in the next
+			// request. For the initial request, the payload should be an empty JSON
+			// object.
 			definitions.Entry{
 				Name:        "listNamespaces",
 				Title:       "List Namespaces",
@@ -754,8 +1685,29 @@ var services = map[string]definitions.Service{
 					"continuationToken",
 					"limit",
 				},
-				Input: "",
+				Input:     "",
+				Output:    "v1/list-namespaces-response.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listNamespaces <namespace> [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api index listNamespaces <namespace>",
+				Aliases:   []string(nil),
 			},
+			// listTasks: List Tasks
+			//
+			// List the tasks immediately under a given namespace.
+			//
+			// This endpoint
+			// lists up to 1000 tasks. If more tasks are present, a
+			// `continuationToken` will be returned, which can be given in the next
+			// request. For the initial request, the payload should be an empty JSON
+			// object.
+			//
+			// **Remark**, this end-point is designed for humans browsing for tasks, not
+			// services, as that makes little sense.
 			definitions.Entry{
 				Name:        "listTasks",
 				Title:       "List Tasks",
@@ -770,34 +1722,40 @@ var services = map[string]definitions.Service{
 					"continuationToken",
 					"limit",
 				},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "insertTask",
-				Title:       "Insert Task into Index",
-				Description: "Insert a task into the index.  If the new rank is less than the existing rank\nat the given index path, the task is not indexed but the response is still 200 OK.\n\nPlease see the introduction above for information\nabout indexing successfully completed tasks automatically using custom routes.",
-				Stability:   "stable",
-				Method:      "put",
-				Route:       "/task/<namespace>",
-				Args: []string{
-					"namespace",
-				},
-				Query: []string{},
-				Input: "v1/insert-task-request.json#",
+				Input:     "",
+				Output:    "v1/list-tasks-response.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listTasks <namespace> [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api index listTasks <namespace>",
+				Aliases:   []string(nil),
 			},
+			// ping: Ping Server
+			//
+			// Respond without doing anything.
+			// This endpoint is used to check that the service is up.
 			definitions.Entry{
-				Name:        "findArtifactFromTask",
-				Title:       "Get Artifact From Indexed Task",
-				Description: "Find a task by index path and redirect to the artifact on the most recent\nrun with the given `name`.\n\nNote that multiple calls to this endpoint may return artifacts from differen tasks\nif a new task is inserted into the index between calls. Avoid using this method as\na stable link to multiple, connected files if the index path does not contain a\nunique identifier.  For example, the following two links may return unrelated files:\n* https://tc.example.com/api/index/v1/task/some-app.win64.latest.installer/artifacts/public/installer.exe`\n* https://tc.example.com/api/index/v1/task/some-app.win64.latest.installer/artifacts/public/debug-symbols.zip`\n\nThis problem be remedied by including the revision in the index path or by bundling both\ninstaller and debug symbols into a single artifact.\n\nIf no task exists for the given index path, this API end-point responds with 404.",
+				Name:        "ping",
+				Title:       "Ping Server",
+				Description: "Respond without doing anything.\nThis endpoint is used to check that the service is up.",
 				Stability:   "stable",
 				Method:      "get",
-				Route:       "/task/<indexPath>/artifacts/<name>",
-				Args: []string{
-					"indexPath",
-					"name",
-				},
-				Query: []string{},
-				Input: "",
+				Route:       "/ping",
+				Args:        []string{},
+				Query:       []string{},
+				Input:       "",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "ping",
+				Example:     "  taskcluster api index ping",
+				Aliases:     []string(nil),
 			},
 		},
 	},
@@ -807,39 +1765,94 @@ var services = map[string]definitions.Service{
 		Title:       "Notification Service",
 		Description: "The notification service listens for tasks with associated notifications\nand handles requests to send emails and post pulse messages.",
 		Entries: []definitions.Entry{
+			// addDenylistAddress: Denylist Given Address
+			//
+			// Add the given address to the notification denylist. The address
+			// can be of either of the three supported address type namely pulse, email
+			// or IRC(user or channel). Addresses in the denylist will be ignored
+			// by the notification service.
 			definitions.Entry{
-				Name:        "ping",
-				Title:       "Ping Server",
-				Description: "Respond without doing anything.\nThis endpoint is used to check that the service is up.",
-				Stability:   "stable",
-				Method:      "get",
-				Route:       "/ping",
+				Name:        "addDenylistAddress",
+				Title:       "Denylist Given Address",
+				Description: "Add the given address to the notification denylist. The address\ncan be of either of the three supported address type namely pulse, email\nor IRC(user or channel). Addresses in the denylist will be ignored\nby the notification service.",
+				Stability:   "experimental",
+				Method:      "post",
+				Route:       "/denylist/add",
 				Args:        []string{},
 				Query:       []string{},
-				Input:       "",
+				Input:       "v1/notification-address.json#",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "addDenylistAddress [--body <payload>]",
+				Example:     "  taskcluster api notify addDenylistAddress --body @notification-address.json",
+				Aliases:     []string(nil),
 			},
+			// deleteDenylistAddress: Delete Denylisted Address
+			//
+			// Delete the specified address from the notification denylist.
 			definitions.Entry{
-				Name:        "email",
-				Title:       "Send an Email",
-				Description: "Send an email to `address`. The content is markdown and will be rendered\nto HTML, but both the HTML and raw markdown text will be sent in the\nemail. If a link is included, it will be rendered to a nice button in the\nHTML version of the email",
+				Name:        "deleteDenylistAddress",
+				Title:       "Delete Denylisted Address",
+				Description: "Delete the specified address from the notification denylist.",
 				Stability:   "experimental",
-				Method:      "post",
-				Route:       "/email",
+				Method:      "delete",
+				Route:       "/denylist/delete",
 				Args:        []string{},
 				Query:       []string{},
-				Input:       "v1/email-request.json#",
+				Input:       "v1/notification-address.json#",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "deleteDenylistAddress [--body <payload>]",
+				Example:     "  taskcluster api notify deleteDenylistAddress --body @notification-address.json",
+				Aliases:     []string(nil),
 			},
+			// email: Send an Email
+			//
+			// Send an email to `address`. The content is markdown and will be rendered
+			// to HTML, but both the HTML and raw markdown text will be sent in the
+			// email. If a link is included, it will be rendered to a nice button in the
+			// HTML version of the email
 			definitions.Entry{
-				Name:        "pulse",
-				Title:       "Publish a Pulse Message",
-				Description: "Publish a message on pulse with the given `routingKey`.",
+				Name:        "email",
+				Title:       "Send an Email",
+				Description: "Send an email to `address`. The content is markdown and will be rendered\nto HTML, but both the HTML and raw markdown text will be sent in the\nemail. If a link is included, it will be rendered to a nice button in the\nHTML version of the email",
 				Stability:   "experimental",
 				Method:      "post",
-				Route:       "/pulse",
+				Route:       "/email",
 				Args:        []string{},
 				Query:       []string{},
-				Input:       "v1/pulse-request.json#",
+				Input:       "v1/email-request.json#",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "email [--body <payload>]",
+				Example:     "  taskcluster api notify email --body @email-request.json",
+				Aliases:     []string(nil),
 			},
+			// irc: Post IRC Message
+			//
+			// Post a message on IRC to a specific channel or user, or a specific user
+			// on a specific channel.
+			//
+			// Success of this API method does not imply the message was successfully
+			// posted. This API method merely inserts the IRC message into a queue
+			// that will be processed by a background process.
+			// This allows us to re-send the message in face of connection issues.
+			//
+			// However, if the user isn't online the message will be dropped without
+			// error. We maybe improve this behavior in the future. For now just keep
+			// in mind that IRC is a best-effort service.
 			definitions.Entry{
 				Name:        "irc",
 				Title:       "Post IRC Message",
@@ -850,7 +1863,62 @@ var services = map[string]definitions.Service{
 				Args:        []string{},
 				Query:       []string{},
 				Input:       "v1/irc-request.json#",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "irc [--body <payload>]",
+				Example:     "  taskcluster api notify irc --body @irc-request.json",
+				Aliases:     []string(nil),
+			},
+			// listDenylist: List Denylisted Notifications
+			//
+			// Lists all the denylisted addresses.
+			//
+			// By default this end-point will try to return up to 1000 addresses in one
+			// request. But it **may return less**, even if more tasks are available.
+			// It may also return a `continuationToken` even though there are no more
+			// results. However, you can only be sure to have seen all results if you
+			// keep calling `list` with the last `continuationToken` until you
+			// get a result without a `continuationToken`.
+			//
+			// If you are not interested in listing all the members at once, you may
+			// use the query-string option `limit` to return fewer.
+			definitions.Entry{
+				Name:        "listDenylist",
+				Title:       "List Denylisted Notifications",
+				Description: "Lists all the denylisted addresses.\n\nBy default this end-point will try to return up to 1000 addresses in one\nrequest. But it **may return less**, even if more tasks are available.\nIt may also return a `continuationToken` even though there are no more\nresults. However, you can only be sure to have seen all results if you\nkeep calling `list` with the last `continuationToken` until you\nget a result without a `continuationToken`.\n\nIf you are not interested in listing all the members at once, you may\nuse the query-string option `limit` to return fewer.",
+				Stability:   "experimental",
+				Method:      "get",
+				Route:       "/denylist/list",
+				Args:        []string{},
+				Query: []string{
+					"continuationToken",
+					"limit",
+				},
+				Input:     "",
+				Output:    "v1/notification-address-list.json#",
+				Paginated: true,
+				SignedURL: true,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listDenylist [--continuationToken <continuationToken>] [--limit <limit>] [--all] [--sign-url <duration>]",
+				Example:   "  taskcluster api notify listDenylist",
+				Aliases:   []string(nil),
 			},
+			// matrix: Post Matrix Message
+			//
+			// Post a message to a room in Matrix. Optionally includes formatted message.
+			//
+			// The `roomId` in the scopes is a fully formed `roomId` with leading `!` such
+			// as `!foo:bar.com`.
+			//
+			// Note that the matrix client used by taskcluster must be invited to a room
+			// before
+			// it can post there!
 			definitions.Entry{
 				Name:        "matrix",
 				Title:       "Post Matrix Message",
@@ -861,51 +1929,107 @@ var services = map[string]definitions.Service{
 				Args:        []string{},
 				Query:       []string{},
 				Input:       "v1/matrix-request.json#",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "matrix [--body <payload>]",
+				Example:     "  taskcluster api notify matrix --body @matrix-request.json",
+				Aliases:     []string(nil),
 			},
+			// ping: Ping Server
+			//
+			// Respond without doing anything.
+			// This endpoint is used to check that the service is up.
 			definitions.Entry{
-				Name:        "addDenylistAddress",
-				Title:       "Denylist Given Address",
-				Description: "Add the given address to the notification denylist. The address\ncan be of either of the three supported address type namely pulse, email\nor IRC(user or channel). Addresses in the denylist will be ignored\nby the notification service.",
-				Stability:   "experimental",
-				Method:      "post",
-				Route:       "/denylist/add",
+				Name:        "ping",
+				Title:       "Ping Server",
+				Description: "Respond without doing anything.\nThis endpoint is used to check that the service is up.",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/ping",
 				Args:        []string{},
 				Query:       []string{},
-				Input:       "v1/notification-address.json#",
+				Input:       "",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "ping",
+				Example:     "  taskcluster api notify ping",
+				Aliases:     []string(nil),
 			},
+			// pulse: Publish a Pulse Message
+			//
+			// Publish a message on pulse with the given `routingKey`.
 			definitions.Entry{
-				Name:        "deleteDenylistAddress",
-				Title:       "Delete Denylisted Address",
-				Description: "Delete the specified address from the notification denylist.",
+				Name:        "pulse",
+				Title:       "Publish a Pulse Message",
+				Description: "Publish a message on pulse with the given `routingKey`.",
 				Stability:   "experimental",
-				Method:      "delete",
-				Route:       "/denylist/delete",
+				Method:      "post",
+				Route:       "/pulse",
 				Args:        []string{},
 				Query:       []string{},
-				Input:       "v1/notification-address.json#",
+				Input:       "v1/pulse-request.json#",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "pulse [--body <payload>]",
+				Example:     "  taskcluster api notify pulse --body @pulse-request.json",
+				Aliases:     []string(nil),
 			},
+		},
+	},
+	"PurgeCache": definitions.Service{
+		APIVersion:  "v1",
+		ServiceName: "purge-cache",
+		Title:       "Purge Cache API",
+		Description: "The purge-cache service is responsible for tracking cache-purge requests.\n\nUser create purge requests for specific caches on specific workers, and\nthese requests are timestamped.  Workers consult the service before\nstarting a new task, and purge any caches older than the timestamp.",
+		Entries: []definitions.Entry{
+			// allPurgeRequests: All Open Purge Requests
+			//
+			// View all active purge requests.
+			//
+			// This is useful mostly for administors to view
+			// the set of open purge requests. It should not
+			// be used by workers. They should use the purgeRequests
+			// endpoint that is specific to their workerType and
+			// provisionerId.
 			definitions.Entry{
-				Name:        "listDenylist",
-				Title:       "List Denylisted Notifications",
-				Description: "Lists all the denylisted addresses.\n\nBy default this end-point will try to return up to 1000 addresses in one\nrequest. But it **may return less**, even if more tasks are available.\nIt may also return a `continuationToken` even though there are no more\nresults. However, you can only be sure to have seen all results if you\nkeep calling `list` with the last `continuationToken` until you\nget a result without a `continuationToken`.\n\nIf you are not interested in listing all the members at once, you may\nuse the query-string option `limit` to return fewer.",
-				Stability:   "experimental",
+				Name:        "allPurgeRequests",
+				Title:       "All Open Purge Requests",
+				Description: "View all active purge requests.\n\nThis is useful mostly for administors to view\nthe set of open purge requests. It should not\nbe used by workers. They should use the purgeRequests\nendpoint that is specific to their workerType and\nprovisionerId.",
+				Stability:   "stable",
 				Method:      "get",
-				Route:       "/denylist/list",
+				Route:       "/purge-cache/list",
 				Args:        []string{},
 				Query: []string{
 					"continuationToken",
 					"limit",
 				},
-				Input: "",
+				Input:     "",
+				Output:    "v1/all-purge-cache-request-list.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "allPurgeRequests [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api purgeCache allPurgeRequests",
+				Aliases:   []string(nil),
 			},
-		},
-	},
-	"PurgeCache": definitions.Service{
-		APIVersion:  "v1",
-		ServiceName: "purge-cache",
-		Title:       "Purge Cache API",
-		Description: "The purge-cache service is responsible for tracking cache-purge requests.\n\nUser create purge requests for specific caches on specific workers, and\nthese requests are timestamped.  Workers consult the service before\nstarting a new task, and purge any caches older than the timestamp.",
-		Entries: []definitions.Entry{
+			// ping: Ping Server
+			//
+			// Respond without doing anything.
+			// This endpoint is used to check that the service is up.
 			definitions.Entry{
 				Name:        "ping",
 				Title:       "Ping Server",
@@ -916,7 +2040,23 @@ var services = map[string]definitions.Service{
 				Args:        []string{},
 				Query:       []string{},
 				Input:       "",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "ping",
+				Example:     "  taskcluster api purgeCache ping",
+				Aliases:     []string(nil),
 			},
+			// purgeCache: Purge Worker Cache
+			//
+			// Publish a request to purge caches named `cacheName` with
+			// on `provisionerId`/`workerType` workers.
+			//
+			// If such a request already exists, its `before` timestamp is updated to
+			// the current time.
 			definitions.Entry{
 				Name:        "purgeCache",
 				Title:       "Purge Worker Cache",
@@ -928,23 +2068,24 @@ var services = map[string]definitions.Service{
 					"provisionerId",
 					"workerType",
 				},
-				Query: []string{},
-				Input: "v1/purge-cache-request.json#",
-			},
-			definitions.Entry{
-				Name:        "allPurgeRequests",
-				Title:       "All Open Purge Requests",
-				Description: "View all active purge requests.\n\nThis is useful mostly for administors to view\nthe set of open purge requests. It should not\nbe used by workers. They should use the purgeRequests\nendpoint that is specific to their workerType and\nprovisionerId.",
-				Stability:   "stable",
-				Method:      "get",
-				Route:       "/purge-cache/list",
-				Args:        []string{},
-				Query: []string{
-					"continuationToken",
-					"limit",
-				},
-				Input: "",
+				Query:     []string{},
+				Input:     "v1/purge-cache-request.json#",
+				Output:    "",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "purgeCache <provisionerId> <workerType> [--body <payload>]",
+				Example:   "  taskcluster api purgeCache purgeCache <provisionerId> <workerType> --body @purge-cache-request.json",
+				Aliases:   []string(nil),
 			},
+			// purgeRequests: Open Purge Requests for a provisionerId/workerType pair
+			//
+			// List the caches for this `provisionerId`/`workerType` that should to be
+			// purged if they are from before the time given in the response.
+			//
+			// This is intended to be used by workers to determine which caches to purge.
 			definitions.Entry{
 				Name:        "purgeRequests",
 				Title:       "Open Purge Requests for a provisionerId/workerType pair",
@@ -959,7 +2100,16 @@ var services = map[string]definitions.Service{
 				Query: []string{
 					"since",
 				},
-				Input: "",
+				Input:     "",
+				Output:    "v1/purge-cache-request-list.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "purgeRequests <provisionerId> <workerType> [--since <since>]",
+				Example:   "  taskcluster api purgeCache purgeRequests <provisionerId> <workerType>",
+				Aliases:   []string(nil),
 			},
 		},
 	},
@@ -969,114 +2119,20 @@ var services = map[string]definitions.Service{
 		Title:       "Queue API Documentation",
 		Description: "The queue service is responsible for accepting tasks and track their state\nas they are executed by workers. In order ensure they are eventually\nresolved.\n\nThis document describes the API end-points offered by the queue. These \nend-points targets the following audience:\n * Schedulers, who create tasks to be executed,\n * Workers, who execute tasks, and\n * Tools, that wants to inspect the state of a task.",
 		Entries: []definitions.Entry{
-			definitions.Entry{
-				Name:        "ping",
-				Title:       "Ping Server",
-				Description: "Respond without doing anything.\nThis endpoint is used to check that the service is up.",
-				Stability:   "stable",
-				Method:      "get",
-				Route:       "/ping",
-				Args:        []string{},
-				Query:       []string{},
-				Input:       "",
-			},
-			definitions.Entry{
-				Name:        "task",
-				Title:       "Get Task Definition",
-				Description: "This end-point will return the task-definition. Notice that the task\ndefinition may have been modified by queue, if an optional property is\nnot specified the queue may provide a default value.",
-				Stability:   "stable",
-				Method:      "get",
-				Route:       "/task/<taskId>",
-				Args: []string{
-					"taskId",
-				},
-				Query: []string{},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "status",
-				Title:       "Get task status",
-				Description: "Get task status structure from `taskId`",
-				Stability:   "stable",
-				Method:      "get",
-				Route:       "/task/<taskId>/status",
-				Args: []string{
-					"taskId",
-				},
-				Query: []string{},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "listTaskGroup",
-				Title:       "List Task Group",
-				Description: "List tasks sharing the same `taskGroupId`.\n\nAs a task-group may contain an unbounded number of tasks, this end-point\nmay return a `continuationToken`. To continue listing tasks you must call\nthe `listTaskGroup` again with the `continuationToken` as the\nquery-string option `continuationToken`.\n\nBy default this end-point will try to return up to 1000 members in one\nrequest. But it **may return less**, even if more tasks are available.\nIt may also return a `continuationToken` even though there are no more\nresults. However, you can only be sure to have seen all results if you\nkeep calling `listTaskGroup` with the last `continuationToken` until you\nget a result without a `continuationToken`.\n\nIf you are not interested in listing all the members at once, you may\nuse the query-string option `limit` to return fewer.",
-				Stability:   "stable",
-				Method:      "get",
-				Route:       "/task-group/<taskGroupId>/list",
-				Args: []string{
-					"taskGroupId",
-				},
-				Query: []string{
-					"continuationToken",
-					"limit",
-				},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "listDependentTasks",
-				Title:       "List Dependent Tasks",
-				Description: "List tasks that depend on the given `taskId`.\n\nAs many tasks from different task-groups may dependent on a single tasks,\nthis end-point may return a `continuationToken`. To continue listing\ntasks you must call `listDependentTasks` again with the\n`continuationToken` as the query-string option `continuationToken`.\n\nBy default this end-point will try to return up to 1000 tasks in one\nrequest. But it **may return less**, even if more tasks are available.\nIt may also return a `continuationToken` even though there are no more\nresults. However, you can only be sure to have seen all results if you\nkeep calling `listDependentTasks` with the last `continuationToken` until\nyou get a result without a `continuationToken`.\n\nIf you are not interested in listing all the tasks at once, you may\nuse the query-string option `limit` to return fewer.",
-				Stability:   "stable",
-				Method:      "get",
-				Route:       "/task/<taskId>/dependents",
-				Args: []string{
-					"taskId",
-				},
-				Query: []string{
-					"continuationToken",
-					"limit",
-				},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "createTask",
-				Title:       "Create New Task",
-				Description: "Create a new task, this is an **idempotent** operation, so repeat it if\nyou get an internal server error or network connection is dropped.\n\n**Task `deadline`**: the deadline property can be no more than 5 days\ninto the future. This is to limit the amount of pending tasks not being\ntaken care of. Ideally, you should use a much shorter deadline.\n\n**Task expiration**: the `expires` property must be greater than the\ntask `deadline`. If not provided it will default to `deadline` + one\nyear. Notice, that artifacts created by task must expire before the task.\n\n**Task specific routing-keys**: using the `task.routes` property you may\ndefine task specific routing-keys. If a task has a task specific \nrouting-key: `<route>`, then when the AMQP message about the task is\npublished, the message will be CC'ed with the routing-key: \n`route.<route>`. This is useful if you want another component to listen\nfor completed tasks you have posted.  The caller must have scope\n`queue:route:<route>` for each route.\n\n**Dependencies**: any tasks referenced in `task.dependencies` must have\nalready been created at the time of this call.\n\n**Scopes**: Note that the scopes required to complete this API call depend\non the content of the `scopes`, `routes`, `schedulerId`, `priority`,\n`provisionerId`, and `workerType` properties of the task definition.",
-				Stability:   "stable",
-				Method:      "put",
-				Route:       "/task/<taskId>",
-				Args: []string{
-					"taskId",
-				},
-				Query: []string{},
-				Input: "v1/create-task-request.json#",
-			},
-			definitions.Entry{
-				Name:        "scheduleTask",
-				Title:       "Schedule Defined Task",
-				Description: "scheduleTask will schedule a task to be executed, even if it has\nunresolved dependencies. A task would otherwise only be scheduled if\nits dependencies were resolved.\n\nThis is useful if you have defined a task that depends on itself or on\nsome other task that has not been resolved, but you wish the task to be\nscheduled immediately.\n\nThis will announce the task as pending and workers will be allowed to\nclaim it and resolve the task.\n\n**Note** this operation is **idempotent** and will not fail or complain\nif called with a `taskId` that is already scheduled, or even resolved.\nTo reschedule a task previously resolved, use `rerunTask`.",
-				Stability:   "stable",
-				Method:      "post",
-				Route:       "/task/<taskId>/schedule",
-				Args: []string{
-					"taskId",
-				},
-				Query: []string{},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "rerunTask",
-				Title:       "Rerun a Resolved Task",
-				Description: "This method _reruns_ a previously resolved task, even if it was\n_completed_. This is useful if your task completes unsuccessfully, and\nyou just want to run it from scratch again. This will also reset the\nnumber of `retries` allowed.\n\nThis method is deprecated in favour of creating a new task with the same\ntask definition (but with a new taskId).\n\nRemember that `retries` in the task status counts the number of runs that\nthe queue have started because the worker stopped responding, for example\nbecause a spot node died.\n\n**Remark** this operation is idempotent, if you try to rerun a task that\nis not either `failed` or `completed`, this operation will just return\nthe current task status.",
-				Stability:   "stable",
-				Method:      "post",
-				Route:       "/task/<taskId>/rerun",
-				Args: []string{
-					"taskId",
-				},
-				Query: []string{},
-				Input: "",
-			},
+			// cancelTask: Cancel Task
+			//
+			// This method will cancel a task that is either `unscheduled`, `pending` or
+			// `running`. It will resolve the current run as `exception` with
+			// `reasonResolved` set to `canceled`. If the task isn't scheduled yet, ie.
+			// it doesn't have any runs, an initial run will be added and resolved as
+			// described above. Hence, after canceling a task, it cannot be scheduled
+			// with `queue.scheduleTask`, but a new run can be created with
+			// `queue.rerun`. These semantics is equivalent to calling
+			// `queue.scheduleTask` immediately followed by `queue.cancelTask`.
+			//
+			// **Remark** this operation is idempotent, if you try to cancel a task that
+			// isn't `unscheduled`, `pending` or `running`, this operation will just
+			// return the current task status.
 			definitions.Entry{
 				Name:        "cancelTask",
 				Title:       "Cancel Task",
@@ -1087,23 +2143,21 @@ var services = map[string]definitions.Service{
 				Args: []string{
 					"taskId",
 				},
-				Query: []string{},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "claimWork",
-				Title:       "Claim Work",
-				Description: "Claim pending task(s) for the given `provisionerId`/`workerType` queue.\n\nIf any work is available (even if fewer than the requested number of\ntasks, this will return immediately. Otherwise, it will block for tens of\nseconds waiting for work.  If no work appears, it will return an emtpy\nlist of tasks.  Callers should sleep a short while (to avoid denial of\nservice in an error condition) and call the endpoint again.  This is a\nsimple implementation of \"long polling\".",
-				Stability:   "stable",
-				Method:      "post",
-				Route:       "/claim-work/<provisionerId>/<workerType>",
-				Args: []string{
-					"provisionerId",
-					"workerType",
-				},
-				Query: []string{},
-				Input: "v1/claim-work-request.json#",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/task-status-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "cancelTask <taskId>",
+				Example:   "  taskcluster api queue cancelTask <taskId>",
+				Aliases:   []string(nil),
 			},
+			// claimTask: Claim Task
+			//
+			// claim a task - never documented
 			definitions.Entry{
 				Name:        "claimTask",
 				Title:       "Claim Task",
@@ -1115,80 +2169,367 @@ var services = map[string]definitions.Service{
 					"taskId",
 					"runId",
 				},
-				Query: []string{},
-				Input: "v1/task-claim-request.json#",
+				Query:     []string{},
+				Input:     "v1/task-claim-request.json#",
+				Output:    "v1/task-claim-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "claimTask <taskId> <runId> [--body <payload>]",
+				Example:   "  taskcluster api queue claimTask <taskId> <runId> --body @task-claim-request.json",
+				Aliases:   []string(nil),
 			},
+			// claimWork: Claim Work
+			//
+			// Claim pending task(s) for the given `provisionerId`/`workerType` queue.
+			//
+			// If any work is available (even if fewer than the requested number of
+			// tasks, this will return immediately. Otherwise, it will block for tens of
+			// seconds waiting for work.  If no work appears, it will return an emtpy
+			// list of tasks.  Callers should sleep a short while (to avoid denial of
+			// service in an error condition) and call the endpoint again.  This is a
+			// simple implementation of "long polling".
 			definitions.Entry{
-				Name:        "reclaimTask",
-				Title:       "Reclaim task",
-				Description: "Refresh the claim for a specific `runId` for given `taskId`. This updates\nthe `takenUntil` property and returns a new set of temporary credentials\nfor performing requests on behalf of the task. These credentials should\nbe used in-place of the credentials returned by `claimWork`.\n\nThe `reclaimTask` requests serves to:\n * Postpone `takenUntil` preventing the queue from resolving\n   `claim-expired`,\n * Refresh temporary credentials used for processing the task, and\n * Abort execution if the task/run have been resolved.\n\nIf the `takenUntil` timestamp is exceeded the queue will resolve the run\nas _exception_ with reason `claim-expired`, and proceeded to retry to the\ntask. This ensures that tasks are retried, even if workers disappear\nwithout warning.\n\nIf the task is resolved, this end-point will return `409` reporting\n`RequestConflict`. This typically happens if the task have been canceled\nor the `task.deadline` have been exceeded. If reclaiming fails, workers\nshould abort the task and forget about the given `runId`. There is no\nneed to resolve the run or upload artifacts.",
+				Name:        "claimWork",
+				Title:       "Claim Work",
+				Description: "Claim pending task(s) for the given `provisionerId`/`workerType` queue.\n\nIf any work is available (even if fewer than the requested number of\ntasks, this will return immediately. Otherwise, it will block for tens of\nseconds waiting for work.  If no work appears, it will return an emtpy\nlist of tasks.  Callers should sleep a short while (to avoid denial of\nservice in an error condition) and call the endpoint again.  This is a\nsimple implementation of \"long polling\".",
 				Stability:   "stable",
 				Method:      "post",
-				Route:       "/task/<taskId>/runs/<runId>/reclaim",
+				Route:       "/claim-work/<provisionerId>/<workerType>",
 				Args: []string{
-					"taskId",
-					"runId",
+					"provisionerId",
+					"workerType",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "v1/claim-work-request.json#",
+				Output:    "v1/claim-work-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "claimWork <provisionerId> <workerType> [--body <payload>]",
+				Example:   "  taskcluster api queue claimWork <provisionerId> <workerType> --body @claim-work-request.json",
+				Aliases:   []string(nil),
 			},
+			// createArtifact: Create Artifact
+			//
+			// This API end-point creates an artifact for a specific run of a task. This
+			// should **only** be used by a worker currently operating on this task, or
+			// from a process running within the task (ie. on the worker).
+			//
+			// All artifacts must specify when they `expires`, the queue will
+			// automatically take care of deleting artifacts past their
+			// expiration point. This features makes it feasible to upload large
+			// intermediate artifacts from data processing applications, as the
+			// artifacts can be set to expire a few days later.
+			//
+			// We currently support "S3 Artifacts" officially, with remaining support
+			// for two deprecated types.  Do not use these deprecated types.
+			//
+			// **S3 artifacts**, is useful for static files which will be
+			// stored on S3. When creating an S3 artifact the queue will return a
+			// pre-signed URL to which you can do a `PUT` request to upload your
+			// artifact. Note that `PUT` request **must** specify the `content-length`
+			// header and **must** give the `content-type` header the same value as in
+			// the request to `createArtifact`.
+			//
+			// **Reference artifacts**, only consists of meta-data which the queue will
+			// store for you. These artifacts really only have a `url` property and
+			// when the artifact is requested the client will be redirect the URL
+			// provided with a `303` (See Other) redirect. Please note that we cannot
+			// delete artifacts you upload to other service, we can only delete the
+			// reference to the artifact, when it expires.
+			//
+			// **Error artifacts**, only consists of meta-data which the queue will
+			// store for you. These artifacts are only meant to indicate that you the
+			// worker or the task failed to generate a specific artifact, that you
+			// would otherwise have uploaded. For example docker-worker will upload an
+			// error artifact, if the file it was supposed to upload doesn't exists or
+			// turns out to be a directory. Clients requesting an error artifact will
+			// get a `424` (Failed Dependency) response. This is mainly designed to
+			// ensure that dependent tasks can distinguish between artifacts that were
+			// suppose to be generated and artifacts for which the name is misspelled.
+			//
+			// **Artifact immutability**, generally speaking you cannot overwrite an
+			// artifact when created. But if you repeat the request with the same
+			// properties the request will succeed as the operation is idempotent.
+			// This is useful if you need to refresh a signed URL while uploading.
+			// Do not abuse this to overwrite artifacts created by another entity!
+			// Such as worker-host overwriting artifact created by worker-code.
+			//
+			// As a special case the `url` property on _reference artifacts_ can be
+			// updated. You should only use this to update the `url` property for
+			// reference artifacts your process has created.
 			definitions.Entry{
-				Name:        "reportCompleted",
-				Title:       "Report Run Completed",
-				Description: "Report a task completed, resolving the run as `completed`.",
+				Name:        "createArtifact",
+				Title:       "Create Artifact",
+				Description: "This API end-point creates an artifact for a specific run of a task. This\nshould **only** be used by a worker currently operating on this task, or\nfrom a process running within the task (ie. on the worker).\n\nAll artifacts must specify when they `expires`, the queue will\nautomatically take care of deleting artifacts past their\nexpiration point. This features makes it feasible to upload large\nintermediate artifacts from data processing applications, as the\nartifacts can be set to expire a few days later.\n\nWe currently support \"S3 Artifacts\" officially, with remaining support\nfor two deprecated types.  Do not use these deprecated types.\n\n**S3 artifacts**, is useful for static files which will be\nstored on S3. When creating an S3 artifact the queue will return a\npre-signed URL to which you can do a `PUT` request to upload your\nartifact. Note that `PUT` request **must** specify the `content-length`\nheader and **must** give the `content-type` header the same value as in\nthe request to `createArtifact`.\n\n**Reference artifacts**, only consists of meta-data which the queue will\nstore for you. These artifacts really only have a `url` property and\nwhen the artifact is requested the client will be redirect the URL\nprovided with a `303` (See Other) redirect. Please note that we cannot\ndelete artifacts you upload to other service, we can only delete the\nreference to the artifact, when it expires.\n\n**Error artifacts**, only consists of meta-data which the queue will\nstore for you. These artifacts are only meant to indicate that you the\nworker or the task failed to generate a specific artifact, that you\nwould otherwise have uploaded. For example docker-worker will upload an\nerror artifact, if the file it was supposed to upload doesn't exists or\nturns out to be a directory. Clients requesting an error artifact will\nget a `424` (Failed Dependency) response. This is mainly designed to\nensure that dependent tasks can distinguish between artifacts that were\nsuppose to be generated and artifacts for which the name is misspelled.\n\n**Artifact immutability**, generally speaking you cannot overwrite an\nartifact when created. But if you repeat the request with the same\nproperties the request will succeed as the operation is idempotent.\nThis is useful if you need to refresh a signed URL while uploading.\nDo not abuse this to overwrite artifacts created by another entity!\nSuch as worker-host overwriting artifact created by worker-code.\n\nAs a special case the `url` property on _reference artifacts_ can be\nupdated. You should only use this to update the `url` property for\nreference artifacts your process has created.",
 				Stability:   "stable",
 				Method:      "post",
-				Route:       "/task/<taskId>/runs/<runId>/completed",
+				Route:       "/task/<taskId>/runs/<runId>/artifacts/<name>",
 				Args: []string{
 					"taskId",
 					"runId",
+					"name",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "v1/post-artifact-request.json#",
+				Output:    "v1/post-artifact-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    true,
+				Usage:     "createArtifact <taskId> <runId> <name> [--body <payload>] [--input <file>]",
+				Example:   "  taskcluster api queue createArtifact <taskId> <runId> <name> --body @post-artifact-request.json --input <file>",
+				Aliases:   []string(nil),
 			},
+			// createTask: Create New Task
+			//
+			// Create a new task, this is an **idempotent** operation, so repeat it if
+			// you get an internal server error or network connection is dropped.
+			//
+			// **Task `deadline`**: the deadline property can be no more than 5 days
+			// into the future. This is to limit the amount of pending tasks not being
+			// taken care of. Ideally, you should use a much shorter deadline.
+			//
+			// **Task expiration**: the `expires` property must be greater than the
+			// task `deadline`. If not provided it will default to `deadline` + one
+			// year. Notice, that artifacts created by task must expire before the task.
+			//
+			// **Task specific routing-keys**: using the `task.routes` property you may
+			// define task specific routing-keys. If a task has a task specific
+			// routing-key: `<route>`, then when the AMQP message about the task is
+			// published, the message will be CC'ed with the routing-key:
+			// `route.<route>`. This is useful if you want another component to listen
+			// for completed tasks you have posted.  The caller must have scope
+			// `queue:route:<route>` for each route.
+			//
+			// **Dependencies**: any tasks referenced in `task.dependencies` must have
+			// already been created at the time of this call.
+			//
+			// **Scopes**: Note that the scopes required to complete this API call depend
+			// on the content of the `scopes`, `routes`, `schedulerId`, `priority`,
+			// `provisionerId`, and `workerType` properties of the task definition.
 			definitions.Entry{
-				Name:        "reportFailed",
-				Title:       "Report Run Failed",
-				Description: "Report a run failed, resolving the run as `failed`. Use this to resolve\na run that failed because the task specific code behaved unexpectedly.\nFor example the task exited non-zero, or didn't produce expected output.\n\nDo not use this if the task couldn't be run because if malformed\npayload, or other unexpected condition. In these cases we have a task\nexception, which should be reported with `reportException`.",
+				Name:        "createTask",
+				Title:       "Create New Task",
+				Description: "Create a new task, this is an **idempotent** operation, so repeat it if\nyou get an internal server error or network connection is dropped.\n\n**Task `deadline`**: the deadline property can be no more than 5 days\ninto the future. This is to limit the amount of pending tasks not being\ntaken care of. Ideally, you should use a much shorter deadline.\n\n**Task expiration**: the `expires` property must be greater than the\ntask `deadline`. If not provided it will default to `deadline` + one\nyear. Notice, that artifacts created by task must expire before the task.\n\n**Task specific routing-keys**: using the `task.routes` property you may\ndefine task specific routing-keys. If a task has a task specific \nrouting-key: `<route>`, then when the AMQP message about the task is\npublished, the message will be CC'ed with the routing-key: \n`route.<route>`. This is useful if you want another component to listen\nfor completed tasks you have posted.  The caller must have scope\n`queue:route:<route>` for each route.\n\n**Dependencies**: any tasks referenced in `task.dependencies` must have\nalready been created at the time of this call.\n\n**Scopes**: Note that the scopes required to complete this API call depend\non the content of the `scopes`, `routes`, `schedulerId`, `priority`,\n`provisionerId`, and `workerType` properties of the task definition.",
 				Stability:   "stable",
-				Method:      "post",
-				Route:       "/task/<taskId>/runs/<runId>/failed",
+				Method:      "put",
+				Route:       "/task/<taskId>",
 				Args: []string{
 					"taskId",
-					"runId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "v1/create-task-request.json#",
+				Output:    "v1/task-status-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "createTask <taskId> [--body <payload>]",
+				Example:   "  taskcluster api queue createTask <taskId> --body @create-task-request.json",
+				Aliases:   []string(nil),
 			},
+			// declareProvisioner: Update a provisioner
+			//
+			// Declare a provisioner, supplying some details about it.
+			//
+			// `declareProvisioner` allows updating one or more properties of a provisioner
+			// as long as the required scopes are
+			// possessed. For example, a request to update the `my-provisioner`
+			// provisioner with a body `{description: 'This provisioner is great'}` would
+			// require you to have the scope
+			// `queue:declare-provisioner:my-provisioner#description`.
+			//
+			// The term "provisioner" is taken broadly to mean anything with a
+			// provisionerId.
+			// This does not necessarily mean there is an associated service performing any
+			// provisioning activity.
 			definitions.Entry{
-				Name:        "reportException",
-				Title:       "Report Task Exception",
-				Description: "Resolve a run as _exception_. Generally, you will want to report tasks as\nfailed instead of exception. You should `reportException` if,\n\n  * The `task.payload` is invalid,\n  * Non-existent resources are referenced,\n  * Declared actions cannot be executed due to unavailable resources,\n  * The worker had to shutdown prematurely,\n  * The worker experienced an unknown error, or,\n  * The task explicitly requested a retry.\n\nDo not use this to signal that some user-specified code crashed for any\nreason specific to this code. If user-specific code hits a resource that\nis temporarily unavailable worker should report task _failed_.",
-				Stability:   "stable",
-				Method:      "post",
-				Route:       "/task/<taskId>/runs/<runId>/exception",
+				Name:        "declareProvisioner",
+				Title:       "Update a provisioner",
+				Description: "Declare a provisioner, supplying some details about it.\n\n`declareProvisioner` allows updating one or more properties of a provisioner as long as the required scopes are\npossessed. For example, a request to update the `my-provisioner`\nprovisioner with a body `{description: 'This provisioner is great'}` would require you to have the scope\n`queue:declare-provisioner:my-provisioner#description`.\n\nThe term \"provisioner\" is taken broadly to mean anything with a provisionerId.\nThis does not necessarily mean there is an associated service performing any\nprovisioning activity.",
+				Stability:   "experimental",
+				Method:      "put",
+				Route:       "/provisioners/<provisionerId>",
 				Args: []string{
-					"taskId",
-					"runId",
+					"provisionerId",
 				},
-				Query: []string{},
-				Input: "v1/task-exception-request.json#",
+				Query:     []string{},
+				Input:     "v1/update-provisioner-request.json#",
+				Output:    "v1/provisioner-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "declareProvisioner <provisionerId> [--body <payload>]",
+				Example:   "  taskcluster api queue declareProvisioner <provisionerId> --body @update-provisioner-request.json",
+				Aliases:   []string(nil),
 			},
+			// declareWorker: Declare a worker
+			//
+			// Declare a worker, supplying some details about it.
+			//
+			// `declareWorker` allows updating one or more properties of a worker as long as
+			// the required scopes are
+			// possessed.
 			definitions.Entry{
-				Name:        "createArtifact",
-				Title:       "Create Artifact",
-				Description: "This API end-point creates an artifact for a specific run of a task. This\nshould **only** be used by a worker currently operating on this task, or\nfrom a process running within the task (ie. on the worker).\n\nAll artifacts must specify when they `expires`, the queue will\nautomatically take care of deleting artifacts past their\nexpiration point. This features makes it feasible to upload large\nintermediate artifacts from data processing applications, as the\nartifacts can be set to expire a few days later.\n\nWe currently support \"S3 Artifacts\" officially, with remaining support\nfor two deprecated types.  Do not use these deprecated types.\n\n**S3 artifacts**, is useful for static files which will be\nstored on S3. When creating an S3 artifact the queue will return a\npre-signed URL to which you can do a `PUT` request to upload your\nartifact. Note that `PUT` request **must** specify the `content-length`\nheader and **must** give the `content-type` header the same value as in\nthe request to `createArtifact`.\n\n**Reference artifacts**, only consists of meta-data which the queue will\nstore for you. These artifacts really only have a `url` property and\nwhen the artifact is requested the client will be redirect the URL\nprovided with a `303` (See Other) redirect. Please note that we cannot\ndelete artifacts you upload to other service, we can only delete the\nreference to the artifact, when it expires.\n\n**Error artifacts**, only consists of meta-data which the queue will\nstore for you. These artifacts are only meant to indicate that you the\nworker or the task failed to generate a specific artifact, that you\nwould otherwise have uploaded. For example docker-worker will upload an\nerror artifact, if the file it was supposed to upload doesn't exists or\nturns out to be a directory. Clients requesting an error artifact will\nget a `424` (Failed Dependency) response. This is mainly designed to\nensure that dependent tasks can distinguish between artifacts that were\nsuppose to be generated and artifacts for which the name is misspelled.\n\n**Artifact immutability**, generally speaking you cannot overwrite an\nartifact when created. But if you repeat the request with the same\nproperties the request will succeed as the operation is idempotent.\nThis is useful if you need to refresh a signed URL while uploading.\nDo not abuse this to overwrite artifacts created by another entity!\nSuch as worker-host overwriting artifact created by worker-code.\n\nAs a special case the `url` property on _reference artifacts_ can be\nupdated. You should only use this to update the `url` property for\nreference artifacts your process has created.",
-				Stability:   "stable",
-				Method:      "post",
-				Route:       "/task/<taskId>/runs/<runId>/artifacts/<name>",
+				Name:        "declareWorker",
+				Title:       "Declare a worker",
+				Description: "Declare a worker, supplying some details about it.\n\n`declareWorker` allows updating one or more properties of a worker as long as the required scopes are\npossessed.",
+				Stability:   "experimental",
+				Method:      "put",
+				Route:       "/provisioners/<provisionerId>/worker-types/<workerType>/<workerGroup>/<workerId>",
 				Args: []string{
-					"taskId",
-					"runId",
-					"name",
+					"provisionerId",
+					"workerType",
+					"workerGroup",
+					"workerId",
+				},
+				Query:     []string{},
+				Input:     "v1/update-worker-request.json#",
+				Output:    "v1/worker-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "declareWorker <provisionerId> <workerType> <workerGroup> <workerId> [--body <payload>]",
+				Example:   "  taskcluster api queue declareWorker <provisionerId> <workerType> <workerGroup> <workerId> --body @update-worker-request.json",
+				Aliases:   []string(nil),
+			},
+			// declareWorkerType: Update a worker-type
+			//
+			// Declare a workerType, supplying some details about it.
+			//
+			// `declareWorkerType` allows updating one or more properties of a worker-type
+			// as long as the required scopes are
+			// possessed. For example, a request to update the `highmem` worker-type within
+			// the `my-provisioner`
+			// provisioner with a body `{description: 'This worker type is great'}` would
+			// require you to have the scope
+			// `queue:declare-worker-type:my-provisioner/highmem#description`.
+			definitions.Entry{
+				Name:        "declareWorkerType",
+				Title:       "Update a worker-type",
+				Description: "Declare a workerType, supplying some details about it.\n\n`declareWorkerType` allows updating one or more properties of a worker-type as long as the required scopes are\npossessed. For example, a request to update the `highmem` worker-type within the `my-provisioner`\nprovisioner with a body `{description: 'This worker type is great'}` would require you to have the scope\n`queue:declare-worker-type:my-provisioner/highmem#description`.",
+				Stability:   "experimental",
+				Method:      "put",
+				Route:       "/provisioners/<provisionerId>/worker-types/<workerType>",
+				Args: []string{
+					"provisionerId",
+					"workerType",
 				},
-				Query: []string{},
-				Input: "v1/post-artifact-request.json#",
+				Query:     []string{},
+				Input:     "v1/update-workertype-request.json#",
+				Output:    "v1/workertype-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "declareWorkerType <provisionerId> <workerType> [--body <payload>]",
+				Example:   "  taskcluster api queue declareWorkerType <provisionerId> <workerType> --body @update-workertype-request.json",
+				Aliases:   []string(nil),
 			},
+			// getArtifact: Get Artifact from Run
+			//
+			// Get artifact by `<name>` from a specific run.
+			//
+			// **Public Artifacts**, in-order to get an artifact you need the scope
+			// `queue:get-artifact:<name>`, where `<name>` is the name of the artifact.
+			// But if the artifact `name` starts with `public/`, authentication and
+			// authorization is not necessary to fetch the artifact.
+			//
+			// **API Clients**, this method will redirect you to the artifact, if it is
+			// stored externally. Either way, the response may not be JSON. So API
+			// client users might want to generate a signed URL for this end-point and
+			// use that URL with an HTTP client that can handle responses correctly.
+			//
+			// **Downloading artifacts**
+			// There are some special considerations for those http clients which download
+			// artifacts.  This api endpoint is designed to be compatible with an HTTP 1.1
+			// compliant client, but has extra features to ensure the download is valid.
+			// It is strongly recommend that consumers use either taskcluster-lib-artifact
+			// (JS),
+			// taskcluster-lib-artifact-go (Go) or the CLI written in Go to interact with
+			// artifacts.
+			//
+			// In order to download an artifact the following must be done:
+			//
+			// 1. Obtain queue url.  Building a signed url with a taskcluster client is
+			// recommended
+			// 1. Make a GET request which does not follow redirects
+			// 1. In all cases, if specified, the
+			// x-taskcluster-location-{content,transfer}-{sha256,length} values must be
+			// validated to be equal to the Content-Length and Sha256 checksum of the
+			// final artifact downloaded. as well as any intermediate redirects
+			// 1. If this response is a 500-series error, retry using an exponential
+			// backoff.  No more than 5 retries should be attempted
+			// 1. If this response is a 400-series error, treat it appropriately for
+			// your context.  This might be an error in responding to this request or
+			// an Error storage type body.  This request should not be retried.
+			// 1. If this response is a 200-series response, the response body is the
+			// artifact.
+			// If the x-taskcluster-location-{content,transfer}-{sha256,length} and
+			// x-taskcluster-location-content-encoding are specified, they should match
+			// this response body
+			// 1. If the response type is a 300-series redirect, the artifact will be at the
+			// location specified by the `Location` header. There are multiple artifact
+			// storage
+			// types which use a 300-series redirect.
+			// 1. For all redirects followed, the user must verify that the content-sha256,
+			// content-length,
+			// transfer-sha256, transfer-length and content-encoding match every further
+			// request. The final
+			// artifact must also be validated against the values specified in the original
+			// queue response
+			// 1. Caching of requests with an x-taskcluster-artifact-storage-type value of
+			// `reference`
+			// must not occur
+			//
+			// **Headers**
+			// The following important headers are set on the response to this method:
+			//
+			// * location: the url of the artifact if a redirect is to be performed
+			// * x-taskcluster-artifact-storage-type: the storage type.  Example: s3
+			//
+			// The following important headers are set on responses to this method for Blob
+			// artifacts
+			//
+			// * x-taskcluster-location-content-sha256: the SHA256 of the artifact
+			// *after* any content-encoding is undone. Sha256 is hex encoded (e.g.
+			// [0-9A-Fa-f]{64})
+			// * x-taskcluster-location-content-length: the number of bytes *after* any
+			// content-encoding
+			// is undone
+			// * x-taskcluster-location-transfer-sha256: the SHA256 of the artifact
+			// *before* any content-encoding is undone. This is the SHA256 of what is sent
+			// over
+			// the wire.  Sha256 is hex encoded (e.g. [0-9A-Fa-f]{64})
+			// * x-taskcluster-location-transfer-length: the number of bytes *after* any
+			// content-encoding
+			// is undone
+			// * x-taskcluster-location-content-encoding: the content-encoding used. It will
+			// either
+			// be `gzip` or `identity` right now. This is hardcoded to a value set when the
+			// artifact
+			// was created and no content-negotiation occurs
+			// * x-taskcluster-location-content-type: the content-type of the artifact
+			//
+			// **Caching**, artifacts may be cached in data centers closer to the
+			// workers in-order to reduce bandwidth costs. This can lead to longer
+			// response times. Caching can be skipped by setting the header
+			// `x-taskcluster-skip-cache: true`, this should only be used for resources
+			// where request volume is known to be low, and caching not useful.
+			// (This feature may be disabled in the future, use is sparingly!)
 			definitions.Entry{
 				Name:        "getArtifact",
 				Title:       "Get Artifact from Run",
@@ -1201,9 +2542,35 @@ var services = map[string]definitions.Service{
 					"runId",
 					"name",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "",
+				Paginated: false,
+				SignedURL: true,
+				Download:  true,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "getArtifact <taskId> <runId> <name> [--sign-url <duration>]",
+				Example:   "  taskcluster api queue getArtifact <taskId> <runId> <name> --output <file>",
+				Aliases:   []string(nil),
 			},
+			// getLatestArtifact: Get Artifact from Latest Run
+			//
+			// Get artifact by `<name>` from the last run of a task.
+			//
+			// **Public Artifacts**, in-order to get an artifact you need the scope
+			// `queue:get-artifact:<name>`, where `<name>` is the name of the artifact.
+			// But if the artifact `name` starts with `public/`, authentication and
+			// authorization is not necessary to fetch the artifact.
+			//
+			// **API Clients**, this method will redirect you to the artifact, if it is
+			// stored externally. Either way, the response may not be JSON. So API
+			// client users might want to generate a signed URL for this end-point and
+			// use that URL with a normal HTTP client.
+			//
+			// **Remark**, this end-point is slightly slower than
+			// `queue.getArtifact`, so consider that if you already know the `runId` of
+			// the latest run. Otherwise, just us the most convenient API end-point.
 			definitions.Entry{
 				Name:        "getLatestArtifact",
 				Title:       "Get Artifact from Latest Run",
@@ -1215,9 +2582,113 @@ var services = map[string]definitions.Service{
 					"taskId",
 					"name",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "",
+				Paginated: false,
+				SignedURL: true,
+				Download:  true,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "getLatestArtifact <taskId> <name> [--sign-url <duration>]",
+				Example:   "  taskcluster api queue getLatestArtifact <taskId> <name> --output <file>",
+				Aliases:   []string(nil),
+			},
+			// getProvisioner: Get an active provisioner
+			//
+			// Get an active provisioner.
+			//
+			// The term "provisioner" is taken broadly to mean anything with a
+			// provisionerId.
+			// This does not necessarily mean there is an associated service performing any
+			// provisioning activity.
+			definitions.Entry{
+				Name:        "getProvisioner",
+				Title:       "Get an active provisioner",
+				Description: "Get an active provisioner.\n\nThe term \"provisioner\" is taken broadly to mean anything with a provisionerId.\nThis does not necessarily mean there is an associated service performing any\nprovisioning activity.",
+				Stability:   "experimental",
+				Method:      "get",
+				Route:       "/provisioners/<provisionerId>",
+				Args: []string{
+					"provisionerId",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/provisioner-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "getProvisioner <provisionerId>",
+				Example:   "  taskcluster api queue getProvisioner <provisionerId>",
+				Aliases:   []string(nil),
 			},
+			// getWorker: Get a worker-type
+			//
+			// Get a worker from a worker-type.
+			definitions.Entry{
+				Name:        "getWorker",
+				Title:       "Get a worker-type",
+				Description: "Get a worker from a worker-type.",
+				Stability:   "experimental",
+				Method:      "get",
+				Route:       "/provisioners/<provisionerId>/worker-types/<workerType>/workers/<workerGroup>/<workerId>",
+				Args: []string{
+					"provisionerId",
+					"workerType",
+					"workerGroup",
+					"workerId",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/worker-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "getWorker <provisionerId> <workerType> <workerGroup> <workerId>",
+				Example:   "  taskcluster api queue getWorker <provisionerId> <workerType> <workerGroup> <workerId>",
+				Aliases:   []string(nil),
+			},
+			// getWorkerType: Get a worker-type
+			//
+			// Get a worker-type from a provisioner.
+			definitions.Entry{
+				Name:        "getWorkerType",
+				Title:       "Get a worker-type",
+				Description: "Get a worker-type from a provisioner.",
+				Stability:   "experimental",
+				Method:      "get",
+				Route:       "/provisioners/<provisionerId>/worker-types/<workerType>",
+				Args: []string{
+					"provisionerId",
+					"workerType",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/workertype-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "getWorkerType <provisionerId> <workerType>",
+				Example:   "  taskcluster api queue getWorkerType <provisionerId> <workerType>",
+				Aliases:   []string(nil),
+			},
+			// listArtifacts: Get Artifacts from Run
+			//
+			// Returns a list of artifacts and associated meta-data for a given run.
+			//
+			// As a task may have many artifacts paging may be necessary. If this
+			// end-point returns a `continuationToken`, you should call the end-point
+			// again with the `continuationToken` as the query-string option:
+			// `continuationToken`.
+			//
+			// By default this end-point will list up-to 1000 artifacts in a single page
+			// you may limit this with the query-string parameter `limit`.
 			definitions.Entry{
 				Name:        "listArtifacts",
 				Title:       "Get Artifacts from Run",
@@ -1233,12 +2704,76 @@ var services = map[string]definitions.Service{
 					"continuationToken",
 					"limit",
 				},
-				Input: "",
+				Input:     "",
+				Output:    "v1/list-artifacts-response.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listArtifacts <taskId> <runId> [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api queue listArtifacts <taskId> <runId>",
+				Aliases:   []string(nil),
 			},
+			// listDependentTasks: List Dependent Tasks
+			//
+			// List tasks that depend on the given `taskId`.
+			//
+			// As many tasks from different task-groups may dependent on a single tasks,
+			// this end-point may return a `continuationToken`. To continue listing
+			// tasks you must call `listDependentTasks` again with the
+			// `continuationToken` as the query-string option `continuationToken`.
+			//
+			// By default this end-point will try to return up to 1000 tasks in one
+			// request. But it **may return less**, even if more tasks are available.
+			// It may also return a `continuationToken` even though there are no more
+			// results. However, you can only be sure to have seen all results if you
+			// keep calling `listDependentTasks` with the last `continuationToken` until
+			// you get a result without a `continuationToken`.
+			//
+			// If you are not interested in listing all the tasks at once, you may
+			// use the query-string option `limit` to return fewer.
 			definitions.Entry{
-				Name:        "listLatestArtifacts",
-				Title:       "Get Artifacts from Latest Run",
-				Description: "Returns a list of artifacts and associated meta-data for the latest run\nfrom the given task.\n\nAs a task may have many artifacts paging may be necessary. If this\nend-point returns a `continuationToken`, you should call the end-point\nagain with the `continuationToken` as the query-string option:\n`continuationToken`.\n\nBy default this end-point will list up-to 1000 artifacts in a single page\nyou may limit this with the query-string parameter `limit`.",
+				Name:        "listDependentTasks",
+				Title:       "List Dependent Tasks",
+				Description: "List tasks that depend on the given `taskId`.\n\nAs many tasks from different task-groups may dependent on a single tasks,\nthis end-point may return a `continuationToken`. To continue listing\ntasks you must call `listDependentTasks` again with the\n`continuationToken` as the query-string option `continuationToken`.\n\nBy default this end-point will try to return up to 1000 tasks in one\nrequest. But it **may return less**, even if more tasks are available.\nIt may also return a `continuationToken` even though there are no more\nresults. However, you can only be sure to have seen all results if you\nkeep calling `listDependentTasks` with the last `continuationToken` until\nyou get a result without a `continuationToken`.\n\nIf you are not interested in listing all the tasks at once, you may\nuse the query-string option `limit` to return fewer.",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/task/<taskId>/dependents",
+				Args: []string{
+					"taskId",
+				},
+				Query: []string{
+					"continuationToken",
+					"limit",
+				},
+				Input:     "",
+				Output:    "v1/list-dependent-tasks-response.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listDependentTasks <taskId> [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api queue listDependentTasks <taskId>",
+				Aliases:   []string(nil),
+			},
+			// listLatestArtifacts: Get Artifacts from Latest Run
+			//
+			// Returns a list of artifacts and associated meta-data for the latest run
+			// from the given task.
+			//
+			// As a task may have many artifacts paging may be necessary. If this
+			// end-point returns a `continuationToken`, you should call the end-point
+			// again with the `continuationToken` as the query-string option:
+			// `continuationToken`.
+			//
+			// By default this end-point will list up-to 1000 artifacts in a single page
+			// you may limit this with the query-string parameter `limit`.
+			definitions.Entry{
+				Name:        "listLatestArtifacts",
+				Title:       "Get Artifacts from Latest Run",
+				Description: "Returns a list of artifacts and associated meta-data for the latest run\nfrom the given task.\n\nAs a task may have many artifacts paging may be necessary. If this\nend-point returns a `continuationToken`, you should call the end-point\nagain with the `continuationToken` as the query-string option:\n`continuationToken`.\n\nBy default this end-point will list up-to 1000 artifacts in a single page\nyou may limit this with the query-string parameter `limit`.",
 				Stability:   "stable",
 				Method:      "get",
 				Route:       "/task/<taskId>/artifacts",
@@ -1249,8 +2784,32 @@ var services = map[string]definitions.Service{
 					"continuationToken",
 					"limit",
 				},
-				Input: "",
+				Input:     "",
+				Output:    "v1/list-artifacts-response.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listLatestArtifacts <taskId> [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api queue listLatestArtifacts <taskId>",
+				Aliases:   []string(nil),
 			},
+			// listProvisioners: Get a list of all active provisioners
+			//
+			// Get all active provisioners.
+			//
+			// The term "provisioner" is taken broadly to mean anything with a
+			// provisionerId.
+			// This does not necessarily mean there is an associated service performing any
+			// provisioning activity.
+			//
+			// The response is paged. If this end-point returns a `continuationToken`, you
+			// should call the end-point again with the `continuationToken` as a
+			// query-string
+			// option. By default this end-point will list up to 1000 provisioners in a
+			// single
+			// page. You may limit this with the query-string parameter `limit`.
 			definitions.Entry{
 				Name:        "listProvisioners",
 				Title:       "Get a list of all active provisioners",
@@ -1263,48 +2822,70 @@ var services = map[string]definitions.Service{
 					"continuationToken",
 					"limit",
 				},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "getProvisioner",
-				Title:       "Get an active provisioner",
-				Description: "Get an active provisioner.\n\nThe term \"provisioner\" is taken broadly to mean anything with a provisionerId.\nThis does not necessarily mean there is an associated service performing any\nprovisioning activity.",
-				Stability:   "experimental",
-				Method:      "get",
-				Route:       "/provisioners/<provisionerId>",
-				Args: []string{
-					"provisionerId",
-				},
-				Query: []string{},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "declareProvisioner",
-				Title:       "Update a provisioner",
-				Description: "Declare a provisioner, supplying some details about it.\n\n`declareProvisioner` allows updating one or more properties of a provisioner as long as the required scopes are\npossessed. For example, a request to update the `my-provisioner`\nprovisioner with a body `{description: 'This provisioner is great'}` would require you to have the scope\n`queue:declare-provisioner:my-provisioner#description`.\n\nThe term \"provisioner\" is taken broadly to mean anything with a provisionerId.\nThis does not necessarily mean there is an associated service performing any\nprovisioning activity.",
-				Stability:   "experimental",
-				Method:      "put",
-				Route:       "/provisioners/<provisionerId>",
-				Args: []string{
-					"provisionerId",
-				},
-				Query: []string{},
-				Input: "v1/update-provisioner-request.json#",
+				Input:     "",
+				Output:    "v1/list-provisioners-response.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listProvisioners [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api queue listProvisioners",
+				Aliases:   []string(nil),
 			},
+			// listTaskGroup: List Task Group
+			//
+			// List tasks sharing the same `taskGroupId`.
+			//
+			// As a task-group may contain an unbounded number of tasks, this end-point
+			// may return a `continuationToken`. To continue listing tasks you must call
+			// the `listTaskGroup` again with the `continuationToken` as the
+			// query-string option `continuationToken`.
+			//
+			// By default this end-point will try to return up to 1000 members in one
+			// request. But it **may return less**, even if more tasks are available.
+			// It may also return a `continuationToken` even though there are no more
+			// results. However, you can only be sure to have seen all results if you
+			// keep calling `listTaskGroup` with the last `continuationToken` until you
+			// get a result without a `continuationToken`.
+			//
+			// If you are not interested in listing all the members at once, you may
+			// use the query-string option `limit` to return fewer.
 			definitions.Entry{
-				Name:        "pendingTasks",
-				Title:       "Get Number of Pending Tasks",
-				Description: "Get an approximate number of pending tasks for the given `provisionerId`\nand `workerType`.\n\nThe underlying Azure Storage Queues only promises to give us an estimate.\nFurthermore, we cache the result in memory for 20 seconds. So consumers\nshould be no means expect this to be an accurate number.\nIt is, however, a solid estimate of the number of pending tasks.",
+				Name:        "listTaskGroup",
+				Title:       "List Task Group",
+				Description: "List tasks sharing the same `taskGroupId`.\n\nAs a task-group may contain an unbounded number of tasks, this end-point\nmay return a `continuationToken`. To continue listing tasks you must call\nthe `listTaskGroup` again with the `continuationToken` as the\nquery-string option `continuationToken`.\n\nBy default this end-point will try to return up to 1000 members in one\nrequest. But it **may return less**, even if more tasks are available.\nIt may also return a `continuationToken` even though there are no more\nresults. However, you can only be sure to have seen all results if you\nkeep calling `listTaskGroup` with the last `continuationToken` until you\nget a result without a `continuationToken`.\n\nIf you are not interested in listing all the members at once, you may\nuse the query-string option `limit` to return fewer.",
 				Stability:   "stable",
 				Method:      "get",
-				Route:       "/pending/<provisionerId>/<workerType>",
+				Route:       "/task-group/<taskGroupId>/list",
 				Args: []string{
-					"provisionerId",
-					"workerType",
+					"taskGroupId",
 				},
-				Query: []string{},
-				Input: "",
+				Query: []string{
+					"continuationToken",
+					"limit",
+				},
+				Input:     "",
+				Output:    "v1/list-task-group-response.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listTaskGroup <taskGroupId> [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api queue listTaskGroup <taskGroupId>",
+				Aliases:   []string(nil),
 			},
+			// listWorkerTypes: Get a list of all active worker-types
+			//
+			// Get all active worker-types for the given provisioner.
+			//
+			// The response is paged. If this end-point returns a `continuationToken`, you
+			// should call the end-point again with the `continuationToken` as a
+			// query-string
+			// option. By default this end-point will list up to 1000 worker-types in a
+			// single
+			// page. You may limit this with the query-string parameter `limit`.
 			definitions.Entry{
 				Name:        "listWorkerTypes",
 				Title:       "Get a list of all active worker-types",
@@ -1319,36 +2900,32 @@ var services = map[string]definitions.Service{
 					"continuationToken",
 					"limit",
 				},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "getWorkerType",
-				Title:       "Get a worker-type",
-				Description: "Get a worker-type from a provisioner.",
-				Stability:   "experimental",
-				Method:      "get",
-				Route:       "/provisioners/<provisionerId>/worker-types/<workerType>",
-				Args: []string{
-					"provisionerId",
-					"workerType",
-				},
-				Query: []string{},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "declareWorkerType",
-				Title:       "Update a worker-type",
-				Description: "Declare a workerType, supplying some details about it.\n\n`declareWorkerType` allows updating one or more properties of a worker-type as long as the required scopes are\npossessed. For example, a request to update the `highmem` worker-type within the `my-provisioner`\nprovisioner with a body `{description: 'This worker type is great'}` would require you to have the scope\n`queue:declare-worker-type:my-provisioner/highmem#description`.",
-				Stability:   "experimental",
-				Method:      "put",
-				Route:       "/provisioners/<provisionerId>/worker-types/<workerType>",
-				Args: []string{
-					"provisionerId",
-					"workerType",
-				},
-				Query: []string{},
-				Input: "v1/update-workertype-request.json#",
+				Input:     "",
+				Output:    "v1/list-workertypes-response.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listWorkerTypes <provisionerId> [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api queue listWorkerTypes <provisionerId>",
+				Aliases:   []string(nil),
 			},
+			// listWorkers: Get a list of all active workers of a workerType
+			//
+			// Get a list of all active workers of a workerType.
+			//
+			// `listWorkers` allows a response to be filtered by quarantined and non
+			// quarantined workers.
+			// To filter the query, you should call the end-point with `quarantined` as a
+			// query-string option with a
+			// true or false value.
+			//
+			// The response is paged. If this end-point returns a `continuationToken`, you
+			// should call the end-point again with the `continuationToken` as a
+			// query-string
+			// option. By default this end-point will list up to 1000 workers in a single
+			// page. You may limit this with the query-string parameter `limit`.
 			definitions.Entry{
 				Name:        "listWorkers",
 				Title:       "Get a list of all active workers of a workerType",
@@ -1365,24 +2942,76 @@ var services = map[string]definitions.Service{
 					"limit",
 					"quarantined",
 				},
-				Input: "",
+				Input:     "",
+				Output:    "v1/list-workers-response.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listWorkers <provisionerId> <workerType> [--continuationToken <continuationToken>] [--limit <limit>] [--quarantined <quarantined>] [--all]",
+				Example:   "  taskcluster api queue listWorkers <provisionerId> <workerType>",
+				Aliases:   []string(nil),
 			},
+			// pendingTasks: Get Number of Pending Tasks
+			//
+			// Get an approximate number of pending tasks for the given `provisionerId`
+			// and `workerType`.
+			//
+			// The underlying Azure Storage Queues only promises to give us an estimate.
+			// Furthermore, we cache the result in memory for 20 seconds. So consumers
+			// should be no means expect this to be an accurate number.
+			// It is, however, a solid estimate of the number of pending tasks.
 			definitions.Entry{
-				Name:        "getWorker",
-				Title:       "Get a worker-type",
-				Description: "Get a worker from a worker-type.",
-				Stability:   "experimental",
+				Name:        "pendingTasks",
+				Title:       "Get Number of Pending Tasks",
+				Description: "Get an approximate number of pending tasks for the given `provisionerId`\nand `workerType`.\n\nThe underlying Azure Storage Queues only promises to give us an estimate.\nFurthermore, we cache the result in memory for 20 seconds. So consumers\nshould be no means expect this to be an accurate number.\nIt is, however, a solid estimate of the number of pending tasks.",
+				Stability:   "stable",
 				Method:      "get",
-				Route:       "/provisioners/<provisionerId>/worker-types/<workerType>/workers/<workerGroup>/<workerId>",
+				Route:       "/pending/<provisionerId>/<workerType>",
 				Args: []string{
 					"provisionerId",
 					"workerType",
-					"workerGroup",
-					"workerId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/pending-tasks-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "pendingTasks <provisionerId> <workerType>",
+				Example:   "  taskcluster api queue pendingTasks <provisionerId> <workerType>",
+				Aliases:   []string(nil),
+			},
+			// ping: Ping Server
+			//
+			// Respond without doing anything.
+			// This endpoint is used to check that the service is up.
+			definitions.Entry{
+				Name:        "ping",
+				Title:       "Ping Server",
+				Description: "Respond without doing anything.\nThis endpoint is used to check that the service is up.",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/ping",
+				Args:        []string{},
+				Query:       []string{},
+				Input:       "",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "ping",
+				Example:     "  taskcluster api queue ping",
+				Aliases:     []string(nil),
 			},
+			// quarantineWorker: Quarantine a worker
+			//
+			// Quarantine a worker
 			definitions.Entry{
 				Name:        "quarantineWorker",
 				Title:       "Quarantine a worker",
@@ -1396,70 +3025,303 @@ var services = map[string]definitions.Service{
 					"workerGroup",
 					"workerId",
 				},
-				Query: []string{},
-				Input: "v1/quarantine-worker-request.json#",
+				Query:     []string{},
+				Input:     "v1/quarantine-worker-request.json#",
+				Output:    "v1/worker-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "quarantineWorker <provisionerId> <workerType> <workerGroup> <workerId> [--body <payload>]",
+				Example:   "  taskcluster api queue quarantineWorker <provisionerId> <workerType> <workerGroup> <workerId> --body @quarantine-worker-request.json",
+				Aliases:   []string(nil),
 			},
+			// reclaimTask: Reclaim task
+			//
+			// Refresh the claim for a specific `runId` for given `taskId`. This updates
+			// the `takenUntil` property and returns a new set of temporary credentials
+			// for performing requests on behalf of the task. These credentials should
+			// be used in-place of the credentials returned by `claimWork`.
+			//
+			// The `reclaimTask` requests serves to:
+			//  * Postpone `takenUntil` preventing the queue from resolving
+			//    `claim-expired`,
+			//  * Refresh temporary credentials used for processing the task, and
+			//  * Abort execution if the task/run have been resolved.
+			//
+			// If the `takenUntil` timestamp is exceeded the queue will resolve the run
+			// as _exception_ with reason `claim-expired`, and proceeded to retry to the
+			// task. This ensures that tasks are retried, even if workers disappear
+			// without warning.
+			//
+			// If the task is resolved, this end-point will return `409` reporting
+			// `RequestConflict`. This typically happens if the task have been canceled
+			// or the `task.deadline` have been exceeded. If reclaiming fails, workers
+			// should abort the task and forget about the given `runId`. There is no
+			// need to resolve the run or upload artifacts.
 			definitions.Entry{
-				Name:        "declareWorker",
-				Title:       "Declare a worker",
-				Description: "Declare a worker, supplying some details about it.\n\n`declareWorker` allows updating one or more properties of a worker as long as the required scopes are\npossessed.",
-				Stability:   "experimental",
-				Method:      "put",
-				Route:       "/provisioners/<provisionerId>/worker-types/<workerType>/<workerGroup>/<workerId>",
+				Name:        "reclaimTask",
+				Title:       "Reclaim task",
+				Description: "Refresh the claim for a specific `runId` for given `taskId`. This updates\nthe `takenUntil` property and returns a new set of temporary credentials\nfor performing requests on behalf of the task. These credentials should\nbe used in-place of the credentials returned by `claimWork`.\n\nThe `reclaimTask` requests serves to:\n * Postpone `takenUntil` preventing the queue from resolving\n   `claim-expired`,\n * Refresh temporary credentials used for processing the task, and\n * Abort execution if the task/run have been resolved.\n\nIf the `takenUntil` timestamp is exceeded the queue will resolve the run\nas _exception_ with reason `claim-expired`, and proceeded to retry to the\ntask. This ensures that tasks are retried, even if workers disappear\nwithout warning.\n\nIf the task is resolved, this end-point will return `409` reporting\n`RequestConflict`. This typically happens if the task have been canceled\nor the `task.deadline` have been exceeded. If reclaiming fails, workers\nshould abort the task and forget about the given `runId`. There is no\nneed to resolve the run or upload artifacts.",
+				Stability:   "stable",
+				Method:      "post",
+				Route:       "/task/<taskId>/runs/<runId>/reclaim",
 				Args: []string{
-					"provisionerId",
-					"workerType",
-					"workerGroup",
-					"workerId",
+					"taskId",
+					"runId",
 				},
-				Query: []string{},
-				Input: "v1/update-worker-request.json#",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/task-reclaim-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "reclaimTask <taskId> <runId>",
+				Example:   "  taskcluster api queue reclaimTask <taskId> <runId>",
+				Aliases:   []string(nil),
 			},
-		},
-	},
-	"Secrets": definitions.Service{
-		APIVersion:  "v1",
-		ServiceName: "secrets",
-		Title:       "Taskcluster Secrets API Documentation",
-		Description: "The secrets service provides a simple key/value store for small bits of secret\ndata.  Access is limited by scopes, so values can be considered secret from\nthose who do not have the relevant scopes.\n\nSecrets also have an expiration date, and once a secret has expired it can no\nlonger be read.  This is useful for short-term secrets such as a temporary\nservice credential or a one-time signing key.",
-		Entries: []definitions.Entry{
+			// reportCompleted: Report Run Completed
+			//
+			// Report a task completed, resolving the run as `completed`.
 			definitions.Entry{
-				Name:        "ping",
-				Title:       "Ping Server",
-				Description: "Respond without doing anything.\nThis endpoint is used to check that the service is up.",
+				Name:        "reportCompleted",
+				Title:       "Report Run Completed",
+				Description: "Report a task completed, resolving the run as `completed`.",
 				Stability:   "stable",
-				Method:      "get",
-				Route:       "/ping",
-				Args:        []string{},
-				Query:       []string{},
-				Input:       "",
+				Method:      "post",
+				Route:       "/task/<taskId>/runs/<runId>/completed",
+				Args: []string{
+					"taskId",
+					"runId",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/task-status-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "reportCompleted <taskId> <runId>",
+				Example:   "  taskcluster api queue reportCompleted <taskId> <runId>",
+				Aliases:   []string(nil),
 			},
+			// reportException: Report Task Exception
+			//
+			// Resolve a run as _exception_. Generally, you will want to report tasks as
+			// failed instead of exception. You should `reportException` if,
+			//
+			//   * The `task.payload` is invalid,
+			//   * Non-existent resources are referenced,
+			//   * Declared actions cannot be executed due to unavailable resources,
+			//   * The worker had to shutdown prematurely,
+			//   * The worker experienced an unknown error, or,
+			//   * The task explicitly requested a retry.
+			//
+			// Do not use this to signal that some user-specified code crashed for any
+			// reason specific to this code. If user-specific code hits a resource that
+			// is temporarily unavailable worker should report task _failed_.
 			definitions.Entry{
-				Name:        "set",
-				Title:       "Set Secret",
-				Description: "Set the secret associated with some key.  If the secret already exists, it is\nupdated instead.",
+				Name:        "reportException",
+				Title:       "Report Task Exception",
+				Description: "Resolve a run as _exception_. Generally, you will want to report tasks as\nfailed instead of exception. You should `reportException` if,\n\n  * The `task.payload` is invalid,\n  * Non-existent resources are referenced,\n  * Declared actions cannot be executed due to unavailable resources,\n  * The worker had to shutdown prematurely,\n  * The worker experienced an unknown error, or,\n  * The task explicitly requested a retry.\n\nDo not use this to signal that some user-specified code crashed for any\nreason specific to this code. If user-specific code hits a resource that\nis temporarily unavailable worker should report task _failed_.",
 				Stability:   "stable",
-				Method:      "put",
-				Route:       "/secret/<name>",
+				Method:      "post",
+				Route:       "/task/<taskId>/runs/<runId>/exception",
 				Args: []string{
-					"name",
+					"taskId",
+					"runId",
 				},
-				Query: []string{},
-				Input: "v1/secret.json#",
+				Query:     []string{},
+				Input:     "v1/task-exception-request.json#",
+				Output:    "v1/task-status-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "reportException <taskId> <runId> [--body <payload>]",
+				Example:   "  taskcluster api queue reportException <taskId> <runId> --body @task-exception-request.json",
+				Aliases:   []string(nil),
 			},
+			// reportFailed: Report Run Failed
+			//
+			// Report a run failed, resolving the run as `failed`. Use this to resolve
+			// a run that failed because the task specific code behaved unexpectedly.
+			// For example the task exited non-zero, or didn't produce expected output.
+			//
+			// Do not use this if the task couldn't be run because if malformed
+			// payload, or other unexpected condition. In these cases we have a task
+			// exception, which should be reported with `reportException`.
 			definitions.Entry{
-				Name:        "remove",
-				Title:       "Delete Secret",
-				Description: "Delete the secret associated with some key.",
+				Name:        "reportFailed",
+				Title:       "Report Run Failed",
+				Description: "Report a run failed, resolving the run as `failed`. Use this to resolve\na run that failed because the task specific code behaved unexpectedly.\nFor example the task exited non-zero, or didn't produce expected output.\n\nDo not use this if the task couldn't be run because if malformed\npayload, or other unexpected condition. In these cases we have a task\nexception, which should be reported with `reportException`.",
 				Stability:   "stable",
-				Method:      "delete",
-				Route:       "/secret/<name>",
+				Method:      "post",
+				Route:       "/task/<taskId>/runs/<runId>/failed",
 				Args: []string{
-					"name",
+					"taskId",
+					"runId",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/task-status-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "reportFailed <taskId> <runId>",
+				Example:   "  taskcluster api queue reportFailed <taskId> <runId>",
+				Aliases:   []string(nil),
+			},
+			// rerunTask: Rerun a Resolved Task
+			//
+			// This method _reruns_ a previously resolved task, even if it was
+			// _completed_. This is useful if your task completes unsuccessfully, and
+			// you just want to run it from scratch again. This will also reset the
+			// number of `retries` allowed.
+			//
+			// This method is deprecated in favour of creating a new task with the same
+			// task definition (but with a new taskId).
+			//
+			// Remember that `retries` in the task status counts the number of runs that
+			// the queue have started because the worker stopped responding, for example
+			// because a spot node died.
+			//
+			// **Remark** this operation is idempotent, if you try to rerun a task that
+			// is not either `failed` or `completed`, this operation will just return
+			// the current task status.
+			definitions.Entry{
+				Name:        "rerunTask",
+				Title:       "Rerun a Resolved Task",
+				Description: "This method _reruns_ a previously resolved task, even if it was\n_completed_. This is useful if your task completes unsuccessfully, and\nyou just want to run it from scratch again. This will also reset the\nnumber of `retries` allowed.\n\nThis method is deprecated in favour of creating a new task with the same\ntask definition (but with a new taskId).\n\nRemember that `retries` in the task status counts the number of runs that\nthe queue have started because the worker stopped responding, for example\nbecause a spot node died.\n\n**Remark** this operation is idempotent, if you try to rerun a task that\nis not either `failed` or `completed`, this operation will just return\nthe current task status.",
+				Stability:   "stable",
+				Method:      "post",
+				Route:       "/task/<taskId>/rerun",
+				Args: []string{
+					"taskId",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/task-status-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "rerunTask <taskId>",
+				Example:   "  taskcluster api queue rerunTask <taskId>",
+				Aliases:   []string(nil),
+			},
+			// scheduleTask: Schedule Defined Task
+			//
+			// scheduleTask will schedule a task to be executed, even if it has
+			// unresolved dependencies. A task would otherwise only be scheduled if
+			// its dependencies were resolved.
+			//
+			// This is useful if you have defined a task that depends on itself or on
+			// some other task that has not been resolved, but you wish the task to be
+			// scheduled immediately.
+			//
+			// This will announce the task as pending and workers will be allowed to
+			// claim it and resolve the task.
+			//
+			// **Note** this operation is **idempotent** and will not fail or complain
+			// if called with a `taskId` that is already scheduled, or even resolved.
+			// To reschedule a task previously resolved, use `rerunTask`.
+			definitions.Entry{
+				Name:        "scheduleTask",
+				Title:       "Schedule Defined Task",
+				Description: "scheduleTask will schedule a task to be executed, even if it has\nunresolved dependencies. A task would otherwise only be scheduled if\nits dependencies were resolved.\n\nThis is useful if you have defined a task that depends on itself or on\nsome other task that has not been resolved, but you wish the task to be\nscheduled immediately.\n\nThis will announce the task as pending and workers will be allowed to\nclaim it and resolve the task.\n\n**Note** this operation is **idempotent** and will not fail or complain\nif called with a `taskId` that is already scheduled, or even resolved.\nTo reschedule a task previously resolved, use `rerunTask`.",
+				Stability:   "stable",
+				Method:      "post",
+				Route:       "/task/<taskId>/schedule",
+				Args: []string{
+					"taskId",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/task-status-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "scheduleTask <taskId>",
+				Example:   "  taskcluster api queue scheduleTask <taskId>",
+				Aliases:   []string(nil),
+			},
+			// status: Get task status
+			//
+			// Get task status structure from `taskId`
+			definitions.Entry{
+				Name:        "status",
+				Title:       "Get task status",
+				Description: "Get task status structure from `taskId`",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/task/<taskId>/status",
+				Args: []string{
+					"taskId",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/task-status-response.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "status <taskId>",
+				Example:   "  taskcluster api queue status <taskId>",
+				Aliases:   []string(nil),
+			},
+			// task: Get Task Definition
+			//
+			// This end-point will return the task-definition. Notice that the task
+			// definition may have been modified by queue, if an optional property is
+			// not specified the queue may provide a default value.
+			definitions.Entry{
+				Name:        "task",
+				Title:       "Get Task Definition",
+				Description: "This end-point will return the task-definition. Notice that the task\ndefinition may have been modified by queue, if an optional property is\nnot specified the queue may provide a default value.",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/task/<taskId>",
+				Args: []string{
+					"taskId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/task.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "task <taskId>",
+				Example:   "  taskcluster api queue task <taskId>",
+				Aliases:   []string(nil),
 			},
+		},
+	},
+	"Secrets": definitions.Service{
+		APIVersion:  "v1",
+		ServiceName: "secrets",
+		Title:       "Taskcluster Secrets API Documentation",
+		Description: "The secrets service provides a simple key/value store for small bits of secret\ndata.  Access is limited by scopes, so values can be considered secret from\nthose who do not have the relevant scopes.\n\nSecrets also have an expiration date, and once a secret has expired it can no\nlonger be read.  This is useful for short-term secrets such as a temporary\nservice credential or a one-time signing key.",
+		Entries: []definitions.Entry{
+			// get: Read Secret
+			//
+			// Read the secret associated with some key.  If the secret has recently
+			// expired, the response code 410 is returned.  If the caller lacks the
+			// scope necessary to get the secret, the call will fail with a 403 code
+			// regardless of whether the secret exists.
 			definitions.Entry{
 				Name:        "get",
 				Title:       "Read Secret",
@@ -1470,9 +3332,31 @@ var services = map[string]definitions.Service{
 				Args: []string{
 					"name",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/secret.json#",
+				Paginated: false,
+				SignedURL: true,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "get <name> [--sign-url <duration>]",
+				Example:   "  taskcluster api secrets get <name>",
+				Aliases:   []string(nil),
 			},
+			// list: List Secrets
+			//
+			// List the names of all secrets.
+			//
+			// By default this end-point will try to return up to 1000 secret names in one
+			// request. But it **may return less**, even if more tasks are available.
+			// It may also return a `continuationToken` even though there are no more
+			// results. However, you can only be sure to have seen all results if you
+			// keep calling `listTaskGroup` with the last `continuationToken` until you
+			// get a result without a `continuationToken`.
+			//
+			// If you are not interested in listing all the members at once, you may
+			// use the query-string option `limit` to return fewer.
 			definitions.Entry{
 				Name:        "list",
 				Title:       "List Secrets",
@@ -1485,16 +3369,21 @@ var services = map[string]definitions.Service{
 					"continuationToken",
 					"limit",
 				},
-				Input: "",
+				Input:     "",
+				Output:    "v1/secret-list.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "list [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api secrets list",
+				Aliases:   []string(nil),
 			},
-		},
-	},
-	"WorkerManager": definitions.Service{
-		APIVersion:  "v1",
-		ServiceName: "worker-manager",
-		Title:       "Taskcluster Worker Manager",
-		Description: "This service manages workers, including provisioning for dynamic worker pools.",
-		Entries: []definitions.Entry{
+			// ping: Ping Server
+			//
+			// Respond without doing anything.
+			// This endpoint is used to check that the service is up.
 			definitions.Entry{
 				Name:        "ping",
 				Title:       "Ping Server",
@@ -1505,100 +3394,186 @@ var services = map[string]definitions.Service{
 				Args:        []string{},
 				Query:       []string{},
 				Input:       "",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "ping",
+				Example:     "  taskcluster api secrets ping",
+				Aliases:     []string(nil),
 			},
+			// remove: Delete Secret
+			//
+			// Delete the secret associated with some key.
 			definitions.Entry{
-				Name:        "listProviders",
-				Title:       "List Providers",
-				Description: "Retrieve a list of providers that are available for worker pools.",
+				Name:        "remove",
+				Title:       "Delete Secret",
+				Description: "Delete the secret associated with some key.",
 				Stability:   "stable",
-				Method:      "get",
-				Route:       "/providers",
-				Args:        []string{},
-				Query: []string{
-					"continuationToken",
-					"limit",
+				Method:      "delete",
+				Route:       "/secret/<name>",
+				Args: []string{
+					"name",
 				},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "remove <name>",
+				Example:   "  taskcluster api secrets remove <name>",
+				Aliases:   []string(nil),
 			},
+			// set: Set Secret
+			//
+			// Set the secret associated with some key.  If the secret already exists, it is
+			// updated instead.
 			definitions.Entry{
-				Name:        "createWorkerPool",
-				Title:       "Create Worker Pool",
-				Description: "Create a new worker pool. If the worker pool already exists, this will throw an error.",
+				Name:        "set",
+				Title:       "Set Secret",
+				Description: "Set the secret associated with some key.  If the secret already exists, it is\nupdated instead.",
 				Stability:   "stable",
 				Method:      "put",
-				Route:       "/worker-pool/<workerPoolId>",
+				Route:       "/secret/<name>",
 				Args: []string{
-					"workerPoolId",
+					"name",
 				},
-				Query: []string{},
-				Input: "v1/create-worker-pool-request.json#",
+				Query:     []string{},
+				Input:     "v1/secret.json#",
+				Output:    "",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "set <name> [--body <payload>]",
+				Example:   "  taskcluster api secrets set <name> --body @secret.json",
+				Aliases:   []string(nil),
 			},
-			definitions.Entry{
-				Name:        "updateWorkerPool",
-				Title:       "Update Worker Pool",
-				Description: "Given an existing worker pool definition, this will modify it and return\nthe new definition.\n\nTo delete a worker pool, set its `providerId` to `\"null-provider\"`.\nAfter any existing workers have exited, a cleanup job will remove the\nworker pool.  During that time, the worker pool can be updated again, such\nas to set its `providerId` to a real provider.",
+		},
+	},
+	"WorkerManager": definitions.Service{
+		APIVersion:  "v1",
+		ServiceName: "worker-manager",
+		Title:       "Taskcluster Worker Manager",
+		Description: "This service manages workers, including provisioning for dynamic worker pools.",
+		Entries: []definitions.Entry{
+			// createWorker: Create a Worker
+			//
+			// Create a new worker.  The precise behavior of this method depends
+			// on the provider implementing the given worker pool.  Some providers
+			// do not support creating workers at all, and will return a 400 error.
+			definitions.Entry{
+				Name:        "createWorker",
+				Title:       "Create a Worker",
+				Description: "Create a new worker.  The precise behavior of this method depends\non the provider implementing the given worker pool.  Some providers\ndo not support creating workers at all, and will return a 400 error.",
 				Stability:   "stable",
-				Method:      "post",
-				Route:       "/worker-pool/<workerPoolId>",
+				Method:      "put",
+				Route:       "/workers/<workerPoolId>:/<workerGroup>/<workerId>",
 				Args: []string{
 					"workerPoolId",
+					"workerGroup",
+					"workerId",
 				},
-				Query: []string{},
-				Input: "v1/update-worker-pool-request.json#",
+				Query:     []string{},
+				Input:     "v1/create-worker-request.json#",
+				Output:    "v1/worker-full.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "createWorker <workerPoolId> <workerGroup> <workerId> [--body <payload>]",
+				Example:   "  taskcluster api workerManager createWorker <workerPoolId> <workerGroup> <workerId> --body @create-worker-request.json",
+				Aliases:   []string(nil),
 			},
+			// createWorkerPool: Create Worker Pool
+			//
+			// Create a new worker pool. If the worker pool already exists, this will throw
+			// an error.
 			definitions.Entry{
-				Name:        "deleteWorkerPool",
-				Title:       "Delete Worker Pool",
-				Description: "Mark a worker pool for deletion.  This is the same as updating the pool to\nset its providerId to `\"null-provider\"`, but does not require scope\n`worker-manager:provider:null-provider`.",
+				Name:        "createWorkerPool",
+				Title:       "Create Worker Pool",
+				Description: "Create a new worker pool. If the worker pool already exists, this will throw an error.",
 				Stability:   "stable",
-				Method:      "delete",
+				Method:      "put",
 				Route:       "/worker-pool/<workerPoolId>",
 				Args: []string{
 					"workerPoolId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "v1/create-worker-pool-request.json#",
+				Output:    "v1/worker-pool-full.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "createWorkerPool <workerPoolId> [--body <payload>]",
+				Example:   "  taskcluster api workerManager createWorkerPool <workerPoolId> --body @create-worker-pool-request.json",
+				Aliases:   []string(nil),
 			},
+			// deleteWorkerPool: Delete Worker Pool
+			//
+			// Mark a worker pool for deletion.  This is the same as updating the pool to
+			// set its providerId to `"null-provider"`, but does not require scope
+			// `worker-manager:provider:null-provider`.
 			definitions.Entry{
-				Name:        "workerPool",
-				Title:       "Get Worker Pool",
-				Description: "Fetch an existing worker pool defition.",
+				Name:        "deleteWorkerPool",
+				Title:       "Delete Worker Pool",
+				Description: "Mark a worker pool for deletion.  This is the same as updating the pool to\nset its providerId to `\"null-provider\"`, but does not require scope\n`worker-manager:provider:null-provider`.",
 				Stability:   "stable",
-				Method:      "get",
+				Method:      "delete",
 				Route:       "/worker-pool/<workerPoolId>",
 				Args: []string{
 					"workerPoolId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/worker-pool-full.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "deleteWorkerPool <workerPoolId>",
+				Example:   "  taskcluster api workerManager deleteWorkerPool <workerPoolId>",
+				Aliases:   []string(nil),
 			},
+			// listProviders: List Providers
+			//
+			// Retrieve a list of providers that are available for worker pools.
 			definitions.Entry{
-				Name:        "listWorkerPools",
-				Title:       "List All Worker Pools",
-				Description: "Get the list of all the existing worker pools.",
+				Name:        "listProviders",
+				Title:       "List Providers",
+				Description: "Retrieve a list of providers that are available for worker pools.",
 				Stability:   "stable",
 				Method:      "get",
-				Route:       "/worker-pools",
+				Route:       "/providers",
 				Args:        []string{},
 				Query: []string{
 					"continuationToken",
 					"limit",
 				},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "reportWorkerError",
-				Title:       "Report an error from a worker",
-				Description: "Report an error that occurred on a worker.  This error will be included\nwith the other errors in `listWorkerPoolErrors(workerPoolId)`.\n\nWorkers can use this endpoint to report startup or configuration errors\nthat might be associated with the worker pool configuration and thus of\ninterest to a worker-pool administrator.\n\nNOTE: errors are publicly visible.  Ensure that none of the content\ncontains secrets or other sensitive information.",
-				Stability:   "stable",
-				Method:      "post",
-				Route:       "/worker-pool-errors/<workerPoolId>",
-				Args: []string{
-					"workerPoolId",
-				},
-				Query: []string{},
-				Input: "v1/report-worker-error-request.json#",
+				Input:     "",
+				Output:    "v1/provider-list.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listProviders [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api workerManager listProviders",
+				Aliases:   []string(nil),
 			},
+			// listWorkerPoolErrors: List Worker Pool Errors
+			//
+			// Get the list of worker pool errors.
 			definitions.Entry{
 				Name:        "listWorkerPoolErrors",
 				Title:       "List Worker Pool Errors",
@@ -1613,8 +3588,48 @@ var services = map[string]definitions.Service{
 					"continuationToken",
 					"limit",
 				},
-				Input: "",
+				Input:     "",
+				Output:    "v1/worker-pool-error-list.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listWorkerPoolErrors <workerPoolId> [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api workerManager listWorkerPoolErrors <workerPoolId>",
+				Aliases:   []string(nil),
+			},
+			// listWorkerPools: List All Worker Pools
+			//
+			// Get the list of all the existing worker pools.
+			definitions.Entry{
+				Name:        "listWorkerPools",
+				Title:       "List All Worker Pools",
+				Description: "Get the list of all the existing worker pools.",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/worker-pools",
+				Args:        []string{},
+				Query: []string{
+					"continuationToken",
+					"limit",
+				},
+				Input:     "",
+				Output:    "v1/worker-pool-list.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listWorkerPools [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api workerManager listWorkerPools",
+				Aliases:   []string(nil),
 			},
+			// listWorkersForWorkerGroup: Workers in a specific Worker Group in a Worker
+			// Pool
+			//
+			// Get the list of all the existing workers in a given group in a given worker
+			// pool.
 			definitions.Entry{
 				Name:        "listWorkersForWorkerGroup",
 				Title:       "Workers in a specific Worker Group in a Worker Pool",
@@ -1630,38 +3645,103 @@ var services = map[string]definitions.Service{
 					"continuationToken",
 					"limit",
 				},
-				Input: "",
+				Input:     "",
+				Output:    "v1/worker-list.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listWorkersForWorkerGroup <workerPoolId> <workerGroup> [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api workerManager listWorkersForWorkerGroup <workerPoolId> <workerGroup>",
+				Aliases:   []string(nil),
 			},
+			// listWorkersForWorkerPool: Workers in a Worker Pool
+			//
+			// Get the list of all the existing workers in a given worker pool.
 			definitions.Entry{
-				Name:        "worker",
-				Title:       "Get a Worker",
-				Description: "Get a single worker.",
+				Name:        "listWorkersForWorkerPool",
+				Title:       "Workers in a Worker Pool",
+				Description: "Get the list of all the existing workers in a given worker pool.",
 				Stability:   "stable",
 				Method:      "get",
-				Route:       "/workers/<workerPoolId>:/<workerGroup>/<workerId>",
+				Route:       "/workers/<workerPoolId>",
 				Args: []string{
 					"workerPoolId",
-					"workerGroup",
-					"workerId",
 				},
-				Query: []string{},
-				Input: "",
+				Query: []string{
+					"continuationToken",
+					"limit",
+				},
+				Input:     "",
+				Output:    "v1/worker-list.json#",
+				Paginated: true,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "listWorkersForWorkerPool <workerPoolId> [--continuationToken <continuationToken>] [--limit <limit>] [--all]",
+				Example:   "  taskcluster api workerManager listWorkersForWorkerPool <workerPoolId>",
+				Aliases:   []string(nil),
 			},
+			// ping: Ping Server
+			//
+			// Respond without doing anything.
+			// This endpoint is used to check that the service is up.
 			definitions.Entry{
-				Name:        "createWorker",
-				Title:       "Create a Worker",
-				Description: "Create a new worker.  The precise behavior of this method depends\non the provider implementing the given worker pool.  Some providers\ndo not support creating workers at all, and will return a 400 error.",
+				Name:        "ping",
+				Title:       "Ping Server",
+				Description: "Respond without doing anything.\nThis endpoint is used to check that the service is up.",
 				Stability:   "stable",
-				Method:      "put",
-				Route:       "/workers/<workerPoolId>:/<workerGroup>/<workerId>",
-				Args: []string{
-					"workerPoolId",
-					"workerGroup",
-					"workerId",
-				},
-				Query: []string{},
-				Input: "v1/create-worker-request.json#",
+				Method:      "get",
+				Route:       "/ping",
+				Args:        []string{},
+				Query:       []string{},
+				Input:       "",
+				Output:      "",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "ping",
+				Example:     "  taskcluster api workerManager ping",
+				Aliases:     []string(nil),
+			},
+			// registerWorker: Register a running worker
+			//
+			// Register a running worker.  Workers call this method on worker start-up.
+			//
+			// This call both marks the worker as running and returns the credentials
+			// the worker will require to perform its work.  The worker must provide
+			// some proof of its identity, and that proof varies by provider type.
+			definitions.Entry{
+				Name:        "registerWorker",
+				Title:       "Register a running worker",
+				Description: "Register a running worker.  Workers call this method on worker start-up.\n\nThis call both marks the worker as running and returns the credentials\nthe worker will require to perform its work.  The worker must provide\nsome proof of its identity, and that proof varies by provider type.",
+				Stability:   "stable",
+				Method:      "post",
+				Route:       "/worker/register",
+				Args:        []string{},
+				Query:       []string{},
+				Input:       "v1/register-worker-request.json#",
+				Output:      "v1/register-worker-response.json#",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "registerWorker [--body <payload>]",
+				Example:     "  taskcluster api workerManager registerWorker --body @register-worker-request.json",
+				Aliases:     []string(nil),
 			},
+			// removeWorker: Remove a Worker
+			//
+			// Remove an existing worker.  The precise behavior of this method depends
+			// on the provider implementing the given worker.  Some providers
+			// do not support removing workers at all, and will return a 400 error.
+			// Others may begin removing the worker, but it may remain available via
+			// the API (perhaps even in state RUNNING) afterward.
 			definitions.Entry{
 				Name:        "removeWorker",
 				Title:       "Remove a Worker",
@@ -1674,36 +3754,60 @@ var services = map[string]definitions.Service{
 					"workerGroup",
 					"workerId",
 				},
-				Query: []string{},
-				Input: "",
+				Query:     []string{},
+				Input:     "",
+				Output:    "",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "removeWorker <workerPoolId> <workerGroup> <workerId>",
+				Example:   "  taskcluster api workerManager removeWorker <workerPoolId> <workerGroup> <workerId>",
+				Aliases:   []string(nil),
 			},
+			// reportWorkerError: Report an error from a worker
+			//
+			// Report an error that occurred on a worker.  This error will be included
+			// with the other errors in `listWorkerPoolErrors(workerPoolId)`.
+			//
+			// Workers can use this endpoint to report startup or configuration errors
+			// that might be associated with the worker pool configuration and thus of
+			// interest to a worker-pool administrator.
+			//
+			// NOTE: errors are publicly visible.  Ensure that none of the content
+			// contains secrets or other sensitive information.
 			definitions.Entry{
-				Name:        "listWorkersForWorkerPool",
-				Title:       "Workers in a Worker Pool",
-				Description: "Get the list of all the existing workers in a given worker pool.",
+				Name:        "reportWorkerError",
+				Title:       "Report an error from a worker",
+				Description: "Report an error that occurred on a worker.  This error will be included\nwith the other errors in `listWorkerPoolErrors(workerPoolId)`.\n\nWorkers can use this endpoint to report startup or configuration errors\nthat might be associated with the worker pool configuration and thus of\ninterest to a worker-pool administrator.\n\nNOTE: errors are publicly visible.  Ensure that none of the content\ncontains secrets or other sensitive information.",
 				Stability:   "stable",
-				Method:      "get",
-				Route:       "/workers/<workerPoolId>",
+				Method:      "post",
+				Route:       "/worker-pool-errors/<workerPoolId>",
 				Args: []string{
 					"workerPoolId",
 				},
-				Query: []string{
-					"continuationToken",
-					"limit",
-				},
-				Input: "",
-			},
-			definitions.Entry{
-				Name:        "registerWorker",
-				Title:       "Register a running worker",
-				Description: "Register a running worker.  Workers call this method on worker start-up.\n\nThis call both marks the worker as running and returns the credentials\nthe worker will require to perform its work.  The worker must provide\nsome proof of its identity, and that proof varies by provider type.",
-				Stability:   "stable",
-				Method:      "post",
-				Route:       "/worker/register",
-				Args:        []string{},
-				Query:       []string{},
-				Input:       "v1/register-worker-request.json#",
+				Query:     []string{},
+				Input:     "v1/report-worker-error-request.json#",
+				Output:    "v1/worker-pool-error.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "reportWorkerError <workerPoolId> [--body <payload>]",
+				Example:   "  taskcluster api workerManager reportWorkerError <workerPoolId> --body @report-worker-error-request.json",
+				Aliases:   []string(nil),
 			},
+			// reregisterWorker: Reregister a Worker
+			//
+			// Reregister a running worker.
+			//
+			// This will generate and return new Taskcluster credentials for the worker
+			// on that instance to use. The credentials will not live longer the
+			// `registrationTimeout` for that worker. The endpoint will update
+			// `terminateAfter`
+			// for the worker so that worker-manager does not terminate the instance.
 			definitions.Entry{
 				Name:        "reregisterWorker",
 				Title:       "Reregister a Worker",
@@ -1714,7 +3818,2685 @@ var services = map[string]definitions.Service{
 				Args:        []string{},
 				Query:       []string{},
 				Input:       "v1/reregister-worker-request.json#",
+				Output:      "v1/reregister-worker-response.json#",
+				Paginated:   false,
+				SignedURL:   false,
+				Download:    false,
+				Upload:      false,
+				PutURL:      false,
+				Usage:       "reregisterWorker [--body <payload>]",
+				Example:     "  taskcluster api workerManager reregisterWorker --body @reregister-worker-request.json",
+				Aliases:     []string(nil),
+			},
+			// updateWorkerPool: Update Worker Pool
+			//
+			// Given an existing worker pool definition, this will modify it and return
+			// the new definition.
+			//
+			// To delete a worker pool, set its `providerId` to `"null-provider"`.
+			// After any existing workers have exited, a cleanup job will remove the
+			// worker pool.  During that time, the worker pool can be updated again, such
+			// as to set its `providerId` to a real provider.
+			definitions.Entry{
+				Name:        "updateWorkerPool",
+				Title:       "Update Worker Pool",
+				Description: "Given an existing worker pool definition, this will modify it and return\nthe new definition.\n\nTo delete a worker pool, set its `providerId` to `\"null-provider\"`.\nAfter any existing workers have exited, a cleanup job will remove the\nworker pool.  During that time, the worker pool can be updated again, such\nas to set its `providerId` to a real provider.",
+				Stability:   "stable",
+				Method:      "post",
+				Route:       "/worker-pool/<workerPoolId>",
+				Args: []string{
+					"workerPoolId",
+				},
+				Query:     []string{},
+				Input:     "v1/update-worker-pool-request.json#",
+				Output:    "v1/worker-pool-full.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "updateWorkerPool <workerPoolId> [--body <payload>]",
+				Example:   "  taskcluster api workerManager updateWorkerPool <workerPoolId> --body @update-worker-pool-request.json",
+				Aliases:   []string(nil),
+			},
+			// worker: Get a Worker
+			//
+			// Get a single worker.
+			definitions.Entry{
+				Name:        "worker",
+				Title:       "Get a Worker",
+				Description: "Get a single worker.",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/workers/<workerPoolId>:/<workerGroup>/<workerId>",
+				Args: []string{
+					"workerPoolId",
+					"workerGroup",
+					"workerId",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/worker-full.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "worker <workerPoolId> <workerGroup> <workerId>",
+				Example:   "  taskcluster api workerManager worker <workerPoolId> <workerGroup> <workerId>",
+				Aliases:   []string(nil),
+			},
+			// workerPool: Get Worker Pool
+			//
+			// Fetch an existing worker pool defition.
+			definitions.Entry{
+				Name:        "workerPool",
+				Title:       "Get Worker Pool",
+				Description: "Fetch an existing worker pool defition.",
+				Stability:   "stable",
+				Method:      "get",
+				Route:       "/worker-pool/<workerPoolId>",
+				Args: []string{
+					"workerPoolId",
+				},
+				Query:     []string{},
+				Input:     "",
+				Output:    "v1/worker-pool-full.json#",
+				Paginated: false,
+				SignedURL: false,
+				Download:  false,
+				Upload:    false,
+				PutURL:    false,
+				Usage:     "workerPool <workerPoolId>",
+				Example:   "  taskcluster api workerManager workerPool <workerPoolId>",
+				Aliases:   []string(nil),
+			},
+		},
+	},
+}
+
+// exchanges holds the Pulse exchanges of the services, from which bindings
+// are built by ListenFor and the binding functions of the exchanges.
+var exchanges = map[string]definitions.Exchanges{
+	"Auth": definitions.Exchanges{
+		ExchangePrefix: "exchange/taskcluster-auth/v1/",
+		ServiceName:    "auth",
+		APIVersion:     "v1",
+		Title:          "Auth Pulse Exchanges",
+		Description:    "The auth service is responsible for storing credentials, managing\nassignment of scopes, and validation of request signatures from other\nservices.\n\nThese exchanges provides notifications when credentials or roles are\nupdated. This is mostly so that multiple instances of the auth service\ncan purge their caches and synchronize state. But you are of course\nwelcome to use these for other purposes, monitoring changes for example.",
+		Entries: []definitions.Exchange{
+			// clientCreated: Client Created Messages
+			//
+			// Message that a new client has been created.
+			definitions.Exchange{
+				Name:        "clientCreated",
+				Title:       "Client Created Messages",
+				Description: "Message that a new client has been created.",
+				Exchange:    "client-created",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/client-message.json#",
+			},
+			// clientDeleted: Client Deleted Messages
+			//
+			// Message that a new client has been deleted.
+			definitions.Exchange{
+				Name:        "clientDeleted",
+				Title:       "Client Deleted Messages",
+				Description: "Message that a new client has been deleted.",
+				Exchange:    "client-deleted",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/client-message.json#",
+			},
+			// clientUpdated: Client Updated Messages
+			//
+			// Message that a new client has been updated.
+			definitions.Exchange{
+				Name:        "clientUpdated",
+				Title:       "Client Updated Messages",
+				Description: "Message that a new client has been updated.",
+				Exchange:    "client-updated",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/client-message.json#",
+			},
+			// roleCreated: Role Created Messages
+			//
+			// Message that a new role has been created.
+			definitions.Exchange{
+				Name:        "roleCreated",
+				Title:       "Role Created Messages",
+				Description: "Message that a new role has been created.",
+				Exchange:    "role-created",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/role-message.json#",
+			},
+			// roleDeleted: Role Deleted Messages
+			//
+			// Message that a new role has been deleted.
+			definitions.Exchange{
+				Name:        "roleDeleted",
+				Title:       "Role Deleted Messages",
+				Description: "Message that a new role has been deleted.",
+				Exchange:    "role-deleted",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/role-message.json#",
+			},
+			// roleUpdated: Role Updated Messages
+			//
+			// Message that a new role has been updated.
+			definitions.Exchange{
+				Name:        "roleUpdated",
+				Title:       "Role Updated Messages",
+				Description: "Message that a new role has been updated.",
+				Exchange:    "role-updated",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/role-message.json#",
+			},
+		},
+	},
+	"Github": definitions.Exchanges{
+		ExchangePrefix: "exchange/taskcluster-github/v1/",
+		ServiceName:    "github",
+		APIVersion:     "v1",
+		Title:          "Taskcluster-Github Exchanges",
+		Description:    "The github service publishes a pulse\nmessage for supported github events, translating Github webhook\nevents into pulse messages.\n\nThis document describes the exchange offered by the taskcluster\ngithub service",
+		Entries: []definitions.Exchange{
+			// pullRequest: GitHub Pull Request Event
+			//
+			// When a GitHub pull request event is posted it will be broadcast on this
+			// exchange with the designated `organization` and `repository`
+			// in the routing-key along with event specific metadata in the payload.
+			definitions.Exchange{
+				Name:        "pullRequest",
+				Title:       "GitHub Pull Request Event",
+				Description: "When a GitHub pull request event is posted it will be broadcast on this\nexchange with the designated `organization` and `repository`\nin the routing-key along with event specific metadata in the payload.",
+				Exchange:    "pull-request",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `\"primary\"` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "organization",
+						Summary:       "The GitHub `organization` which had an event. All periods have been replaced by % - such that foo.bar becomes foo%bar - and all other special characters aside from - and _ have been stripped.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "repository",
+						Summary:       "The GitHub `repository` which had an event.All periods have been replaced by % - such that foo.bar becomes foo%bar - and all other special characters aside from - and _ have been stripped.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "action",
+						Summary:       "The GitHub `action` which triggered an event. See for possible values see the payload actions property.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+				},
+				Schema: "v1/github-pull-request-message.json#",
+			},
+			// push: GitHub push Event
+			//
+			// When a GitHub push event is posted it will be broadcast on this
+			// exchange with the designated `organization` and `repository`
+			// in the routing-key along with event specific metadata in the payload.
+			definitions.Exchange{
+				Name:        "push",
+				Title:       "GitHub push Event",
+				Description: "When a GitHub push event is posted it will be broadcast on this\nexchange with the designated `organization` and `repository`\nin the routing-key along with event specific metadata in the payload.",
+				Exchange:    "push",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `\"primary\"` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "organization",
+						Summary:       "The GitHub `organization` which had an event. All periods have been replaced by % - such that foo.bar becomes foo%bar - and all other special characters aside from - and _ have been stripped.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "repository",
+						Summary:       "The GitHub `repository` which had an event.All periods have been replaced by % - such that foo.bar becomes foo%bar - and all other special characters aside from - and _ have been stripped.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+				},
+				Schema: "v1/github-push-message.json#",
+			},
+			// release: GitHub release Event
+			//
+			// When a GitHub release event is posted it will be broadcast on this
+			// exchange with the designated `organization` and `repository`
+			// in the routing-key along with event specific metadata in the payload.
+			definitions.Exchange{
+				Name:        "release",
+				Title:       "GitHub release Event",
+				Description: "When a GitHub release event is posted it will be broadcast on this\nexchange with the designated `organization` and `repository`\nin the routing-key along with event specific metadata in the payload.",
+				Exchange:    "release",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `\"primary\"` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "organization",
+						Summary:       "The GitHub `organization` which had an event. All periods have been replaced by % - such that foo.bar becomes foo%bar - and all other special characters aside from - and _ have been stripped.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "repository",
+						Summary:       "The GitHub `repository` which had an event.All periods have been replaced by % - such that foo.bar becomes foo%bar - and all other special characters aside from - and _ have been stripped.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+				},
+				Schema: "v1/github-release-message.json#",
+			},
+			// taskGroupCreationRequested: tc-gh requested the Queue service to create all
+			// the tasks in a group
+			//
+			// supposed to signal that taskCreate API has been called for every task in the
+			// task group
+			// for this particular repo and this particular organization
+			// currently used for creating initial status indicators in GitHub UI using
+			// Statuses API.
+			// This particular exchange can also be bound to RabbitMQ queues by custom
+			// routes - for that,
+			// Pass in the array of routes as a second argument to the publish method.
+			// Currently, we do
+			// use the statuses routes to bind the handler that creates the initial status.
+			definitions.Exchange{
+				Name:        "taskGroupCreationRequested",
+				Title:       "tc-gh requested the Queue service to create all the tasks in a group",
+				Description: "supposed to signal that taskCreate API has been called for every task in the task group\nfor this particular repo and this particular organization\ncurrently used for creating initial status indicators in GitHub UI using Statuses API.\nThis particular exchange can also be bound to RabbitMQ queues by custom routes - for that,\nPass in the array of routes as a second argument to the publish method. Currently, we do\nuse the statuses routes to bind the handler that creates the initial status.",
+				Exchange:    "task-group-creation-requested",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `\"primary\"` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "organization",
+						Summary:       "The GitHub `organization` which had an event. All periods have been replaced by % - such that foo.bar becomes foo%bar - and all other special characters aside from - and _ have been stripped.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "repository",
+						Summary:       "The GitHub `repository` which had an event.All periods have been replaced by % - such that foo.bar becomes foo%bar - and all other special characters aside from - and _ have been stripped.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+				},
+				Schema: "v1/task-group-creation-requested.json#",
+			},
+		},
+	},
+	"Hooks": definitions.Exchanges{
+		ExchangePrefix: "exchange/taskcluster-hooks/v1/",
+		ServiceName:    "hooks",
+		APIVersion:     "v1",
+		Title:          "Exchanges to manage hooks",
+		Description:    "The hooks service is responsible for creating tasks at specific times orin .  response to webhooks and API calls.Using this exchange allows us tomake hooks which repsond to particular pulse messagesThese exchanges provide notifications when a hook is created, updatedor deleted. This is so that the listener running in a different hooks process at the other end can direct another listener specified by`hookGroupId` and `hookId` to synchronize its bindings. But you are ofcourse welcome to use these for other purposes, monitoring changes for example.",
+		Entries: []definitions.Exchange{
+			// hookCreated: Hook Created Messages
+			//
+			// Whenever the api receives a request to create apulse based hook, a message is
+			// posted to this exchange andthe receiver creates a listener with the bindings,
+			// to create a task
+			definitions.Exchange{
+				Name:        "hookCreated",
+				Title:       "Hook Created Messages",
+				Description: "Whenever the api receives a request to create apulse based hook, a message is posted to this exchange andthe receiver creates a listener with the bindings, to create a task",
+				Exchange:    "hook-created",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/pulse-hook-changed-message.json#",
+			},
+			// hookDeleted: Hook Deleted Messages
+			//
+			// Whenever the api receives a request to delete apulse based hook, a message is
+			// posted to this exchange andthe receiver deletes the listener associated with
+			// that hook.
+			definitions.Exchange{
+				Name:        "hookDeleted",
+				Title:       "Hook Deleted Messages",
+				Description: "Whenever the api receives a request to delete apulse based hook, a message is posted to this exchange andthe receiver deletes the listener associated with that hook.",
+				Exchange:    "hook-deleted",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/pulse-hook-changed-message.json#",
+			},
+			// hookUpdated: Hook Updated Messages
+			//
+			// Whenever the api receives a request to update apulse based hook, a message is
+			// posted to this exchange andthe receiver updates the listener associated with
+			// that hook.
+			definitions.Exchange{
+				Name:        "hookUpdated",
+				Title:       "Hook Updated Messages",
+				Description: "Whenever the api receives a request to update apulse based hook, a message is posted to this exchange andthe receiver updates the listener associated with that hook.",
+				Exchange:    "hook-updated",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/pulse-hook-changed-message.json#",
+			},
+		},
+	},
+	"Notify": definitions.Exchanges{
+		ExchangePrefix: "exchange/taskcluster-notify/v1/",
+		ServiceName:    "notify",
+		APIVersion:     "v1",
+		Title:          "Notify AMQP Exchanges",
+		Description:    "This pretty much only contains the simple free-form\nmessage that can be published from this service from a request\nby anybody with the proper scopes.",
+		Entries: []definitions.Exchange{
+			// ircRequest: Request for irc notification
+			//
+			// A message which is to be sent to an irc channel or
+			// user is published to this exchange
+			definitions.Exchange{
+				Name:        "ircRequest",
+				Title:       "Request for irc notification",
+				Description: "A message which is to be sent to an irc channel or\nuser is published to this exchange",
+				Exchange:    "irc-request",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `'primary'` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/irc-request.json#",
+			},
+			// notify: Notification Messages
+			//
+			// An arbitrary message that a taskcluster user
+			// can trigger if they like.
+			//
+			// The standard one that is published by us watching
+			// for the completion of tasks is just the task status
+			// data that we pull from the queue `status()` endpoint
+			// when we notice a task is complete.
+			definitions.Exchange{
+				Name:        "notify",
+				Title:       "Notification Messages",
+				Description: "An arbitrary message that a taskcluster user\ncan trigger if they like.\n\nThe standard one that is published by us watching\nfor the completion of tasks is just the task status\ndata that we pull from the queue `status()` endpoint\nwhen we notice a task is complete.",
+				Exchange:    "notification",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `'primary'` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/notification-message.json#",
+			},
+		},
+	},
+	"Queue": definitions.Exchanges{
+		ExchangePrefix: "exchange/taskcluster-queue/v1/",
+		ServiceName:    "queue",
+		APIVersion:     "v1",
+		Title:          "Queue AMQP Exchanges",
+		Description:    "The queue service is responsible for accepting tasks and track their state\nas they are executed by workers. In order ensure they are eventually\nresolved.\n\nThis document describes AMQP exchanges offered by the queue, which allows\nthird-party listeners to monitor tasks as they progress to resolution.\nThese exchanges targets the following audience:\n * Schedulers, who takes action after tasks are completed,\n * Workers, who wants to listen for new or canceled tasks (optional),\n * Tools, that wants to update their view as task progress.\n\nYou'll notice that all the exchanges in the document shares the same\nrouting key pattern. This makes it very easy to bind to all messages\nabout a certain kind tasks.\n\n**Task specific routes**, a task can define a task specific route using\nthe `task.routes` property. See task creation documentation for details\non permissions required to provide task specific routes. If a task has\nthe entry `'notify.by-email'` in as task specific route defined in\n`task.routes` all messages about this task will be CC'ed with the\nrouting-key `'route.notify.by-email'`.\n\nThese routes will always be prefixed `route.`, so that cannot interfere\nwith the _primary_ routing key as documented here. Notice that the\n_primary_ routing key is always prefixed `primary.`. This is ensured\nin the routing key reference, so API clients will do this automatically.\n\nPlease, note that the way RabbitMQ works, the message will only arrive\nin your queue once, even though you may have bound to the exchange with\nmultiple routing key patterns that matches more of the CC'ed routing\nrouting keys.\n\n**Delivery guarantees**, most operations on the queue are idempotent,\nwhich means that if repeated with the same arguments then the requests\nwill ensure completion of the operation and return the same response.\nThis is useful if the server crashes or the TCP connection breaks, but\nwhen re-executing an idempotent operation, the queue will also resend\nany related AMQP messages. Hence, messages may be repeated.\n\nThis shouldn't be much of a problem, as the best you can achieve using\nconfirm messages with AMQP is at-least-once delivery semantics. Hence,\nthis only prevents you from obtaining at-most-once delivery semantics.\n\n**Remark**, some message generated by timeouts maybe dropped if the\nserver crashes at wrong time. Ideally, we'll address this in the\nfuture. For now we suggest you ignore this corner case, and notify us\nif this corner case is of concern to you.",
+		Entries: []definitions.Exchange{
+			// artifactCreated: Artifact Creation Messages
+			//
+			// Whenever the `createArtifact` end-point is called, the queue will create
+			// a record of the artifact and post a message on this exchange. All of this
+			// happens before the queue returns a signed URL for the caller to upload
+			// the actual artifact with (pending on `storageType`).
+			//
+			// This means that the actual artifact is rarely available when this message
+			// is posted. But it is not unreasonable to assume that the artifact will
+			// will become available at some point later. Most signatures will expire in
+			// 30 minutes or so, forcing the uploader to call `createArtifact` with
+			// the same payload again in-order to continue uploading the artifact.
+			//
+			// However, in most cases (especially for small artifacts) it's very
+			// reasonable assume the artifact will be available within a few minutes.
+			// This property means that this exchange is mostly useful for tools
+			// monitoring task evaluation. One could also use it count number of
+			// artifacts per task, or _index_ artifacts though in most cases it'll be
+			// smarter to index artifacts after the task in question have completed
+			// successfully.
+			//
+			// *NOTE*: this message is currently only sent for reference and error
+			// artifacts.  This will be remedied in a future version of Taskcluster.
+			definitions.Exchange{
+				Name:        "artifactCreated",
+				Title:       "Artifact Creation Messages",
+				Description: "Whenever the `createArtifact` end-point is called, the queue will create\na record of the artifact and post a message on this exchange. All of this\nhappens before the queue returns a signed URL for the caller to upload\nthe actual artifact with (pending on `storageType`).\n\nThis means that the actual artifact is rarely available when this message\nis posted. But it is not unreasonable to assume that the artifact will\nwill become available at some point later. Most signatures will expire in\n30 minutes or so, forcing the uploader to call `createArtifact` with\nthe same payload again in-order to continue uploading the artifact.\n\nHowever, in most cases (especially for small artifacts) it's very\nreasonable assume the artifact will be available within a few minutes.\nThis property means that this exchange is mostly useful for tools\nmonitoring task evaluation. One could also use it count number of\nartifacts per task, or _index_ artifacts though in most cases it'll be\nsmarter to index artifacts after the task in question have completed\nsuccessfully.\n\n*NOTE*: this message is currently only sent for reference and error\nartifacts.  This will be remedied in a future version of Taskcluster.",
+				Exchange:    "artifact-created",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `'primary'` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "taskId",
+						Summary:       "`taskId` for the task this message concerns",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "runId",
+						Summary:       "`runId` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerGroup",
+						Summary:       "`workerGroup` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerId",
+						Summary:       "`workerId` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "provisionerId",
+						Summary:       "`provisionerId` this task is targeted at.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerType",
+						Summary:       "`workerType` this task must run on.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "schedulerId",
+						Summary:       "`schedulerId` this task was created by.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "taskGroupId",
+						Summary:       "`taskGroupId` this task was created in.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/artifact-created-message.json#",
+			},
+			// taskCompleted: Task Completed Messages
+			//
+			// When a task is successfully completed by a worker a message is posted
+			// this exchange.
+			// This message is routed using the `runId`, `workerGroup` and `workerId`
+			// that completed the task. But information about additional runs is also
+			// available from the task status structure.
+			definitions.Exchange{
+				Name:        "taskCompleted",
+				Title:       "Task Completed Messages",
+				Description: "When a task is successfully completed by a worker a message is posted\nthis exchange.\nThis message is routed using the `runId`, `workerGroup` and `workerId`\nthat completed the task. But information about additional runs is also\navailable from the task status structure.",
+				Exchange:    "task-completed",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `'primary'` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "taskId",
+						Summary:       "`taskId` for the task this message concerns",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "runId",
+						Summary:       "`runId` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerGroup",
+						Summary:       "`workerGroup` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerId",
+						Summary:       "`workerId` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "provisionerId",
+						Summary:       "`provisionerId` this task is targeted at.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerType",
+						Summary:       "`workerType` this task must run on.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "schedulerId",
+						Summary:       "`schedulerId` this task was created by.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "taskGroupId",
+						Summary:       "`taskGroupId` this task was created in.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/task-completed-message.json#",
+			},
+			// taskDefined: Task Defined Messages
+			//
+			// When a task is created or just defined a message is posted to this
+			// exchange.
+			//
+			// This message exchange is mainly useful when tasks are created with
+			// dependencies
+			// on incomplete tasks, as this does not make the task
+			// `pending`. Thus, no `taskPending` message is published.
+			definitions.Exchange{
+				Name:        "taskDefined",
+				Title:       "Task Defined Messages",
+				Description: "When a task is created or just defined a message is posted to this\nexchange.\n\nThis message exchange is mainly useful when tasks are created with dependencies\non incomplete tasks, as this does not make the task\n`pending`. Thus, no `taskPending` message is published.",
+				Exchange:    "task-defined",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `'primary'` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "taskId",
+						Summary:       "`taskId` for the task this message concerns",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "runId",
+						Summary:       "`runId` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      false,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerGroup",
+						Summary:       "`workerGroup` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      false,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerId",
+						Summary:       "`workerId` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      false,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "provisionerId",
+						Summary:       "`provisionerId` this task is targeted at.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerType",
+						Summary:       "`workerType` this task must run on.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "schedulerId",
+						Summary:       "`schedulerId` this task was created by.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "taskGroupId",
+						Summary:       "`taskGroupId` this task was created in.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/task-defined-message.json#",
+			},
+			// taskException: Task Exception Messages
+			//
+			// Whenever Taskcluster fails to run a message is posted to this exchange.
+			// This happens if the task isn't completed before its `deadlìne`,
+			// all retries failed (i.e. workers stopped responding), the task was
+			// canceled by another entity, or the task carried a malformed payload.
+			//
+			// The specific _reason_ is evident from that task status structure, refer
+			// to the `reasonResolved` property for the last run.
+			definitions.Exchange{
+				Name:        "taskException",
+				Title:       "Task Exception Messages",
+				Description: "Whenever Taskcluster fails to run a message is posted to this exchange.\nThis happens if the task isn't completed before its `deadlìne`,\nall retries failed (i.e. workers stopped responding), the task was\ncanceled by another entity, or the task carried a malformed payload.\n\nThe specific _reason_ is evident from that task status structure, refer\nto the `reasonResolved` property for the last run.",
+				Exchange:    "task-exception",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `'primary'` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "taskId",
+						Summary:       "`taskId` for the task this message concerns",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "runId",
+						Summary:       "`runId` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      false,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerGroup",
+						Summary:       "`workerGroup` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      false,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerId",
+						Summary:       "`workerId` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      false,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "provisionerId",
+						Summary:       "`provisionerId` this task is targeted at.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerType",
+						Summary:       "`workerType` this task must run on.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "schedulerId",
+						Summary:       "`schedulerId` this task was created by.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "taskGroupId",
+						Summary:       "`taskGroupId` this task was created in.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/task-exception-message.json#",
+			},
+			// taskFailed: Task Failed Messages
+			//
+			// When a task ran, but failed to complete successfully a message is posted
+			// to this exchange. This is same as worker ran task-specific code, but the
+			// task specific code exited non-zero.
+			definitions.Exchange{
+				Name:        "taskFailed",
+				Title:       "Task Failed Messages",
+				Description: "When a task ran, but failed to complete successfully a message is posted\nto this exchange. This is same as worker ran task-specific code, but the\ntask specific code exited non-zero.",
+				Exchange:    "task-failed",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `'primary'` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "taskId",
+						Summary:       "`taskId` for the task this message concerns",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "runId",
+						Summary:       "`runId` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      false,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerGroup",
+						Summary:       "`workerGroup` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      false,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerId",
+						Summary:       "`workerId` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      false,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "provisionerId",
+						Summary:       "`provisionerId` this task is targeted at.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerType",
+						Summary:       "`workerType` this task must run on.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "schedulerId",
+						Summary:       "`schedulerId` this task was created by.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "taskGroupId",
+						Summary:       "`taskGroupId` this task was created in.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/task-failed-message.json#",
+			},
+			// taskGroupResolved: Task Group Resolved Messages
+			//
+			// A message is published on task-group-resolved whenever all submitted
+			// tasks (whether scheduled or unscheduled) for a given task group have
+			// been resolved, regardless of whether they resolved as successful or
+			// not. A task group may be resolved multiple times, since new tasks may
+			// be submitted against an already resolved task group.
+			definitions.Exchange{
+				Name:        "taskGroupResolved",
+				Title:       "Task Group Resolved Messages",
+				Description: "A message is published on task-group-resolved whenever all submitted\ntasks (whether scheduled or unscheduled) for a given task group have\nbeen resolved, regardless of whether they resolved as successful or\nnot. A task group may be resolved multiple times, since new tasks may\nbe submitted against an already resolved task group.",
+				Exchange:    "task-group-resolved",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `'primary'` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "taskGroupId",
+						Summary:       "`taskGroupId` for the task-group this message concerns",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "schedulerId",
+						Summary:       "`schedulerId` for the task-group this message concerns",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/task-group-resolved.json#",
+			},
+			// taskPending: Task Pending Messages
+			//
+			// When a task becomes `pending` a message is posted to this exchange.
+			//
+			// This is useful for workers who doesn't want to constantly poll the queue
+			// for new tasks. The queue will also be authority for task states and
+			// claims. But using this exchange workers should be able to distribute work
+			// efficiently and they would be able to reduce their polling interval
+			// significantly without affecting general responsiveness.
+			definitions.Exchange{
+				Name:        "taskPending",
+				Title:       "Task Pending Messages",
+				Description: "When a task becomes `pending` a message is posted to this exchange.\n\nThis is useful for workers who doesn't want to constantly poll the queue\nfor new tasks. The queue will also be authority for task states and\nclaims. But using this exchange workers should be able to distribute work\nefficiently and they would be able to reduce their polling interval\nsignificantly without affecting general responsiveness.",
+				Exchange:    "task-pending",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `'primary'` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "taskId",
+						Summary:       "`taskId` for the task this message concerns",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "runId",
+						Summary:       "`runId` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerGroup",
+						Summary:       "`workerGroup` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      false,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerId",
+						Summary:       "`workerId` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      false,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "provisionerId",
+						Summary:       "`provisionerId` this task is targeted at.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerType",
+						Summary:       "`workerType` this task must run on.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "schedulerId",
+						Summary:       "`schedulerId` this task was created by.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "taskGroupId",
+						Summary:       "`taskGroupId` this task was created in.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/task-pending-message.json#",
+			},
+			// taskRunning: Task Running Messages
+			//
+			// Whenever a task is claimed by a worker, a run is started on the worker,
+			// and a message is posted on this exchange.
+			definitions.Exchange{
+				Name:        "taskRunning",
+				Title:       "Task Running Messages",
+				Description: "Whenever a task is claimed by a worker, a run is started on the worker,\nand a message is posted on this exchange.",
+				Exchange:    "task-running",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `'primary'` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "taskId",
+						Summary:       "`taskId` for the task this message concerns",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "runId",
+						Summary:       "`runId` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerGroup",
+						Summary:       "`workerGroup` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerId",
+						Summary:       "`workerId` of latest run for the task, `_` if no run is exists for the task.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "provisionerId",
+						Summary:       "`provisionerId` this task is targeted at.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "workerType",
+						Summary:       "`workerType` this task must run on.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "schedulerId",
+						Summary:       "`schedulerId` this task was created by.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "taskGroupId",
+						Summary:       "`taskGroupId` this task was created in.",
+						Constant:      "",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/task-running-message.json#",
+			},
+		},
+	},
+	"WorkerManager": definitions.Exchanges{
+		ExchangePrefix: "exchange/taskcluster-worker-manager/v1/",
+		ServiceName:    "worker-manager",
+		APIVersion:     "v1",
+		Title:          "Worker Manager Exchanges",
+		Description:    "These exchanges provide notifications when a worker pool is created or updated.This is so that the provisioner running in a differentprocess at the other end can synchronize to the changes. But you are ofcourse welcome to use these for other purposes, monitoring changes for example.",
+		Entries: []definitions.Exchange{
+			// workerPoolCreated: Worker Pool Created Messages
+			//
+			// Whenever the api receives a request to create aworker pool, a message is
+			// posted to this exchange anda provider can act upon it.
+			definitions.Exchange{
+				Name:        "workerPoolCreated",
+				Title:       "Worker Pool Created Messages",
+				Description: "Whenever the api receives a request to create aworker pool, a message is posted to this exchange anda provider can act upon it.",
+				Exchange:    "worker-pool-created",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `'primary'` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/pulse-worker-pool-message.json#",
+			},
+			// workerPoolUpdated: Worker Pool Updated Messages
+			//
+			// Whenever the api receives a request to update aworker pool, a message is
+			// posted to this exchange anda provider can act upon it.
+			definitions.Exchange{
+				Name:        "workerPoolUpdated",
+				Title:       "Worker Pool Updated Messages",
+				Description: "Whenever the api receives a request to update aworker pool, a message is posted to this exchange anda provider can act upon it.",
+				Exchange:    "worker-pool-updated",
+				RoutingKey: []definitions.RoutingKeyElement{
+					definitions.RoutingKeyElement{
+						Name:          "routingKeyKind",
+						Summary:       "Identifier for the routing-key kind. This is always `'primary'` for the formalized routing key.",
+						Constant:      "primary",
+						MultipleWords: false,
+						Required:      true,
+					},
+					definitions.RoutingKeyElement{
+						Name:          "reserved",
+						Summary:       "Space reserved for future routing-key entries, you should always match this entry with `#`. As automatically done by our tooling, if not specified.",
+						Constant:      "",
+						MultipleWords: true,
+						Required:      false,
+					},
+				},
+				Schema: "v1/pulse-worker-pool-message.json#",
 			},
 		},
 	},
 }
+
+// schemas holds the schemas of the API methods' payloads and responses,
+// and the schemas they refer to, by path; they are used to validate payloads
+// before they are sent, and shown by --schema.
+var schemas = map[string]string{
+	"/schemas/auth/v1/authenticate-hawk-request.json":             "{\"$id\":\"/schemas/auth/v1/authenticate-hawk-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Request to authenticate a hawk request.\\n\",\"properties\":{\"authorization\":{\"description\":\"Authorization header, **must** only be specified if request being\\nauthenticated has a `Authorization` header.\\n\",\"type\":\"string\"},\"host\":{\"description\":\"Host for which the request came in, this is typically the `Host` header\\nexcluding the port if any.\\n\",\"format\":\"hostname\",\"title\":\"Hostname or IPv4\",\"type\":\"string\"},\"method\":{\"description\":\"HTTP method of the request being authenticated.\\n\",\"enum\":[\"get\",\"post\",\"put\",\"head\",\"delete\",\"options\",\"trace\",\"copy\",\"lock\",\"mkcol\",\"move\",\"purge\",\"propfind\",\"proppatch\",\"unlock\",\"report\",\"mkactivity\",\"checkout\",\"merge\",\"m-search\",\"notify\",\"subscribe\",\"unsubscribe\",\"patch\",\"search\",\"connect\"],\"type\":\"string\"},\"port\":{\"description\":\"Port on which the request came in, this is typically `80` or `443`.\\nIf you are running behind a reverse proxy look for the `x-forwarded-port`\\nheader.\\n\",\"maximum\":65535,\"minimum\":0,\"type\":\"integer\"},\"resource\":{\"description\":\"Resource the request operates on including querystring. This is the\\nstring that follows the HTTP method.\\n**Note,** order of querystring elements is important.\\n\",\"type\":\"string\"},\"sourceIp\":{\"description\":\"Source IP of the authentication request or request that requires\\nauthentication. This is only used for audit logging.\\n\",\"oneOf\":[{\"format\":\"ipv6\"},{\"format\":\"ipv4\"}],\"title\":\"Source IP\",\"type\":\"string\"}},\"required\":[\"method\",\"resource\",\"host\",\"port\"],\"title\":\"Hawk Signature Authentication Request\",\"type\":\"object\"}",
+	"/schemas/auth/v1/authenticate-hawk-response.json":            "{\"$id\":\"/schemas/auth/v1/authenticate-hawk-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"description\":\"Response from a request to authenticate a hawk request.\\n\",\"oneOf\":[{\"additionalProperties\":false,\"properties\":{\"clientId\":{\"description\":\"The `clientId` that made this request.  This may be the `id` supplied in\\nthe Authorization header, or in the case of a named temporary credential\\nmay be embedded in the payload.  In any case, this clientId can be used\\nfor logging, auditing, and identifying the credential but **must** not be\\nused for access control.  That's what scopes are for.\\n\",\"pattern\":\"^[A-Za-z0-9!@/:.+|_-]+$\",\"type\":\"string\"},\"expires\":{\"description\":\"The expiration time for the credentials used to make this request.\\nThis should be treated as the latest time at which the authorization\\nis valid.  For most cases, where the access being authorized occurs\\nimmediately, this field can be ignored, as the value will always be\\nin the future if the status is `auth-success`.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"hash\":{\"description\":\"Payload as extracted from `Authentication` header. This property is\\nonly present if a hash is available. You are not required to validate\\nthis hash, but if you do, please check `scheme` to ensure that it's\\non a scheme you support.\\n\"},\"scheme\":{\"description\":\"Authentication scheme the client used. Generally, you don't need to\\nread this property unless `hash` is provided and you want to validate\\nthe payload hash. Additional values may be added in the future.\\n\",\"enum\":[\"hawk\"],\"type\":\"string\"},\"scopes\":{\"description\":\"List of scopes the client is authorized to access.  Scopes must be\\ncomposed of printable ASCII characters and spaces.\\n\",\"items\":{\"pattern\":\"^[ -~]*$\",\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":true},\"status\":{\"description\":\"The kind of response, `auth-failed` or `auth-success`.\\n\",\"enum\":[\"auth-success\"],\"type\":\"string\"}},\"required\":[\"status\",\"scopes\",\"scheme\",\"clientId\",\"expires\"],\"title\":\"Authentication Successful Response\",\"type\":\"object\"},{\"additionalProperties\":false,\"properties\":{\"message\":{\"description\":\"Message saying why the authentication failed.\\n\",\"type\":\"string\"},\"status\":{\"description\":\"The kind of response, `auth-failed` or `auth-success`.\\n\",\"enum\":[\"auth-failed\"],\"type\":\"string\"}},\"required\":[\"status\",\"message\"],\"title\":\"Authentication Failed Response\",\"type\":\"object\"}],\"title\":\"Hawk Signature Authentication Response\"}",
+	"/schemas/auth/v1/aws-s3-credentials-response.json":           "{\"$id\":\"/schemas/auth/v1/aws-s3-credentials-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response for a request to get access to an S3 bucket.\\n\",\"properties\":{\"credentials\":{\"additionalProperties\":false,\"description\":\"Temporary STS credentials for use when operating on S3\\n\",\"properties\":{\"accessKeyId\":{\"description\":\"Access key identifier that identifies the temporary security\\ncredentials.\\n\",\"title\":\"AccessKeyId\",\"type\":\"string\"},\"secretAccessKey\":{\"description\":\"Secret access key used to sign requests\\n\",\"title\":\"SecretAccessKey\",\"type\":\"string\"},\"sessionToken\":{\"description\":\"A token that must passed with request to use the temporary\\nsecurity credentials.\\n\",\"title\":\"SessionToken\",\"type\":\"string\"}},\"required\":[\"accessKeyId\",\"secretAccessKey\",\"sessionToken\"],\"title\":\"Temporary Security Credentials\",\"type\":\"object\"},\"expires\":{\"description\":\"Date and time of when the temporary credentials expires.\\n\",\"format\":\"date-time\",\"type\":\"string\"}},\"required\":[\"credentials\",\"expires\"],\"title\":\"AWS S3 Credentials Response\",\"type\":\"object\"}",
+	"/schemas/auth/v1/azure-account-list-response.json":           "{\"$id\":\"/schemas/auth/v1/azure-account-list-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A list of Azure accounts managed by taskcluster-auth\\n\",\"properties\":{\"accounts\":{\"description\":\"A list of accountIds that are managed by auth. These are\\nthe accounts that can have SAS credentials fetched for tables\\nwithin them.\\n\",\"items\":{\"type\":\"string\"},\"title\":\"Azure Accounts\",\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"accounts\"],\"title\":\"Azure List Account Response\",\"type\":\"object\"}",
+	"/schemas/auth/v1/azure-container-list-response.json":         "{\"$id\":\"/schemas/auth/v1/azure-container-list-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A list of Azure containers in an account\\n\",\"properties\":{\"containers\":{\"description\":\"A list of containers that are in an account.  Credentials are available for\\nthese containers from the `azureBlobSAS` method.\\n\",\"items\":{\"type\":\"string\"},\"title\":\"Azure Containers\",\"type\":\"array\",\"uniqueItems\":true},\"continuationToken\":{\"description\":\"Opaque `continuationToken` to be given as query-string option to get the\\nnext set of containers.\\nThis property is only present if another request is necessary to fetch all\\nresults. In practice the next request with a `continuationToken` may not\\nreturn additional results, but it can. Thus, you can only be sure to have\\nall the results if you've called this method with `continuationToken`\\nuntil you get a result without a `continuationToken`.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"}},\"required\":[\"containers\"],\"title\":\"Azure List Containers Response\",\"type\":\"object\"}",
+	"/schemas/auth/v1/azure-container-response.json":              "{\"$id\":\"/schemas/auth/v1/azure-container-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response to a request for an Shared-Access-Signature to access an Azure\\nBlob Storage container.\\n\",\"properties\":{\"expiry\":{\"description\":\"Date and time of when the Shared-Access-Signature expires.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"sas\":{\"description\":\"Shared-Access-Signature string. This is the querystring parameters to\\nbe appened after `?` or `&` depending on whether or not a querystring is\\nalready present in the URL.\\n\",\"type\":\"string\"}},\"required\":[\"sas\",\"expiry\"],\"title\":\"Azure Blob Shared-Access-Signature\",\"type\":\"object\"}",
+	"/schemas/auth/v1/azure-table-access-response.json":           "{\"$id\":\"/schemas/auth/v1/azure-table-access-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response to a request for an Shared-Access-Signature to access and Azure\\nTable Storage table.\\n\",\"properties\":{\"expiry\":{\"description\":\"Date and time of when the Shared-Access-Signature expires.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"sas\":{\"description\":\"Shared-Access-Signature string. This is the querystring parameters to\\nbe appened after `?` or `&` depending on whether or not a querystring is\\nalready present in the URL.\\n\",\"type\":\"string\"}},\"required\":[\"sas\",\"expiry\"],\"title\":\"Azure Table Shared-Access-Signature\",\"type\":\"object\"}",
+	"/schemas/auth/v1/azure-table-list-response.json":             "{\"$id\":\"/schemas/auth/v1/azure-table-list-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A list of Azure tables in an account\\n\",\"properties\":{\"continuationToken\":{\"description\":\"Opaque `continuationToken` to be given as query-string option to get the\\nnext set of tables.\\nThis property is only present if another request is necessary to fetch all\\nresults. In practice the next request with a `continuationToken` may not\\nreturn additional results, but it can. Thus, you can only be sure to have\\nall the results if you've called `azureAccountTables` with `continuationToken`\\nuntil you get a result without a `continuationToken`.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"},\"tables\":{\"description\":\"A list of tables that are in an account. These are\\nthe tables that can have SAS credentials fetched for them.\\n\",\"items\":{\"type\":\"string\"},\"title\":\"Azure Tables\",\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"tables\"],\"title\":\"Azure List Table Response\",\"type\":\"object\"}",
+	"/schemas/auth/v1/create-client-request.json":                 "{\"$id\":\"/schemas/auth/v1/create-client-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Properties to create a client.\\n\",\"properties\":{\"deleteOnExpiration\":{\"default\":false,\"description\":\"If `true`, the service may delete this client after it has expired.  If\\n`false` (the default), the client will remain after expiration, although\\nit cannot be used for authentication in that state.\\n\",\"type\":\"boolean\"},\"description\":{\"description\":\"Description of what these credentials are used for in markdown.\\nShould include who is the owner, point of contact.\\n\",\"maxLength\":10240,\"type\":\"string\"},\"expires\":{\"description\":\"Date and time where the clients access is set to expire\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"scopes\":{\"description\":\"List of scopes the client has (unexpanded).\\n\",\"items\":{\"description\":\"A single scope. A scope must be composed of\\nprintable ASCII characters and spaces.  Scopes ending in more than\\none `*` character are forbidden.\\n\",\"name\":\"Scope\",\"pattern\":\"^[ -~]*$\",\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"expires\",\"description\"],\"title\":\"Create Client Request\",\"type\":\"object\"}",
+	"/schemas/auth/v1/create-client-response.json":                "{\"$id\":\"/schemas/auth/v1/create-client-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"All details about a client including the `accessToken`\\n\",\"properties\":{\"accessToken\":{\"description\":\"AccessToken used for authenticating requests, you should store this\\nyou won't be able to retrive it again!\\n\",\"pattern\":\"^[a-zA-Z0-9_-]{22,66}$\",\"type\":\"string\"},\"clientId\":{\"description\":\"ClientId of the client\\n\",\"pattern\":\"^[A-Za-z0-9!@/:.+|_-]+$\",\"type\":\"string\"},\"created\":{\"description\":\"Date and time when this client was created\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"deleteOnExpiration\":{\"description\":\"If `true`, the service may delete this client after it has expired.  If\\n`false`, the client will remain after expiration, although it cannot be\\nused for authentication in that state.\\n\",\"type\":\"boolean\"},\"description\":{\"description\":\"Description of what these credentials are used for in markdown.\\nShould include who is the owner, point of contact.\\n\",\"maxLength\":10240,\"type\":\"string\"},\"disabled\":{\"description\":\"If true, this client is disabled and cannot be used.  This usually occurs when the\\nscopes available to the user owning the client no longer satisfy the client.\\n\",\"type\":\"boolean\"},\"expandedScopes\":{\"description\":\"List of scopes granted to this client by matching roles, including the\\nclient's scopes and the implicit role `client-id:<clientId>`.\\n\",\"items\":{\"description\":\"A single scope. A scope must be composed of\\nprintable ASCII characters and spaces.  Scopes ending in more than\\none `*` character are forbidden.\\n\",\"name\":\"Scope\",\"pattern\":\"^[ -~]*$\",\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":true},\"expires\":{\"description\":\"Date and time where the clients access is set to expire\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"lastDateUsed\":{\"description\":\"Date of last time this client was used. Will only be updated every 6 hours\\nor so this may be off by up-to 6 hours. But it still gives a solid hint\\nas to whether or not this client is in use.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"lastModified\":{\"description\":\"Date and time of last modification\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"lastRotated\":{\"description\":\"Date and time of when the `accessToken` was reset last time.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"scopes\":{\"default\":[],\"description\":\"List of scopes the client has (unexpanded).\\n\",\"items\":{\"description\":\"A single scope. A scope must be composed of\\nprintable ASCII characters and spaces.  Scopes ending in more than\\none `*` character are forbidden.\\n\",\"name\":\"Scope\",\"pattern\":\"^[ -~]*$\",\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"clientId\",\"accessToken\",\"expires\",\"description\",\"created\",\"lastModified\",\"lastDateUsed\",\"lastRotated\",\"scopes\",\"expandedScopes\",\"disabled\",\"deleteOnExpiration\"],\"title\":\"Create Client Response\",\"type\":\"object\"}",
+	"/schemas/auth/v1/create-role-request.json":                   "{\"$id\":\"/schemas/auth/v1/create-role-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Data to create or update a role.\\n\",\"properties\":{\"description\":{\"description\":\"Description of what this role is used for in markdown.\\nShould include who is the owner, point of contact.\\n\",\"maxLength\":10240,\"type\":\"string\"},\"scopes\":{\"description\":\"List of scopes the role grants access to.  Scopes must be composed of\\nprintable ASCII characters and spaces.\\n\",\"items\":{\"description\":\"A single scope. A scope must be composed of\\nprintable ASCII characters and spaces.  Scopes ending in more than\\none `*` character are forbidden.\\n\",\"name\":\"Scope\",\"pattern\":\"^[ -~]*$\",\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"scopes\",\"description\"],\"title\":\"Create Role Request\",\"type\":\"object\"}",
+	"/schemas/auth/v1/gcp-credentials-response.json":              "{\"$id\":\"/schemas/auth/v1/gcp-credentials-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response for a request to get a GCP temporary credential.\\n\",\"properties\":{\"accessToken\":{\"description\":\"Temporary oauth2 access token to access the given service account\\n\",\"title\":\"Temporary access token\",\"type\":\"string\"},\"expireTime\":{\"description\":\"The access token expire time\",\"format\":\"date-time\",\"title\":\"Expire time\",\"type\":\"string\"}},\"required\":[\"accessToken\",\"expireTime\"],\"title\":\"GCP Credentials Response\",\"type\":\"object\"}",
+	"/schemas/auth/v1/get-client-response.json":                   "{\"$id\":\"/schemas/auth/v1/get-client-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Get all details about a client, useful for tools modifying a client\\n\",\"properties\":{\"clientId\":{\"description\":\"ClientId of the client scopes is requested about\\n\",\"pattern\":\"^[A-Za-z0-9!@/:.+|_-]+$\",\"type\":\"string\"},\"created\":{\"description\":\"Date and time when this client was created\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"deleteOnExpiration\":{\"description\":\"If `true`, the service may delete this client after it has expired.  If\\n`false`, the client will remain after expiration, although it cannot be\\nused for authentication in that state.\\n\",\"type\":\"boolean\"},\"description\":{\"description\":\"Description of what these credentials are used for in markdown.\\nShould include who is the owner, point of contact.\\n\",\"maxLength\":10240,\"type\":\"string\"},\"disabled\":{\"description\":\"If true, this client is disabled and cannot be used.  This usually occurs when the\\nscopes available to the user owning the client no longer satisfy the client.\\n\",\"type\":\"boolean\"},\"expandedScopes\":{\"description\":\"List of scopes granted to this client by matching roles.  Scopes must be\\ncomposed of printable ASCII characters and spaces.\\n\",\"items\":{\"description\":\"Scope that client is granted by a role\\n\",\"pattern\":\"^[ -~]*$\",\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":true},\"expires\":{\"description\":\"Date and time where the clients access is set to expire\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"lastDateUsed\":{\"description\":\"Date of last time this client was used. Will only be updated every 6 hours\\nor so this may be off by up-to 6 hours. But it still gives a solid hint\\nas to whether or not this client is in use.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"lastModified\":{\"description\":\"Date and time of last modification\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"lastRotated\":{\"description\":\"Date and time of when the `accessToken` was reset last time.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"scopes\":{\"default\":[],\"description\":\"List of scopes the client has (unexpanded).  Scopes must be composed of\\nprintable ASCII characters and spaces.\\n\",\"items\":{\"description\":\"Scope\",\"pattern\":\"^[ -~]*$\",\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"clientId\",\"expires\",\"description\",\"created\",\"lastModified\",\"lastDateUsed\",\"lastRotated\",\"scopes\",\"expandedScopes\",\"disabled\",\"deleteOnExpiration\"],\"title\":\"Get Client Response\",\"type\":\"object\"}",
+	"/schemas/auth/v1/get-role-response.json":                     "{\"$id\":\"/schemas/auth/v1/get-role-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Get all details about a role\\n\",\"properties\":{\"created\":{\"description\":\"Date and time when this role was created\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"description\":{\"description\":\"Description of what this role is used for in markdown.\\nShould include who is the owner, point of contact.\\n\",\"maxLength\":10240,\"type\":\"string\"},\"expandedScopes\":{\"description\":\"List of scopes granted anyone who assumes this role, including anything\\ngranted by roles that can be assumed when you have this role.\\nHence, this includes any scopes in-directly granted as well.\\n\",\"items\":{\"description\":\"A single scope. A scope must be composed of\\nprintable ASCII characters and spaces.  Scopes ending in more than\\none `*` character are forbidden.\\n\",\"name\":\"Scope\",\"pattern\":\"^[ -~]*$\",\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":true},\"lastModified\":{\"description\":\"Date and time of last modification\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"roleId\":{\"description\":\"roleId of the role requested\\n\",\"pattern\":\"^[\\\\x20-\\\\x7e]+$\",\"type\":\"string\"},\"scopes\":{\"description\":\"List of scopes the role grants access to.  Scopes must be composed of\\nprintable ASCII characters and spaces.\\n\",\"items\":{\"description\":\"A single scope. A scope must be composed of\\nprintable ASCII characters and spaces.  Scopes ending in more than\\none `*` character are forbidden.\\n\",\"name\":\"Scope\",\"pattern\":\"^[ -~]*$\",\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":false}},\"required\":[\"roleId\",\"scopes\",\"description\",\"created\",\"lastModified\",\"expandedScopes\"],\"title\":\"Get Role Response\",\"type\":\"object\"}",
+	"/schemas/auth/v1/list-clients-response.json":                 "{\"$id\":\"/schemas/auth/v1/list-clients-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"List of clients\\n\",\"properties\":{\"clients\":{\"items\":{\"$ref\":\"get-client-response.json#\"},\"type\":\"array\",\"uniqueItems\":true},\"continuationToken\":{\"description\":\"A continuation token is returned if there are more results than listed\\nhere. You can optionally provide the token in the request payload to\\nload the additional results.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"}},\"required\":[\"clients\"],\"title\":\"List Client Response\",\"type\":\"object\"}",
+	"/schemas/auth/v1/list-role-ids-response.json":                "{\"$id\":\"/schemas/auth/v1/list-role-ids-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"If no limit is given, the roleIds of all roles are returned. Since this\\nlist may become long, callers can use the `limit` and `continuationToken`\\nquery arguments to page through the responses.\\n\",\"properties\":{\"continuationToken\":{\"description\":\"A continuation token is returned if there are more results than listed\\nhere. You can optionally provide the token in the request payload to\\nload the additional results.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"},\"roleIds\":{\"description\":\"A list of requested roleIds\\n\",\"items\":{\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"roleIds\"],\"title\":\"Get Role Ids Response\",\"type\":\"object\"}",
+	"/schemas/auth/v1/list-roles-response.json":                   "{\"$id\":\"/schemas/auth/v1/list-roles-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"description\":\"List of roles\\n\",\"items\":{\"$ref\":\"get-role-response.json#\"},\"title\":\"Get All Roles (no pagination)\",\"type\":\"array\",\"uniqueItems\":true}",
+	"/schemas/auth/v1/list-roles2-response.json":                  "{\"$id\":\"/schemas/auth/v1/list-roles2-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"If no limit is given, all roles are returned. Since this\\nlist may become long, callers can use the `limit` and `continuationToken`\\nquery arguments to page through the responses.\\n\",\"properties\":{\"continuationToken\":{\"description\":\"A continuation token is returned if there are more results than listed\\nhere. You can optionally provide the token in the request payload to\\nload the additional results.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"},\"roles\":{\"description\":\"A list of requested roles\\n\",\"items\":{\"$ref\":\"get-role-response.json#\"},\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"roles\"],\"title\":\"Get All Roles Response\",\"type\":\"object\"}",
+	"/schemas/auth/v1/scopeset.json":                              "{\"$id\":\"/schemas/auth/v1/scopeset.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A set of scopes\\n\",\"properties\":{\"scopes\":{\"description\":\"List of scopes.  Scopes must be composed of printable ASCII characters and spaces.\\n\",\"items\":{\"description\":\"Scope\",\"pattern\":\"^[ -~]*$\",\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"scopes\"],\"title\":\"Set of scopes\",\"type\":\"object\"}",
+	"/schemas/auth/v1/sentry-dsn-response.json":                   "{\"$id\":\"/schemas/auth/v1/sentry-dsn-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Sentry DSN for submitting errors.\\n\",\"properties\":{\"dsn\":{\"additionalProperties\":false,\"description\":\"Access credentials and urls for the Sentry project.\\nCredentials will expire in 24-48 hours, you should refresh them within\\n24 hours.\\n\",\"properties\":{\"public\":{\"description\":\"Access credential and URL for public error reports.\\nThese credentials can be used for up-to 24 hours.\\nThis is for use in client-side applications only.\\n\",\"format\":\"uri\",\"type\":\"string\"},\"secret\":{\"description\":\"Access credential and URL for private error reports.\\nThese credentials can be used for up-to 24 hours.\\nThis is for use in serser-side applications and should **not** be\\nleaked.\\n\",\"format\":\"uri\",\"type\":\"string\"}},\"required\":[\"secret\",\"public\"],\"type\":\"object\"},\"expires\":{\"description\":\"Expiration time for the credentials. The credentials should not be used\\nafter this time. They might not be revoked immediately, but will be at\\nsome arbitrary point after this date-time.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"project\":{\"description\":\"Project name that the DSN grants access to.\\n\",\"title\":\"Project\",\"type\":\"string\"}},\"required\":[\"project\",\"dsn\",\"expires\"],\"title\":\"Sentry DSN Response\",\"type\":\"object\"}",
+	"/schemas/auth/v1/test-authenticate-request.json":             "{\"$id\":\"/schemas/auth/v1/test-authenticate-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Details on how the test request should be authenticated.\\n\",\"properties\":{\"clientScopes\":{\"default\":[],\"description\":\"List of scopes that should be client used should be given.\\n\",\"items\":{\"description\":\"Scope\",\"pattern\":\"^[ -~]*$\",\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":true},\"requiredScopes\":{\"default\":[],\"description\":\"List of scopes the request should require.\\n\",\"items\":{\"description\":\"Scope\",\"pattern\":\"^[ -~]*$\",\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"clientScopes\",\"requiredScopes\"],\"title\":\"Test Authenticate Request\",\"type\":\"object\"}",
+	"/schemas/auth/v1/test-authenticate-response.json":            "{\"$id\":\"/schemas/auth/v1/test-authenticate-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Details on how the test request was authenticated.\\n\",\"properties\":{\"clientId\":{\"description\":\"ClientId from the request as it will be logged\\n\",\"pattern\":\"^[A-Za-z0-9!@/:.+|_-]+$\",\"type\":\"string\"},\"scopes\":{\"default\":[],\"description\":\"List of scopes the request was authorized.\\n\",\"items\":{\"description\":\"Scope\",\"pattern\":\"^[ -~]*$\",\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"clientId\",\"scopes\"],\"title\":\"Test Authenticate Response\",\"type\":\"object\"}",
+	"/schemas/auth/v1/websocktunnel-token-response.json":          "{\"$id\":\"/schemas/auth/v1/websocktunnel-token-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Token for connecting a worker to websocktunnel proxy\\n\",\"properties\":{\"expires\":{\"description\":\"The time at which the JWT will expire.\\n\",\"format\":\"date-time\",\"title\":\"Expires\",\"type\":\"string\"},\"token\":{\"description\":\"The JWT to be used as `Bearer <token>` when connecting to the service.\\n\",\"title\":\"Token\",\"type\":\"string\"},\"wstAudience\":{\"description\":\"Audience identifying the websocktunnel servers that will honor this token; this will be the\\nsame as the requested `wstAudience`.\\n\",\"pattern\":\"^[a-zA-Z0-9_-]{1,38}$\",\"title\":\"Websocktunnel Audience\",\"type\":\"string\"},\"wstClient\":{\"description\":\"Id for the websocktunnel client connection; this will be the same as the requested `wstClient`.\\n\",\"pattern\":\"^[a-zA-Z0-9_~.%-]+$\",\"title\":\"Websocktunnel Client\",\"type\":\"string\"}},\"required\":[\"wstClient\",\"wstAudience\",\"token\",\"expires\"],\"title\":\"Websocktunnel Token Response\",\"type\":\"object\"}",
+	"/schemas/github/v1/build-list.json":                          "{\"$id\":\"/schemas/github/v1/build-list.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A paginated list of builds\\n\",\"properties\":{\"builds\":{\"description\":\"A simple list of builds.\\n\",\"items\":{\"additionalProperties\":false,\"properties\":{\"created\":{\"description\":\"The initial creation time of the build. This is when it became pending.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"eventId\":{\"description\":\"The GitHub webhook deliveryId. Extracted from the header 'X-GitHub-Delivery'\\n\",\"oneOf\":[{\"pattern\":\"^[a-zA-Z0-9]{8}-[a-zA-Z0-9]{4}-[a-zA-Z0-9]{4}-[a-zA-Z0-9]{4}-[a-zA-Z0-9]{12}$\",\"title\":\"Github GUID\",\"type\":\"string\"},{\"enum\":[\"Unknown\"],\"title\":\"Unknown Github GUID\",\"type\":\"string\"}],\"type\":\"string\"},\"eventType\":{\"description\":\"Type of Github event that triggered the build (i.e. push, pull_request.opened).\",\"type\":\"string\"},\"organization\":{\"description\":\"Github organization associated with the build.\",\"maxLength\":100,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_%]*)$\",\"type\":\"string\"},\"repository\":{\"description\":\"Github repository associated with the build.\",\"maxLength\":100,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_%]*)$\",\"type\":\"string\"},\"sha\":{\"description\":\"Github revision associated with the build.\",\"maxLength\":40,\"minLength\":40,\"type\":\"string\"},\"state\":{\"description\":\"Github status associated with the build.\",\"enum\":[\"pending\",\"success\",\"error\",\"failure\"],\"type\":\"string\"},\"taskGroupId\":{\"description\":\"Taskcluster task-group associated with the build.\",\"pattern\":\"^[A-Za-z0-9_-]{8}[Q-T][A-Za-z0-9_-][CGKOSWaeimquy26-][A-Za-z0-9_-]{10}[AQgw]$\",\"type\":\"string\"},\"updated\":{\"description\":\"The last updated of the build. If it is done, this is when it finished.\\n\",\"format\":\"date-time\",\"type\":\"string\"}},\"required\":[\"organization\",\"repository\",\"sha\",\"state\",\"taskGroupId\",\"eventType\",\"eventId\",\"created\",\"updated\"],\"title\":\"Build\",\"type\":\"object\"},\"type\":\"array\",\"uniqueItems\":false},\"continuationToken\":{\"description\":\"Passed back from Azure to allow us to page through long result sets.\",\"type\":\"string\"}},\"required\":[\"builds\"],\"title\":\"Builds Response\",\"type\":\"object\"}",
+	"/schemas/github/v1/create-comment.json":                      "{\"$id\":\"/schemas/github/v1/create-comment.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Write a new comment on a GitHub Issue or Pull Request.\\nFull specification on [GitHub docs](https://developer.github.com/v3/issues/comments/#create-a-comment)\\n\",\"properties\":{\"body\":{\"description\":\"The contents of the comment.\",\"type\":\"string\"}},\"required\":[\"body\"],\"title\":\"Create Comment Request\",\"type\":\"object\"}",
+	"/schemas/github/v1/create-status.json":                       "{\"$id\":\"/schemas/github/v1/create-status.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Create a commit status on GitHub.\\nFull specification on [GitHub docs](https://developer.github.com/v3/repos/statuses/#create-a-status)\\n\",\"properties\":{\"context\":{\"description\":\"A string label to differentiate this status from the status of other systems.\",\"type\":\"string\"},\"description\":{\"description\":\"A short description of the status.\",\"type\":\"string\"},\"state\":{\"description\":\"The state of the status.\",\"enum\":[\"pending\",\"success\",\"error\",\"failure\"],\"type\":\"string\"},\"target_url\":{\"description\":\"The target URL to associate with this status. This URL will be linked from the GitHub UI to allow users to easily see the 'source' of the Status.\",\"type\":\"string\"}},\"required\":[\"state\"],\"title\":\"Create Status Request\",\"type\":\"object\"}",
+	"/schemas/github/v1/repository.json":                          "{\"$id\":\"/schemas/github/v1/repository.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Any Taskcluster-specific Github repository information.\\n\",\"properties\":{\"installed\":{\"description\":\"True if integration is installed, False otherwise.\\n\",\"type\":\"boolean\"}},\"required\":[\"installed\"],\"title\":\"Repository Response\",\"type\":\"object\"}",
+	"/schemas/hooks/v1/bindings.json":                             "{\"$id\":\"/schemas/hooks/v1/bindings.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"items\":{\"additionalProperties\":false,\"description\":\"Exchange and RoutingKeyPattern for each binding\\n\",\"properties\":{\"exchange\":{\"minLength\":1,\"type\":\"string\"},\"routingKeyPattern\":{\"minLength\":1,\"type\":\"string\"}},\"required\":[\"exchange\",\"routingKeyPattern\"],\"title\":\"Binding\",\"type\":\"object\"},\"title\":\"List of Bindings\",\"type\":\"array\",\"uniqueItems\":true}",
+	"/schemas/hooks/v1/create-hook-request.json":                  "{\"$id\":\"/schemas/hooks/v1/create-hook-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Definition of a hook that can create tasks at defined times.\\n\",\"properties\":{\"bindings\":{\"$ref\":\"bindings.json#\"},\"hookGroupId\":{\"maxLength\":64,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"},\"hookId\":{\"maxLength\":64,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_/]*)$\",\"type\":\"string\"},\"metadata\":{\"$ref\":\"hook-metadata.json#\"},\"schedule\":{\"default\":[],\"description\":\"Definition of the times at which a hook will result in creation of a task.\\nIf several patterns are specified, tasks will be created at any time\\nspecified by one or more patterns.\\n\",\"items\":{\"description\":\"Cron-like specification for when tasks should be created.  The pattern is\\nparsed in a UTC context.\\nSee [cron-parser on npm](https://www.npmjs.com/package/cron-parser).\\nNote that tasks may not be created at exactly the time specified.\\n\",\"title\":\"Cron Pattern\",\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":true},\"task\":{\"description\":\"Template for the task definition.  This is rendered using [JSON-e](https://taskcluster.github.io/json-e/)\\nas described in [firing hooks](/docs/reference/core/hooks/firing-hooks) to produce\\na task definition that is submitted to the Queue service.\\n\",\"title\":\"Task Template\",\"type\":\"object\"},\"triggerSchema\":{\"default\":{\"additionalProperties\":false,\"type\":\"object\"},\"type\":\"object\"}},\"required\":[\"metadata\",\"task\"],\"title\":\"Hook creation request\",\"type\":\"object\"}",
+	"/schemas/hooks/v1/hook-definition.json":                      "{\"$id\":\"/schemas/hooks/v1/hook-definition.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Definition of a hook that will create tasks when defined events occur.\\n\",\"properties\":{\"bindings\":{\"$ref\":\"bindings.json#\"},\"hookGroupId\":{\"maxLength\":64,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"},\"hookId\":{\"maxLength\":64,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_/]*)$\",\"type\":\"string\"},\"metadata\":{\"$ref\":\"hook-metadata.json#\"},\"schedule\":{\"$ref\":\"schedule.json#\"},\"task\":{\"description\":\"Template for the task definition.  This is rendered using [JSON-e](https://taskcluster.github.io/json-e/)\\nas described in [firing hooks](/docs/reference/core/hooks/firing-hooks) to produce\\na task definition that is submitted to the Queue service.\\n\",\"title\":\"Task Template\",\"type\":\"object\"},\"triggerSchema\":{\"type\":\"object\"}},\"required\":[\"hookGroupId\",\"hookId\",\"metadata\",\"task\",\"schedule\",\"triggerSchema\"],\"title\":\"Hook definition\",\"type\":\"object\"}",
+	"/schemas/hooks/v1/hook-metadata.json":                        "{\"$id\":\"/schemas/hooks/v1/hook-metadata.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"properties\":{\"description\":{\"description\":\"Long-form of the hook's purpose and behavior\",\"maxLength\":32768,\"title\":\"Description\",\"type\":\"string\"},\"emailOnError\":{\"default\":true,\"description\":\"Whether to email the owner on an error creating the task.\",\"title\":\"Email on error\",\"type\":\"boolean\"},\"name\":{\"description\":\"Human readable name of the hook\",\"maxLength\":255,\"title\":\"Name\",\"type\":\"string\"},\"owner\":{\"description\":\"Email of the person or group responsible for this hook.\",\"format\":\"email\",\"maxLength\":255,\"title\":\"Owner\",\"type\":\"string\"}},\"required\":[\"name\",\"description\",\"owner\"],\"title\":\"Hook Metadata\",\"type\":\"object\"}",
+	"/schemas/hooks/v1/hook-status.json":                          "{\"$id\":\"/schemas/hooks/v1/hook-status.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A snapshot of the current status of a hook.\\n\",\"properties\":{\"lastFire\":{\"description\":\"Information about the last time this hook fired.  This property is only present\\nif the hook has fired at least once.\\n\",\"oneOf\":[{\"additionalProperties\":false,\"description\":\"Information about a successful firing of the hook\",\"properties\":{\"result\":{\"enum\":[\"success\"],\"type\":\"string\"},\"taskId\":{\"description\":\"The task created\",\"pattern\":\"^[A-Za-z0-9_-]{8}[Q-T][A-Za-z0-9_-][CGKOSWaeimquy26-][A-Za-z0-9_-]{10}[AQgw]$\",\"type\":\"string\"},\"time\":{\"description\":\"The time the task was created.  This will not necessarily match `task.created`.\\n\",\"format\":\"date-time\",\"type\":\"string\"}},\"required\":[\"result\",\"taskId\",\"time\"],\"title\":\"Successful Fire\",\"type\":\"object\"},{\"additionalProperties\":false,\"description\":\"Information about an unsuccessful firing of the hook\",\"properties\":{\"error\":{\"description\":\"The error that occurred when firing the task.  This is typically,\\nbut not always, an API error message.\\n\",\"type\":\"object\"},\"result\":{\"enum\":[\"error\"],\"type\":\"string\"},\"time\":{\"description\":\"The time the task was created.  This will not necessarily match `task.created`.\\n\",\"format\":\"date-time\",\"type\":\"string\"}},\"required\":[\"result\",\"error\",\"time\"],\"title\":\"Failed Fire\",\"type\":\"object\"},{\"additionalProperties\":false,\"description\":\"Information about no firing of the hook (e.g., a new hook)\",\"properties\":{\"result\":{\"enum\":[\"no-fire\"],\"type\":\"string\"}},\"required\":[\"result\"],\"title\":\"No Fire\",\"type\":\"object\"}]},\"nextScheduledDate\":{\"description\":\"The next time this hook's task is scheduled to be created. This property\\nis only present if there is a scheduled next time. Some hooks don't have\\nany schedules.\\n\",\"format\":\"date-time\",\"type\":\"string\"}},\"required\":[\"lastFire\"],\"title\":\"Hook status response\",\"type\":\"object\"}",
+	"/schemas/hooks/v1/list-hook-groups-response.json":            "{\"$id\":\"/schemas/hooks/v1/list-hook-groups-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"List of `hookGroupIds`.\\n\",\"properties\":{\"groups\":{\"items\":{\"type\":\"string\"},\"title\":\"Groups\",\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"groups\"],\"title\":\"Hook groups\",\"type\":\"object\"}",
+	"/schemas/hooks/v1/list-hooks-response.json":                  "{\"$id\":\"/schemas/hooks/v1/list-hooks-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"List of hooks\\n\",\"properties\":{\"hooks\":{\"items\":{\"$ref\":\"hook-definition.json#\"},\"title\":\"Hooks\",\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"hooks\"],\"title\":\"Hook list\",\"type\":\"object\"}",
+	"/schemas/hooks/v1/list-lastFires-response.json":              "{\"$id\":\"/schemas/hooks/v1/list-lastFires-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"List of lastFires\\n\",\"properties\":{\"lastFires\":{\"items\":{\"additionalProperties\":false,\"properties\":{\"error\":{\"description\":\"The error that occurred when firing the task. This is typically,\\nbut not always, an API error message.\\n\",\"type\":\"string\"},\"firedBy\":{\"enum\":[\"schedule\",\"triggerHook\",\"triggerHookWithToken\",\"pulseMessage\"],\"type\":\"string\"},\"hookGroupId\":{\"maxLength\":64,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"},\"hookId\":{\"maxLength\":64,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_/]*)$\",\"type\":\"string\"},\"result\":{\"description\":\"Information about success or failure of firing of the hook\",\"enum\":[\"success\",\"error\"],\"type\":\"string\"},\"taskCreateTime\":{\"description\":\"Time when the task was created\",\"format\":\"date-time\",\"type\":\"string\"},\"taskId\":{\"description\":\"Unique task identifier, this is UUID encoded as\\n[URL-safe base64](http://tools.ietf.org/html/rfc4648#section-5) and\\nstripped of `=` padding.\\n\",\"pattern\":\"^[A-Za-z0-9_-]{8}[Q-T][A-Za-z0-9_-][CGKOSWaeimquy26-][A-Za-z0-9_-]{10}[AQgw]$\",\"title\":\"Task Identifier\",\"type\":\"string\"}},\"required\":[\"hookGroupId\",\"hookId\",\"taskId\",\"firedBy\",\"taskCreateTime\",\"result\",\"error\"],\"type\":\"object\"},\"title\":\"LastFires\",\"type\":\"array\",\"uniqueItems\":false}},\"required\":[\"lastFires\"],\"title\":\"LastFires list\",\"type\":\"object\"}",
+	"/schemas/hooks/v1/schedule.json":                             "{\"$id\":\"/schemas/hooks/v1/schedule.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"default\":[],\"description\":\"A list of cron-style definitions to represent a set of moments in (UTC) time.\\nIf several patterns are specified, a given moment in time represented by\\nmore than one pattern is considered only to be counted once, in other words\\nit is allowed for the cron patterns to overlap; duplicates are redundant.\\n\",\"items\":{\"description\":\"Cron-like specification for when tasks should be created.  The pattern is\\nparsed in a UTC context.\\nSee [cron-parser on npm](https://www.npmjs.com/package/cron-parser).\\n\",\"title\":\"Cron Pattern\",\"type\":\"string\"},\"title\":\"Schedule\",\"type\":\"array\",\"uniqueItems\":true}",
+	"/schemas/hooks/v1/task-status.json":                          "{\"$id\":\"/schemas/hooks/v1/task-status.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A representation of **task status** as known by the queue\\n\",\"properties\":{\"status\":{\"additionalProperties\":false,\"properties\":{\"deadline\":{\"description\":\"Deadline of the task, `pending` and `running` runs are\\nresolved as **exception** if not resolved by other means\\nbefore the deadline. Note, deadline cannot be more than\\n5 days into the future\\n\",\"format\":\"date-time\",\"title\":\"Deadline\",\"type\":\"string\"},\"expires\":{\"description\":\"Task expiration, time at which task definition and\\nstatus is deleted. Notice that all artifacts for the task\\nmust have an expiration that is no later than this.\\n\",\"format\":\"date-time\",\"title\":\"Expiration\",\"type\":\"string\"},\"provisionerId\":{\"description\":\"Unique identifier for the provisioner that this task must be scheduled on\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"title\":\"Provisioner Id\",\"type\":\"string\"},\"retriesLeft\":{\"description\":\"Number of retries left for the task in case of infrastructure issues\\n\",\"maximum\":999,\"minimum\":0,\"title\":\"Retries Left\",\"type\":\"integer\"},\"runs\":{\"description\":\"List of runs, ordered so that index `i` has `runId == i`\\n\",\"items\":{\"additionalProperties\":false,\"description\":\"JSON object with information about a run\\n\",\"properties\":{\"reasonCreated\":{\"description\":\"Reason for the creation of this run,\\n**more reasons may be added in the future**.\\n\",\"enum\":[\"scheduled\",\"retry\",\"task-retry\",\"rerun\",\"exception\"],\"title\":\"Reason Created\",\"type\":\"string\"},\"reasonResolved\":{\"description\":\"Reason that run was resolved, this is mainly\\nuseful for runs resolved as `exception`.\\nNote, **more reasons may be added in the future**, also this\\nproperty is only available after the run is resolved.\\n\",\"enum\":[\"completed\",\"failed\",\"deadline-exceeded\",\"canceled\",\"superseded\",\"claim-expired\",\"worker-shutdown\",\"malformed-payload\",\"resource-unavailable\",\"internal-error\",\"intermittent-task\"],\"title\":\"Reason Resolved\",\"type\":\"string\"},\"resolved\":{\"description\":\"Date-time at which this run was resolved, ie. when the run changed\\nstate from `running` to either `completed`, `failed` or `exception`.\\nThis property is only present after the run as been resolved.\\n\",\"format\":\"date-time\",\"title\":\"Resolved\",\"type\":\"string\"},\"runId\":{\"description\":\"Id of this task run, `run-id`s always starts from `0`\\n\",\"maximum\":1000,\"minimum\":0,\"title\":\"Run Identifier\",\"type\":\"integer\"},\"scheduled\":{\"description\":\"Date-time at which this run was scheduled, ie. when the run was\\ncreated in state `pending`.\\n\",\"format\":\"date-time\",\"title\":\"Scheduled\",\"type\":\"string\"},\"started\":{\"description\":\"Date-time at which this run was claimed, ie. when the run changed\\nstate from `pending` to `running`. This property is only present\\nafter the run has been claimed.\\n\",\"format\":\"date-time\",\"title\":\"Started\",\"type\":\"string\"},\"state\":{\"description\":\"State of this run\\n\",\"enum\":[\"pending\",\"running\",\"completed\",\"failed\",\"exception\"],\"title\":\"Run State\",\"type\":\"string\"},\"takenUntil\":{\"description\":\"Time at which the run expires and is resolved as `failed`, if the\\nrun isn't reclaimed. Note, only present after the run has been\\nclaimed.\\n\",\"format\":\"date-time\",\"title\":\"Taken Until\",\"type\":\"string\"},\"workerGroup\":{\"description\":\"Identifier for group that worker who executes this run is a part of,\\nthis identifier is mainly used for efficient routing.\\nNote, this property is only present after the run is claimed.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"title\":\"Worker Group\",\"type\":\"string\"},\"workerId\":{\"description\":\"Identifier for worker evaluating this run within given\\n`workerGroup`. Note, this property is only available after the run\\nhas been claimed.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"title\":\"Worker Identifier\",\"type\":\"string\"}},\"required\":[\"runId\",\"state\",\"reasonCreated\",\"scheduled\"],\"title\":\"Run Information\",\"type\":\"object\"},\"title\":\"List of Runs\",\"type\":\"array\",\"uniqueItems\":false},\"schedulerId\":{\"description\":\"Identifier for the scheduler that _defined_ this task.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"title\":\"Scheduler Identifier\",\"type\":\"string\"},\"state\":{\"description\":\"State of this task. This is just an auxiliary property derived from state\\nof latests run, or `unscheduled` if none.\\n\",\"enum\":[\"unscheduled\",\"pending\",\"running\",\"completed\",\"failed\",\"exception\"],\"title\":\"State\",\"type\":\"string\"},\"taskGroupId\":{\"description\":\"Identifier for a group of tasks scheduled together with this task, by\\nscheduler identified by `schedulerId`. For tasks scheduled by the\\ntask-graph scheduler, this is the `taskGraphId`.\\n\",\"pattern\":\"^[A-Za-z0-9_-]{8}[Q-T][A-Za-z0-9_-][CGKOSWaeimquy26-][A-Za-z0-9_-]{10}[AQgw]$\",\"title\":\"Task-Group Identifier\",\"type\":\"string\"},\"taskId\":{\"description\":\"Unique task identifier, this is UUID encoded as\\n[URL-safe base64](http://tools.ietf.org/html/rfc4648#section-5) and\\nstripped of `=` padding.\\n\",\"pattern\":\"^[A-Za-z0-9_-]{8}[Q-T][A-Za-z0-9_-][CGKOSWaeimquy26-][A-Za-z0-9_-]{10}[AQgw]$\",\"title\":\"Task Identifier\",\"type\":\"string\"},\"workerType\":{\"description\":\"Identifier for worker type within the specified provisioner\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"title\":\"Worker Type\",\"type\":\"string\"}},\"required\":[\"taskId\",\"provisionerId\",\"workerType\",\"schedulerId\",\"taskGroupId\",\"deadline\",\"expires\",\"retriesLeft\",\"state\",\"runs\"],\"type\":\"object\"}},\"required\":[\"status\"],\"title\":\"Task Status Structure\",\"type\":\"object\"}",
+	"/schemas/hooks/v1/trigger-hook-response.json":                "{\"$id\":\"/schemas/hooks/v1/trigger-hook-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"anyOf\":[{\"$ref\":\"task-status.json#\"},{\"additionalProperties\":false,\"description\":\"Empty response indicating no task was created\",\"properties\":{},\"required\":[],\"type\":\"object\"}],\"description\":\"Response to a `triggerHook` or `triggerHookWithToken` call.\\n\\nIn most cases, this is a task status, but in cases where the hook template\\ndoes not generate a task, it is an empty object with no `status` property.\\n\",\"title\":\"Trigger Hook Response\"}",
+	"/schemas/hooks/v1/trigger-hook.json":                         "{\"$id\":\"/schemas/hooks/v1/trigger-hook.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"description\":\"A request to trigger a hook.  The payload must be a JSON object, and is used as the context\\nfor a JSON-e rendering of the hook's task template, as described in \\\"Firing Hooks\\\".\\n\",\"title\":\"Trigger Hook Request\",\"type\":\"object\"}",
+	"/schemas/hooks/v1/trigger-token-response.json":               "{\"$id\":\"/schemas/hooks/v1/trigger-token-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Secret token for a trigger\\n\",\"properties\":{\"token\":{\"title\":\"Token\",\"type\":\"string\"}},\"required\":[\"token\"],\"title\":\"trigger token response\",\"type\":\"object\"}",
+	"/schemas/index/v1/indexed-task-response.json":                "{\"$id\":\"/schemas/index/v1/indexed-task-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Representation of an indexed task.\\n\",\"properties\":{\"data\":{\"description\":\"Data that was reported with the task. This is an arbitrary JSON object.\\n\",\"title\":\"Task Specific Data\",\"type\":\"object\"},\"expires\":{\"description\":\"Date at which this entry expires from the task index.\\n\",\"format\":\"date-time\",\"title\":\"Expiration\",\"type\":\"string\"},\"namespace\":{\"description\":\"Namespace of the indexed task, used to find the indexed task in the index.\\n\",\"maxLength\":255,\"title\":\"Namespace\",\"type\":\"string\"},\"rank\":{\"description\":\"If multiple tasks are indexed with the same `namespace` the task with the\\nhighest `rank` will be stored and returned in later requests. If two tasks\\nhas the same `rank` the latest task will be stored.\\n\",\"title\":\"Rank\",\"type\":\"number\"},\"taskId\":{\"description\":\"Unique task identifier, this is UUID encoded as\\n[URL-safe base64](http://tools.ietf.org/html/rfc4648#section-5) and\\nstripped of `=` padding.\\n\",\"pattern\":\"^[A-Za-z0-9_-]{8}[Q-T][A-Za-z0-9_-][CGKOSWaeimquy26-][A-Za-z0-9_-]{10}[AQgw]$\",\"title\":\"Task Identifier\",\"type\":\"string\"}},\"required\":[\"namespace\",\"taskId\",\"rank\",\"data\",\"expires\"],\"title\":\"Indexed Task Response\",\"type\":\"object\"}",
+	"/schemas/index/v1/insert-task-request.json":                  "{\"$id\":\"/schemas/index/v1/insert-task-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Representation of the index entry to insert.\\n\",\"properties\":{\"data\":{\"description\":\"This is an arbitrary JSON object. Feel free to put whatever data you want\\nhere, but do limit it, you'll get errors if you store more than 32KB.\\nSo stay well, below that limit.\\n\",\"title\":\"Task Specific Data\",\"type\":\"object\"},\"expires\":{\"description\":\"Date at which this entry expires from the task index.\\n\",\"format\":\"date-time\",\"title\":\"Expiration\",\"type\":\"string\"},\"rank\":{\"description\":\"If multiple tasks are indexed with the same `namespace` the task with the\\nhighest `rank` will be stored and returned in later requests. If two tasks\\nhas the same `rank` the latest task will be stored.\\n\",\"title\":\"Rank\",\"type\":\"number\"},\"taskId\":{\"description\":\"Unique task identifier, this is UUID encoded as\\n[URL-safe base64](http://tools.ietf.org/html/rfc4648#section-5) and\\nstripped of `=` padding.\\n\",\"pattern\":\"^[A-Za-z0-9_-]{8}[Q-T][A-Za-z0-9_-][CGKOSWaeimquy26-][A-Za-z0-9_-]{10}[AQgw]$\",\"title\":\"Task Identifier\",\"type\":\"string\"}},\"required\":[\"taskId\",\"rank\",\"data\",\"expires\"],\"title\":\"Insert Task Request\",\"type\":\"object\"}",
+	"/schemas/index/v1/list-namespaces-response.json":             "{\"$id\":\"/schemas/index/v1/list-namespaces-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response from a request to list namespaces within a given namespace.\\n\",\"properties\":{\"continuationToken\":{\"description\":\"A continuation token is returned if there are more results than listed\\nhere. You can optionally provide the token in the request payload to\\nload the additional results.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"},\"namespaces\":{\"description\":\"List of namespaces.\\n\",\"items\":{\"additionalProperties\":false,\"description\":\"Representation of a namespace that contains indexed tasks.\\n\",\"properties\":{\"expires\":{\"description\":\"Date at which this entry, and by implication all entries below it,\\nexpires from the task index.\\n\",\"format\":\"date-time\",\"title\":\"Expiration\",\"type\":\"string\"},\"name\":{\"description\":\"Name of namespace within it's parent namespace.\\n\",\"title\":\"Name\",\"type\":\"string\"},\"namespace\":{\"description\":\"Fully qualified name of the namespace, you can use this to list\\nnamespaces or tasks under this namespace.\\n\",\"maxLength\":255,\"title\":\"Namespace\",\"type\":\"string\"}},\"required\":[\"namespace\",\"name\",\"expires\"],\"title\":\"Namespace\",\"type\":\"object\"},\"title\":\"Namespaces\",\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"namespaces\"],\"title\":\"List Namespaces Response\",\"type\":\"object\"}",
+	"/schemas/index/v1/list-tasks-response.json":                  "{\"$id\":\"/schemas/index/v1/list-tasks-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Representation of an indexed task.\\n\",\"properties\":{\"continuationToken\":{\"description\":\"A continuation token is returned if there are more results than listed\\nhere. You can optionally provide the token in the request payload to\\nload the additional results.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"},\"tasks\":{\"description\":\"List of tasks.\\n\",\"items\":{\"additionalProperties\":false,\"description\":\"Representation of a task.\\n\",\"properties\":{\"data\":{\"description\":\"Data that was reported with the task. This is an arbitrary JSON\\nobject.\\n\",\"title\":\"Task Specific Data\",\"type\":\"object\"},\"expires\":{\"description\":\"Date at which this entry expires from the task index.\\n\",\"format\":\"date-time\",\"title\":\"Expiration\",\"type\":\"string\"},\"namespace\":{\"description\":\"Index path of the task.\\n\",\"maxLength\":255,\"title\":\"Namespace\",\"type\":\"string\"},\"rank\":{\"description\":\"If multiple tasks are indexed with the same `namespace` the task\\nwith the highest `rank` will be stored and returned in later\\nrequests. If two tasks has the same `rank` the latest task will be\\nstored.\\n\",\"title\":\"Rank\",\"type\":\"number\"},\"taskId\":{\"description\":\"Unique task identifier for the task currently indexed at `namespace`.\\n\",\"pattern\":\"^[A-Za-z0-9_-]{8}[Q-T][A-Za-z0-9_-][CGKOSWaeimquy26-][A-Za-z0-9_-]{10}[AQgw]$\",\"title\":\"Task Identifier\",\"type\":\"string\"}},\"required\":[\"namespace\",\"taskId\",\"rank\",\"data\",\"expires\"],\"title\":\"Task\",\"type\":\"object\"},\"title\":\"Tasks\",\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"tasks\"],\"title\":\"List Tasks Response\",\"type\":\"object\"}",
+	"/schemas/notify/v1/email-request.json":                       "{\"$id\":\"/schemas/notify/v1/email-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Request to send an email\\n\",\"properties\":{\"address\":{\"description\":\"E-mail address to which the message should be sent\\n\",\"format\":\"email\",\"type\":\"string\"},\"content\":{\"description\":\"Content of the e-mail as **markdown**, will be rendered to HTML before\\nthe email is sent. Notice that markdown allows for a few HTML tags, but\\nwon't allow inclusion of script tags and other unpleasantries.\\n\",\"maxLength\":102400,\"minLength\":1,\"type\":\"string\"},\"link\":{\"additionalProperties\":false,\"description\":\"Optional link that can be added as a button to the email.\\n\",\"properties\":{\"href\":{\"description\":\"Where the link should point to.\\n\",\"format\":\"uri\",\"maxLength\":1024,\"minLength\":1,\"type\":\"string\"},\"text\":{\"description\":\"Text to display on link.\\n\",\"maxLength\":40,\"minLength\":1,\"type\":\"string\"}},\"required\":[\"text\",\"href\"],\"type\":\"object\"},\"replyTo\":{\"description\":\"Reply-to e-mail (this property is optional)\\n\",\"format\":\"email\",\"type\":\"string\"},\"subject\":{\"description\":\"Subject line of the e-mail, this is plain-text\\n\",\"maxLength\":255,\"minLength\":1,\"type\":\"string\"},\"template\":{\"default\":\"simple\",\"description\":\"E-mail html template used to format your content.\\n\",\"enum\":[\"simple\",\"fullscreen\"],\"type\":\"string\"}},\"required\":[\"address\",\"subject\",\"content\"],\"title\":\"Send Email Request\",\"type\":\"object\"}",
+	"/schemas/notify/v1/irc-request.json":                         "{\"$id\":\"/schemas/notify/v1/irc-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"definitions\":{\"message\":{\"description\":\"IRC message to send as plain text.\\n\",\"maxLength\":510,\"minLength\":1,\"title\":\"IRC Message Text\",\"type\":\"string\"}},\"description\":\"Request to post a message on IRC.\\n\",\"oneOf\":[{\"additionalProperties\":false,\"properties\":{\"channel\":{\"description\":\"Channel to post the message in.\\n\",\"minLength\":1,\"pattern\":\"^[#&][^ ,\\\\u0007]{1,199}$\",\"title\":\"Channel Name\",\"type\":\"string\"},\"message\":{\"$ref\":\"#/definitions/message\"}},\"required\":[\"channel\",\"message\"],\"title\":\"Channel Message\",\"type\":\"object\"},{\"additionalProperties\":false,\"properties\":{\"message\":{\"$ref\":\"#/definitions/message\"},\"user\":{\"description\":\"User to post the message to.\\n\",\"maxLength\":255,\"minLength\":1,\"pattern\":\"^[A-Za-z\\\\[\\\\]\\\\\\\\~_\\\\^{|}][A-Za-z0-9\\\\-\\\\[\\\\]\\\\\\\\~_\\\\^{|}]{0,254}$\",\"title\":\"IRC Handle\",\"type\":\"string\"}},\"required\":[\"user\",\"message\"],\"title\":\"Private Message\",\"type\":\"object\"}],\"title\":\"Post IRC Message Request\"}",
+	"/schemas/notify/v1/matrix-request.json":                      "{\"$id\":\"/schemas/notify/v1/matrix-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Request to send a Matrix notice. Many of these fields are better understood by\\nchecking the matrix spec itself. The precise definitions of these fields is\\nbeyond the scope of this document.\\n\",\"properties\":{\"body\":{\"description\":\"Unformatted text that will be displayed in the room if you do not\\nspecify `formattedBody` or if a user's client can not render the format.\\n\",\"type\":\"string\"},\"format\":{\"description\":\"The format for `formattedBody`. For instance, `org.matrix.custom.html`\",\"type\":\"string\"},\"formattedBody\":{\"description\":\"Text that will be rendered by matrix clients that support the given\\nformat in that format. For instance, `<h1>Header Text</h1>`.\\n\",\"type\":\"string\"},\"msgtype\":{\"default\":\"m.notice\",\"description\":\"Which of the `m.room.message` msgtypes to use. At the moment only the\\ntypes that take `body`/`format`/`formattedBody` are supported.\\n\",\"enum\":[\"m.notice\",\"m.text\",\"m.emote\"],\"type\":\"string\"},\"roomId\":{\"description\":\"The fully qualified room name, such as `!whDRjjSmICCgrhFHsQ:mozilla.org`\\nIf you are using riot, you can find this under the advanced settings for a room.\\n\",\"type\":\"string\"}},\"required\":[\"roomId\",\"body\"],\"title\":\"Send Matrix Notice Request\",\"type\":\"object\"}",
+	"/schemas/notify/v1/notification-address-list.json":           "{\"$id\":\"/schemas/notify/v1/notification-address-list.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"List of notification addresses.\\n\",\"properties\":{\"addresses\":{\"items\":{\"$ref\":\"notification-address.json#\"},\"type\":\"array\",\"uniqueItems\":true},\"continuationToken\":{\"description\":\"A continuation token is returned if there are more results than listed\\nhere. You can optionally provide the token in the request payload to\\nload the additional results.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"}},\"required\":[\"addresses\"],\"title\":\"List of notification adresses\",\"type\":\"object\"}",
+	"/schemas/notify/v1/notification-address.json":                "{\"$id\":\"/schemas/notify/v1/notification-address.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Type of notification and its corresponding address.\\n\",\"properties\":{\"notificationAddress\":{\"type\":\"string\"},\"notificationType\":{\"enum\":[\"email\",\"pulse\",\"irc-user\",\"irc-channel\",\"matrix-room\"],\"type\":\"string\"}},\"required\":[\"notificationType\",\"notificationAddress\"],\"title\":\"Notification Type And Address\",\"type\":\"object\"}",
+	"/schemas/notify/v1/pulse-request.json":                       "{\"$id\":\"/schemas/notify/v1/pulse-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Request to post a message on pulse.\\n\",\"properties\":{\"message\":{\"description\":\"Pulse message to send as plain text.\\n\",\"type\":\"object\"},\"routingKey\":{\"description\":\"Routing-key to use when posting the message.\\n\",\"maxLength\":255,\"type\":\"string\"}},\"required\":[\"routingKey\",\"message\"],\"title\":\"Post Pulse Message Request\",\"type\":\"object\"}",
+	"/schemas/purge-cache/v1/all-purge-cache-request-list.json":   "{\"$id\":\"/schemas/purge-cache/v1/all-purge-cache-request-list.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A list of currently open purge-cache requests. Should not be used by workers.\\n\",\"properties\":{\"continuationToken\":{\"description\":\"Passed back from Azure to allow us to page through long result sets.\",\"type\":\"string\"},\"requests\":{\"$ref\":\"purge-cache-requests.json#\"}},\"required\":[\"requests\"],\"title\":\"Open All Purge Requests List\",\"type\":\"object\"}",
+	"/schemas/purge-cache/v1/purge-cache-request-list.json":       "{\"$id\":\"/schemas/purge-cache/v1/purge-cache-request-list.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A list of currently open purge-cache requests.\\n\",\"properties\":{\"requests\":{\"$ref\":\"purge-cache-requests.json#\"}},\"required\":[\"requests\"],\"title\":\"Open Purge Request List\",\"type\":\"object\"}",
+	"/schemas/purge-cache/v1/purge-cache-request.json":            "{\"$id\":\"/schemas/purge-cache/v1/purge-cache-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Request that a message be published to purge a specific cache.\\n\",\"properties\":{\"cacheName\":{\"description\":\"Name of cache to purge. Notice that if a `workerType` have multiple kinds\\nof caches (with independent names), it should purge all caches identified\\nby `cacheName` regardless of cache type.\\n\",\"type\":\"string\"}},\"required\":[\"cacheName\"],\"title\":\"Purge Cache Request\",\"type\":\"object\"}",
+	"/schemas/purge-cache/v1/purge-cache-requests.json":           "{\"$id\":\"/schemas/purge-cache/v1/purge-cache-requests.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"description\":\"A list of Purge Cache requests that the Purge Cache service has previously received.\\n\",\"items\":{\"additionalProperties\":false,\"description\":\"An entry in a list of Purge Cache Requests that the Purge Cache service has previously received.\\n\",\"properties\":{\"before\":{\"description\":\"All caches that match this provisionerId, workerType, and cacheName must be destroyed if they were created _before_ this time.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"cacheName\":{\"description\":\"Name of cache to purge.\",\"type\":\"string\"},\"provisionerId\":{\"description\":\"ProvisionerId associated with the workerType.\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"},\"workerType\":{\"description\":\"Workertype cache exists on.\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"}},\"required\":[\"provisionerId\",\"workerType\",\"cacheName\",\"before\"],\"title\":\"Purge Cache Requests Entry\",\"type\":\"object\"},\"title\":\"Purge Cache Requests\",\"type\":\"array\",\"uniqueItems\":false}",
+	"/schemas/queue/v1/actions.json":                              "{\"$id\":\"/schemas/queue/v1/actions.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"description\":\"See taskcluster [actions](/docs/reference/platform/taskcluster-queue/docs/actions) documentation.\\n\",\"items\":{\"additionalProperties\":false,\"description\":\"Actions provide a generic mechanism to expose additional features of a\\nprovisioner, worker type, or worker to Taskcluster clients.\\n\\nAn action is comprised of metadata describing the feature it exposes,\\ntogether with a webhook for triggering it.\\n\\nThe Taskcluster tools site, for example, retrieves actions when displaying\\nprovisioners, worker types and workers. It presents the provisioner/worker\\ntype/worker specific actions to the user. When the user triggers an action,\\nthe web client takes the registered webhook, substitutes parameters into the\\nURL (see `url`), signs the requests with the Taskcluster credentials of the\\nuser operating the web interface, and issues the HTTP request.\\n\\nThe level to which the action relates (provisioner, worker type, worker) is\\ncalled the action context. All actions, regardless of the action contexts,\\nare registered against the provisioner when calling\\n`queue.declareProvisioner`.\\n\\nThe action context is used by the web client to determine where in the web\\ninterface to present the action to the user as follows:\\n\\n| `context`   | Tool where action is displayed |\\n|-------------|--------------------------------|\\n| provisioner | Provisioner Explorer           |\\n| worker-type | Workers Explorer               |\\n| worker      | Worker Explorer                |\\n\\nSee [actions docs](/docs/reference/platform/taskcluster-queue/docs/actions)\\nfor more information.\\n\",\"properties\":{\"context\":{\"description\":\"Actions have a \\\"context\\\" that is one of provisioner, worker-type, or worker, indicating\\nwhich it applies to. `context` is used by the front-end to know where to display the action.\\n\\n| `context`   | Page displayed        |\\n|-------------|-----------------------|\\n| provisioner | Provisioner Explorer  |\\n| worker-type | Workers Explorer      |\\n| worker      | Worker Explorer       |\\n\",\"enum\":[\"provisioner\",\"worker-type\",\"worker\"],\"title\":\"Context\",\"type\":\"string\"},\"description\":{\"description\":\"Description of the provisioner.\\n\",\"title\":\"Description\",\"type\":\"string\"},\"method\":{\"description\":\"Method to indicate the desired action to be performed for a given resource.\\n\",\"enum\":[\"POST\",\"PUT\",\"DELETE\",\"PATCH\"],\"title\":\"Method\",\"type\":\"string\"},\"name\":{\"description\":\"Short names for things like logging/error messages.\\n\",\"title\":\"Name\",\"type\":\"string\"},\"title\":{\"description\":\"Appropriate title for any sort of Modal prompt.\\n\",\"title\":\"Title\"},\"url\":{\"description\":\"When an action is triggered, a request is made using the `url` and `method`.\\nDepending on the `context`, the following parameters will be substituted in the url:\\n\\n| `context`   | Path parameters                                          |\\n|-------------|----------------------------------------------------------|\\n| provisioner | <provisionerId>                                          |\\n| worker-type | <provisionerId>, <workerType>                            |\\n| worker      | <provisionerId>, <workerType>, <workerGroup>, <workerId> |\\n\\n_Note: The request needs to be signed with the user's Taskcluster credentials._\\n\",\"title\":\"URL\",\"type\":\"string\"}},\"required\":[\"name\",\"title\",\"context\",\"url\",\"method\",\"description\"],\"title\":\"Action\",\"type\":\"object\"},\"title\":\"Actions\",\"type\":\"array\",\"uniqueItems\":true}",
+	"/schemas/queue/v1/claim-work-request.json":                   "{\"$id\":\"/schemas/queue/v1/claim-work-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Request to claim a task for a worker to process.\\n\",\"properties\":{\"tasks\":{\"default\":1,\"description\":\"Number of tasks to attempt to claim.\\n\",\"maximum\":32,\"minimum\":1,\"type\":\"integer\"},\"workerGroup\":{\"description\":\"Identifier for group that worker claiming the task is a part of.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"},\"workerId\":{\"description\":\"Identifier for worker within the given workerGroup\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"}},\"required\":[\"workerGroup\",\"workerId\",\"tasks\"],\"title\":\"Claim Work Request\",\"type\":\"object\"}",
+	"/schemas/queue/v1/claim-work-response.json":                  "{\"$id\":\"/schemas/queue/v1/claim-work-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response to an attempt to claim tasks for a worker to process.\\n\",\"properties\":{\"tasks\":{\"description\":\"List of task claims, may be empty if no tasks was claimed, in which case\\nthe worker should sleep a tiny bit before polling again.\\n\",\"items\":{\"additionalProperties\":false,\"properties\":{\"credentials\":{\"$ref\":\"task-credentials.json#\"},\"runId\":{\"description\":\"`run-id` assigned to this run of the task\\n\",\"maximum\":1000,\"minimum\":0,\"type\":\"integer\"},\"status\":{\"$ref\":\"task-status.json#\"},\"takenUntil\":{\"description\":\"Time at which the run expires and is resolved as `exception`,\\nwith reason `claim-expired` if the run haven't been reclaimed.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"task\":{\"$ref\":\"task.json#\"},\"workerGroup\":{\"description\":\"Identifier for the worker-group within which this run started.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"},\"workerId\":{\"description\":\"Identifier for the worker executing this run.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"}},\"required\":[\"status\",\"runId\",\"workerGroup\",\"workerId\",\"takenUntil\",\"task\",\"credentials\"],\"title\":\"Task Claim\",\"type\":\"object\"},\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"tasks\"],\"title\":\"Claim Work Response\",\"type\":\"object\"}",
+	"/schemas/queue/v1/create-task-request.json":                  "{\"$id\":\"/schemas/queue/v1/create-task-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Definition of a task that can be scheduled\\n\",\"properties\":{\"created\":{\"description\":\"Creation time of task\",\"format\":\"date-time\",\"title\":\"Created\",\"type\":\"string\"},\"deadline\":{\"description\":\"Deadline of the task, by which this task must be complete. `pending` and\\n`running` runs are resolved as **exception** if not resolved by other means\\nbefore the deadline. After the deadline, a task is immutable. Note,\\ndeadline cannot be more than 5 days into the future\\n\",\"format\":\"date-time\",\"title\":\"Deadline\",\"type\":\"string\"},\"dependencies\":{\"$ref\":\"task.json#/properties/dependencies\",\"default\":[]},\"expires\":{\"$ref\":\"task.json#/properties/expires\"},\"extra\":{\"$ref\":\"task.json#/properties/extra\",\"default\":{}},\"metadata\":{\"$ref\":\"task-metadata.json#\"},\"payload\":{\"$ref\":\"task.json#/properties/payload\",\"default\":[]},\"priority\":{\"$ref\":\"task.json#/properties/priority\",\"default\":\"lowest\"},\"provisionerId\":{\"$ref\":\"task.json#/properties/provisionerId\"},\"requires\":{\"$ref\":\"task.json#/properties/requires\",\"default\":\"all-completed\"},\"retries\":{\"$ref\":\"task.json#/properties/retries\",\"default\":5},\"routes\":{\"$ref\":\"task.json#/properties/routes\",\"default\":[]},\"schedulerId\":{\"$ref\":\"task.json#/properties/schedulerId\",\"default\":\"-\"},\"scopes\":{\"$ref\":\"task.json#/properties/scopes\",\"default\":[]},\"tags\":{\"$ref\":\"task.json#/properties/tags\",\"default\":{}},\"taskGroupId\":{\"$ref\":\"task.json#/properties/taskGroupId\"},\"workerType\":{\"$ref\":\"task.json#/properties/workerType\"}},\"required\":[\"provisionerId\",\"workerType\",\"created\",\"deadline\",\"payload\",\"metadata\"],\"title\":\"Task Definition Request\",\"type\":\"object\"}",
+	"/schemas/queue/v1/list-artifacts-response.json":              "{\"$id\":\"/schemas/queue/v1/list-artifacts-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"List of artifacts for a given `taskId` and `runId`.\\n\",\"properties\":{\"artifacts\":{\"description\":\"List of artifacts for given `taskId` and `runId`.\\n\",\"items\":{\"additionalProperties\":false,\"description\":\"Information about an artifact for the given `taskId` and `runId`.\\n\",\"properties\":{\"contentType\":{\"description\":\"Mimetype for the artifact that was created.\\n\",\"maxLength\":255,\"title\":\"Content-Type\",\"type\":\"string\"},\"expires\":{\"description\":\"Date and time after which the artifact created will be automatically\\ndeleted by the queue.\\n\",\"format\":\"date-time\",\"title\":\"Artifact Expiration\",\"type\":\"string\"},\"name\":{\"description\":\"Name of the artifact that was created, this is useful if you want to\\nattempt to fetch the artifact.\\n\",\"maxLength\":1024,\"title\":\"Artifact Name\",\"type\":\"string\"},\"storageType\":{\"description\":\"This is the `storageType` for the request that was used to create\\nthe artifact.\\n\",\"enum\":[\"s3\",\"reference\",\"error\"],\"title\":\"Artifact Storage-Type\",\"type\":\"string\"}},\"required\":[\"storageType\",\"name\",\"expires\",\"contentType\"],\"title\":\"Artifact\",\"type\":\"object\"},\"title\":\"Artifact List\",\"type\":\"array\",\"uniqueItems\":true},\"continuationToken\":{\"description\":\"Opaque `continuationToken` to be given as query-string option to get the\\nnext set of artifacts.\\nThis property is only present if another request is necessary to fetch all\\nresults. In practice the next request with a `continuationToken` may not\\nreturn additional results, but it can. Thus, you can only be sure to have\\nall the results if you've called with `continuationToken` until you get a\\nresult without a `continuationToken`.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"}},\"required\":[\"artifacts\"],\"title\":\"List Artifacts Response\",\"type\":\"object\"}",
+	"/schemas/queue/v1/list-dependent-tasks-response.json":        "{\"$id\":\"/schemas/queue/v1/list-dependent-tasks-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response from a `listDependentTasks` request.\\n\",\"properties\":{\"continuationToken\":{\"description\":\"Opaque `continuationToken` to be given as query-string option to get the\\nnext set of dependent tasks.\\nThis property is only present if another request is necessary to fetch all\\nresults. In practice the next request with a `continuationToken` may not\\nreturn additional results, but it can. Thus, you can only be sure to have\\nall the results if you've called `listDependentTasks` with\\n`continuationToken` until you get a result without a `continuationToken`.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"},\"taskId\":{\"description\":\"Identifier for the task whose dependents are being listed.\\n\",\"pattern\":\"^[A-Za-z0-9_-]{8}[Q-T][A-Za-z0-9_-][CGKOSWaeimquy26-][A-Za-z0-9_-]{10}[AQgw]$\",\"title\":\"Task Identifier\",\"type\":\"string\"},\"tasks\":{\"description\":\"List of tasks that have `taskId` in the `task.dependencies` property.\\n\",\"items\":{\"$ref\":\"task-definition-and-status.json#\"},\"title\":\"Tasks that depend on `taskId`\",\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"taskId\",\"tasks\"],\"title\":\"List Dependent Tasks Response\",\"type\":\"object\"}",
+	"/schemas/queue/v1/list-provisioners-response.json":           "{\"$id\":\"/schemas/queue/v1/list-provisioners-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"properties\":{\"continuationToken\":{\"description\":\"Opaque `continuationToken` to be given as query-string option to get the\\nnext set of provisioners.\\nThis property is only present if another request is necessary to fetch all\\nresults. In practice the next request with a `continuationToken` may not\\nreturn additional results, but it can. Thus, you can only be sure to have\\nall the results if you've called with `continuationToken` until you get a\\nresult without a `continuationToken`.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"},\"provisioners\":{\"items\":{\"additionalProperties\":false,\"properties\":{\"actions\":{\"$ref\":\"actions.json#\"},\"description\":{\"description\":\"Description of the provisioner.\\n\",\"title\":\"Description\",\"type\":\"string\"},\"expires\":{\"description\":\"Date and time after which the provisioner created will be automatically\\ndeleted by the queue.\\n\",\"format\":\"date-time\",\"title\":\"Provisioner Expiration\",\"type\":\"string\"},\"lastDateActive\":{\"description\":\"Date and time where the provisioner was last seen active\\n\",\"format\":\"date-time\",\"title\":\"Provisioner Last Date Active\",\"type\":\"string\"},\"provisionerId\":{\"$ref\":\"task.json#/properties/provisionerId\"},\"stability\":{\"description\":\"This is the stability of the provisioner. Accepted values:\\n * `experimental`\\n * `stable`\\n * `deprecated`\\n\",\"enum\":[\"experimental\",\"stable\",\"deprecated\"],\"title\":\"Stability\",\"type\":\"string\"}},\"required\":[\"provisionerId\",\"description\",\"stability\",\"expires\",\"lastDateActive\",\"actions\"],\"title\":\"Provisioner Information\",\"type\":\"object\"},\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"provisioners\"],\"title\":\"List Provisioners Response\",\"type\":\"object\"}",
+	"/schemas/queue/v1/list-task-group-response.json":             "{\"$id\":\"/schemas/queue/v1/list-task-group-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response from a `listTaskGroup` request.\\n\",\"properties\":{\"continuationToken\":{\"description\":\"Opaque `continuationToken` to be given as query-string option to get the\\nnext set of tasks in the task-group.\\nThis property is only present if another request is necessary to fetch all\\nresults. In practice the next request with a `continuationToken` may not\\nreturn additional results, but it can. Thus, you can only be sure to have\\nall the results if you've called `listTaskGroup` with `continuationToken`\\nuntil you get a result without a `continuationToken`.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"},\"taskGroupId\":{\"description\":\"Identifier for the task-group being listed.\\n\",\"pattern\":\"^[A-Za-z0-9_-]{8}[Q-T][A-Za-z0-9_-][CGKOSWaeimquy26-][A-Za-z0-9_-]{10}[AQgw]$\",\"title\":\"Task-Group Identifier\",\"type\":\"string\"},\"tasks\":{\"description\":\"List of tasks in this task-group.\\n\",\"items\":{\"$ref\":\"task-definition-and-status.json#\"},\"title\":\"Tasks from the Task-Group\",\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"taskGroupId\",\"tasks\"],\"title\":\"List Task-Group Response\",\"type\":\"object\"}",
+	"/schemas/queue/v1/list-workers-response.json":                "{\"$id\":\"/schemas/queue/v1/list-workers-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response from a `listWorkers` request.\\n\",\"properties\":{\"continuationToken\":{\"description\":\"Opaque `continuationToken` to be given as query-string option to get the\\nnext set of workers in the worker-type.\\nThis property is only present if another request is necessary to fetch all\\nresults. In practice the next request with a `continuationToken` may not\\nreturn additional results, but it can. Thus, you can only be sure to have\\nall the results if you've called `listWorkerTypes` with `continuationToken`\\nuntil you get a result without a `continuationToken`.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"},\"workers\":{\"description\":\"List of workers in this worker-type.\\n\",\"items\":{\"additionalProperties\":false,\"properties\":{\"firstClaim\":{\"description\":\"Date of the first time this worker claimed a task.\\n\",\"format\":\"date-time\",\"title\":\"First task claimed\",\"type\":\"string\"},\"latestTask\":{\"$ref\":\"task-run.json#\",\"description\":\"The most recent claimed task\\n\",\"title\":\"Most Recent Task\"},\"quarantineUntil\":{\"description\":\"Quarantining a worker allows the machine to remain alive but not accept jobs.\\nOnce the quarantineUntil time has elapsed, the worker resumes accepting jobs.\\nNote that a quarantine can be lifted by setting `quarantineUntil` to the present time (or\\nsomewhere in the past).\\n\",\"format\":\"date-time\",\"title\":\"Worker Quarantine\",\"type\":\"string\"},\"workerGroup\":{\"description\":\"Identifier for the worker group containing this worker.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"},\"workerId\":{\"description\":\"Identifier for this worker (unique within this worker group).\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"}},\"required\":[\"workerGroup\",\"workerId\",\"firstClaim\"],\"title\":\"Worker\",\"type\":\"object\"},\"title\":\"Workers from a WorkerType\",\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"workers\"],\"title\":\"List Workers Response\",\"type\":\"object\"}",
+	"/schemas/queue/v1/list-workertypes-response.json":            "{\"$id\":\"/schemas/queue/v1/list-workertypes-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response from a `listWorkerTypes` request.\\n\",\"properties\":{\"continuationToken\":{\"description\":\"Opaque `continuationToken` to be given as query-string option to get the\\nnext set of worker-types in the provisioner.\\nThis property is only present if another request is necessary to fetch all\\nresults. In practice the next request with a `continuationToken` may not\\nreturn additional results, but it can. Thus, you can only be sure to have\\nall the results if you've called `listWorkerTypes` with `continuationToken`\\nuntil you get a result without a `continuationToken`.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"},\"workerTypes\":{\"description\":\"List of worker-types in this provisioner.\\n\",\"items\":{\"additionalProperties\":false,\"properties\":{\"description\":{\"description\":\"Description of the worker-type.\\n\",\"title\":\"Description\",\"type\":\"string\"},\"expires\":{\"description\":\"Date and time after which the worker-type will be automatically\\ndeleted by the queue.\\n\",\"format\":\"date-time\",\"title\":\"Worker-type Expiration\",\"type\":\"string\"},\"lastDateActive\":{\"description\":\"Date and time where the worker-type was last seen active\\n\",\"format\":\"date-time\",\"title\":\"Worker-type Last Date Active\",\"type\":\"string\"},\"provisionerId\":{\"$ref\":\"task.json#/properties/provisionerId\"},\"stability\":{\"description\":\"This is the stability of the worker-type. Accepted values:\\n * `experimental`\\n * `stable`\\n * `deprecated`\\n\",\"enum\":[\"experimental\",\"stable\",\"deprecated\"],\"title\":\"Stability\",\"type\":\"string\"},\"workerType\":{\"$ref\":\"task.json#/properties/workerType\"}},\"required\":[\"workerType\",\"provisionerId\",\"stability\",\"description\",\"expires\",\"lastDateActive\"],\"title\":\"Worker Type\",\"type\":\"object\"},\"title\":\"WorkerTypes from the Provisioner\",\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"workerTypes\"],\"title\":\"List Worker-Types Response\",\"type\":\"object\"}",
+	"/schemas/queue/v1/pending-tasks-response.json":               "{\"$id\":\"/schemas/queue/v1/pending-tasks-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response to a request for the number of pending tasks for a given\\n`provisionerId` and `workerType`.\\n\",\"properties\":{\"pendingTasks\":{\"description\":\"An approximate number of pending tasks for the given `provisionerId` and\\n`workerType`. This is based on Azure Queue Storage metadata API, thus,\\nnumber of reported here may be higher than actual number of pending tasks.\\nBut there cannot be more pending tasks reported here. Ie. this is an\\n**upper-bound** on the number of pending tasks.\\n\",\"minimum\":0,\"title\":\"Number of Pending Tasks\",\"type\":\"integer\"},\"provisionerId\":{\"$ref\":\"task.json#/properties/provisionerId\"},\"workerType\":{\"$ref\":\"task.json#/properties/workerType\"}},\"required\":[\"provisionerId\",\"workerType\",\"pendingTasks\"],\"title\":\"Count Pending Tasks Response\",\"type\":\"object\"}",
+	"/schemas/queue/v1/post-artifact-request.json":                "{\"$id\":\"/schemas/queue/v1/post-artifact-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"description\":\"Request a authorization to put and artifact or posting of a URL as an artifact. Note that the `storageType` property is referenced in the response as well.\",\"oneOf\":[{\"additionalProperties\":false,\"description\":\"Request for a signed PUT URL that will allow you to upload an artifact\\nto an S3 bucket managed by the queue.\\n\",\"properties\":{\"contentType\":{\"description\":\"Artifact mime-type, when uploading artifact to the signed\\n`PUT` URL returned from this request this must given with the\\n `ContentType` header. Please, provide correct mime-type,\\n this make tooling a lot easier, specifically,\\n always using `application/json` for JSON artifacts.\\n\",\"maxLength\":255,\"type\":\"string\"},\"expires\":{\"description\":\"Date-time after which the artifact should be deleted. Note, that\\nthese will be collected over time, and artifacts may remain\\navailable after expiration. S3 based artifacts are identified in\\nazure table storage and explicitly deleted on S3 after expiration.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"storageType\":{\"description\":\"Artifact storage type, in this case `'s3'`\\n\",\"enum\":[\"s3\"],\"type\":\"string\"}},\"required\":[\"storageType\",\"expires\",\"contentType\"],\"title\":\"S3 Artifact Request\",\"type\":\"object\"},{\"additionalProperties\":false,\"description\":\"Request the queue to redirect to a URL for a given artifact.\\nThis allows you to reference artifacts that aren't managed by the queue.\\nThe queue will still authenticate the request, so depending on the level\\nof secrecy required, secret URLs **might** work. Note, this is mainly\\nuseful for public artifacts, for example temporary files directly\\nstored on the worker host and only available there for a specific\\namount of time.\\n\",\"properties\":{\"contentType\":{\"description\":\"Artifact mime-type for the resource to which the queue should\\nredirect. Please use the same `Content-Type`, consistently using\\nthe correct mime-type make tooling a lot easier, specifically,\\nalways using `application/json` for JSON artifacts.\\n\",\"maxLength\":255,\"type\":\"string\"},\"expires\":{\"description\":\"Date-time after which the queue should no longer redirect to this URL.\\nNote, that the queue will and cannot delete the resource your URL\\nreferences, you are responsible for doing that yourself.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"storageType\":{\"description\":\"Artifact storage type, in this case `reference`\\n\",\"enum\":[\"reference\"],\"type\":\"string\"},\"url\":{\"description\":\"URL to which the queue should redirect using a `303` (See other)\\nredirect.\\n\",\"format\":\"uri\",\"type\":\"string\"}},\"required\":[\"storageType\",\"expires\",\"url\",\"contentType\"],\"title\":\"Redirect Artifact Request\",\"type\":\"object\"},{\"additionalProperties\":false,\"description\":\"Request the queue to reply `424` (Failed Dependency) with `reason` and \\n`message` to any `GET` request for this artifact. This is mainly useful\\nas a way for a task to declare that it failed to provide an artifact it\\nwanted to upload.\\n\",\"properties\":{\"expires\":{\"description\":\"Date-time after which the queue should stop replying with the error\\nand forget about the artifact.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"message\":{\"description\":\"Human readable explanation of why the artifact is missing\\n\",\"maxLength\":4096,\"type\":\"string\"},\"reason\":{\"description\":\"Reason why the artifact doesn't exist.\\n\",\"enum\":[\"file-missing-on-worker\",\"invalid-resource-on-worker\",\"too-large-file-on-worker\"],\"type\":\"string\"},\"storageType\":{\"description\":\"Artifact storage type, in this case `error`\\n\",\"enum\":[\"error\"],\"type\":\"string\"}},\"required\":[\"storageType\",\"expires\",\"reason\",\"message\"],\"title\":\"Error Artifact Request\",\"type\":\"object\"}],\"title\":\"Post Artifact Request\"}",
+	"/schemas/queue/v1/post-artifact-response.json":               "{\"$id\":\"/schemas/queue/v1/post-artifact-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"description\":\"Response to a request for posting an artifact.\\nNote that the `storageType` property is referenced in the request as well.\\n\",\"oneOf\":[{\"additionalProperties\":false,\"description\":\"Response to a request for a signed PUT URL that will allow you to\\nupload an artifact to an S3 bucket managed by the queue.\\n\",\"properties\":{\"contentType\":{\"description\":\"Artifact mime-type, must be specified as header when uploading with\\nthe signed `putUrl`.\\n\",\"maxLength\":255,\"type\":\"string\"},\"expires\":{\"description\":\"Date-time after which the signed `putUrl` no longer works\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"putUrl\":{\"description\":\"URL to which a `PUT` request can be made to upload the artifact\\nrequested. Note, the `Content-Length` must be specified correctly,\\nand the `ContentType` header must be set the value specified below.\\n\",\"format\":\"uri\",\"type\":\"string\"},\"storageType\":{\"description\":\"Artifact storage type, in this case `'s3'`\\n\",\"enum\":[\"s3\"],\"type\":\"string\"}},\"required\":[\"storageType\",\"putUrl\",\"expires\",\"contentType\"],\"title\":\"S3 Artifact Response\",\"type\":\"object\"},{\"additionalProperties\":false,\"description\":\"Response to a request for the queue to redirect to a URL for a given\\nartifact.\\n\",\"properties\":{\"storageType\":{\"description\":\"Artifact storage type, in this case `reference`\\n\",\"enum\":[\"reference\"],\"type\":\"string\"}},\"required\":[\"storageType\"],\"title\":\"Redirect Artifact Response\",\"type\":\"object\"},{\"additionalProperties\":false,\"description\":\"Response to a request for the queue to reply `424` (Failed Dependency)\\nwith `reason` and `message` to any `GET` request for this artifact.\\n\",\"properties\":{\"storageType\":{\"description\":\"Artifact storage type, in this case `error`\\n\",\"enum\":[\"error\"],\"type\":\"string\"}},\"required\":[\"storageType\"],\"title\":\"Error Artifact Response\",\"type\":\"object\"}],\"title\":\"Post Artifact Response\"}",
+	"/schemas/queue/v1/provisioner-response.json":                 "{\"$id\":\"/schemas/queue/v1/provisioner-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response containing information about a provisioner.\\n\",\"properties\":{\"actions\":{\"$ref\":\"actions.json#\"},\"description\":{\"description\":\"Description of the provisioner.\\n\",\"title\":\"Description\",\"type\":\"string\"},\"expires\":{\"description\":\"Date and time after which the provisioner will be automatically\\ndeleted by the queue.\\n\",\"format\":\"date-time\",\"title\":\"Provisioner Expiration\",\"type\":\"string\"},\"lastDateActive\":{\"description\":\"Date of the last time this provisioner was seen active. `lastDateActive` is updated every 6 hours\\nbut may be off by up-to 6 hours. Nonetheless, `lastDateActive` is a good indicator\\nof when the provisioner was last seen active.\\n\",\"format\":\"date-time\",\"title\":\"Provisioner Last Date Active\",\"type\":\"string\"},\"provisionerId\":{\"$ref\":\"task.json#/properties/provisionerId\"},\"stability\":{\"description\":\"This is the stability of the provisioner. Accepted values:\\n  * `experimental`\\n  * `stable`\\n  * `deprecated`\\n\",\"enum\":[\"experimental\",\"stable\",\"deprecated\"],\"title\":\"Stability\",\"type\":\"string\"}},\"required\":[\"provisionerId\",\"description\",\"actions\",\"stability\",\"expires\",\"lastDateActive\"],\"title\":\"Provisioner Response\",\"type\":\"object\"}",
+	"/schemas/queue/v1/quarantine-worker-request.json":            "{\"$id\":\"/schemas/queue/v1/quarantine-worker-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Request to update a worker's quarantineUntil property.\\n\",\"properties\":{\"quarantineUntil\":{\"description\":\"Quarantining a worker allows the machine to remain alive but not accept jobs.\\nOnce the quarantineUntil time has elapsed, the worker resumes accepting jobs.\\nNote that a quarantine can be lifted by setting `quarantineUntil` to the present time (or\\nsomewhere in the past).\\n\",\"format\":\"date-time\",\"title\":\"Worker Quarantine\",\"type\":\"string\"}},\"required\":[\"quarantineUntil\"],\"title\":\"Quarantine Worker Request\",\"type\":\"object\"}",
+	"/schemas/queue/v1/task-claim-request.json":                   "{\"$id\":\"/schemas/queue/v1/task-claim-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Request to claim (or reclaim) a task\\n\",\"properties\":{\"workerGroup\":{\"description\":\"Identifier for group that worker claiming the task is a part of.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"},\"workerId\":{\"description\":\"Identifier for worker within the given workerGroup\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"}},\"required\":[\"workerGroup\",\"workerId\"],\"title\":\"Task Claim Request\",\"type\":\"object\"}",
+	"/schemas/queue/v1/task-claim-response.json":                  "{\"$id\":\"/schemas/queue/v1/task-claim-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response to a successful task claim\\n\",\"properties\":{\"credentials\":{\"$ref\":\"task-credentials.json#\"},\"runId\":{\"description\":\"`run-id` assigned to this run of the task\\n\",\"maximum\":1000,\"minimum\":0,\"type\":\"integer\"},\"status\":{\"$ref\":\"task-status.json#\"},\"takenUntil\":{\"description\":\"Time at which the run expires and is resolved as `exception`,\\nwith reason `claim-expired` if the run haven't been reclaimed.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"task\":{\"$ref\":\"task.json#\"},\"workerGroup\":{\"description\":\"Identifier for the worker-group within which this run started.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"},\"workerId\":{\"description\":\"Identifier for the worker executing this run.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"}},\"required\":[\"status\",\"runId\",\"workerGroup\",\"workerId\",\"takenUntil\",\"task\",\"credentials\"],\"title\":\"Task Claim Response\",\"type\":\"object\"}",
+	"/schemas/queue/v1/task-credentials.json":                     "{\"$id\":\"/schemas/queue/v1/task-credentials.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Temporary credentials granting `task.scopes` and the scope:\\n`queue:claim-task:<taskId>/<runId>` which allows the worker to reclaim\\nthe task, upload artifacts and report task resolution.\\n\\nThe temporary credentials are set to expire after `takenUntil`. They\\nwon't expire exactly at `takenUntil` but shortly after, hence, requests\\ncoming close `takenUntil` won't have problems even if there is a little\\nclock drift.\\n\\nWorkers should use these credentials when making requests on behalf of\\na task. This includes requests to create artifacts, reclaiming the task\\nreporting the task `completed`, `failed` or `exception`.\\n\\nNote, a new set of temporary credentials is issued when the worker\\nreclaims the task.\\n\",\"properties\":{\"accessToken\":{\"description\":\"The `accessToken` for the temporary credentials.\\n\",\"minLength\":1,\"type\":\"string\"},\"certificate\":{\"description\":\"The `certificate` for the temporary credentials, these are required\\nfor the temporary credentials to work.\\n\",\"minLength\":1,\"type\":\"string\"},\"clientId\":{\"description\":\"The `clientId` for the temporary credentials.\\n\",\"minLength\":1,\"type\":\"string\"}},\"required\":[\"clientId\",\"accessToken\",\"certificate\"],\"title\":\"Task Credentials\",\"type\":\"object\"}",
+	"/schemas/queue/v1/task-definition-and-status.json":           "{\"$id\":\"/schemas/queue/v1/task-definition-and-status.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Task Definition and task status structure.\\n\",\"properties\":{\"status\":{\"$ref\":\"task-status.json#\"},\"task\":{\"$ref\":\"task.json#\"}},\"required\":[\"task\",\"status\"],\"title\":\"Task definition and status\",\"type\":\"object\"}",
+	"/schemas/queue/v1/task-exception-request.json":               "{\"$id\":\"/schemas/queue/v1/task-exception-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Request for a run of a task to be resolved with an exception\\n\",\"properties\":{\"reason\":{\"description\":\"Reason that the task is resolved with an exception. This is a subset\\nof the values for `resolvedReason` given in the task status structure.\\n**Report `worker-shutdown`** if the run failed because the worker\\nhad to shutdown (spot node disappearing). In case of `worker-shutdown`\\nthe queue will immediately **retry** the task, by making a new run.\\nThis is much faster than ignoreing the issue and letting the task _retry_\\nby claim expiration. For any other _reason_ reported the queue will not\\nretry the task.\\n**Report `malformed-payload`** if the `task.payload` doesn't match the\\nschema for the worker payload, or referenced resource doesn't exists.\\nIn either case, you should still log the error to a log file for the\\nspecific run.\\n**Report `resource-unavailable`** if a resource/service needed or\\nreferenced in `task.payload` is _temporarily_ unavailable. Do not use this\\nunless you know the resource exists, if the resource doesn't exist you\\nshould report `malformed-payload`. Example use-case if you contact the\\nindex (a service) on behalf of the task, because of a declaration in\\n`task.payload`, and the service (index) is temporarily down. Don't use\\nthis if a URL returns 404, but if it returns 503 or hits a timeout when\\nyou retry the request, then this _may_ be a valid exception. The queue\\nassumes that workers have applied retries as needed, and will not retry\\n the task.\\n**Report `internal-error`** if the worker experienced an unhandled internal\\nerror from which it couldn't recover. The queue will not retry runs\\nresolved with this reason, but you are clearly signaling that this is a\\nbug in the worker code.\\n**Report `superseded`** if the task was determined to have been\\nsuperseded by another task, and its results are no longer needed.  It is\\nconvention in this case to create an artifact entitled\\n`public/superseded-by` containing the taskId of the task that superseded\\nthis one.\\n**Report `intermittent-task`** if the task explicitly requested a retry\\nbecause task is intermittent. Workers can choose whether or not to\\nsupport this, but workers shouldn't blindly report this for every task\\nthat fails.\\n\",\"enum\":[\"worker-shutdown\",\"malformed-payload\",\"resource-unavailable\",\"internal-error\",\"superseded\",\"intermittent-task\"],\"type\":\"string\"}},\"required\":[\"reason\"],\"title\":\"Task Exception Request\",\"type\":\"object\"}",
+	"/schemas/queue/v1/task-metadata.json":                        "{\"$id\":\"/schemas/queue/v1/task-metadata.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Required task metadata\\n\",\"properties\":{\"description\":{\"description\":\"Human readable description of the task, please **explain** what the\\ntask does. A few lines of documentation is not going to hurt you.\\n\",\"maxLength\":32768,\"title\":\"Description\",\"type\":\"string\"},\"name\":{\"description\":\"Human readable name of task, used to very briefly given an idea about\\nwhat the task does.\\n\",\"maxLength\":255,\"title\":\"Name\",\"type\":\"string\"},\"owner\":{\"description\":\"Entity who caused this task, not necessarily a person with email who did\\n`hg push` as it could be automation bots as well. The entity we should\\ncontact to ask why this task is here.\\n\",\"maxLength\":255,\"title\":\"Owner\",\"type\":\"string\"},\"source\":{\"description\":\"Link to source of this task, should specify a file, revision and\\nrepository. This should be place someone can go an do a git/hg blame\\nto who came up with recipe for this task.\\n\",\"format\":\"uri\",\"maxLength\":4096,\"pattern\":\"^(https?|ssh)://\",\"title\":\"Source\",\"type\":\"string\"}},\"required\":[\"name\",\"description\",\"owner\",\"source\"],\"title\":\"Task Metadata\",\"type\":\"object\"}",
+	"/schemas/queue/v1/task-reclaim-response.json":                "{\"$id\":\"/schemas/queue/v1/task-reclaim-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response to a successful task claim\\n\",\"properties\":{\"credentials\":{\"$ref\":\"task-credentials.json#\"},\"runId\":{\"description\":\"`run-id` assigned to this run of the task\\n\",\"maximum\":1000,\"minimum\":0,\"type\":\"integer\"},\"status\":{\"$ref\":\"task-status.json#\"},\"takenUntil\":{\"description\":\"Time at which the run expires and is resolved as `exception`,\\nwith reason `claim-expired` if the run haven't been reclaimed.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"workerGroup\":{\"description\":\"Identifier for the worker-group within which this run started.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"},\"workerId\":{\"description\":\"Identifier for the worker executing this run.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"type\":\"string\"}},\"required\":[\"status\",\"runId\",\"workerGroup\",\"workerId\",\"takenUntil\",\"credentials\"],\"title\":\"Task Reclaim Response\",\"type\":\"object\"}",
+	"/schemas/queue/v1/task-run.json":                             "{\"$id\":\"/schemas/queue/v1/task-run.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A run of a task.\\n\",\"properties\":{\"runId\":{\"description\":\"Id of this task run, `run-id`s always starts from `0`\\n\",\"maximum\":1000,\"minimum\":0,\"title\":\"Run Identifier\",\"type\":\"integer\"},\"taskId\":{\"description\":\"Unique task identifier, this is UUID encoded as\\n[URL-safe base64](http://tools.ietf.org/html/rfc4648#section-5) and\\nstripped of `=` padding.\\n\",\"pattern\":\"^[A-Za-z0-9_-]{8}[Q-T][A-Za-z0-9_-][CGKOSWaeimquy26-][A-Za-z0-9_-]{10}[AQgw]$\",\"title\":\"Task Identifier\",\"type\":\"string\"}},\"required\":[\"taskId\",\"runId\"],\"title\":\"Task Run\",\"type\":\"object\"}",
+	"/schemas/queue/v1/task-status-response.json":                 "{\"$id\":\"/schemas/queue/v1/task-status-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response to a task status request\\n\",\"properties\":{\"status\":{\"$ref\":\"task-status.json#\"}},\"required\":[\"status\"],\"title\":\"Task Status Response\",\"type\":\"object\"}",
+	"/schemas/queue/v1/task-status.json":                          "{\"$id\":\"/schemas/queue/v1/task-status.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A representation of **task status** as known by the queue\\n\",\"properties\":{\"deadline\":{\"description\":\"Deadline of the task, by which this task must be complete. `pending` and\\n`running` runs are resolved as **exception** if not resolved by other means\\nbefore the deadline. After the deadline, a task is immutable. Note,\\ndeadline cannot be more than 5 days into the future\\n\",\"format\":\"date-time\",\"title\":\"Deadline\",\"type\":\"string\"},\"expires\":{\"description\":\"Task expiration, time at which task definition and\\nstatus is deleted. Notice that all artifacts for the task\\nmust have an expiration that is no later than this.\\n\",\"format\":\"date-time\",\"title\":\"Expiration\",\"type\":\"string\"},\"provisionerId\":{\"$ref\":\"task.json#/properties/provisionerId\"},\"retriesLeft\":{\"description\":\"Number of retries left for the task in case of infrastructure issues\\n\",\"maximum\":999,\"minimum\":0,\"title\":\"Retries Left\",\"type\":\"integer\"},\"runs\":{\"description\":\"List of runs, ordered so that index `i` has `runId == i`\\n\",\"items\":{\"additionalProperties\":false,\"description\":\"JSON object with information about a run\\n\",\"properties\":{\"reasonCreated\":{\"description\":\"Reason for the creation of this run,\\n**more reasons may be added in the future**.\\n\",\"enum\":[\"scheduled\",\"retry\",\"task-retry\",\"rerun\",\"exception\"],\"title\":\"Reason Created\",\"type\":\"string\"},\"reasonResolved\":{\"description\":\"Reason that run was resolved, this is mainly\\nuseful for runs resolved as `exception`.\\nNote, **more reasons may be added in the future**, also this\\nproperty is only available after the run is resolved. Some of these\\nreasons, notably `intermittent-task`, `worker-shutdown`, and\\n`claim-expired`, will trigger an automatic retry of the task.\\n\",\"enum\":[\"completed\",\"failed\",\"deadline-exceeded\",\"canceled\",\"superseded\",\"claim-expired\",\"worker-shutdown\",\"malformed-payload\",\"resource-unavailable\",\"internal-error\",\"intermittent-task\"],\"title\":\"Reason Resolved\",\"type\":\"string\"},\"resolved\":{\"description\":\"Date-time at which this run was resolved, ie. when the run changed\\nstate from `running` to either `completed`, `failed` or `exception`.\\nThis property is only present after the run as been resolved.\\n\",\"format\":\"date-time\",\"title\":\"Resolved\",\"type\":\"string\"},\"runId\":{\"description\":\"Id of this task run, `run-id`s always starts from `0`\\n\",\"maximum\":1000,\"minimum\":0,\"title\":\"Run Identifier\",\"type\":\"integer\"},\"scheduled\":{\"description\":\"Date-time at which this run was scheduled, ie. when the run was\\ncreated in state `pending`.\\n\",\"format\":\"date-time\",\"title\":\"Scheduled\",\"type\":\"string\"},\"started\":{\"description\":\"Date-time at which this run was claimed, ie. when the run changed\\nstate from `pending` to `running`. This property is only present\\nafter the run has been claimed.\\n\",\"format\":\"date-time\",\"title\":\"Started\",\"type\":\"string\"},\"state\":{\"description\":\"State of this run\\n\",\"enum\":[\"pending\",\"running\",\"completed\",\"failed\",\"exception\"],\"title\":\"Run State\",\"type\":\"string\"},\"takenUntil\":{\"description\":\"Time at which the run expires and is resolved as `failed`, if the\\nrun isn't reclaimed. Note, only present after the run has been\\nclaimed.\\n\",\"format\":\"date-time\",\"title\":\"Taken Until\",\"type\":\"string\"},\"workerGroup\":{\"description\":\"Identifier for group that worker who executes this run is a part of,\\nthis identifier is mainly used for efficient routing.\\nNote, this property is only present after the run is claimed.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"title\":\"Worker Group\",\"type\":\"string\"},\"workerId\":{\"description\":\"Identifier for worker evaluating this run within given\\n`workerGroup`. Note, this property is only available after the run\\nhas been claimed.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"title\":\"Worker Identifier\",\"type\":\"string\"}},\"required\":[\"runId\",\"state\",\"reasonCreated\",\"scheduled\"],\"title\":\"Run Information\",\"type\":\"object\"},\"title\":\"List of Runs\",\"type\":\"array\",\"uniqueItems\":true},\"schedulerId\":{\"$ref\":\"task.json#/properties/schedulerId\",\"default\":\"-\"},\"state\":{\"description\":\"State of this task. This is just an auxiliary property derived from state\\nof latests run, or `unscheduled` if none.\\n\",\"enum\":[\"unscheduled\",\"pending\",\"running\",\"completed\",\"failed\",\"exception\"],\"title\":\"State\",\"type\":\"string\"},\"taskGroupId\":{\"$ref\":\"task.json#/properties/taskGroupId\"},\"taskId\":{\"description\":\"Unique task identifier, this is UUID encoded as\\n[URL-safe base64](http://tools.ietf.org/html/rfc4648#section-5) and\\nstripped of `=` padding.\\n\",\"pattern\":\"^[A-Za-z0-9_-]{8}[Q-T][A-Za-z0-9_-][CGKOSWaeimquy26-][A-Za-z0-9_-]{10}[AQgw]$\",\"title\":\"Task Identifier\",\"type\":\"string\"},\"workerType\":{\"$ref\":\"task.json#/properties/workerType\"}},\"required\":[\"taskId\",\"provisionerId\",\"workerType\",\"schedulerId\",\"taskGroupId\",\"deadline\",\"expires\",\"retriesLeft\",\"state\",\"runs\"],\"title\":\"Task Status Structure\",\"type\":\"object\"}",
+	"/schemas/queue/v1/task.json":                                 "{\"$id\":\"/schemas/queue/v1/task.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Definition of a task that can be scheduled\\n\",\"properties\":{\"created\":{\"description\":\"Creation time of task\",\"format\":\"date-time\",\"title\":\"Created\",\"type\":\"string\"},\"deadline\":{\"description\":\"Deadline of the task, by which this task must be complete. `pending` and\\n`running` runs are resolved as **exception** if not resolved by other means\\nbefore the deadline. After the deadline, a task is immutable. Note,\\ndeadline cannot be more than 5 days into the future\\n\",\"format\":\"date-time\",\"title\":\"Deadline\",\"type\":\"string\"},\"dependencies\":{\"default\":[],\"description\":\"List of dependent tasks. These must either be _completed_ or _resolved_\\nbefore this task is scheduled. See `requires` for semantics.\\n\",\"items\":{\"description\":\"The `taskId` of a task that must be resolved before this task is\\nscheduled.\\n\",\"pattern\":\"^[A-Za-z0-9_-]{8}[Q-T][A-Za-z0-9_-][CGKOSWaeimquy26-][A-Za-z0-9_-]{10}[AQgw]$\",\"title\":\"Task Dependency\",\"type\":\"string\"},\"maxItems\":100,\"title\":\"Task Dependencies\",\"type\":\"array\",\"uniqueItems\":true},\"expires\":{\"description\":\"Task expiration, time at which task definition and status is deleted.\\nNotice that all artifacts for the task must have an expiration that is no\\nlater than this. If this property isn't it will be set to `deadline`\\nplus one year (this default may change).\\n\",\"format\":\"date-time\",\"title\":\"Expiration\",\"type\":\"string\"},\"extra\":{\"default\":{},\"description\":\"Object with properties that can hold any kind of extra data that should be\\nassociated with the task. This can be data for the task which doesn't\\nfit into `payload`, or it can supplementary data for use in services\\nlistening for events from this task. For example this could be details to\\ndisplay on dashboard, or information for indexing the task. Please, try\\nto put all related information under one property, so `extra` data keys\\ndon't conflict.  **Warning**, do not stuff large data-sets in here --\\ntask definitions should not take-up multiple MiBs.\\n\",\"title\":\"Extra Data\",\"type\":\"object\"},\"metadata\":{\"$ref\":\"task-metadata.json#\"},\"payload\":{\"description\":\"Task-specific payload following worker-specific format.\\nRefer to the documentation for the worker implementing\\n`<provisionerId>/<workerType>` for details.\\n\",\"title\":\"Task Payload\",\"type\":\"object\"},\"priority\":{\"default\":\"lowest\",\"description\":\"Priority of task. This defaults to `lowest` and the scope\\n`queue:create-task:<priority>/<provisionerId>/<workerType>` is required\\nto define a task with `<priority>`. The `normal` priority is treated as\\n`lowest`.\\n\",\"enum\":[\"highest\",\"very-high\",\"high\",\"medium\",\"low\",\"very-low\",\"lowest\",\"normal\"],\"title\":\"Task Priority\",\"type\":\"string\"},\"provisionerId\":{\"description\":\"Unique identifier for a provisioner, that can supply specified\\n`workerType`\\n\",\"pattern\":\"^[a-zA-Z0-9-_]{1,38}$\",\"title\":\"Provisioner Id\",\"type\":\"string\"},\"requires\":{\"default\":\"all-completed\",\"description\":\"The tasks relation to its dependencies. This property specifies the\\nsemantics of the `task.dependencies` property.\\nIf `all-completed` is given the task will be scheduled when all\\ndependencies are resolved _completed_ (successful resolution).\\nIf `all-resolved` is given the task will be scheduled when all dependencies\\nhave been resolved, regardless of what their resolution is.\\n\",\"enum\":[\"all-completed\",\"all-resolved\"],\"title\":\"Dependency Requirement Semantics\",\"type\":\"string\"},\"retries\":{\"default\":5,\"description\":\"Number of times to retry the task in case of infrastructure issues.\\nAn _infrastructure issue_ is a worker node that crashes or is shutdown,\\nthese events are to be expected.\\n\",\"maximum\":49,\"minimum\":0,\"title\":\"Retries\",\"type\":\"integer\"},\"routes\":{\"default\":[],\"description\":\"List of task-specific routes. Pulse messages about the task will be CC'ed to\\n`route.<value>` for each `<value>` in this array.\\n\\nThis array has a maximum size due to a limitation of the AMQP protocol,\\nover which Pulse runs.  All routes must fit in the same \\\"frame\\\" of this\\nprotocol, and the frames have a fixed maximum size (typically 128k).\\n\",\"items\":{\"description\":\"A task specific route.\\n\",\"maxLength\":249,\"minLength\":1,\"title\":\"Task Specific Route\",\"type\":\"string\"},\"maxItems\":64,\"title\":\"Task Specific Routes\",\"type\":\"array\",\"uniqueItems\":true},\"schedulerId\":{\"default\":\"-\",\"description\":\"All tasks in a task group must have the same `schedulerId`. This is used for several purposes:\\n\\n* it can represent the entity that created the task;\\n* it can limit addition of new tasks to a task group: the caller of\\n    `createTask` must have a scope related to the `schedulerId` of the task\\n    group;\\n* it controls who can manipulate tasks, again by requiring\\n    `schedulerId`-related scopes; and\\n* it appears in the routing key for Pulse messages about the task.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"title\":\"Scheduler Identifier\",\"type\":\"string\"},\"scopes\":{\"description\":\"List of scopes that the task is authorized to use during its execution.\\n\",\"items\":{\"description\":\"A single scope. A scope must be composed of\\nprintable ASCII characters and spaces.  Scopes ending in more than\\none `*` character are forbidden.\\n\",\"name\":\"Scope\",\"pattern\":\"^[ -~]*$\",\"type\":\"string\"},\"title\":\"Scopes\",\"type\":\"array\",\"uniqueItems\":false},\"tags\":{\"additionalProperties\":{\"maxLength\":4096,\"type\":\"string\"},\"default\":{},\"description\":\"Arbitrary key-value tags (only strings limited to 4k). These can be used\\nto attach informal metadata to a task. Use this for informal tags that\\ntasks can be classified by. You can also think of strings here as\\ncandidates for formal metadata. Something like\\n`purpose: 'build' || 'test'` is a good example.\\n\",\"title\":\"Tags\",\"type\":\"object\"},\"taskGroupId\":{\"description\":\"Identifier for a group of tasks scheduled together with this task.\\nGenerally, all tasks related to a single event such as a version-control\\npush or a nightly build have the same `taskGroupId`.  This property\\ndefaults to `taskId` if it isn't specified.  Tasks with `taskId` equal to\\nthe `taskGroupId` are, [by convention](/docs/manual/using/task-graph),\\ndecision tasks.\\n\",\"pattern\":\"^[A-Za-z0-9_-]{8}[Q-T][A-Za-z0-9_-][CGKOSWaeimquy26-][A-Za-z0-9_-]{10}[AQgw]$\",\"title\":\"Task-Group Identifier\",\"type\":\"string\"},\"workerType\":{\"description\":\"Unique identifier for a worker-type within a specific provisioner\\n\",\"pattern\":\"^[a-z]([-a-z0-9]{0,36}[a-z0-9])?$\",\"title\":\"Worker Type\",\"type\":\"string\"}},\"required\":[\"provisionerId\",\"workerType\",\"schedulerId\",\"taskGroupId\",\"dependencies\",\"requires\",\"routes\",\"priority\",\"retries\",\"created\",\"deadline\",\"scopes\",\"payload\",\"metadata\",\"tags\",\"extra\"],\"title\":\"Task Definition Response\",\"type\":\"object\"}",
+	"/schemas/queue/v1/update-provisioner-request.json":           "{\"$id\":\"/schemas/queue/v1/update-provisioner-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Request to update a provisioner.\\n\",\"properties\":{\"actions\":{\"$ref\":\"actions.json#\"},\"description\":{\"description\":\"Description of the provisioner.\\n\",\"title\":\"Description\",\"type\":\"string\"},\"expires\":{\"description\":\"Date and time after which the provisioner will be automatically\\ndeleted by the queue.\\n\",\"format\":\"date-time\",\"title\":\"Provisioner Expiration\",\"type\":\"string\"},\"stability\":{\"description\":\"This is the stability of the provisioner. Accepted values:\\n  * `experimental`\\n  * `stable`\\n  * `deprecated`\\n\",\"enum\":[\"experimental\",\"stable\",\"deprecated\"],\"title\":\"Stability\",\"type\":\"string\"}},\"required\":[],\"title\":\"Provisioner Request\",\"type\":\"object\"}",
+	"/schemas/queue/v1/update-worker-request.json":                "{\"$id\":\"/schemas/queue/v1/update-worker-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Request to update a worker.\\n\",\"properties\":{\"expires\":{\"description\":\"Date and time after which the worker will be automatically\\ndeleted by the queue.\\n\",\"format\":\"date-time\",\"title\":\"Worker Expiration\",\"type\":\"string\"}},\"required\":[],\"title\":\"Worker Request\",\"type\":\"object\"}",
+	"/schemas/queue/v1/update-workertype-request.json":            "{\"$id\":\"/schemas/queue/v1/update-workertype-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Request to update a worker-type.\\n\",\"properties\":{\"description\":{\"description\":\"Description of the provisioner.\\n\",\"title\":\"Description\",\"type\":\"string\"},\"expires\":{\"description\":\"Date and time after which the worker-type will be automatically\\ndeleted by the queue.\\n\",\"format\":\"date-time\",\"title\":\"Worker-type Expiration\",\"type\":\"string\"},\"stability\":{\"description\":\"This is the stability of the provisioner. Accepted values:\\n  * `experimental`\\n  * `stable`\\n  * `deprecated`\\n\",\"enum\":[\"experimental\",\"stable\",\"deprecated\"],\"title\":\"Stability\",\"type\":\"string\"}},\"required\":[],\"title\":\"Worker-type Request\",\"type\":\"object\"}",
+	"/schemas/queue/v1/worker-response.json":                      "{\"$id\":\"/schemas/queue/v1/worker-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response containing information about a worker.\\n\",\"properties\":{\"actions\":{\"items\":{\"additionalProperties\":false,\"description\":\"Actions provide a generic mechanism to expose additional features of a\\nprovisioner, worker type, or worker to Taskcluster clients.\\n\\nAn action is comprised of metadata describing the feature it exposes,\\ntogether with a webhook for triggering it.\\n\\nThe Taskcluster tools site, for example, retrieves actions when displaying\\nprovisioners, worker types and workers. It presents the provisioner/worker\\ntype/worker specific actions to the user. When the user triggers an action,\\nthe web client takes the registered webhook, substitutes parameters into the\\nURL (see `url`), signs the requests with the Taskcluster credentials of the\\nuser operating the web interface, and issues the HTTP request.\\n\\nThe level to which the action relates (provisioner, worker type, worker) is\\ncalled the action context. All actions, regardless of the action contexts,\\nare registered against the provisioner when calling\\n`queue.declareProvisioner`.\\n\\nThe action context is used by the web client to determine where in the web\\ninterface to present the action to the user as follows:\\n\\n| `context`   | Tool where action is displayed |\\n|-------------|--------------------------------|\\n| provisioner | Provisioner Explorer           |\\n| worker-type | Workers Explorer               |\\n| worker      | Worker Explorer                |\\n\\nSee [actions docs](/docs/reference/platform/taskcluster-queue/docs/actions)\\nfor more information.\\n\",\"properties\":{\"context\":{\"description\":\"Only actions with the context `worker` are included.\\n\",\"enum\":[\"worker\"],\"title\":\"Context\",\"type\":\"string\"},\"description\":{\"description\":\"Description of the provisioner.\\n\",\"title\":\"Description\",\"type\":\"string\"},\"method\":{\"description\":\"Method to indicate the desired action to be performed for a given resource.\\n\",\"enum\":[\"POST\",\"PUT\",\"DELETE\",\"PATCH\"],\"title\":\"Method\",\"type\":\"string\"},\"name\":{\"description\":\"Short names for things like logging/error messages.\\n\",\"title\":\"Name\",\"type\":\"string\"},\"title\":{\"description\":\"Appropriate title for any sort of Modal prompt.\\n\",\"title\":\"Title\"},\"url\":{\"description\":\"When an action is triggered, a request is made using the `url` and `method`.\\nDepending on the `context`, the following parameters will be substituted in the url:\\n\\n| `context`   | Path parameters                                          |\\n|-------------|----------------------------------------------------------|\\n| provisioner | <provisionerId>                                          |\\n| worker-type | <provisionerId>, <workerType>                            |\\n| worker      | <provisionerId>, <workerType>, <workerGroup>, <workerId> |\\n\\n_Note: The request needs to be signed with the user's Taskcluster credentials._\\n\",\"title\":\"URL\",\"type\":\"string\"}},\"required\":[\"name\",\"title\",\"context\",\"url\",\"method\",\"description\"],\"title\":\"Worker Action\",\"type\":\"object\"},\"title\":\"Worker Actions\",\"type\":\"array\",\"uniqueItems\":false},\"expires\":{\"description\":\"Date and time after which the worker will be automatically\\ndeleted by the queue.\\n\",\"format\":\"date-time\",\"title\":\"Worker Expiration\",\"type\":\"string\"},\"firstClaim\":{\"description\":\"Date of the first time this worker claimed a task.\\n\",\"format\":\"date-time\",\"title\":\"First task claimed\",\"type\":\"string\"},\"provisionerId\":{\"$ref\":\"task.json#/properties/provisionerId\"},\"quarantineUntil\":{\"description\":\"Quarantining a worker allows the machine to remain alive but not accept jobs.\\nOnce the quarantineUntil time has elapsed, the worker resumes accepting jobs.\\nNote that a quarantine can be lifted by setting `quarantineUntil` to the present time (or\\nsomewhere in the past).\\n\",\"format\":\"date-time\",\"title\":\"Worker Quarantine\",\"type\":\"string\"},\"recentTasks\":{\"description\":\"List of 20 most recent tasks claimed by the worker.\\n\",\"items\":{\"$ref\":\"task-run.json#\"},\"title\":\"Most Recent Tasks\",\"type\":\"array\",\"uniqueItems\":false},\"workerGroup\":{\"description\":\"Identifier for group that worker who executes this run is a part of,\\nthis identifier is mainly used for efficient routing.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"title\":\"Worker Group\",\"type\":\"string\"},\"workerId\":{\"description\":\"Identifier for worker evaluating this run within given\\n`workerGroup`.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"title\":\"Worker Identifier\",\"type\":\"string\"},\"workerType\":{\"$ref\":\"task.json#/properties/workerType\"}},\"required\":[\"provisionerId\",\"workerType\",\"workerGroup\",\"workerId\",\"recentTasks\",\"expires\",\"firstClaim\",\"actions\"],\"title\":\"Worker Response\",\"type\":\"object\"}",
+	"/schemas/queue/v1/workertype-response.json":                  "{\"$id\":\"/schemas/queue/v1/workertype-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response to a worker-type request from a provisioner.\\n\",\"properties\":{\"actions\":{\"items\":{\"additionalProperties\":false,\"description\":\"Actions provide a generic mechanism to expose additional features of a\\nprovisioner, worker type, or worker to Taskcluster clients.\\n\\nAn action is comprised of metadata describing the feature it exposes,\\ntogether with a webhook for triggering it.\\n\\nThe Taskcluster tools site, for example, retrieves actions when displaying\\nprovisioners, worker types and workers. It presents the provisioner/worker\\ntype/worker specific actions to the user. When the user triggers an action,\\nthe web client takes the registered webhook, substitutes parameters into the\\nURL (see `url`), signs the requests with the Taskcluster credentials of the\\nuser operating the web interface, and issues the HTTP request.\\n\\nThe level to which the action relates (provisioner, worker type, worker) is\\ncalled the action context. All actions, regardless of the action contexts,\\nare registered against the provisioner when calling\\n`queue.declareProvisioner`.\\n\\nThe action context is used by the web client to determine where in the web\\ninterface to present the action to the user as follows:\\n\\n| `context`   | Tool where action is displayed |\\n|-------------|--------------------------------|\\n| provisioner | Provisioner Explorer           |\\n| worker-type | Workers Explorer               |\\n| worker      | Worker Explorer                |\\n\\nSee [actions docs](/docs/reference/platform/taskcluster-queue/docs/actions)\\nfor more information.\\n\",\"properties\":{\"context\":{\"description\":\"Only actions with the context `worker-type` are included.\\n\",\"enum\":[\"worker-type\"],\"title\":\"Context\",\"type\":\"string\"},\"description\":{\"description\":\"Description of the provisioner.\\n\",\"title\":\"Description\",\"type\":\"string\"},\"method\":{\"description\":\"Method to indicate the desired action to be performed for a given resource.\\n\",\"enum\":[\"POST\",\"PUT\",\"DELETE\",\"PATCH\"],\"title\":\"Method\",\"type\":\"string\"},\"name\":{\"description\":\"Short names for things like logging/error messages.\\n\",\"title\":\"Name\",\"type\":\"string\"},\"title\":{\"description\":\"Appropriate title for any sort of Modal prompt.\\n\",\"title\":\"Title\"},\"url\":{\"description\":\"When an action is triggered, a request is made using the `url` and `method`.\\nDepending on the `context`, the following parameters will be substituted in the url:\\n\\n| `context`   | Path parameters                                          |\\n|-------------|----------------------------------------------------------|\\n| provisioner | <provisionerId>                                          |\\n| worker-type | <provisionerId>, <workerType>                            |\\n| worker      | <provisionerId>, <workerType>, <workerGroup>, <workerId> |\\n\\n_Note: The request needs to be signed with the user's Taskcluster credentials._\\n\",\"title\":\"URL\",\"type\":\"string\"}},\"required\":[\"name\",\"title\",\"context\",\"url\",\"method\",\"description\"],\"title\":\"Worker-type Action\",\"type\":\"object\"},\"title\":\"Worker-type Actions\",\"type\":\"array\",\"uniqueItems\":false},\"description\":{\"description\":\"Description of the worker-type.\\n\",\"title\":\"Description\",\"type\":\"string\"},\"expires\":{\"description\":\"Date and time after which the worker-type will be automatically\\ndeleted by the queue.\\n\",\"format\":\"date-time\",\"title\":\"Worker-type Expiration\",\"type\":\"string\"},\"lastDateActive\":{\"description\":\"Date of the last time this worker-type was seen active. `lastDateActive` is updated every 6 hours\\nbut may be off by up-to 6 hours. Nonetheless, `lastDateActive` is a good indicator\\nof when the worker-type was last seen active.\\n\",\"format\":\"date-time\",\"title\":\"Worker-type Last Date Active\",\"type\":\"string\"},\"provisionerId\":{\"$ref\":\"task.json#/properties/provisionerId\"},\"stability\":{\"description\":\"This is the stability of the worker-type. Accepted values:\\n  * `experimental`\\n  * `stable`\\n  * `deprecated`\\n\",\"enum\":[\"experimental\",\"stable\",\"deprecated\"],\"title\":\"Stability\",\"type\":\"string\"},\"workerType\":{\"$ref\":\"task.json#/properties/workerType\"}},\"required\":[\"workerType\",\"provisionerId\",\"description\",\"stability\",\"expires\",\"lastDateActive\",\"actions\"],\"title\":\"Worker-type Response\",\"type\":\"object\"}",
+	"/schemas/secrets/v1/secret-list.json":                        "{\"$id\":\"/schemas/secrets/v1/secret-list.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Message containing a list of secret names\\n\",\"properties\":{\"continuationToken\":{\"description\":\"Opaque `continuationToken` to be given as query-string option to get the\\nnext set of provisioners.\\nThis property is only present if another request is necessary to fetch all\\nresults. In practice the next request with a `continuationToken` may not\\nreturn additional results, but it can. Thus, you can only be sure to have\\nall the results if you've called with `continuationToken` until you get a\\nresult without a `continuationToken`.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"},\"secrets\":{\"description\":\"Secret names\",\"items\":{\"description\":\"Secret name\",\"title\":\"Secret\",\"type\":\"string\"},\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"secrets\"],\"title\":\"Secrets List\",\"type\":\"object\"}",
+	"/schemas/secrets/v1/secret.json":                             "{\"$id\":\"/schemas/secrets/v1/secret.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Message containing a Taskcluster Secret\\n\",\"properties\":{\"expires\":{\"description\":\"An expiration date for this secret.\\n\",\"format\":\"date-time\",\"type\":\"string\"},\"secret\":{\"description\":\"The secret value to be encrypted.\\n\",\"type\":\"object\"}},\"required\":[\"secret\",\"expires\"],\"title\":\"Secret\",\"type\":\"object\"}",
+	"/schemas/worker-manager/v1/create-worker-pool-request.json":  "{\"$id\":\"/schemas/worker-manager/v1/create-worker-pool-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Fields that are defined by a user for a worker pool.\\nUsed to create worker-pool definitions. There is a larger\\nset of fields for viewing since some parts are generated\\nby the service.\\n\",\"properties\":{\"config\":{\"$ref\":\"worker-pool-full.json#/properties/config\"},\"description\":{\"$ref\":\"worker-pool-full.json#/properties/description\"},\"emailOnError\":{\"$ref\":\"worker-pool-full.json#/properties/emailOnError\"},\"owner\":{\"$ref\":\"worker-pool-full.json#/properties/owner\"},\"providerId\":{\"$ref\":\"worker-pool-full.json#/properties/providerId\"}},\"required\":[\"providerId\",\"description\",\"config\",\"owner\",\"emailOnError\"],\"title\":\"Worker Pool Definition\",\"type\":\"object\"}",
+	"/schemas/worker-manager/v1/create-worker-request.json":       "{\"$id\":\"/schemas/worker-manager/v1/create-worker-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Request to create a worker. Capacity will default to 1 if not specified.\",\"properties\":{\"capacity\":{\"$ref\":\"worker-full.json#/properties/capacity\"},\"expires\":{\"description\":\"Date and time when this worker will be deleted from the DB\",\"format\":\"date-time\",\"title\":\"Expires\",\"type\":\"string\"},\"providerInfo\":{\"description\":\"Provider-specific information\",\"oneOf\":[{\"additionalProperties\":false,\"properties\":{\"staticSecret\":{\"description\":\"A secret value shared with the worker.  This value must be passed in the `workerIdentityProof` of the `registerWorker` method.\\nThe ideal way to generate a secret of this form is `slugid() + slugid()`.\\n\\nSecrets are traded for Taskcluster credentials, and should be treated with similar care.\\nEach worker should have a distinct secret.\\n\",\"pattern\":\"^[a-zA-Z0-9_-]{44}$\",\"title\":\"Secret\",\"type\":\"string\"}},\"required\":[\"staticSecret\"],\"title\":\"static provider type\",\"type\":\"object\"}],\"title\":\"Provider Data\"}},\"required\":[\"expires\"],\"title\":\"Worker Creation Request\",\"type\":\"object\"}",
+	"/schemas/worker-manager/v1/provider-list.json":               "{\"$id\":\"/schemas/worker-manager/v1/provider-list.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A list of providers\",\"properties\":{\"continuationToken\":{\"description\":\"Opaque `continuationToken` to be given as query-string option to get the\\nnext set of workers in the worker-manager.\\nThis property is only present if another request is necessary to fetch all\\nresults. In practice the next request with a `continuationToken` may not\\nreturn additional results, but it can. Thus, you can only be sure to have\\nall the results if you've called `listWorkerPools` with `continuationToken`\\nuntil you get a result without a `continuationToken`.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"},\"providers\":{\"description\":\"List of all providers\",\"items\":{\"additionalProperties\":false,\"properties\":{\"providerId\":{\"description\":\"The id of this provider\",\"title\":\"Provider ID\",\"type\":\"string\"},\"providerType\":{\"description\":\"The provider implementation underlying this provider\",\"title\":\"Provider Type\",\"type\":\"string\"}},\"required\":[\"providerId\",\"providerType\"],\"type\":\"object\"},\"title\":\"Providers\",\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"providers\"],\"title\":\"Provider List\",\"type\":\"object\"}",
+	"/schemas/worker-manager/v1/register-worker-request.json":     "{\"$id\":\"/schemas/worker-manager/v1/register-worker-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Request body to `registerWorker`.\",\"properties\":{\"providerId\":{\"$ref\":\"worker-full.json#/properties/providerId\"},\"workerGroup\":{\"$ref\":\"worker-full.json#/properties/workerGroup\"},\"workerId\":{\"$ref\":\"worker-full.json#/properties/workerId\"},\"workerIdentityProof\":{\"description\":\"Proof that this call is coming from the worker identified by the other fields.\\nThe form of this proof varies depending on the provider type.\\n\",\"oneOf\":[{\"additionalProperties\":false,\"properties\":{\"token\":{\"description\":\"A JWT token as defined in [this google documentation](https://cloud.google.com/compute/docs/instances/verifying-instance-identity)\\n\",\"title\":\"Token\",\"type\":\"string\"}},\"required\":[\"token\"],\"title\":\"google provider type\",\"type\":\"object\"},{\"additionalProperties\":false,\"properties\":{\"staticSecret\":{\"description\":\"The secret value that was configured when the worker was created (in `createWorker`).\\n\",\"pattern\":\"^[a-zA-Z0-9_-]{44}$\",\"title\":\"Secret\",\"type\":\"string\"}},\"required\":[\"staticSecret\"],\"title\":\"static provider type\",\"type\":\"object\"},{\"additionalProperties\":false,\"properties\":{\"document\":{\"description\":\"Instance identity document that is obtained by\\ncurl http://169.254.169.254/latest/dynamic/instance-identity/document on the instance\\n\",\"title\":\"Document\",\"type\":\"string\"},\"signature\":{\"description\":\"The signature for instance identity document. Can be obtained by\\ncurl http://169.254.169.254/latest/dynamic/instance-identity/signature on the instance\\n\",\"title\":\"Signature\",\"type\":\"string\"}},\"required\":[\"document\",\"signature\"],\"title\":\"aws provider type\",\"type\":\"object\"},{\"additionalProperties\":false,\"properties\":{\"document\":{\"description\":\"Attested data document that is obtained by\\ncurl http://169.254.169.254/metadata/attested/document on the instance\\n\",\"title\":\"Document\",\"type\":\"string\"}},\"required\":[\"document\"],\"title\":\"azure provider type\",\"type\":\"object\"}],\"title\":\"Worker Identity Proof\"},\"workerPoolId\":{\"$ref\":\"worker-pool-full.json#/properties/workerPoolId\"}},\"required\":[\"workerPoolId\",\"providerId\",\"workerGroup\",\"workerId\",\"workerIdentityProof\"],\"title\":\"Register Worker Request\",\"type\":\"object\"}",
+	"/schemas/worker-manager/v1/register-worker-response.json":    "{\"$id\":\"/schemas/worker-manager/v1/register-worker-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response body to `registerWorker`.\",\"properties\":{\"credentials\":{\"additionalProperties\":false,\"description\":\"The credentials the worker\\nwill need to perform its work.  Specifically, credentials with scopes\\n* `assume:worker-pool:<workerPoolId>`\\n* `assume:worker-id:<workerGroup>/<workerId>`\\n* `queue:worker-id:<workerGroup>/<workerId>`\\n* `secrets:get:worker-pool:<workerPoolId>`\\n* `queue:claim-work:<workerPoolId>`\\n* `worker-manager:remove-worker:<workerPoolId>/<workerGroup>/<workerId>`\\n\",\"properties\":{\"accessToken\":{\"type\":\"string\"},\"certificate\":{\"description\":\"Note that a certificate may not be provided, if the credentials are not temporary.\\n\",\"type\":\"string\"},\"clientId\":{\"type\":\"string\"}},\"required\":[\"accessToken\",\"clientId\"],\"title\":\"Credentials\",\"type\":\"object\"},\"expires\":{\"description\":\"Time at which the included credentials will expire.  Workers must either\\nre-register (for static workers) or terminate (for dynamically\\nprovisioned workers) before this time.\\n\",\"format\":\"date-time\",\"title\":\"Expires\",\"type\":\"string\"},\"secret\":{\"description\":\"A secret value generated by worker-manager that can be used in the call to `reregisterWorker`.\\nFor more information, refer to https://docs.taskcluster.net/docs/reference/core/worker-manager#reregistration.\\n\",\"pattern\":\"^[a-zA-Z0-9_-]{44}$\",\"title\":\"Secret\",\"type\":\"string\"},\"workerConfig\":{\"additionalProperties\":true,\"description\":\"This value is supplied unchanged to the worker from the worker-pool configuration.\\nThe expectation is that the worker will merge this information with configuration from other sources,\\nand this is precisely what [worker-runner](https://github.com/taskcluster/taskcluster/tree/master/tools/worker-runner) does.\\nThis property must not be used for secret configuration, as it is visible both in the worker pool configuration and in the worker instance's metadata.\\nInstead, put secret configuration in the [secrets service](https://github.com/taskcluster/taskcluster/tree/master/tools/worker-runner#secrets).\\n\",\"title\":\"Worker Config\",\"type\":\"object\"}},\"required\":[\"expires\",\"credentials\",\"workerConfig\",\"secret\"],\"title\":\"Register Worker Response\",\"type\":\"object\"}",
+	"/schemas/worker-manager/v1/report-worker-error-request.json": "{\"$id\":\"/schemas/worker-manager/v1/report-worker-error-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A report of an error from a worker.  This will be recorded with kind\\n`worker-error`.\\n\\nThe worker's `workerGroup` and `workerId` will be added to `extra`.\\n\",\"properties\":{\"description\":{\"$ref\":\"worker-pool-error.json#/properties/description\"},\"extra\":{\"$ref\":\"worker-pool-error.json#/properties/extra\"},\"kind\":{\"$ref\":\"worker-pool-error.json#/properties/kind\"},\"title\":{\"$ref\":\"worker-pool-error.json#/properties/title\"},\"workerGroup\":{\"$ref\":\"worker-full.json#/properties/workerGroup\"},\"workerId\":{\"$ref\":\"worker-full.json#/properties/workerId\"}},\"required\":[\"workerGroup\",\"workerId\",\"kind\",\"title\",\"description\",\"extra\"],\"title\":\"Worker Error Report\",\"type\":\"object\"}",
+	"/schemas/worker-manager/v1/reregister-worker-request.json":   "{\"$id\":\"/schemas/worker-manager/v1/reregister-worker-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Request body to `reregisterWorker`.\",\"properties\":{\"secret\":{\"description\":\"The secret value that was last configured in `registerWorker` (in the case of a newly registerd worker) or\\n`reregisterWorker`.\\nFor more information, refer to https://docs.taskcluster.net/docs/reference/core/worker-manager#reregistration.\\n\",\"pattern\":\"^[a-zA-Z0-9_-]{44}$\",\"title\":\"Secret\",\"type\":\"string\"},\"workerGroup\":{\"$ref\":\"worker-full.json#/properties/workerGroup\"},\"workerId\":{\"$ref\":\"worker-full.json#/properties/workerId\"},\"workerPoolId\":{\"$ref\":\"worker-pool-full.json#/properties/workerPoolId\"}},\"required\":[\"workerPoolId\",\"workerGroup\",\"workerId\",\"secret\"],\"title\":\"Reregister Worker Request\",\"type\":\"object\"}",
+	"/schemas/worker-manager/v1/reregister-worker-response.json":  "{\"$id\":\"/schemas/worker-manager/v1/reregister-worker-response.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Response body to `reregisterWorker`.\",\"properties\":{\"credentials\":{\"additionalProperties\":false,\"description\":\"The credentials the worker\\nwill need to perform its work. Specifically, credentials with scopes\\n* `assume:worker-pool:<workerPoolId>`\\n* `assume:worker-id:<workerGroup>/<workerId>`\\n* `queue:worker-id:<workerGroup>/<workerId>`\\n* `secrets:get:worker-pool:<workerPoolId>`\\n* `queue:claim-work:<workerPoolId>`\\n* `worker-manager:remove-worker:<workerPoolId>/<workerGroup>/<workerId>`\\n* `worker-manager:reregister-worker:<workerPoolId>/<workerGroup>/<workerId>`\\n\",\"properties\":{\"accessToken\":{\"type\":\"string\"},\"certificate\":{\"description\":\"Note that a certificate may not be provided, if the credentials are not temporary.\\n\",\"type\":\"string\"},\"clientId\":{\"type\":\"string\"}},\"required\":[\"accessToken\",\"clientId\"],\"title\":\"Credentials\",\"type\":\"object\"},\"expires\":{\"description\":\"Time at which the included credentials will expire. Workers must\\nre-register before this time.\\n\",\"format\":\"date-time\",\"title\":\"Expires\",\"type\":\"string\"},\"secret\":{\"description\":\"The next secret value needed to reregister the worker (in `reregisterWorker).\\nFor more information, refer to https://docs.taskcluster.net/docs/reference/core/worker-manager#reregistration.\\n\",\"pattern\":\"^[a-zA-Z0-9_-]{44}$\",\"title\":\"Secret\",\"type\":\"string\"}},\"required\":[\"expires\",\"credentials\",\"secret\"],\"title\":\"Reregister Worker Response\",\"type\":\"object\"}",
+	"/schemas/worker-manager/v1/update-worker-pool-request.json":  "{\"$id\":\"/schemas/worker-manager/v1/update-worker-pool-request.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"Fields that are defined by a user for a worker pool.\\nUsed to modify worker-pool definitions.\\n\\nThe `workerPoolId`, `created`, and `lastModified` fields are optional and\\nallowed only to ease the common practice of getting a worker pool definition\\nwith `workerPool(..)`, modifying it, and writing it back with\\n`updateWorkerPool(..).  `workerPoolId` must be correct if\\nsupplied, and the values of `created` and `lastModified` are ignored.\\n\",\"properties\":{\"config\":{\"$ref\":\"worker-pool-full.json#/properties/config\"},\"created\":{\"description\":\"Ignored on update\",\"format\":\"date-time\",\"title\":\"Created\",\"type\":\"string\"},\"description\":{\"$ref\":\"worker-pool-full.json#/properties/description\"},\"emailOnError\":{\"$ref\":\"worker-pool-full.json#/properties/emailOnError\"},\"lastModified\":{\"description\":\"Ignored on update\",\"format\":\"date-time\",\"title\":\"Last Modified\",\"type\":\"string\"},\"owner\":{\"$ref\":\"worker-pool-full.json#/properties/owner\"},\"providerId\":{\"$ref\":\"worker-pool-full.json#/properties/providerId\"},\"workerPoolId\":{\"pattern\":\"^[a-zA-Z0-9-_]{1,38}/[a-z]([-a-z0-9]{0,36}[a-z0-9])?$\",\"title\":\"Worker Pool ID\",\"type\":\"string\"}},\"required\":[\"providerId\",\"description\",\"config\",\"owner\",\"emailOnError\"],\"title\":\"Worker Pool Definition\",\"type\":\"object\"}",
+	"/schemas/worker-manager/v1/worker-full.json":                 "{\"$id\":\"/schemas/worker-manager/v1/worker-full.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A complete worker definition.\",\"properties\":{\"capacity\":{\"description\":\"Number of tasks this worker can handle at once\",\"minimum\":1,\"title\":\"Worker Capacity\",\"type\":\"integer\"},\"created\":{\"description\":\"Date and time when this worker was created\",\"format\":\"date-time\",\"title\":\"Created\",\"type\":\"string\"},\"expires\":{\"description\":\"Date and time when this worker will be deleted from the DB\",\"format\":\"date-time\",\"title\":\"Expires\",\"type\":\"string\"},\"lastChecked\":{\"description\":\"Date and time when the state of this worker was verified with a cloud api.\\nFor providers with nothing to check, this will just be permanently set to the\\ntime the worker was created.\\n\",\"format\":\"date-time\",\"title\":\"Last Checked\",\"type\":\"string\"},\"lastModified\":{\"description\":\"Date and time when this worker last changed state\",\"format\":\"date-time\",\"title\":\"Last Modified\",\"type\":\"string\"},\"providerId\":{\"description\":\"The provider that had started the worker and responsible for managing it.\\nCan be different from the provider that's currently in the worker pool config.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"title\":\"Provider\",\"type\":\"string\"},\"state\":{\"description\":\"A string specifying the state this worker is in so far as worker-manager knows.\\nA \\\"requested\\\" worker is in the process of starting up, and if successful will enter\\nthe \\\"running\\\" state once it has registered with the `registerWorker` API method.  A\\n\\\"stopping\\\" worker is in the process of shutting down and deleting resources, while\\na \\\"stopped\\\" worker is completely stopped.  Stopped workers are kept for historical\\npurposes and are purged when they expire.  Note that some providers transition workers\\ndirectly from \\\"running\\\" to \\\"stopped\\\".\\n\",\"enum\":[\"requested\",\"running\",\"stopping\",\"stopped\"],\"title\":\"State\",\"type\":\"string\"},\"workerGroup\":{\"description\":\"Worker group to which this worker belongs\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"title\":\"Worker group\",\"type\":\"string\"},\"workerId\":{\"description\":\"Worker ID\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"title\":\"Worker ID\",\"type\":\"string\"},\"workerPoolId\":{\"$ref\":\"worker-pool-full.json#/properties/workerPoolId\"}},\"required\":[\"workerPoolId\",\"workerGroup\",\"workerId\",\"providerId\",\"created\",\"expires\",\"state\",\"capacity\",\"lastChecked\",\"lastModified\"],\"title\":\"Worker Full Definition\",\"type\":\"object\"}",
+	"/schemas/worker-manager/v1/worker-list.json":                 "{\"$id\":\"/schemas/worker-manager/v1/worker-list.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A list of workers in a given worker pool\",\"properties\":{\"continuationToken\":{\"description\":\"Opaque `continuationToken` to be given as query-string option to get the\\nnext set of workers in the worker-manager.\\nThis property is only present if another request is necessary to fetch all\\nresults. In practice the next request with a `continuationToken` may not\\nreturn additional results, but it can. Thus, you can only be sure to have\\nall the results if you've called `listWorkerPools` with `continuationToken`\\nuntil you get a result without a `continuationToken`.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"},\"workers\":{\"description\":\"List of all workers in a given worker pool\",\"items\":{\"$ref\":\"worker-full.json#\"},\"title\":\"Workers\",\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"workers\"],\"title\":\"Worker List in a Given Worker Pool\",\"type\":\"object\"}",
+	"/schemas/worker-manager/v1/worker-pool-error-list.json":      "{\"$id\":\"/schemas/worker-manager/v1/worker-pool-error-list.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A list of worker pool errors\",\"properties\":{\"continuationToken\":{\"description\":\"Opaque `continuationToken` to be given as query-string option to get the\\nnext set of worker-types in the worker-manager.\\nThis property is only present if another request is necessary to fetch all\\nresults. In practice the next request with a `continuationToken` may not\\nreturn additional results, but it can. Thus, you can only be sure to have\\nall the results if you've called `listWorkerPools` with `continuationToken`\\nuntil you get a result without a `continuationToken`.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"},\"workerPoolErrors\":{\"description\":\"List of worker pool errors\",\"items\":{\"$ref\":\"worker-pool-error.json#\"},\"title\":\"Worker Pool Errors\",\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"workerPoolErrors\"],\"title\":\"Worker Pool Error List\",\"type\":\"object\"}",
+	"/schemas/worker-manager/v1/worker-pool-error.json":           "{\"$id\":\"/schemas/worker-manager/v1/worker-pool-error.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A complete worker pool error definition.\\n\",\"properties\":{\"description\":{\"description\":\"A longer description of what occured in the error.\",\"maxLength\":10240,\"title\":\"Description\",\"type\":\"string\"},\"errorId\":{\"description\":\"An arbitary unique identifier for this error\",\"pattern\":\"^[A-Za-z0-9_-]{8}[Q-T][A-Za-z0-9_-][CGKOSWaeimquy26-][A-Za-z0-9_-]{10}[AQgw]$\",\"title\":\"Error ID\",\"type\":\"string\"},\"extra\":{\"additionalProperties\":true,\"description\":\"Any extra structured information about this error\",\"title\":\"Extra\",\"type\":\"object\"},\"kind\":{\"description\":\"A general machine-readable way to identify this sort of error.\",\"maxLength\":128,\"pattern\":\"[-a-z0-9]+\",\"title\":\"Kind\",\"type\":\"string\"},\"reported\":{\"description\":\"Date and time when this error was reported\",\"format\":\"date-time\",\"title\":\"Reported\",\"type\":\"string\"},\"title\":{\"description\":\"A human-readable version of `kind`.\",\"maxLength\":128,\"title\":\"Title\",\"type\":\"string\"},\"workerPoolId\":{\"$ref\":\"worker-pool-full.json#/properties/workerPoolId\"}},\"required\":[\"workerPoolId\",\"errorId\",\"reported\",\"kind\",\"title\",\"description\",\"extra\"],\"title\":\"Worker Pool Error\",\"type\":\"object\"}",
+	"/schemas/worker-manager/v1/worker-pool-full.json":            "{\"$id\":\"/schemas/worker-manager/v1/worker-pool-full.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A complete worker pool definition.\\n\",\"properties\":{\"config\":{\"additionalProperties\":true,\"type\":\"object\"},\"created\":{\"description\":\"Date and time when this worker pool was created\\n\",\"format\":\"date-time\",\"title\":\"Created\",\"type\":\"string\"},\"currentCapacity\":{\"description\":\"Total capacity available across all workers for this worker pool that are currently not \\\"stopped\\\"\",\"minimum\":0,\"title\":\"Current Capacity\",\"type\":\"integer\"},\"description\":{\"description\":\"A description of this worker pool.\\n\",\"maxLength\":10240,\"title\":\"Description\",\"type\":\"string\"},\"emailOnError\":{\"description\":\"If true, the owner should be emailed on provisioning errors\",\"title\":\"Wants Email\",\"type\":\"boolean\"},\"lastModified\":{\"description\":\"Date and time when this worker pool was last updated\\n\",\"format\":\"date-time\",\"title\":\"Last Modified\",\"type\":\"string\"},\"owner\":{\"description\":\"An email address to notify when there are provisioning errors for this\\nworker pool.\\n\",\"format\":\"email\",\"title\":\"Owner Email\",\"type\":\"string\"},\"providerId\":{\"description\":\"The provider responsible for managing this worker pool.\\n\\nIf this value is `\\\"null-provider\\\"`, then the worker pool is pending deletion\\nonce all existing workers have terminated.\\n\",\"maxLength\":38,\"minLength\":1,\"pattern\":\"^([a-zA-Z0-9-_]*)$\",\"title\":\"Provider\",\"type\":\"string\"},\"workerPoolId\":{\"description\":\"The ID of this worker pool (of the form `providerId/workerType` for compatibility)\\n\",\"pattern\":\"^[a-zA-Z0-9-_]{1,38}/[a-z]([-a-z0-9]{0,36}[a-z0-9])?$\",\"title\":\"Worker Pool ID\",\"type\":\"string\"}},\"required\":[\"providerId\",\"description\",\"created\",\"lastModified\",\"config\",\"owner\",\"emailOnError\",\"currentCapacity\"],\"title\":\"Worker Pool Full Definition\",\"type\":\"object\"}",
+	"/schemas/worker-manager/v1/worker-pool-list.json":            "{\"$id\":\"/schemas/worker-manager/v1/worker-pool-list.json#\",\"$schema\":\"/schemas/common/metaschema.json#\",\"additionalProperties\":false,\"description\":\"A list of worker pools\",\"properties\":{\"continuationToken\":{\"description\":\"Opaque `continuationToken` to be given as query-string option to get the\\nnext set of worker-types in the worker-manager.\\nThis property is only present if another request is necessary to fetch all\\nresults. In practice the next request with a `continuationToken` may not\\nreturn additional results, but it can. Thus, you can only be sure to have\\nall the results if you've called `listWorkerPools` with `continuationToken`\\nuntil you get a result without a `continuationToken`.\\n\",\"title\":\"Continuation Token\",\"type\":\"string\"},\"workerPools\":{\"description\":\"List of all worker pools\",\"items\":{\"$ref\":\"worker-pool-full.json#\"},\"title\":\"Worker Pools\",\"type\":\"array\",\"uniqueItems\":true}},\"required\":[\"workerPools\"],\"title\":\"Worker Pool List\",\"type\":\"object\"}",
+}
+
+// Auth is the interface of the methods of the Auth service, as implemented by
+// the client returned by NewAuth.
+type Auth interface {
+	// AuthenticateHawk calls authenticateHawk: Authenticate Hawk Request
+	AuthenticateHawk(payload []byte) ([]byte, error)
+	// AwsS3Credentials calls awsS3Credentials: Get Temporary Read/Write Credentials
+	// S3
+	AwsS3Credentials(level string, bucket string, prefix string, query map[string]string) ([]byte, error)
+	// AwsS3CredentialsSignURL returns a URL for awsS3Credentials, signed with the
+	// credentials and valid for the given duration, without calling it
+	AwsS3CredentialsSignURL(duration time.Duration, level string, bucket string, prefix string, query map[string]string) (string, error)
+	// AzureAccounts calls azureAccounts: List Accounts Managed by Auth
+	AzureAccounts() ([]byte, error)
+	// AzureAccountsSignURL returns a URL for azureAccounts, signed with the
+	// credentials and valid for the given duration, without calling it
+	AzureAccountsSignURL(duration time.Duration) (string, error)
+	// AzureContainerSAS calls azureContainerSAS: Get Shared-Access-Signature for
+	// Azure Container
+	AzureContainerSAS(account string, container string, level string) ([]byte, error)
+	// AzureContainerSASSignURL returns a URL for azureContainerSAS, signed with the
+	// credentials and valid for the given duration, without calling it
+	AzureContainerSASSignURL(duration time.Duration, account string, container string, level string) (string, error)
+	// AzureContainers calls azureContainers: List containers in an Account Managed
+	// by Auth
+	AzureContainers(account string, query map[string]string) ([]byte, error)
+	// AzureContainersSignURL returns a URL for azureContainers, signed with the
+	// credentials and valid for the given duration, without calling it
+	AzureContainersSignURL(duration time.Duration, account string, query map[string]string) (string, error)
+	// AzureTableSAS calls azureTableSAS: Get Shared-Access-Signature for Azure
+	// Table
+	AzureTableSAS(account string, table string, level string) ([]byte, error)
+	// AzureTableSASSignURL returns a URL for azureTableSAS, signed with the
+	// credentials and valid for the given duration, without calling it
+	AzureTableSASSignURL(duration time.Duration, account string, table string, level string) (string, error)
+	// AzureTables calls azureTables: List Tables in an Account Managed by Auth
+	AzureTables(account string, query map[string]string) ([]byte, error)
+	// AzureTablesSignURL returns a URL for azureTables, signed with the credentials
+	// and valid for the given duration, without calling it
+	AzureTablesSignURL(duration time.Duration, account string, query map[string]string) (string, error)
+	// Client calls client: Get Client
+	Client(clientId string) ([]byte, error)
+	// CreateClient calls createClient: Create Client
+	CreateClient(clientId string, payload []byte) ([]byte, error)
+	// CreateRole calls createRole: Create Role
+	CreateRole(roleId string, payload []byte) ([]byte, error)
+	// CurrentScopes calls currentScopes: Get Current Scopes
+	CurrentScopes() ([]byte, error)
+	// DeleteClient calls deleteClient: Delete Client
+	DeleteClient(clientId string) ([]byte, error)
+	// DeleteRole calls deleteRole: Delete Role
+	DeleteRole(roleId string) ([]byte, error)
+	// DisableClient calls disableClient: Disable Client
+	DisableClient(clientId string) ([]byte, error)
+	// EnableClient calls enableClient: Enable Client
+	EnableClient(clientId string) ([]byte, error)
+	// ExpandScopes calls expandScopes: Expand Scopes
+	ExpandScopes(payload []byte) ([]byte, error)
+	// GcpCredentials calls gcpCredentials: Get Temporary GCP Credentials
+	GcpCredentials(projectId string, serviceAccount string) ([]byte, error)
+	// GcpCredentialsSignURL returns a URL for gcpCredentials, signed with the
+	// credentials and valid for the given duration, without calling it
+	GcpCredentialsSignURL(duration time.Duration, projectId string, serviceAccount string) (string, error)
+	// ListClients calls listClients: List Clients
+	ListClients(query map[string]string) ([]byte, error)
+	// ListRoleIds calls listRoleIds: List Role IDs
+	ListRoleIds(query map[string]string) ([]byte, error)
+	// ListRoles calls listRoles: List Roles (no pagination)
+	ListRoles() ([]byte, error)
+	// ListRoles2 calls listRoles2: List Roles
+	ListRoles2(query map[string]string) ([]byte, error)
+	// Ping calls ping: Ping Server
+	Ping() ([]byte, error)
+	// ResetAccessToken calls resetAccessToken: Reset `accessToken`
+	ResetAccessToken(clientId string) ([]byte, error)
+	// Role calls role: Get Role
+	Role(roleId string) ([]byte, error)
+	// SentryDSN calls sentryDSN: Get DSN for Sentry Project
+	SentryDSN(project string) ([]byte, error)
+	// SentryDSNSignURL returns a URL for sentryDSN, signed with the credentials and
+	// valid for the given duration, without calling it
+	SentryDSNSignURL(duration time.Duration, project string) (string, error)
+	// TestAuthenticate calls testAuthenticate: Test Authentication
+	TestAuthenticate(payload []byte) ([]byte, error)
+	// TestAuthenticateGet calls testAuthenticateGet: Test Authentication (GET)
+	TestAuthenticateGet() ([]byte, error)
+	// TestAuthenticateGetSignURL returns a URL for testAuthenticateGet, signed with
+	// the credentials and valid for the given duration, without calling it
+	TestAuthenticateGetSignURL(duration time.Duration) (string, error)
+	// UpdateClient calls updateClient: Update Client
+	UpdateClient(clientId string, payload []byte) ([]byte, error)
+	// UpdateRole calls updateRole: Update Role
+	UpdateRole(roleId string, payload []byte) ([]byte, error)
+	// WebsocktunnelToken calls websocktunnelToken: Get a client token for the
+	// Websocktunnel service
+	WebsocktunnelToken(wstAudience string, wstClient string) ([]byte, error)
+	// WebsocktunnelTokenSignURL returns a URL for websocktunnelToken, signed with
+	// the credentials and valid for the given duration, without calling it
+	WebsocktunnelTokenSignURL(duration time.Duration, wstAudience string, wstClient string) (string, error)
+}
+
+type authClient struct {
+	conn *connection
+}
+
+// NewAuth returns a client for the Auth service, using the configured root URL
+// and credentials.
+func NewAuth() Auth {
+	return authClient{}
+}
+
+// NewAuthFromEnv returns a client for the Auth service, using the root URL and
+// credentials given by TASKCLUSTER_ROOT_URL, TASKCLUSTER_CLIENT_ID,
+// TASKCLUSTER_ACCESS_TOKEN and, for temporary credentials,
+// TASKCLUSTER_CERTIFICATE. The error lists the variables which are missing, if
+// any.
+func NewAuthFromEnv() (Auth, error) {
+	conn, err := connectionFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return authClient{conn: conn}, nil
+}
+
+func (c authClient) AuthenticateHawk(payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "authenticateHawk", nil, nil, payload)
+}
+
+func (c authClient) AwsS3Credentials(level string, bucket string, prefix string, query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "awsS3Credentials", map[string]string{"level": level, "bucket": bucket, "prefix": prefix}, query, nil)
+}
+
+func (c authClient) AwsS3CredentialsSignURL(duration time.Duration, level string, bucket string, prefix string, query map[string]string) (string, error) {
+	return signURL(c.conn, "Auth", "awsS3Credentials", duration, map[string]string{"level": level, "bucket": bucket, "prefix": prefix}, query)
+}
+
+func (c authClient) AzureAccounts() ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "azureAccounts", nil, nil, nil)
+}
+
+func (c authClient) AzureAccountsSignURL(duration time.Duration) (string, error) {
+	return signURL(c.conn, "Auth", "azureAccounts", duration, nil, nil)
+}
+
+func (c authClient) AzureContainerSAS(account string, container string, level string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "azureContainerSAS", map[string]string{"account": account, "container": container, "level": level}, nil, nil)
+}
+
+func (c authClient) AzureContainerSASSignURL(duration time.Duration, account string, container string, level string) (string, error) {
+	return signURL(c.conn, "Auth", "azureContainerSAS", duration, map[string]string{"account": account, "container": container, "level": level}, nil)
+}
+
+func (c authClient) AzureContainers(account string, query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "azureContainers", map[string]string{"account": account}, query, nil)
+}
+
+func (c authClient) AzureContainersSignURL(duration time.Duration, account string, query map[string]string) (string, error) {
+	return signURL(c.conn, "Auth", "azureContainers", duration, map[string]string{"account": account}, query)
+}
+
+func (c authClient) AzureTableSAS(account string, table string, level string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "azureTableSAS", map[string]string{"account": account, "table": table, "level": level}, nil, nil)
+}
+
+func (c authClient) AzureTableSASSignURL(duration time.Duration, account string, table string, level string) (string, error) {
+	return signURL(c.conn, "Auth", "azureTableSAS", duration, map[string]string{"account": account, "table": table, "level": level}, nil)
+}
+
+func (c authClient) AzureTables(account string, query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "azureTables", map[string]string{"account": account}, query, nil)
+}
+
+func (c authClient) AzureTablesSignURL(duration time.Duration, account string, query map[string]string) (string, error) {
+	return signURL(c.conn, "Auth", "azureTables", duration, map[string]string{"account": account}, query)
+}
+
+func (c authClient) Client(clientId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "client", map[string]string{"clientId": clientId}, nil, nil)
+}
+
+func (c authClient) CreateClient(clientId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "createClient", map[string]string{"clientId": clientId}, nil, payload)
+}
+
+func (c authClient) CreateRole(roleId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "createRole", map[string]string{"roleId": roleId}, nil, payload)
+}
+
+func (c authClient) CurrentScopes() ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "currentScopes", nil, nil, nil)
+}
+
+func (c authClient) DeleteClient(clientId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "deleteClient", map[string]string{"clientId": clientId}, nil, nil)
+}
+
+func (c authClient) DeleteRole(roleId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "deleteRole", map[string]string{"roleId": roleId}, nil, nil)
+}
+
+func (c authClient) DisableClient(clientId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "disableClient", map[string]string{"clientId": clientId}, nil, nil)
+}
+
+func (c authClient) EnableClient(clientId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "enableClient", map[string]string{"clientId": clientId}, nil, nil)
+}
+
+func (c authClient) ExpandScopes(payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "expandScopes", nil, nil, payload)
+}
+
+func (c authClient) GcpCredentials(projectId string, serviceAccount string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "gcpCredentials", map[string]string{"projectId": projectId, "serviceAccount": serviceAccount}, nil, nil)
+}
+
+func (c authClient) GcpCredentialsSignURL(duration time.Duration, projectId string, serviceAccount string) (string, error) {
+	return signURL(c.conn, "Auth", "gcpCredentials", duration, map[string]string{"projectId": projectId, "serviceAccount": serviceAccount}, nil)
+}
+
+func (c authClient) ListClients(query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "listClients", nil, query, nil)
+}
+
+func (c authClient) ListRoleIds(query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "listRoleIds", nil, query, nil)
+}
+
+func (c authClient) ListRoles() ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "listRoles", nil, nil, nil)
+}
+
+func (c authClient) ListRoles2(query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "listRoles2", nil, query, nil)
+}
+
+func (c authClient) Ping() ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "ping", nil, nil, nil)
+}
+
+func (c authClient) ResetAccessToken(clientId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "resetAccessToken", map[string]string{"clientId": clientId}, nil, nil)
+}
+
+func (c authClient) Role(roleId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "role", map[string]string{"roleId": roleId}, nil, nil)
+}
+
+func (c authClient) SentryDSN(project string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "sentryDSN", map[string]string{"project": project}, nil, nil)
+}
+
+func (c authClient) SentryDSNSignURL(duration time.Duration, project string) (string, error) {
+	return signURL(c.conn, "Auth", "sentryDSN", duration, map[string]string{"project": project}, nil)
+}
+
+func (c authClient) TestAuthenticate(payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "testAuthenticate", nil, nil, payload)
+}
+
+func (c authClient) TestAuthenticateGet() ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "testAuthenticateGet", nil, nil, nil)
+}
+
+func (c authClient) TestAuthenticateGetSignURL(duration time.Duration) (string, error) {
+	return signURL(c.conn, "Auth", "testAuthenticateGet", duration, nil, nil)
+}
+
+func (c authClient) UpdateClient(clientId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "updateClient", map[string]string{"clientId": clientId}, nil, payload)
+}
+
+func (c authClient) UpdateRole(roleId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "updateRole", map[string]string{"roleId": roleId}, nil, payload)
+}
+
+func (c authClient) WebsocktunnelToken(wstAudience string, wstClient string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Auth", "websocktunnelToken", map[string]string{"wstAudience": wstAudience, "wstClient": wstClient}, nil, nil)
+}
+
+func (c authClient) WebsocktunnelTokenSignURL(duration time.Duration, wstAudience string, wstClient string) (string, error) {
+	return signURL(c.conn, "Auth", "websocktunnelToken", duration, map[string]string{"wstAudience": wstAudience, "wstClient": wstClient}, nil)
+}
+
+// Github is the interface of the methods of the Github service, as implemented
+// by the client returned by NewGithub.
+type Github interface {
+	// Badge calls badge: Latest Build Status Badge
+	Badge(owner string, repo string, branch string) ([]byte, error)
+	// Builds calls builds: List of Builds
+	Builds(query map[string]string) ([]byte, error)
+	// CreateComment calls createComment: Post a comment on a given GitHub Issue or
+	// Pull Request
+	CreateComment(owner string, repo string, number string, payload []byte) ([]byte, error)
+	// CreateStatus calls createStatus: Post a status against a given changeset
+	CreateStatus(owner string, repo string, sha string, payload []byte) ([]byte, error)
+	// GithubWebHookConsumer calls githubWebHookConsumer: Consume GitHub WebHook
+	GithubWebHookConsumer() ([]byte, error)
+	// Latest calls latest: Latest Status for Branch
+	Latest(owner string, repo string, branch string) ([]byte, error)
+	// Ping calls ping: Ping Server
+	Ping() ([]byte, error)
+	// Repository calls repository: Get Repository Info
+	Repository(owner string, repo string) ([]byte, error)
+}
+
+type githubClient struct {
+	conn *connection
+}
+
+// NewGithub returns a client for the Github service, using the configured root
+// URL and credentials.
+func NewGithub() Github {
+	return githubClient{}
+}
+
+// NewGithubFromEnv returns a client for the Github service, using the root URL
+// and credentials given by TASKCLUSTER_ROOT_URL, TASKCLUSTER_CLIENT_ID,
+// TASKCLUSTER_ACCESS_TOKEN and, for temporary credentials,
+// TASKCLUSTER_CERTIFICATE. The error lists the variables which are missing, if
+// any.
+func NewGithubFromEnv() (Github, error) {
+	conn, err := connectionFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return githubClient{conn: conn}, nil
+}
+
+func (c githubClient) Badge(owner string, repo string, branch string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Github", "badge", map[string]string{"owner": owner, "repo": repo, "branch": branch}, nil, nil)
+}
+
+func (c githubClient) Builds(query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Github", "builds", nil, query, nil)
+}
+
+func (c githubClient) CreateComment(owner string, repo string, number string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Github", "createComment", map[string]string{"owner": owner, "repo": repo, "number": number}, nil, payload)
+}
+
+func (c githubClient) CreateStatus(owner string, repo string, sha string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Github", "createStatus", map[string]string{"owner": owner, "repo": repo, "sha": sha}, nil, payload)
+}
+
+func (c githubClient) GithubWebHookConsumer() ([]byte, error) {
+	return call(context.Background(), c.conn, "Github", "githubWebHookConsumer", nil, nil, nil)
+}
+
+func (c githubClient) Latest(owner string, repo string, branch string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Github", "latest", map[string]string{"owner": owner, "repo": repo, "branch": branch}, nil, nil)
+}
+
+func (c githubClient) Ping() ([]byte, error) {
+	return call(context.Background(), c.conn, "Github", "ping", nil, nil, nil)
+}
+
+func (c githubClient) Repository(owner string, repo string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Github", "repository", map[string]string{"owner": owner, "repo": repo}, nil, nil)
+}
+
+// Hooks is the interface of the methods of the Hooks service, as implemented by
+// the client returned by NewHooks.
+type Hooks interface {
+	// CreateHook calls createHook: Create a hook
+	CreateHook(hookGroupId string, hookId string, payload []byte) ([]byte, error)
+	// GetHookStatus calls getHookStatus: Get hook status
+	GetHookStatus(hookGroupId string, hookId string) ([]byte, error)
+	// GetTriggerToken calls getTriggerToken: Get a trigger token
+	GetTriggerToken(hookGroupId string, hookId string) ([]byte, error)
+	// GetTriggerTokenSignURL returns a URL for getTriggerToken, signed with the
+	// credentials and valid for the given duration, without calling it
+	GetTriggerTokenSignURL(duration time.Duration, hookGroupId string, hookId string) (string, error)
+	// Hook calls hook: Get hook definition
+	Hook(hookGroupId string, hookId string) ([]byte, error)
+	// ListHookGroups calls listHookGroups: List hook groups
+	ListHookGroups() ([]byte, error)
+	// ListHooks calls listHooks: List hooks in a given group
+	ListHooks(hookGroupId string) ([]byte, error)
+	// ListLastFires calls listLastFires: Get information about recent hook fires
+	ListLastFires(hookGroupId string, hookId string) ([]byte, error)
+	// Ping calls ping: Ping Server
+	Ping() ([]byte, error)
+	// RemoveHook calls removeHook: Delete a hook
+	RemoveHook(hookGroupId string, hookId string) ([]byte, error)
+	// ResetTriggerToken calls resetTriggerToken: Reset a trigger token
+	ResetTriggerToken(hookGroupId string, hookId string) ([]byte, error)
+	// TriggerHook calls triggerHook: Trigger a hook
+	TriggerHook(hookGroupId string, hookId string, payload []byte) ([]byte, error)
+	// TriggerHookWithToken calls triggerHookWithToken: Trigger a hook with a token
+	TriggerHookWithToken(hookGroupId string, hookId string, token string, payload []byte) ([]byte, error)
+	// UpdateHook calls updateHook: Update a hook
+	UpdateHook(hookGroupId string, hookId string, payload []byte) ([]byte, error)
+}
+
+type hooksClient struct {
+	conn *connection
+}
+
+// NewHooks returns a client for the Hooks service, using the configured root
+// URL and credentials.
+func NewHooks() Hooks {
+	return hooksClient{}
+}
+
+// NewHooksFromEnv returns a client for the Hooks service, using the root URL
+// and credentials given by TASKCLUSTER_ROOT_URL, TASKCLUSTER_CLIENT_ID,
+// TASKCLUSTER_ACCESS_TOKEN and, for temporary credentials,
+// TASKCLUSTER_CERTIFICATE. The error lists the variables which are missing, if
+// any.
+func NewHooksFromEnv() (Hooks, error) {
+	conn, err := connectionFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return hooksClient{conn: conn}, nil
+}
+
+func (c hooksClient) CreateHook(hookGroupId string, hookId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Hooks", "createHook", map[string]string{"hookGroupId": hookGroupId, "hookId": hookId}, nil, payload)
+}
+
+func (c hooksClient) GetHookStatus(hookGroupId string, hookId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Hooks", "getHookStatus", map[string]string{"hookGroupId": hookGroupId, "hookId": hookId}, nil, nil)
+}
+
+func (c hooksClient) GetTriggerToken(hookGroupId string, hookId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Hooks", "getTriggerToken", map[string]string{"hookGroupId": hookGroupId, "hookId": hookId}, nil, nil)
+}
+
+func (c hooksClient) GetTriggerTokenSignURL(duration time.Duration, hookGroupId string, hookId string) (string, error) {
+	return signURL(c.conn, "Hooks", "getTriggerToken", duration, map[string]string{"hookGroupId": hookGroupId, "hookId": hookId}, nil)
+}
+
+func (c hooksClient) Hook(hookGroupId string, hookId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Hooks", "hook", map[string]string{"hookGroupId": hookGroupId, "hookId": hookId}, nil, nil)
+}
+
+func (c hooksClient) ListHookGroups() ([]byte, error) {
+	return call(context.Background(), c.conn, "Hooks", "listHookGroups", nil, nil, nil)
+}
+
+func (c hooksClient) ListHooks(hookGroupId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Hooks", "listHooks", map[string]string{"hookGroupId": hookGroupId}, nil, nil)
+}
+
+func (c hooksClient) ListLastFires(hookGroupId string, hookId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Hooks", "listLastFires", map[string]string{"hookGroupId": hookGroupId, "hookId": hookId}, nil, nil)
+}
+
+func (c hooksClient) Ping() ([]byte, error) {
+	return call(context.Background(), c.conn, "Hooks", "ping", nil, nil, nil)
+}
+
+func (c hooksClient) RemoveHook(hookGroupId string, hookId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Hooks", "removeHook", map[string]string{"hookGroupId": hookGroupId, "hookId": hookId}, nil, nil)
+}
+
+func (c hooksClient) ResetTriggerToken(hookGroupId string, hookId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Hooks", "resetTriggerToken", map[string]string{"hookGroupId": hookGroupId, "hookId": hookId}, nil, nil)
+}
+
+func (c hooksClient) TriggerHook(hookGroupId string, hookId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Hooks", "triggerHook", map[string]string{"hookGroupId": hookGroupId, "hookId": hookId}, nil, payload)
+}
+
+func (c hooksClient) TriggerHookWithToken(hookGroupId string, hookId string, token string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Hooks", "triggerHookWithToken", map[string]string{"hookGroupId": hookGroupId, "hookId": hookId, "token": token}, nil, payload)
+}
+
+func (c hooksClient) UpdateHook(hookGroupId string, hookId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Hooks", "updateHook", map[string]string{"hookGroupId": hookGroupId, "hookId": hookId}, nil, payload)
+}
+
+// Index is the interface of the methods of the Index service, as implemented by
+// the client returned by NewIndex.
+type Index interface {
+	// FindArtifactFromTask calls findArtifactFromTask: Get Artifact From Indexed
+	// Task
+	FindArtifactFromTask(indexPath string, name string) ([]byte, error)
+	// FindArtifactFromTaskSignURL returns a URL for findArtifactFromTask, signed
+	// with the credentials and valid for the given duration, without calling it
+	FindArtifactFromTaskSignURL(duration time.Duration, indexPath string, name string) (string, error)
+	// FindArtifactFromTaskStream calls findArtifactFromTask, returning the content
+	// of the artifact as a stream, which the caller must close.
+	FindArtifactFromTaskStream(indexPath string, name string) (io.ReadCloser, error)
+	// FindTask calls findTask: Find Indexed Task
+	FindTask(indexPath string) ([]byte, error)
+	// InsertTask calls insertTask: Insert Task into Index
+	InsertTask(namespace string, payload []byte) ([]byte, error)
+	// ListNamespaces calls listNamespaces: List Namespaces
+	ListNamespaces(namespace string, query map[string]string) ([]byte, error)
+	// ListTasks calls listTasks: List Tasks
+	ListTasks(namespace string, query map[string]string) ([]byte, error)
+	// Ping calls ping: Ping Server
+	Ping() ([]byte, error)
+}
+
+type indexClient struct {
+	conn *connection
+}
+
+// NewIndex returns a client for the Index service, using the configured root
+// URL and credentials.
+func NewIndex() Index {
+	return indexClient{}
+}
+
+// NewIndexFromEnv returns a client for the Index service, using the root URL
+// and credentials given by TASKCLUSTER_ROOT_URL, TASKCLUSTER_CLIENT_ID,
+// TASKCLUSTER_ACCESS_TOKEN and, for temporary credentials,
+// TASKCLUSTER_CERTIFICATE. The error lists the variables which are missing, if
+// any.
+func NewIndexFromEnv() (Index, error) {
+	conn, err := connectionFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return indexClient{conn: conn}, nil
+}
+
+func (c indexClient) FindArtifactFromTask(indexPath string, name string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Index", "findArtifactFromTask", map[string]string{"indexPath": indexPath, "name": name}, nil, nil)
+}
+
+func (c indexClient) FindArtifactFromTaskSignURL(duration time.Duration, indexPath string, name string) (string, error) {
+	return signURL(c.conn, "Index", "findArtifactFromTask", duration, map[string]string{"indexPath": indexPath, "name": name}, nil)
+}
+
+func (c indexClient) FindArtifactFromTaskStream(indexPath string, name string) (io.ReadCloser, error) {
+	return callStream(context.Background(), c.conn, "Index", "findArtifactFromTask", map[string]string{"indexPath": indexPath, "name": name}, nil, nil)
+}
+
+func (c indexClient) FindTask(indexPath string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Index", "findTask", map[string]string{"indexPath": indexPath}, nil, nil)
+}
+
+func (c indexClient) InsertTask(namespace string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Index", "insertTask", map[string]string{"namespace": namespace}, nil, payload)
+}
+
+func (c indexClient) ListNamespaces(namespace string, query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Index", "listNamespaces", map[string]string{"namespace": namespace}, query, nil)
+}
+
+func (c indexClient) ListTasks(namespace string, query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Index", "listTasks", map[string]string{"namespace": namespace}, query, nil)
+}
+
+func (c indexClient) Ping() ([]byte, error) {
+	return call(context.Background(), c.conn, "Index", "ping", nil, nil, nil)
+}
+
+// Notify is the interface of the methods of the Notify service, as implemented
+// by the client returned by NewNotify.
+type Notify interface {
+	// AddDenylistAddress calls addDenylistAddress: Denylist Given Address
+	AddDenylistAddress(payload []byte) ([]byte, error)
+	// DeleteDenylistAddress calls deleteDenylistAddress: Delete Denylisted Address
+	DeleteDenylistAddress(payload []byte) ([]byte, error)
+	// Email calls email: Send an Email
+	Email(payload []byte) ([]byte, error)
+	// Irc calls irc: Post IRC Message
+	Irc(payload []byte) ([]byte, error)
+	// ListDenylist calls listDenylist: List Denylisted Notifications
+	ListDenylist(query map[string]string) ([]byte, error)
+	// ListDenylistSignURL returns a URL for listDenylist, signed with the
+	// credentials and valid for the given duration, without calling it
+	ListDenylistSignURL(duration time.Duration, query map[string]string) (string, error)
+	// Matrix calls matrix: Post Matrix Message
+	Matrix(payload []byte) ([]byte, error)
+	// Ping calls ping: Ping Server
+	Ping() ([]byte, error)
+	// Pulse calls pulse: Publish a Pulse Message
+	Pulse(payload []byte) ([]byte, error)
+}
+
+type notifyClient struct {
+	conn *connection
+}
+
+// NewNotify returns a client for the Notify service, using the configured root
+// URL and credentials.
+func NewNotify() Notify {
+	return notifyClient{}
+}
+
+// NewNotifyFromEnv returns a client for the Notify service, using the root URL
+// and credentials given by TASKCLUSTER_ROOT_URL, TASKCLUSTER_CLIENT_ID,
+// TASKCLUSTER_ACCESS_TOKEN and, for temporary credentials,
+// TASKCLUSTER_CERTIFICATE. The error lists the variables which are missing, if
+// any.
+func NewNotifyFromEnv() (Notify, error) {
+	conn, err := connectionFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return notifyClient{conn: conn}, nil
+}
+
+func (c notifyClient) AddDenylistAddress(payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Notify", "addDenylistAddress", nil, nil, payload)
+}
+
+func (c notifyClient) DeleteDenylistAddress(payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Notify", "deleteDenylistAddress", nil, nil, payload)
+}
+
+func (c notifyClient) Email(payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Notify", "email", nil, nil, payload)
+}
+
+func (c notifyClient) Irc(payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Notify", "irc", nil, nil, payload)
+}
+
+func (c notifyClient) ListDenylist(query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Notify", "listDenylist", nil, query, nil)
+}
+
+func (c notifyClient) ListDenylistSignURL(duration time.Duration, query map[string]string) (string, error) {
+	return signURL(c.conn, "Notify", "listDenylist", duration, nil, query)
+}
+
+func (c notifyClient) Matrix(payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Notify", "matrix", nil, nil, payload)
+}
+
+func (c notifyClient) Ping() ([]byte, error) {
+	return call(context.Background(), c.conn, "Notify", "ping", nil, nil, nil)
+}
+
+func (c notifyClient) Pulse(payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Notify", "pulse", nil, nil, payload)
+}
+
+// PurgeCache is the interface of the methods of the PurgeCache service, as
+// implemented by the client returned by NewPurgeCache.
+type PurgeCache interface {
+	// AllPurgeRequests calls allPurgeRequests: All Open Purge Requests
+	AllPurgeRequests(query map[string]string) ([]byte, error)
+	// Ping calls ping: Ping Server
+	Ping() ([]byte, error)
+	// PurgeCache calls purgeCache: Purge Worker Cache
+	PurgeCache(provisionerId string, workerType string, payload []byte) ([]byte, error)
+	// PurgeRequests calls purgeRequests: Open Purge Requests for a
+	// provisionerId/workerType pair
+	PurgeRequests(provisionerId string, workerType string, query map[string]string) ([]byte, error)
+}
+
+type purgeCacheClient struct {
+	conn *connection
+}
+
+// NewPurgeCache returns a client for the PurgeCache service, using the
+// configured root URL and credentials.
+func NewPurgeCache() PurgeCache {
+	return purgeCacheClient{}
+}
+
+// NewPurgeCacheFromEnv returns a client for the PurgeCache service, using the
+// root URL and credentials given by TASKCLUSTER_ROOT_URL,
+// TASKCLUSTER_CLIENT_ID, TASKCLUSTER_ACCESS_TOKEN and, for temporary
+// credentials, TASKCLUSTER_CERTIFICATE. The error lists the variables which are
+// missing, if any.
+func NewPurgeCacheFromEnv() (PurgeCache, error) {
+	conn, err := connectionFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return purgeCacheClient{conn: conn}, nil
+}
+
+func (c purgeCacheClient) AllPurgeRequests(query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "PurgeCache", "allPurgeRequests", nil, query, nil)
+}
+
+func (c purgeCacheClient) Ping() ([]byte, error) {
+	return call(context.Background(), c.conn, "PurgeCache", "ping", nil, nil, nil)
+}
+
+func (c purgeCacheClient) PurgeCache(provisionerId string, workerType string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "PurgeCache", "purgeCache", map[string]string{"provisionerId": provisionerId, "workerType": workerType}, nil, payload)
+}
+
+func (c purgeCacheClient) PurgeRequests(provisionerId string, workerType string, query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "PurgeCache", "purgeRequests", map[string]string{"provisionerId": provisionerId, "workerType": workerType}, query, nil)
+}
+
+// Queue is the interface of the methods of the Queue service, as implemented by
+// the client returned by NewQueue.
+type Queue interface {
+	// CancelTask calls cancelTask: Cancel Task
+	CancelTask(taskId string) ([]byte, error)
+	// ClaimTask calls claimTask: Claim Task
+	ClaimTask(taskId string, runId string, payload []byte) ([]byte, error)
+	// ClaimWork calls claimWork: Claim Work
+	ClaimWork(provisionerId string, workerType string, payload []byte) ([]byte, error)
+	// CreateArtifact calls createArtifact: Create Artifact
+	CreateArtifact(taskId string, runId string, name string, payload []byte) ([]byte, error)
+	// CreateArtifactUpload calls createArtifact, then uploads the content of the
+	// named file to the putUrl of the response, with the Content-Type of the
+	// payload (which is detected from the file if not given).
+	CreateArtifactUpload(taskId string, runId string, name string, payload []byte, filename string) ([]byte, error)
+	// CreateTask calls createTask: Create New Task
+	CreateTask(taskId string, payload []byte) ([]byte, error)
+	// DeclareProvisioner calls declareProvisioner: Update a provisioner
+	DeclareProvisioner(provisionerId string, payload []byte) ([]byte, error)
+	// DeclareWorker calls declareWorker: Declare a worker
+	DeclareWorker(provisionerId string, workerType string, workerGroup string, workerId string, payload []byte) ([]byte, error)
+	// DeclareWorkerType calls declareWorkerType: Update a worker-type
+	DeclareWorkerType(provisionerId string, workerType string, payload []byte) ([]byte, error)
+	// GetArtifact calls getArtifact: Get Artifact from Run
+	GetArtifact(taskId string, runId string, name string) ([]byte, error)
+	// GetArtifactSignURL returns a URL for getArtifact, signed with the credentials
+	// and valid for the given duration, without calling it
+	GetArtifactSignURL(duration time.Duration, taskId string, runId string, name string) (string, error)
+	// GetArtifactStream calls getArtifact, returning the content of the artifact as
+	// a stream, which the caller must close.
+	GetArtifactStream(taskId string, runId string, name string) (io.ReadCloser, error)
+	// GetLatestArtifact calls getLatestArtifact: Get Artifact from Latest Run
+	GetLatestArtifact(taskId string, name string) ([]byte, error)
+	// GetLatestArtifactSignURL returns a URL for getLatestArtifact, signed with the
+	// credentials and valid for the given duration, without calling it
+	GetLatestArtifactSignURL(duration time.Duration, taskId string, name string) (string, error)
+	// GetLatestArtifactStream calls getLatestArtifact, returning the content of the
+	// artifact as a stream, which the caller must close.
+	GetLatestArtifactStream(taskId string, name string) (io.ReadCloser, error)
+	// GetProvisioner calls getProvisioner: Get an active provisioner
+	GetProvisioner(provisionerId string) ([]byte, error)
+	// GetWorker calls getWorker: Get a worker-type
+	GetWorker(provisionerId string, workerType string, workerGroup string, workerId string) ([]byte, error)
+	// GetWorkerType calls getWorkerType: Get a worker-type
+	GetWorkerType(provisionerId string, workerType string) ([]byte, error)
+	// ListArtifacts calls listArtifacts: Get Artifacts from Run
+	ListArtifacts(taskId string, runId string, query map[string]string) ([]byte, error)
+	// ListDependentTasks calls listDependentTasks: List Dependent Tasks
+	ListDependentTasks(taskId string, query map[string]string) ([]byte, error)
+	// ListLatestArtifacts calls listLatestArtifacts: Get Artifacts from Latest Run
+	ListLatestArtifacts(taskId string, query map[string]string) ([]byte, error)
+	// ListProvisioners calls listProvisioners: Get a list of all active
+	// provisioners
+	ListProvisioners(query map[string]string) ([]byte, error)
+	// ListTaskGroup calls listTaskGroup: List Task Group
+	ListTaskGroup(taskGroupId string, query map[string]string) ([]byte, error)
+	// ListWorkerTypes calls listWorkerTypes: Get a list of all active worker-types
+	ListWorkerTypes(provisionerId string, query map[string]string) ([]byte, error)
+	// ListWorkers calls listWorkers: Get a list of all active workers of a
+	// workerType
+	ListWorkers(provisionerId string, workerType string, query map[string]string) ([]byte, error)
+	// PendingTasks calls pendingTasks: Get Number of Pending Tasks
+	PendingTasks(provisionerId string, workerType string) ([]byte, error)
+	// Ping calls ping: Ping Server
+	Ping() ([]byte, error)
+	// QuarantineWorker calls quarantineWorker: Quarantine a worker
+	QuarantineWorker(provisionerId string, workerType string, workerGroup string, workerId string, payload []byte) ([]byte, error)
+	// ReclaimTask calls reclaimTask: Reclaim task
+	ReclaimTask(taskId string, runId string) ([]byte, error)
+	// ReportCompleted calls reportCompleted: Report Run Completed
+	ReportCompleted(taskId string, runId string) ([]byte, error)
+	// ReportException calls reportException: Report Task Exception
+	ReportException(taskId string, runId string, payload []byte) ([]byte, error)
+	// ReportFailed calls reportFailed: Report Run Failed
+	ReportFailed(taskId string, runId string) ([]byte, error)
+	// RerunTask calls rerunTask: Rerun a Resolved Task
+	RerunTask(taskId string) ([]byte, error)
+	// ScheduleTask calls scheduleTask: Schedule Defined Task
+	ScheduleTask(taskId string) ([]byte, error)
+	// Status calls status: Get task status
+	Status(taskId string) ([]byte, error)
+	// Task calls task: Get Task Definition
+	Task(taskId string) ([]byte, error)
+}
+
+type queueClient struct {
+	conn *connection
+}
+
+// NewQueue returns a client for the Queue service, using the configured root
+// URL and credentials.
+func NewQueue() Queue {
+	return queueClient{}
+}
+
+// NewQueueFromEnv returns a client for the Queue service, using the root URL
+// and credentials given by TASKCLUSTER_ROOT_URL, TASKCLUSTER_CLIENT_ID,
+// TASKCLUSTER_ACCESS_TOKEN and, for temporary credentials,
+// TASKCLUSTER_CERTIFICATE. The error lists the variables which are missing, if
+// any.
+func NewQueueFromEnv() (Queue, error) {
+	conn, err := connectionFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return queueClient{conn: conn}, nil
+}
+
+func (c queueClient) CancelTask(taskId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "cancelTask", map[string]string{"taskId": taskId}, nil, nil)
+}
+
+func (c queueClient) ClaimTask(taskId string, runId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "claimTask", map[string]string{"taskId": taskId, "runId": runId}, nil, payload)
+}
+
+func (c queueClient) ClaimWork(provisionerId string, workerType string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "claimWork", map[string]string{"provisionerId": provisionerId, "workerType": workerType}, nil, payload)
+}
+
+func (c queueClient) CreateArtifact(taskId string, runId string, name string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "createArtifact", map[string]string{"taskId": taskId, "runId": runId, "name": name}, nil, payload)
+}
+
+func (c queueClient) CreateArtifactUpload(taskId string, runId string, name string, payload []byte, filename string) ([]byte, error) {
+	return callUpload(context.Background(), c.conn, "Queue", "createArtifact", map[string]string{"taskId": taskId, "runId": runId, "name": name}, nil, payload, filename)
+}
+
+func (c queueClient) CreateTask(taskId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "createTask", map[string]string{"taskId": taskId}, nil, payload)
+}
+
+func (c queueClient) DeclareProvisioner(provisionerId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "declareProvisioner", map[string]string{"provisionerId": provisionerId}, nil, payload)
+}
+
+func (c queueClient) DeclareWorker(provisionerId string, workerType string, workerGroup string, workerId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "declareWorker", map[string]string{"provisionerId": provisionerId, "workerType": workerType, "workerGroup": workerGroup, "workerId": workerId}, nil, payload)
+}
+
+func (c queueClient) DeclareWorkerType(provisionerId string, workerType string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "declareWorkerType", map[string]string{"provisionerId": provisionerId, "workerType": workerType}, nil, payload)
+}
+
+func (c queueClient) GetArtifact(taskId string, runId string, name string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "getArtifact", map[string]string{"taskId": taskId, "runId": runId, "name": name}, nil, nil)
+}
+
+func (c queueClient) GetArtifactSignURL(duration time.Duration, taskId string, runId string, name string) (string, error) {
+	return signURL(c.conn, "Queue", "getArtifact", duration, map[string]string{"taskId": taskId, "runId": runId, "name": name}, nil)
+}
+
+func (c queueClient) GetArtifactStream(taskId string, runId string, name string) (io.ReadCloser, error) {
+	return callStream(context.Background(), c.conn, "Queue", "getArtifact", map[string]string{"taskId": taskId, "runId": runId, "name": name}, nil, nil)
+}
+
+func (c queueClient) GetLatestArtifact(taskId string, name string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "getLatestArtifact", map[string]string{"taskId": taskId, "name": name}, nil, nil)
+}
+
+func (c queueClient) GetLatestArtifactSignURL(duration time.Duration, taskId string, name string) (string, error) {
+	return signURL(c.conn, "Queue", "getLatestArtifact", duration, map[string]string{"taskId": taskId, "name": name}, nil)
+}
+
+func (c queueClient) GetLatestArtifactStream(taskId string, name string) (io.ReadCloser, error) {
+	return callStream(context.Background(), c.conn, "Queue", "getLatestArtifact", map[string]string{"taskId": taskId, "name": name}, nil, nil)
+}
+
+func (c queueClient) GetProvisioner(provisionerId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "getProvisioner", map[string]string{"provisionerId": provisionerId}, nil, nil)
+}
+
+func (c queueClient) GetWorker(provisionerId string, workerType string, workerGroup string, workerId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "getWorker", map[string]string{"provisionerId": provisionerId, "workerType": workerType, "workerGroup": workerGroup, "workerId": workerId}, nil, nil)
+}
+
+func (c queueClient) GetWorkerType(provisionerId string, workerType string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "getWorkerType", map[string]string{"provisionerId": provisionerId, "workerType": workerType}, nil, nil)
+}
+
+func (c queueClient) ListArtifacts(taskId string, runId string, query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "listArtifacts", map[string]string{"taskId": taskId, "runId": runId}, query, nil)
+}
+
+func (c queueClient) ListDependentTasks(taskId string, query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "listDependentTasks", map[string]string{"taskId": taskId}, query, nil)
+}
+
+func (c queueClient) ListLatestArtifacts(taskId string, query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "listLatestArtifacts", map[string]string{"taskId": taskId}, query, nil)
+}
+
+func (c queueClient) ListProvisioners(query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "listProvisioners", nil, query, nil)
+}
+
+func (c queueClient) ListTaskGroup(taskGroupId string, query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "listTaskGroup", map[string]string{"taskGroupId": taskGroupId}, query, nil)
+}
+
+func (c queueClient) ListWorkerTypes(provisionerId string, query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "listWorkerTypes", map[string]string{"provisionerId": provisionerId}, query, nil)
+}
+
+func (c queueClient) ListWorkers(provisionerId string, workerType string, query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "listWorkers", map[string]string{"provisionerId": provisionerId, "workerType": workerType}, query, nil)
+}
+
+func (c queueClient) PendingTasks(provisionerId string, workerType string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "pendingTasks", map[string]string{"provisionerId": provisionerId, "workerType": workerType}, nil, nil)
+}
+
+func (c queueClient) Ping() ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "ping", nil, nil, nil)
+}
+
+func (c queueClient) QuarantineWorker(provisionerId string, workerType string, workerGroup string, workerId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "quarantineWorker", map[string]string{"provisionerId": provisionerId, "workerType": workerType, "workerGroup": workerGroup, "workerId": workerId}, nil, payload)
+}
+
+func (c queueClient) ReclaimTask(taskId string, runId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "reclaimTask", map[string]string{"taskId": taskId, "runId": runId}, nil, nil)
+}
+
+func (c queueClient) ReportCompleted(taskId string, runId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "reportCompleted", map[string]string{"taskId": taskId, "runId": runId}, nil, nil)
+}
+
+func (c queueClient) ReportException(taskId string, runId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "reportException", map[string]string{"taskId": taskId, "runId": runId}, nil, payload)
+}
+
+func (c queueClient) ReportFailed(taskId string, runId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "reportFailed", map[string]string{"taskId": taskId, "runId": runId}, nil, nil)
+}
+
+func (c queueClient) RerunTask(taskId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "rerunTask", map[string]string{"taskId": taskId}, nil, nil)
+}
+
+func (c queueClient) ScheduleTask(taskId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "scheduleTask", map[string]string{"taskId": taskId}, nil, nil)
+}
+
+func (c queueClient) Status(taskId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "status", map[string]string{"taskId": taskId}, nil, nil)
+}
+
+func (c queueClient) Task(taskId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Queue", "task", map[string]string{"taskId": taskId}, nil, nil)
+}
+
+// Secrets is the interface of the methods of the Secrets service, as
+// implemented by the client returned by NewSecrets.
+type Secrets interface {
+	// Get calls get: Read Secret
+	Get(name string) ([]byte, error)
+	// GetSignURL returns a URL for get, signed with the credentials and valid for
+	// the given duration, without calling it
+	GetSignURL(duration time.Duration, name string) (string, error)
+	// List calls list: List Secrets
+	List(query map[string]string) ([]byte, error)
+	// Ping calls ping: Ping Server
+	Ping() ([]byte, error)
+	// Remove calls remove: Delete Secret
+	Remove(name string) ([]byte, error)
+	// Set calls set: Set Secret
+	Set(name string, payload []byte) ([]byte, error)
+}
+
+type secretsClient struct {
+	conn *connection
+}
+
+// NewSecrets returns a client for the Secrets service, using the configured
+// root URL and credentials.
+func NewSecrets() Secrets {
+	return secretsClient{}
+}
+
+// NewSecretsFromEnv returns a client for the Secrets service, using the root
+// URL and credentials given by TASKCLUSTER_ROOT_URL, TASKCLUSTER_CLIENT_ID,
+// TASKCLUSTER_ACCESS_TOKEN and, for temporary credentials,
+// TASKCLUSTER_CERTIFICATE. The error lists the variables which are missing, if
+// any.
+func NewSecretsFromEnv() (Secrets, error) {
+	conn, err := connectionFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return secretsClient{conn: conn}, nil
+}
+
+func (c secretsClient) Get(name string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Secrets", "get", map[string]string{"name": name}, nil, nil)
+}
+
+func (c secretsClient) GetSignURL(duration time.Duration, name string) (string, error) {
+	return signURL(c.conn, "Secrets", "get", duration, map[string]string{"name": name}, nil)
+}
+
+func (c secretsClient) List(query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Secrets", "list", nil, query, nil)
+}
+
+func (c secretsClient) Ping() ([]byte, error) {
+	return call(context.Background(), c.conn, "Secrets", "ping", nil, nil, nil)
+}
+
+func (c secretsClient) Remove(name string) ([]byte, error) {
+	return call(context.Background(), c.conn, "Secrets", "remove", map[string]string{"name": name}, nil, nil)
+}
+
+func (c secretsClient) Set(name string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "Secrets", "set", map[string]string{"name": name}, nil, payload)
+}
+
+// WorkerManager is the interface of the methods of the WorkerManager service,
+// as implemented by the client returned by NewWorkerManager.
+type WorkerManager interface {
+	// CreateWorker calls createWorker: Create a Worker
+	CreateWorker(workerPoolId string, workerGroup string, workerId string, payload []byte) ([]byte, error)
+	// CreateWorkerPool calls createWorkerPool: Create Worker Pool
+	CreateWorkerPool(workerPoolId string, payload []byte) ([]byte, error)
+	// DeleteWorkerPool calls deleteWorkerPool: Delete Worker Pool
+	DeleteWorkerPool(workerPoolId string) ([]byte, error)
+	// ListProviders calls listProviders: List Providers
+	ListProviders(query map[string]string) ([]byte, error)
+	// ListWorkerPoolErrors calls listWorkerPoolErrors: List Worker Pool Errors
+	ListWorkerPoolErrors(workerPoolId string, query map[string]string) ([]byte, error)
+	// ListWorkerPools calls listWorkerPools: List All Worker Pools
+	ListWorkerPools(query map[string]string) ([]byte, error)
+	// ListWorkersForWorkerGroup calls listWorkersForWorkerGroup: Workers in a
+	// specific Worker Group in a Worker Pool
+	ListWorkersForWorkerGroup(workerPoolId string, workerGroup string, query map[string]string) ([]byte, error)
+	// ListWorkersForWorkerPool calls listWorkersForWorkerPool: Workers in a Worker
+	// Pool
+	ListWorkersForWorkerPool(workerPoolId string, query map[string]string) ([]byte, error)
+	// Ping calls ping: Ping Server
+	Ping() ([]byte, error)
+	// RegisterWorker calls registerWorker: Register a running worker
+	RegisterWorker(payload []byte) ([]byte, error)
+	// RemoveWorker calls removeWorker: Remove a Worker
+	RemoveWorker(workerPoolId string, workerGroup string, workerId string) ([]byte, error)
+	// ReportWorkerError calls reportWorkerError: Report an error from a worker
+	ReportWorkerError(workerPoolId string, payload []byte) ([]byte, error)
+	// ReregisterWorker calls reregisterWorker: Reregister a Worker
+	ReregisterWorker(payload []byte) ([]byte, error)
+	// UpdateWorkerPool calls updateWorkerPool: Update Worker Pool
+	UpdateWorkerPool(workerPoolId string, payload []byte) ([]byte, error)
+	// Worker calls worker: Get a Worker
+	Worker(workerPoolId string, workerGroup string, workerId string) ([]byte, error)
+	// WorkerPool calls workerPool: Get Worker Pool
+	WorkerPool(workerPoolId string) ([]byte, error)
+}
+
+type workerManagerClient struct {
+	conn *connection
+}
+
+// NewWorkerManager returns a client for the WorkerManager service, using the
+// configured root URL and credentials.
+func NewWorkerManager() WorkerManager {
+	return workerManagerClient{}
+}
+
+// NewWorkerManagerFromEnv returns a client for the WorkerManager service, using
+// the root URL and credentials given by TASKCLUSTER_ROOT_URL,
+// TASKCLUSTER_CLIENT_ID, TASKCLUSTER_ACCESS_TOKEN and, for temporary
+// credentials, TASKCLUSTER_CERTIFICATE. The error lists the variables which are
+// missing, if any.
+func NewWorkerManagerFromEnv() (WorkerManager, error) {
+	conn, err := connectionFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return workerManagerClient{conn: conn}, nil
+}
+
+func (c workerManagerClient) CreateWorker(workerPoolId string, workerGroup string, workerId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "createWorker", map[string]string{"workerPoolId": workerPoolId, "workerGroup": workerGroup, "workerId": workerId}, nil, payload)
+}
+
+func (c workerManagerClient) CreateWorkerPool(workerPoolId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "createWorkerPool", map[string]string{"workerPoolId": workerPoolId}, nil, payload)
+}
+
+func (c workerManagerClient) DeleteWorkerPool(workerPoolId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "deleteWorkerPool", map[string]string{"workerPoolId": workerPoolId}, nil, nil)
+}
+
+func (c workerManagerClient) ListProviders(query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "listProviders", nil, query, nil)
+}
+
+func (c workerManagerClient) ListWorkerPoolErrors(workerPoolId string, query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "listWorkerPoolErrors", map[string]string{"workerPoolId": workerPoolId}, query, nil)
+}
+
+func (c workerManagerClient) ListWorkerPools(query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "listWorkerPools", nil, query, nil)
+}
+
+func (c workerManagerClient) ListWorkersForWorkerGroup(workerPoolId string, workerGroup string, query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "listWorkersForWorkerGroup", map[string]string{"workerPoolId": workerPoolId, "workerGroup": workerGroup}, query, nil)
+}
+
+func (c workerManagerClient) ListWorkersForWorkerPool(workerPoolId string, query map[string]string) ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "listWorkersForWorkerPool", map[string]string{"workerPoolId": workerPoolId}, query, nil)
+}
+
+func (c workerManagerClient) Ping() ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "ping", nil, nil, nil)
+}
+
+func (c workerManagerClient) RegisterWorker(payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "registerWorker", nil, nil, payload)
+}
+
+func (c workerManagerClient) RemoveWorker(workerPoolId string, workerGroup string, workerId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "removeWorker", map[string]string{"workerPoolId": workerPoolId, "workerGroup": workerGroup, "workerId": workerId}, nil, nil)
+}
+
+func (c workerManagerClient) ReportWorkerError(workerPoolId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "reportWorkerError", map[string]string{"workerPoolId": workerPoolId}, nil, payload)
+}
+
+func (c workerManagerClient) ReregisterWorker(payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "reregisterWorker", nil, nil, payload)
+}
+
+func (c workerManagerClient) UpdateWorkerPool(workerPoolId string, payload []byte) ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "updateWorkerPool", map[string]string{"workerPoolId": workerPoolId}, nil, payload)
+}
+
+func (c workerManagerClient) Worker(workerPoolId string, workerGroup string, workerId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "worker", map[string]string{"workerPoolId": workerPoolId, "workerGroup": workerGroup, "workerId": workerId}, nil, nil)
+}
+
+func (c workerManagerClient) WorkerPool(workerPoolId string) ([]byte, error) {
+	return call(context.Background(), c.conn, "WorkerManager", "workerPool", map[string]string{"workerPoolId": workerPoolId}, nil, nil)
+}
+
+// AuthClientCreated returns the binding for the messages of the clientCreated
+// (Client Created Messages) exchange of the Auth service whose routing keys
+// have the given values; components given as "" match any value.
+func AuthClientCreated(reserved string) (definitions.Binding, error) {
+	return bindingFor("Auth", "clientCreated", map[string]string{"reserved": reserved})
+}
+
+// AuthClientDeleted returns the binding for the messages of the clientDeleted
+// (Client Deleted Messages) exchange of the Auth service whose routing keys
+// have the given values; components given as "" match any value.
+func AuthClientDeleted(reserved string) (definitions.Binding, error) {
+	return bindingFor("Auth", "clientDeleted", map[string]string{"reserved": reserved})
+}
+
+// AuthClientUpdated returns the binding for the messages of the clientUpdated
+// (Client Updated Messages) exchange of the Auth service whose routing keys
+// have the given values; components given as "" match any value.
+func AuthClientUpdated(reserved string) (definitions.Binding, error) {
+	return bindingFor("Auth", "clientUpdated", map[string]string{"reserved": reserved})
+}
+
+// AuthRoleCreated returns the binding for the messages of the roleCreated (Role
+// Created Messages) exchange of the Auth service whose routing keys have the
+// given values; components given as "" match any value.
+func AuthRoleCreated(reserved string) (definitions.Binding, error) {
+	return bindingFor("Auth", "roleCreated", map[string]string{"reserved": reserved})
+}
+
+// AuthRoleDeleted returns the binding for the messages of the roleDeleted (Role
+// Deleted Messages) exchange of the Auth service whose routing keys have the
+// given values; components given as "" match any value.
+func AuthRoleDeleted(reserved string) (definitions.Binding, error) {
+	return bindingFor("Auth", "roleDeleted", map[string]string{"reserved": reserved})
+}
+
+// AuthRoleUpdated returns the binding for the messages of the roleUpdated (Role
+// Updated Messages) exchange of the Auth service whose routing keys have the
+// given values; components given as "" match any value.
+func AuthRoleUpdated(reserved string) (definitions.Binding, error) {
+	return bindingFor("Auth", "roleUpdated", map[string]string{"reserved": reserved})
+}
+
+// GithubPullRequest returns the binding for the messages of the pullRequest
+// (GitHub Pull Request Event) exchange of the Github service whose routing keys
+// have the given values; components given as "" match any value.
+func GithubPullRequest(organization, repository, action string) (definitions.Binding, error) {
+	return bindingFor("Github", "pullRequest", map[string]string{"organization": organization, "repository": repository, "action": action})
+}
+
+// GithubPush returns the binding for the messages of the push (GitHub push
+// Event) exchange of the Github service whose routing keys have the given
+// values; components given as "" match any value.
+func GithubPush(organization, repository string) (definitions.Binding, error) {
+	return bindingFor("Github", "push", map[string]string{"organization": organization, "repository": repository})
+}
+
+// GithubRelease returns the binding for the messages of the release (GitHub
+// release Event) exchange of the Github service whose routing keys have the
+// given values; components given as "" match any value.
+func GithubRelease(organization, repository string) (definitions.Binding, error) {
+	return bindingFor("Github", "release", map[string]string{"organization": organization, "repository": repository})
+}
+
+// GithubTaskGroupCreationRequested returns the binding for the messages of the
+// taskGroupCreationRequested (tc-gh requested the Queue service to create all
+// the tasks in a group) exchange of the Github service whose routing keys have
+// the given values; components given as "" match any value.
+func GithubTaskGroupCreationRequested(organization, repository string) (definitions.Binding, error) {
+	return bindingFor("Github", "taskGroupCreationRequested", map[string]string{"organization": organization, "repository": repository})
+}
+
+// HooksHookCreated returns the binding for the messages of the hookCreated
+// (Hook Created Messages) exchange of the Hooks service whose routing keys have
+// the given values; components given as "" match any value.
+func HooksHookCreated(reserved string) (definitions.Binding, error) {
+	return bindingFor("Hooks", "hookCreated", map[string]string{"reserved": reserved})
+}
+
+// HooksHookDeleted returns the binding for the messages of the hookDeleted
+// (Hook Deleted Messages) exchange of the Hooks service whose routing keys have
+// the given values; components given as "" match any value.
+func HooksHookDeleted(reserved string) (definitions.Binding, error) {
+	return bindingFor("Hooks", "hookDeleted", map[string]string{"reserved": reserved})
+}
+
+// HooksHookUpdated returns the binding for the messages of the hookUpdated
+// (Hook Updated Messages) exchange of the Hooks service whose routing keys have
+// the given values; components given as "" match any value.
+func HooksHookUpdated(reserved string) (definitions.Binding, error) {
+	return bindingFor("Hooks", "hookUpdated", map[string]string{"reserved": reserved})
+}
+
+// NotifyIrcRequest returns the binding for the messages of the ircRequest
+// (Request for irc notification) exchange of the Notify service whose routing
+// keys have the given values; components given as "" match any value.
+func NotifyIrcRequest(reserved string) (definitions.Binding, error) {
+	return bindingFor("Notify", "ircRequest", map[string]string{"reserved": reserved})
+}
+
+// NotifyNotify returns the binding for the messages of the notify (Notification
+// Messages) exchange of the Notify service whose routing keys have the given
+// values; components given as "" match any value.
+func NotifyNotify(reserved string) (definitions.Binding, error) {
+	return bindingFor("Notify", "notify", map[string]string{"reserved": reserved})
+}
+
+// QueueArtifactCreated returns the binding for the messages of the
+// artifactCreated (Artifact Creation Messages) exchange of the Queue service
+// whose routing keys have the given values; components given as "" match any
+// value.
+func QueueArtifactCreated(taskId, runId, workerGroup, workerId, provisionerId, workerType, schedulerId, taskGroupId, reserved string) (definitions.Binding, error) {
+	return bindingFor("Queue", "artifactCreated", map[string]string{"taskId": taskId, "runId": runId, "workerGroup": workerGroup, "workerId": workerId, "provisionerId": provisionerId, "workerType": workerType, "schedulerId": schedulerId, "taskGroupId": taskGroupId, "reserved": reserved})
+}
+
+// QueueTaskCompleted returns the binding for the messages of the taskCompleted
+// (Task Completed Messages) exchange of the Queue service whose routing keys
+// have the given values; components given as "" match any value.
+func QueueTaskCompleted(taskId, runId, workerGroup, workerId, provisionerId, workerType, schedulerId, taskGroupId, reserved string) (definitions.Binding, error) {
+	return bindingFor("Queue", "taskCompleted", map[string]string{"taskId": taskId, "runId": runId, "workerGroup": workerGroup, "workerId": workerId, "provisionerId": provisionerId, "workerType": workerType, "schedulerId": schedulerId, "taskGroupId": taskGroupId, "reserved": reserved})
+}
+
+// QueueTaskDefined returns the binding for the messages of the taskDefined
+// (Task Defined Messages) exchange of the Queue service whose routing keys have
+// the given values; components given as "" match any value.
+func QueueTaskDefined(taskId, runId, workerGroup, workerId, provisionerId, workerType, schedulerId, taskGroupId, reserved string) (definitions.Binding, error) {
+	return bindingFor("Queue", "taskDefined", map[string]string{"taskId": taskId, "runId": runId, "workerGroup": workerGroup, "workerId": workerId, "provisionerId": provisionerId, "workerType": workerType, "schedulerId": schedulerId, "taskGroupId": taskGroupId, "reserved": reserved})
+}
+
+// QueueTaskException returns the binding for the messages of the taskException
+// (Task Exception Messages) exchange of the Queue service whose routing keys
+// have the given values; components given as "" match any value.
+func QueueTaskException(taskId, runId, workerGroup, workerId, provisionerId, workerType, schedulerId, taskGroupId, reserved string) (definitions.Binding, error) {
+	return bindingFor("Queue", "taskException", map[string]string{"taskId": taskId, "runId": runId, "workerGroup": workerGroup, "workerId": workerId, "provisionerId": provisionerId, "workerType": workerType, "schedulerId": schedulerId, "taskGroupId": taskGroupId, "reserved": reserved})
+}
+
+// QueueTaskFailed returns the binding for the messages of the taskFailed (Task
+// Failed Messages) exchange of the Queue service whose routing keys have the
+// given values; components given as "" match any value.
+func QueueTaskFailed(taskId, runId, workerGroup, workerId, provisionerId, workerType, schedulerId, taskGroupId, reserved string) (definitions.Binding, error) {
+	return bindingFor("Queue", "taskFailed", map[string]string{"taskId": taskId, "runId": runId, "workerGroup": workerGroup, "workerId": workerId, "provisionerId": provisionerId, "workerType": workerType, "schedulerId": schedulerId, "taskGroupId": taskGroupId, "reserved": reserved})
+}
+
+// QueueTaskGroupResolved returns the binding for the messages of the
+// taskGroupResolved (Task Group Resolved Messages) exchange of the Queue
+// service whose routing keys have the given values; components given as ""
+// match any value.
+func QueueTaskGroupResolved(taskGroupId, schedulerId, reserved string) (definitions.Binding, error) {
+	return bindingFor("Queue", "taskGroupResolved", map[string]string{"taskGroupId": taskGroupId, "schedulerId": schedulerId, "reserved": reserved})
+}
+
+// QueueTaskPending returns the binding for the messages of the taskPending
+// (Task Pending Messages) exchange of the Queue service whose routing keys have
+// the given values; components given as "" match any value.
+func QueueTaskPending(taskId, runId, workerGroup, workerId, provisionerId, workerType, schedulerId, taskGroupId, reserved string) (definitions.Binding, error) {
+	return bindingFor("Queue", "taskPending", map[string]string{"taskId": taskId, "runId": runId, "workerGroup": workerGroup, "workerId": workerId, "provisionerId": provisionerId, "workerType": workerType, "schedulerId": schedulerId, "taskGroupId": taskGroupId, "reserved": reserved})
+}
+
+// QueueTaskRunning returns the binding for the messages of the taskRunning
+// (Task Running Messages) exchange of the Queue service whose routing keys have
+// the given values; components given as "" match any value.
+func QueueTaskRunning(taskId, runId, workerGroup, workerId, provisionerId, workerType, schedulerId, taskGroupId, reserved string) (definitions.Binding, error) {
+	return bindingFor("Queue", "taskRunning", map[string]string{"taskId": taskId, "runId": runId, "workerGroup": workerGroup, "workerId": workerId, "provisionerId": provisionerId, "workerType": workerType, "schedulerId": schedulerId, "taskGroupId": taskGroupId, "reserved": reserved})
+}
+
+// WorkerManagerWorkerPoolCreated returns the binding for the messages of the
+// workerPoolCreated (Worker Pool Created Messages) exchange of the
+// WorkerManager service whose routing keys have the given values; components
+// given as "" match any value.
+func WorkerManagerWorkerPoolCreated(reserved string) (definitions.Binding, error) {
+	return bindingFor("WorkerManager", "workerPoolCreated", map[string]string{"reserved": reserved})
+}
+
+// WorkerManagerWorkerPoolUpdated returns the binding for the messages of the
+// workerPoolUpdated (Worker Pool Updated Messages) exchange of the
+// WorkerManager service whose routing keys have the given values; components
+// given as "" match any value.
+func WorkerManagerWorkerPoolUpdated(reserved string) (definitions.Binding, error) {
+	return bindingFor("WorkerManager", "workerPoolUpdated", map[string]string{"reserved": reserved})
+}
@@ -0,0 +1,54 @@
+package apis
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/client"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/root"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+// executeStream calls the API method described by entry, as execute does,
+// but without holding the content of artifacts in memory: for Upload
+// entries, the request body is streamed from body, and the response body is
+// returned unread.  The caller must close it.
+func executeStream(
+	ctx context.Context, conn *connection, serviceName string, apiVersion string, entry *definitions.Entry, args, query map[string]string,
+	body io.Reader,
+) (io.ReadCloser, error) {
+	method := strings.ToUpper(entry.Method)
+	url := entryURL(conn, serviceName, apiVersion, entry, args, query)
+	if !entry.Upload {
+		body = nil
+	}
+
+	// the deadline also covers reading the response body, so it is only
+	// cancelled once the body is closed
+	reqCtx, cancel, timeout := withTimeout(ctx, entry)
+
+	c := client.New(conn.Credentials())
+	c.Logger = root.Logger
+	c.Limiter = requestLimiter()
+	c.TraceID = config.TraceID
+	res, err := c.Stream(reqCtx, method, url, body)
+	if err != nil {
+		cancel()
+		return nil, requestError(ctx, reqCtx, timeout, err)
+	}
+	return &streamBody{ReadCloser: res.Body, cancel: cancel}, nil
+}
+
+// streamBody is the body of a streamed response, releasing the context of
+// its request once closed.
+type streamBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *streamBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
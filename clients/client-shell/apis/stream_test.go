@@ -0,0 +1,119 @@
+package apis
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+var artifactsTest = definitions.Service{
+	ServiceName: "test",
+	APIVersion:  "v1",
+	Entries: []definitions.Entry{
+		definitions.Entry{
+			Name:     "getData",
+			Method:   "get",
+			Route:    "/things/<thingId>/artifacts/<name>",
+			Args:     []string{"thingId", "name"},
+			Download: true,
+		},
+		definitions.Entry{
+			Name:   "putData",
+			Method: "put",
+			Route:  "/things/<thingId>/artifacts/<name>",
+			Args:   []string{"thingId", "name"},
+			Upload: true,
+		},
+	},
+}
+
+// artifactServer serves artifacts of the given size, and responds to uploads
+// with the length of the uploaded content, and how it was sent.
+func artifactServer(size int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			n, _ := io.Copy(ioutil.Discard, r.Body)
+			_, _ = io.WriteString(w, `{"received": `+strconv.FormatInt(n, 10)+`, "contentLength": `+strconv.FormatInt(r.ContentLength, 10)+`}`)
+			return
+		}
+		_, _ = io.Copy(w, io.LimitReader(zeros{}, int64(size)))
+	}))
+}
+
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestCommandDownload(t *testing.T) {
+	assert := assert.New(t)
+
+	server := artifactServer(8 << 20)
+	defer server.Close()
+	config.SetRootURL(server.URL)
+
+	dir, err := ioutil.TempDir("", "download")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	output := filepath.Join(dir, "data.bin")
+
+	cmd := makeCmdFromDefinition("Test", artifactsTest)
+	cmd.PersistentFlags().StringP("output", "o", "-", "Output file")
+	cmd.PersistentFlags().StringP("format", "f", "", "Output format")
+	cmd.SetOutput(&bytes.Buffer{})
+
+	cmd.SetArgs([]string{"getData", "abc", "public/data.bin", "--output", output})
+	assert.NoError(cmd.Execute())
+	info, err := os.Stat(output)
+	assert.NoError(err)
+	assert.Equal(int64(8<<20), info.Size())
+
+	// the content is not JSON, so it cannot be formatted
+	cmd.SetArgs([]string{"getData", "abc", "public/data.bin", "--output", output, "--format", "json"})
+	assert.Error(cmd.Execute())
+}
+
+func TestCommandUpload(t *testing.T) {
+	assert := assert.New(t)
+
+	server := artifactServer(0)
+	defer server.Close()
+	config.SetRootURL(server.URL)
+
+	dir, err := ioutil.TempDir("", "upload")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	input := filepath.Join(dir, "data.bin")
+	assert.NoError(ioutil.WriteFile(input, bytes.Repeat([]byte("x"), 1000), 0644))
+
+	cmd := makeCmdFromDefinition("Test", artifactsTest)
+	buf := &bytes.Buffer{}
+	cmd.SetOutput(buf)
+
+	// the length of files is sent
+	cmd.SetArgs([]string{"putData", "abc", "public/data.bin", "--input", input})
+	assert.NoError(cmd.Execute())
+	assert.Equal(`{"received": 1000, "contentLength": 1000}`, buf.String())
+
+	// or read from stdin
+	buf.Reset()
+	cmd.SetIn(strings.NewReader("hello"))
+	cmd.SetArgs([]string{"putData", "abc", "public/data.bin", "--input", "-"})
+	assert.NoError(cmd.Execute())
+	assert.Contains(buf.String(), `{"received": 5,`)
+}
@@ -0,0 +1,27 @@
+package apis
+
+import (
+	"context"
+	"time"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+// withTimeout returns a context for a request to the API method described by
+// entry, bounded by config.Timeout (or config.IOTimeout, for entries
+// transferring the content of artifacts), and the timeout applied, which is
+// zero if there is none.  The deadline covers all the attempts at the
+// request.
+func withTimeout(ctx context.Context, entry *definitions.Entry) (context.Context, context.CancelFunc, time.Duration) {
+	timeout := config.Timeout
+	if entry.Download || entry.Upload {
+		timeout = config.IOTimeout
+	}
+	if timeout <= 0 {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, cancel, 0
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, timeout
+}
@@ -0,0 +1,65 @@
+package apis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+func TestExecuteTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	// the server does not respond until the test is over
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+	config.SetRootURL(server.URL)
+
+	defer func(timeout time.Duration) { config.Timeout = timeout }(config.Timeout)
+	config.Timeout = 50 * time.Millisecond
+
+	start := time.Now()
+	entry := &definitions.Entry{Name: "ping", Method: "get", Route: "/ping"}
+	_, err := execute(context.Background(), nil, "test", "v1", entry, nil, nil, nil)
+	assert.EqualError(err, "Request timed out after 50ms (see --timeout and --io-timeout)")
+	assert.True(time.Since(start) < 5*time.Second, "the request was not aborted")
+}
+
+func TestWithTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func(timeout, ioTimeout time.Duration) {
+		config.Timeout, config.IOTimeout = timeout, ioTimeout
+	}(config.Timeout, config.IOTimeout)
+	config.Timeout = time.Minute
+	config.IOTimeout = 0
+
+	task := &definitions.Entry{Name: "task", Method: "get", Route: "/task/<taskId>"}
+	ctx, cancel, timeout := withTimeout(context.Background(), task)
+	defer cancel()
+	_, ok := ctx.Deadline()
+	assert.True(ok)
+	assert.Equal(time.Minute, timeout)
+
+	// artifact downloads are only bounded by IOTimeout
+	getArtifact := &definitions.Entry{Name: "getArtifact", Method: "get", Route: "/task/<taskId>/runs/<runId>/artifacts/<name>", Download: true}
+	ctx, cancel, timeout = withTimeout(context.Background(), getArtifact)
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(ok)
+	assert.Equal(time.Duration(0), timeout)
+
+	config.IOTimeout = time.Hour
+	_, cancel, timeout = withTimeout(context.Background(), getArtifact)
+	defer cancel()
+	assert.Equal(time.Hour, timeout)
+}
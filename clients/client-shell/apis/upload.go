@@ -0,0 +1,109 @@
+package apis
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/client"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/root"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+// callUpload calls the named entry of a service in services and uploads a
+// file to the putUrl of the response, as the Upload methods of the service
+// clients do.
+func callUpload(
+	ctx context.Context, conn *connection, serviceName, entryName string, args, query map[string]string, payload []byte, filename string,
+) ([]byte, error) {
+	service, entry, err := lookupEntry(serviceName, entryName)
+	if err != nil {
+		return nil, err
+	}
+	return executeUpload(ctx, conn, service.ServiceName, service.APIVersion, entry, args, query, payload, filename)
+}
+
+// executeUpload calls the API method described by entry, such as queue's
+// createArtifact, with the payload completed by withContentType, then PUTs
+// the content of the named file to the `putUrl` of the response, with the
+// `contentType` of the response.  The upload is bounded by config.IOTimeout,
+// and retried if it fails transiently.  It returns the response of the
+// method.
+func executeUpload(
+	ctx context.Context, conn *connection, serviceName string, apiVersion string, entry *definitions.Entry, args, query map[string]string,
+	payload []byte, filename string,
+) ([]byte, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open input file, error: %s", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read input file, error: %s", err)
+	}
+	if !info.Mode().IsRegular() {
+		// the length must be known, and the content read again for retries
+		return nil, fmt.Errorf("Cannot upload %s, as it is not a regular file", filename)
+	}
+
+	payload, err = withContentType(payload, filename)
+	if err != nil {
+		return nil, err
+	}
+	result, err := execute(ctx, conn, serviceName, apiVersion, entry, args, query, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		PutURL      string `json:"putUrl"`
+		ContentType string `json:"contentType"`
+	}
+	if err := json.Unmarshal(result, &res); err != nil || res.PutURL == "" {
+		return nil, fmt.Errorf("The response of %s has no putUrl to upload %s to; only s3 artifacts have content", entry.Name, filename)
+	}
+
+	uploadCtx, cancel := context.WithCancel(ctx)
+	if config.IOTimeout > 0 {
+		uploadCtx, cancel = context.WithTimeout(ctx, config.IOTimeout)
+	}
+	defer cancel()
+
+	c := client.New(nil)
+	c.Logger = root.Logger
+	if err := c.Upload(uploadCtx, res.PutURL, f, info.Size(), res.ContentType); err != nil {
+		return nil, requestError(ctx, uploadCtx, config.IOTimeout, err)
+	}
+	return result, nil
+}
+
+// withContentType adds to the payload of an s3 artifact without a
+// `contentType` that of the named file, as detected by client.ContentType.
+// Other payloads are returned unchanged.
+func withContentType(payload []byte, filename string) ([]byte, error) {
+	var artifact map[string]interface{}
+	if err := json.Unmarshal(payload, &artifact); err != nil || artifact["storageType"] != "s3" {
+		return payload, nil
+	}
+	if _, ok := artifact["contentType"]; ok {
+		return payload, nil
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open input file, error: %s", err)
+	}
+	defer f.Close()
+	contentType, err := client.ContentType(filename, f)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read input file, error: %s", err)
+	}
+	root.Logger.Debugf("Detected Content-Type %s for %s", contentType, filename)
+
+	artifact["contentType"] = contentType
+	return json.Marshal(artifact)
+}
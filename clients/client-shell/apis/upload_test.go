@@ -0,0 +1,162 @@
+package apis
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+// putURLServer serves queue's createArtifact, returning a putUrl for s3
+// artifacts; the first upload to it fails.  It records the payloads and the
+// uploads received.
+type putURLServer struct {
+	*httptest.Server
+	payloads []map[string]interface{}
+	uploads  []*http.Request
+	content  []byte
+}
+
+func newPutURLServer() *putURLServer {
+	s := &putURLServer{}
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/queue/v1/task/abc/runs/0/artifacts/", func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		s.payloads = append(s.payloads, payload)
+		if payload["storageType"] != "s3" {
+			_, _ = w.Write([]byte(`{"storageType": "reference"}`))
+			return
+		}
+		res, _ := json.Marshal(map[string]interface{}{
+			"storageType": "s3",
+			"putUrl":      s.URL + "/bucket/artifact?signature=xyz",
+			"contentType": payload["contentType"],
+			"expires":     payload["expires"],
+		})
+		_, _ = w.Write(res)
+	})
+	handler.HandleFunc("/bucket/artifact", func(w http.ResponseWriter, r *http.Request) {
+		content, _ := ioutil.ReadAll(r.Body)
+		s.uploads = append(s.uploads, r)
+		if len(s.uploads) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		s.content = content
+		sum := md5.Sum(content)
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+	})
+	s.Server = httptest.NewServer(handler)
+	return s
+}
+
+// createArtifactCmd returns a command for queue's createArtifact, writing to
+// the returned buffer, and a function running it for a task abc with the
+// given arguments.
+func createArtifactCmd(t *testing.T) (*bytes.Buffer, func(args ...string) error) {
+	service, entry, err := lookupEntry("Queue", "createArtifact")
+	assert.NoError(t, err)
+	assert.True(t, entry.PutURL)
+	cmd := makeCmdFromDefinition("Queue", definitions.Service{
+		ServiceName: service.ServiceName,
+		APIVersion:  service.APIVersion,
+		Entries:     []definitions.Entry{*entry},
+	})
+	buf := &bytes.Buffer{}
+	cmd.SetOutput(buf)
+	return buf, func(args ...string) error {
+		buf.Reset()
+		cmd.SetArgs(append([]string{"createArtifact", "abc", "0"}, args...))
+		return cmd.Execute()
+	}
+}
+
+func TestCommandPutURLUpload(t *testing.T) {
+	assert := assert.New(t)
+
+	server := newPutURLServer()
+	defer server.Close()
+	config.SetRootURL(server.URL)
+
+	dir, err := ioutil.TempDir("", "upload")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	input := filepath.Join(dir, "report.html")
+	assert.NoError(ioutil.WriteFile(input, []byte("<html>report</html>"), 0644))
+
+	buf, run := createArtifactCmd(t)
+
+	// the contentType of the file is added to the payload, and the file is
+	// uploaded with it, after retrying the failed attempt
+	assert.NoError(run("public/report.html", "--body", `{"storageType": "s3", "expires": "2030-01-01T00:00:00.000Z"}`, "--input", input))
+	assert.Equal("text/html; charset=utf-8", server.payloads[0]["contentType"])
+	assert.Len(server.uploads, 2)
+	assert.Equal("PUT", server.uploads[1].Method)
+	assert.Equal("text/html; charset=utf-8", server.uploads[1].Header.Get("Content-Type"))
+	assert.Equal(int64(19), server.uploads[1].ContentLength)
+	assert.Empty(server.uploads[1].Header.Get("Authorization"))
+	assert.Equal("<html>report</html>", string(server.content))
+	assert.Contains(buf.String(), `"putUrl"`)
+
+	// a contentType given in the payload is kept
+	assert.NoError(run("public/report.html", "--body", `{"storageType": "s3", "expires": "2030-01-01T00:00:00.000Z", "contentType": "text/plain"}`, "--input", input))
+	assert.Equal("text/plain", server.payloads[1]["contentType"])
+	assert.Equal("text/plain", server.uploads[2].Header.Get("Content-Type"))
+
+	// only s3 artifacts have content to upload
+	err = run("public/report.html", "--body", `{"storageType": "reference", "expires": "2030-01-01T00:00:00.000Z", "url": "https://example.com"}`, "--input", input, "--no-validate")
+	assert.Error(err)
+	assert.Contains(err.Error(), "has no putUrl")
+
+	// without --input, nothing is uploaded (with a new command, as flags keep
+	// their values)
+	uploads := len(server.uploads)
+	_, run = createArtifactCmd(t)
+	assert.NoError(run("public/report.html", "--body", `{"storageType": "s3", "expires": "2030-01-01T00:00:00.000Z", "contentType": "text/plain"}`))
+	assert.Len(server.uploads, uploads)
+}
+
+func TestExecuteUploadRequiresRegularFile(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "upload")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	_, err = callUpload(context.Background(), nil, "Queue", "createArtifact", nil, nil, []byte(`{}`), dir)
+	assert.Error(err)
+	assert.Contains(err.Error(), "not a regular file")
+}
+
+func TestWithContentType(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "upload")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	input := filepath.Join(dir, "data.json")
+	assert.NoError(ioutil.WriteFile(input, []byte(`{}`), 0644))
+
+	payload, err := withContentType([]byte(`{"storageType": "s3"}`), input)
+	assert.NoError(err)
+	assert.JSONEq(`{"storageType": "s3", "contentType": "application/json"}`, string(payload))
+
+	// other payloads are left alone
+	for _, other := range []string{`{"storageType": "s3", "contentType": "text/plain"}`, `{"storageType": "error"}`, `not json`} {
+		payload, err = withContentType([]byte(other), input)
+		assert.NoError(err)
+		assert.Equal(other, string(payload))
+	}
+}
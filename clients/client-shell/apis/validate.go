@@ -0,0 +1,112 @@
+package apis
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/root"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+var (
+	compiledMutex sync.Mutex
+	// compiledSchemas holds nil for the schemas which cannot be compiled
+	compiledSchemas = map[string]*gojsonschema.Schema{}
+)
+
+// compiledSchema returns the compiled schema of the given embedded schema
+// file, or nil if it cannot be compiled.  Each schema is compiled once, as
+// `api call --from-file` may validate many payloads concurrently.
+func compiledSchema(file string) (*gojsonschema.Schema, error) {
+	compiledMutex.Lock()
+	defer compiledMutex.Unlock()
+
+	if schema, ok := compiledSchemas[file]; ok {
+		return schema, nil
+	}
+
+	// schemas refer to one another by path, so all of them are loaded
+	loader := gojsonschema.NewSchemaLoader()
+	for path, schema := range schemas {
+		if err := loader.AddSchema(path, gojsonschema.NewStringLoader(schema)); err != nil {
+			return nil, fmt.Errorf("Failed to load schema %s, error: %s", path, err)
+		}
+	}
+	schema, err := loader.Compile(gojsonschema.NewReferenceLoader(file + "#"))
+	if err != nil {
+		// some schemas use regular expressions that Go does not support
+		root.Logger.Warnf("Not validating payloads, as schema %s cannot be compiled: %s", file, err)
+		schema = nil
+	}
+	compiledSchemas[file] = schema
+	return schema, nil
+}
+
+// validateBody checks a payload against the schema of the given input of a
+// service, such as `v1/create-task-request.json#`, listing every problem
+// found.  Payloads for which no usable schema is known are not checked.
+func validateBody(serviceName, input string, body []byte) error {
+	file := schemaPath(serviceName, input)
+	if _, ok := schemas[file]; !ok {
+		root.Logger.Debugf("No schema known for %s, not validating payload", file)
+		return nil
+	}
+
+	schema, err := compiledSchema(file)
+	if err != nil || schema == nil {
+		return err
+	}
+
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(body))
+	if err != nil {
+		return fmt.Errorf("Failed to validate payload, error: %s", err)
+	}
+	if result.Valid() {
+		return nil
+	}
+
+	msg := fmt.Sprintf("Payload does not match the schema %s (use --no-validate to send it anyway):", input)
+	for _, e := range result.Errors() {
+		msg += fmt.Sprintf("\n  %s: %s", e.Field(), e.Description())
+		if suggestion := suggestEnumValue(e); suggestion != "" {
+			msg += fmt.Sprintf(" (did you mean %q?)", suggestion)
+		}
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// suggestEnumValue returns, for a value not in the `enum` of its schema, the
+// allowed value it was most likely meant to be: one differing only in case,
+// or the only one it is a prefix of.  It returns "" for other errors, or if
+// there is no such value.
+func suggestEnumValue(e gojsonschema.ResultError) string {
+	given, ok := e.Value().(string)
+	if !ok || e.Type() != "enum" || given == "" {
+		return ""
+	}
+	// the allowed values are listed as JSON strings, separated by commas
+	var allowed []interface{}
+	if err := json.Unmarshal([]byte("["+fmt.Sprint(e.Details()["allowed"])+"]"), &allowed); err != nil {
+		return ""
+	}
+
+	var prefixed []string
+	for _, a := range allowed {
+		value, ok := a.(string)
+		if !ok {
+			continue
+		}
+		if strings.EqualFold(value, given) {
+			return value
+		}
+		if strings.HasPrefix(strings.ToLower(value), strings.ToLower(given)) {
+			prefixed = append(prefixed, value)
+		}
+	}
+	if len(prefixed) == 1 {
+		return prefixed[0]
+	}
+	return ""
+}
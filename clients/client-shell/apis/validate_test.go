@@ -0,0 +1,92 @@
+package apis
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestValidateBody(t *testing.T) {
+	assert := assert.New(t)
+
+	valid := `{
+		"provisionerId": "proj-example",
+		"workerType": "ci",
+		"created": "2020-01-01T00:00:00.000Z",
+		"deadline": "2020-01-02T00:00:00.000Z",
+		"payload": {},
+		"metadata": {"name": "x", "description": "y", "owner": "me@example.com", "source": "https://example.com"}
+	}`
+	assert.NoError(validateBody("queue", "v1/create-task-request.json#", []byte(valid)))
+
+	err := validateBody("queue", "v1/create-task-request.json#", []byte(`{"provisionerId": 5}`))
+	assert.Error(err)
+	assert.Contains(err.Error(), "v1/create-task-request.json#")
+	assert.Contains(err.Error(), "\n  (root): workerType is required")
+	assert.Contains(err.Error(), "\n  provisionerId: Invalid type. Expected: string, given: integer")
+
+	// values not in an enum are listed with the allowed values, and the
+	// likely one if any
+	err = validateBody("queue", "v1/create-task-request.json#", []byte(`{"priority": "urgent"}`))
+	assert.Error(err)
+	assert.Contains(err.Error(), "\n  priority: priority must be one of the following: \"highest\", \"very-high\", \"high\", \"medium\", \"low\", \"very-low\", \"lowest\", \"normal\"")
+	assert.NotContains(err.Error(), "did you mean")
+	err = validateBody("queue", "v1/create-task-request.json#", []byte(`{"priority": "Highest"}`))
+	assert.Error(err)
+	assert.Contains(err.Error(), "\"lowest\", \"normal\" (did you mean \"highest\"?)")
+	err = validateBody("queue", "v1/create-task-request.json#", []byte(`{"priority": "med"}`))
+	assert.Error(err)
+	assert.Contains(err.Error(), "(did you mean \"medium\"?)")
+	err = validateBody("queue", "v1/create-task-request.json#", []byte(`{"priority": "very"}`))
+	assert.Error(err)
+	assert.NotContains(err.Error(), "did you mean")
+
+	// without a schema, payloads are not checked
+	assert.NoError(validateBody("test", "v1/unknown.json#", []byte(`{}`)))
+}
+
+// Every embedded schema can be loaded without fetching anything, and any that
+// can't be compiled are skipped rather than failing the call.
+func TestValidateBodyAllSchemas(t *testing.T) {
+	for _, service := range services {
+		for _, entry := range service.Entries {
+			if entry.Input == "" {
+				continue
+			}
+			err := validateBody(service.ServiceName, entry.Input, []byte(`null`))
+			if err != nil && !strings.HasPrefix(err.Error(), "Payload does not match") {
+				t.Errorf("%s.%s: %s", service.ServiceName, entry.Name, err)
+			}
+		}
+	}
+}
+
+// Schemas are compiled once, and shared by concurrent validations.
+func TestValidateBodyCompiledOnce(t *testing.T) {
+	assert := assert.New(t)
+
+	file := schemaPath("queue", "v1/create-task-request.json#")
+	first, err := compiledSchema(file)
+	assert.NoError(err)
+	assert.NotNil(first)
+	second, err := compiledSchema(file)
+	assert.NoError(err)
+	assert.True(first == second, "the schema was compiled again")
+
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = validateBody("queue", "v1/create-task-request.json#", []byte(`{"priority": "urgent"}`))
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		assert.Error(err)
+		assert.Contains(err.Error(), "priority")
+	}
+}
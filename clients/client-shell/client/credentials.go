@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/tent/hawk-go"
 
@@ -80,7 +81,9 @@ func (c *Credentials) newAuth(method, url string, h hash.Hash) (*hawk.Auth, erro
 			return nil, fmt.Errorf("Failed to parse certificate, error: %s", err)
 		}
 	}
-	if len(c.AuthorizedScopes) > 0 {
+	// an empty (but non-nil) list of authorized scopes restricts the request
+	// to no scopes at all, as with the JS client
+	if c.AuthorizedScopes != nil {
 		e.AuthorizedScopes = &c.AuthorizedScopes
 	}
 	if e.Certificate != nil || e.AuthorizedScopes != nil {
@@ -106,14 +109,25 @@ func (c *Credentials) SignHeader(method, url string, h hash.Hash) (string, error
 	return a.RequestHeader(), nil
 }
 
-// SignURL will generate a (bewit) signed URL
-func (c *Credentials) SignURL(URL string) (string, error) {
+// SignURL will generate a (bewit) signed URL for a GET request, valid for the
+// given duration.  As with the JS client, the bewit is added to any existing
+// query string.
+func (c *Credentials) SignURL(URL string, duration time.Duration) (string, error) {
+	return c.signURL(URL, hawk.Now().Add(duration))
+}
+
+// signURL generates a signed URL which expires at the given time.
+func (c *Credentials) signURL(URL string, expires time.Time) (string, error) {
 	a, err := c.newAuth("GET", URL, nil)
 	if err != nil {
 		return "", err
 	}
-	URL += "?bewit=" + url.QueryEscape(a.Bewit())
-	return URL, nil
+	a.Timestamp = expires
+	separator := "?"
+	if strings.Contains(URL, "?") {
+		separator = "&"
+	}
+	return URL + separator + "bewit=" + url.QueryEscape(a.Bewit()), nil
 }
 
 // SignRequest will add an Authorization header
@@ -1,2 +1,3 @@
-// Package client contains integration code for taskcluster-client-go.
+// Package client contains integration code for taskcluster-client-go, and the
+// HTTP client used to call Taskcluster APIs directly.
 package client
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// APICallError is returned by Client.Request when a Taskcluster API responds
+// with a non-2xx status.  Its fields are parsed from the standard Taskcluster
+// error payload, when the response has one.
+type APICallError struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Code is the Taskcluster error code, such as `ResourceNotFound`; it is
+	// empty if the response is not a Taskcluster error payload.
+	Code string
+	// Message explains the error; if the response is not a Taskcluster error
+	// payload, it is the response body.
+	Message string
+	// RequestID identifies the request in the service's logs, if known.
+	RequestID string
+	// TraceID is the trace ID the request was sent with, if any.
+	TraceID string
+	// ServerTime is the time at which the service handled the request, if
+	// known.
+	ServerTime time.Time
+	// Attempts is the number of attempts made at the request.
+	Attempts int
+}
+
+// errorPayload is the standard Taskcluster error payload.
+type errorPayload struct {
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	RequestInfo struct {
+		Time      time.Time `json:"time"`
+		RequestID string    `json:"requestId"`
+	} `json:"requestInfo"`
+}
+
+// newAPICallError builds the error for a non-2xx response, received after the
+// given number of attempts at a request sent with the given trace ID.
+func newAPICallError(res *Response, attempts int, traceID string) *APICallError {
+	e := &APICallError{
+		StatusCode: res.StatusCode,
+		Message:    string(res.Body),
+		RequestID:  res.Header.Get("X-For-Request-Id"),
+		TraceID:    traceID,
+		Attempts:   attempts,
+	}
+
+	var payload errorPayload
+	if err := json.Unmarshal(res.Body, &payload); err == nil && payload.Code != "" {
+		e.Code = payload.Code
+		e.Message = payload.Message
+		e.ServerTime = payload.RequestInfo.Time
+		if e.RequestID == "" {
+			e.RequestID = payload.RequestInfo.RequestID
+		}
+	}
+	return e
+}
+
+// Error returns `code: message`, or describes the status if the response was
+// not a Taskcluster error payload.
+func (e *APICallError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("Non-2xx StatusCode: %d received in %d attempts\n%s", e.StatusCode, e.Attempts, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
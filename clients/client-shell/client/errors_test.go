@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestRequestReturnsAPICallError(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-For-Request-Id", "b1ab1e3a-a7a5-4d7a-94b2-fde1e8a9f1c3")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{
+			"code": "ResourceNotFound",
+			"message": "Task not found",
+			"requestInfo": {"method": "task", "params": {}, "payload": {}, "time": "2020-06-01T12:00:00.000Z"}
+		}`))
+	}))
+	defer server.Close()
+
+	c := &Client{Retry: fastRetries}
+	_, err := c.Request(context.Background(), "GET", server.URL, nil)
+	apiErr, ok := err.(*APICallError)
+	assert.True(ok, "expected an *APICallError, got %T", err)
+	assert.Equal(http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal("ResourceNotFound", apiErr.Code)
+	assert.Equal("Task not found", apiErr.Message)
+	assert.Equal("b1ab1e3a-a7a5-4d7a-94b2-fde1e8a9f1c3", apiErr.RequestID)
+	assert.True(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC).Equal(apiErr.ServerTime))
+	assert.Equal(1, apiErr.Attempts)
+	assert.EqualError(err, "ResourceNotFound: Task not found")
+}
+
+func TestAPICallErrorWithoutPayload(t *testing.T) {
+	assert := assert.New(t)
+
+	server, _ := flakyServer(100, http.StatusBadGateway, nil)
+	defer server.Close()
+
+	c := &Client{Retry: fastRetries}
+	_, err := c.Request(context.Background(), "GET", server.URL, nil)
+	apiErr, ok := err.(*APICallError)
+	assert.True(ok, "expected an *APICallError, got %T", err)
+	assert.Equal(http.StatusBadGateway, apiErr.StatusCode)
+	assert.Equal("", apiErr.Code)
+	assert.True(apiErr.ServerTime.IsZero())
+	assert.Equal("Non-2xx StatusCode: 502 received in 5 attempts\n", err.Error())
+}
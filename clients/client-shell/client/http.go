@@ -0,0 +1,266 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RetryConfig controls how failed requests are retried.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of times a request is attempted.  A
+	// value of 1 (or less) disables retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry; each subsequent retry
+	// doubles it, with +/- 25% jitter.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts, including delays
+	// requested by the server with a `Retry-After` header.
+	MaxDelay time.Duration
+}
+
+// TraceHeader is the header carrying the trace ID of requests, which
+// correlates them across the logs of the services.
+const TraceHeader = "X-Taskcluster-Trace-Id"
+
+// defaultHTTPClient is used by clients without an HTTPClient.  It has no
+// timeout of its own: requests are bounded by the deadline of their context,
+// which covers all their attempts.
+var defaultHTTPClient = &http.Client{}
+
+// DefaultRetryConfig is the retry configuration used by New.
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts: 5,
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// Client sends requests to Taskcluster APIs, signing them if credentials are
+// available and retrying transient failures.
+type Client struct {
+	// HTTPClient is used to send requests; if nil, a default client without a
+	// timeout is used.
+	HTTPClient *http.Client
+	// Credentials used to sign requests, if any.
+	Credentials *Credentials
+	// Retry configures retries of idempotent requests.
+	Retry RetryConfig
+	// Limiter, if set, limits the rate of requests; every attempt, including
+	// retries, waits for it.  It can be shared with other clients.
+	Limiter *RateLimiter
+	// Logger, if set, traces requests: at debug level, every request and
+	// response is logged (with credentials redacted), and at trace level, so
+	// are the timing of each attempt and any retries.
+	Logger *logrus.Logger
+	// TraceID, if set, is sent as the TraceHeader of every attempt at every
+	// request, so that retries carry the same ID as the first attempt.
+	TraceID string
+}
+
+// Response is a response received from a Taskcluster API.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// Attempts is the number of attempts it took to get this response.
+	Attempts int
+}
+
+// New returns a Client using the given credentials (which may be nil) and the
+// default retry configuration.
+func New(credentials *Credentials) *Client {
+	return &Client{
+		Credentials: credentials,
+		Retry:       DefaultRetryConfig,
+	}
+}
+
+// Request sends a request with the given method, URL and JSON body (nil for
+// none), returning the response once it succeeds.
+//
+// Only idempotent requests (GET, HEAD, PUT and DELETE) are retried, and only
+// if they fail with a connection error, a 5xx status, or 429 Too Many
+// Requests.  For 429 and 503 responses, the server's `Retry-After` header is
+// honored.  If the final response is not a success, the error is an
+// *APICallError.
+func (c *Client) Request(ctx context.Context, method, url string, body []byte) (*Response, error) {
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = defaultHTTPClient
+	}
+
+	maxAttempts := c.Retry.MaxAttempts
+	if maxAttempts < 1 || !isIdempotent(method) {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		if err := c.wait(ctx); err != nil {
+			return nil, err
+		}
+		c.tracef("Attempt %d of %d: %s %s", attempt, maxAttempts, method, url)
+		start := time.Now()
+		res, err := c.attempt(ctx, httpClient, method, url, body)
+		c.tracef("Attempt %d finished after %s", attempt, time.Since(start))
+		if err == nil && res.StatusCode/100 == 2 {
+			res.Attempts = attempt
+			return res, nil
+		}
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if attempt >= maxAttempts || (err == nil && !isTransient(res.StatusCode)) {
+			if err != nil {
+				return nil, err
+			}
+			return nil, newAPICallError(res, attempt, c.TraceID)
+		}
+
+		delay := c.Retry.delay(attempt, res)
+		if err != nil {
+			c.tracef("Retrying in %s, after error: %s", delay, err)
+		} else {
+			c.tracef("Retrying in %s, after status %d", delay, res.StatusCode)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// wait waits for the Limiter, if any, to allow another attempt.  As tokens
+// accumulate during the delay before a retry, a retry only waits for the
+// Limiter if the delay was shorter than the Limiter's interval.
+func (c *Client) wait(ctx context.Context) error {
+	if c.Limiter == nil {
+		return nil
+	}
+	start := time.Now()
+	if err := c.Limiter.Wait(ctx); err != nil {
+		return err
+	}
+	if waited := time.Since(start); waited > time.Millisecond {
+		c.tracef("Waited %s for the rate limit", waited)
+	}
+	return nil
+}
+
+// attempt makes a single attempt at a request.
+func (c *Client) attempt(ctx context.Context, httpClient *http.Client, method, url string, body []byte) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	// If there is a body, we set a content-type
+	if len(body) != 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.TraceID != "" {
+		req.Header.Set(TraceHeader, c.TraceID)
+	}
+
+	// Sign request if credentials are available; this is done for each
+	// attempt, so that every attempt carries a fresh timestamp and nonce.
+	if c.Credentials != nil {
+		var h hash.Hash
+		// Create payload hash if there is any
+		if len(body) != 0 {
+			h = PayloadHash("application/json")
+			_, err := h.Write(body)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to write hash, error: %s", err)
+			}
+		}
+		err := c.Credentials.SignRequest(req, h)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to sign request, error: %s", err)
+		}
+	}
+
+	c.logRequest(req, body)
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.logResponse(res, data)
+
+	return &Response{
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+		Body:       data,
+	}, nil
+}
+
+// delay computes how long to wait before the next attempt, given the number
+// of attempts so far and the last response (nil after a connection error).
+func (rc RetryConfig) delay(attempts int, res *Response) time.Duration {
+	if res != nil && (res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable) {
+		if d, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			if rc.MaxDelay > 0 && d > rc.MaxDelay {
+				return rc.MaxDelay
+			}
+			return d
+		}
+	}
+
+	d := math.Pow(2, float64(attempts-1)) * float64(rc.BaseDelay)
+	d *= 1 + 0.25*(rand.Float64()*2-1)
+	if rc.MaxDelay > 0 {
+		d = math.Min(d, float64(rc.MaxDelay))
+	}
+	return time.Duration(d)
+}
+
+// retryAfter parses the value of a `Retry-After` header, which is either a
+// number of seconds or an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		d := time.Until(date)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+func isTransient(statusCode int) bool {
+	return statusCode/100 == 5 || statusCode == http.StatusTooManyRequests
+}
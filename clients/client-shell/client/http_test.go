@@ -0,0 +1,243 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	assert "github.com/stretchr/testify/require"
+)
+
+var fastRetries = RetryConfig{
+	MaxAttempts: 5,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    10 * time.Millisecond,
+}
+
+// flakyServer fails the first `failures` requests with the given status, and
+// then succeeds; it counts every request it receives.
+func flakyServer(failures int32, status int, header http.Header) (*httptest.Server, *int32) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&count, 1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	return server, &count
+}
+
+func TestRequestRetriesTransientErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	server, count := flakyServer(2, http.StatusInternalServerError, nil)
+	defer server.Close()
+
+	c := &Client{Retry: fastRetries}
+	res, err := c.Request(context.Background(), "GET", server.URL, nil)
+	assert.NoError(err)
+	assert.Equal(3, res.Attempts)
+	assert.Equal(int32(3), atomic.LoadInt32(count))
+}
+
+func TestRequestGivesUpAfterMaxAttempts(t *testing.T) {
+	assert := assert.New(t)
+
+	server, count := flakyServer(100, http.StatusBadGateway, nil)
+	defer server.Close()
+
+	c := &Client{Retry: fastRetries}
+	_, err := c.Request(context.Background(), "DELETE", server.URL, nil)
+	assert.Error(err)
+	assert.Equal(int32(5), atomic.LoadInt32(count))
+}
+
+func TestRequestDoesNotRetryPost(t *testing.T) {
+	assert := assert.New(t)
+
+	server, count := flakyServer(1, http.StatusInternalServerError, nil)
+	defer server.Close()
+
+	c := &Client{Retry: fastRetries}
+	_, err := c.Request(context.Background(), "POST", server.URL, []byte(`{}`))
+	assert.Error(err)
+	assert.Equal(int32(1), atomic.LoadInt32(count))
+}
+
+func TestRequestDoesNotRetryClientErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	server, count := flakyServer(1, http.StatusNotFound, nil)
+	defer server.Close()
+
+	c := &Client{Retry: fastRetries}
+	_, err := c.Request(context.Background(), "GET", server.URL, nil)
+	assert.Error(err)
+	assert.Equal(int32(1), atomic.LoadInt32(count))
+}
+
+func TestRequestRetriesTooManyRequests(t *testing.T) {
+	assert := assert.New(t)
+
+	server, count := flakyServer(1, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}})
+	defer server.Close()
+
+	c := &Client{Retry: fastRetries}
+	_, err := c.Request(context.Background(), "PUT", server.URL, []byte(`{}`))
+	assert.NoError(err)
+	assert.Equal(int32(2), atomic.LoadInt32(count))
+}
+
+func TestRequestRetriesDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	server, count := flakyServer(1, http.StatusInternalServerError, nil)
+	defer server.Close()
+
+	c := &Client{Retry: RetryConfig{MaxAttempts: 1}}
+	_, err := c.Request(context.Background(), "GET", server.URL, nil)
+	assert.Error(err)
+	assert.Equal(int32(1), atomic.LoadInt32(count))
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert := assert.New(t)
+
+	rc := RetryConfig{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	// exponential, with jitter
+	d := rc.delay(3, nil)
+	assert.True(d >= 300*time.Millisecond && d <= 500*time.Millisecond, "unexpected delay %s", d)
+
+	// capped
+	assert.Equal(time.Second, rc.delay(10, nil))
+
+	// Retry-After is honored for 429 and 503, but capped
+	res := &Response{StatusCode: 503, Header: http.Header{"Retry-After": {"0"}}}
+	assert.Equal(time.Duration(0), rc.delay(4, res))
+	res.Header.Set("Retry-After", "120")
+	assert.Equal(time.Second, rc.delay(1, res))
+
+	// ..but not for other statuses
+	res.StatusCode = 500
+	res.Header.Set("Retry-After", "0")
+	d = rc.delay(1, res)
+	assert.True(d >= 75*time.Millisecond, "unexpected delay %s", d)
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert := assert.New(t)
+
+	d, ok := retryAfter("7")
+	assert.True(ok)
+	assert.Equal(7*time.Second, d)
+
+	d, ok = retryAfter(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(ok)
+	assert.Equal(time.Duration(0), d)
+
+	_, ok = retryAfter("soon")
+	assert.False(ok)
+}
+
+func TestRequestLogging(t *testing.T) {
+	assert := assert.New(t)
+
+	server, _ := flakyServer(1, http.StatusInternalServerError, nil)
+	defer server.Close()
+
+	buf := &bytes.Buffer{}
+	logger := logrus.New()
+	logger.Out = buf
+	logger.Formatter = &logrus.TextFormatter{DisableTimestamp: true}
+
+	c := &Client{
+		Credentials: &Credentials{ClientID: "tester", AccessToken: "no-secret"},
+		Retry:       fastRetries,
+		Logger:      logger,
+	}
+
+	// nothing is logged at info level
+	logger.SetLevel(logrus.InfoLevel)
+	_, err := c.Request(context.Background(), "PUT", server.URL+"/things", []byte(`{"a":1}`))
+	assert.NoError(err)
+	assert.Empty(buf.String())
+
+	// requests and responses are logged at debug level, without credentials
+	server2, _ := flakyServer(0, http.StatusOK, nil)
+	defer server2.Close()
+	logger.SetLevel(logrus.DebugLevel)
+	_, err = c.Request(context.Background(), "PUT", server2.URL+"/things", []byte(`{"a":1}`))
+	assert.NoError(err)
+	logged := buf.String()
+	assert.Contains(logged, "> PUT "+server2.URL+"/things")
+	assert.Contains(logged, "> Authorization: <redacted>")
+	assert.NotContains(logged, "no-secret")
+	assert.Contains(logged, "< 200 OK")
+	assert.NotContains(logged, "Attempt")
+
+	// timing and retries are logged at trace level
+	server3, _ := flakyServer(1, http.StatusInternalServerError, nil)
+	defer server3.Close()
+	buf.Reset()
+	logger.SetLevel(logrus.TraceLevel)
+	_, err = c.Request(context.Background(), "GET", server3.URL, nil)
+	assert.NoError(err)
+	logged = buf.String()
+	assert.Contains(logged, "Attempt 1 of 5")
+	assert.Contains(logged, "after status 500")
+	assert.Contains(logged, "Attempt 2 finished after")
+}
+
+func TestRequestTraceID(t *testing.T) {
+	assert := assert.New(t)
+
+	var traceIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceIDs = append(traceIDs, r.Header.Get(TraceHeader))
+		w.Header().Set("X-For-Request-Id", "req")
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	buf := &bytes.Buffer{}
+	logger := logrus.New()
+	logger.Out = buf
+	logger.SetLevel(logrus.DebugLevel)
+	logger.Formatter = &logrus.TextFormatter{DisableTimestamp: true}
+
+	// every attempt carries the same trace ID, which is also in the error
+	c := &Client{Retry: fastRetries, Logger: logger, TraceID: "trace"}
+	_, err := c.Request(context.Background(), "GET", server.URL, nil)
+	assert.Equal([]string{"trace", "trace", "trace", "trace", "trace"}, traceIDs)
+	apiErr, ok := err.(*APICallError)
+	assert.True(ok, "expected an *APICallError, got %T", err)
+	assert.Equal("trace", apiErr.TraceID)
+	assert.Equal("req", apiErr.RequestID)
+
+	// and the responses are logged with both IDs
+	assert.Contains(buf.String(), "> X-Taskcluster-Trace-Id: trace")
+	assert.Contains(buf.String(), "requestId=req statusCode=500 traceId=trace")
+
+	// streaming requests carry it too
+	traceIDs = nil
+	_, err = c.Stream(context.Background(), "GET", server.URL, nil)
+	assert.Error(err)
+	assert.Equal([]string{"trace", "trace", "trace", "trace", "trace"}, traceIDs)
+
+	// without a trace ID, no header is sent
+	traceIDs = nil
+	c = &Client{}
+	_, err = c.Request(context.Background(), "GET", server.URL, nil)
+	assert.Equal([]string{""}, traceIDs)
+	assert.Equal("", err.(*APICallError).TraceID)
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ParseProxy parses the URL of a proxy, such as `http://proxy:3128`; as with
+// HTTP_PROXY, the scheme defaults to http.  The schemes http, https and
+// socks5 are supported.
+func ParseProxy(proxy string) (*url.URL, error) {
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	u, err := url.Parse(proxy)
+	if err == nil && u.Host == "" {
+		err = errors.New("no host")
+	}
+	if err == nil && u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5" {
+		err = fmt.Errorf("unsupported scheme '%s'", u.Scheme)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL '%s', error: %s", proxy, err)
+	}
+	return u, nil
+}
+
+// UseProxy sends all requests through the given proxy, rather than the one
+// selected by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables;
+// an empty proxy restores the use of the environment variables.
+//
+// The proxy is set on http.DefaultTransport, which is used by the HTTP
+// clients of this package as well as those of the Taskcluster Go client.
+// Requests are still signed for their own URL, so that the proxy forwards
+// them to the service unchanged.
+func UseProxy(proxy string) error {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return errors.New("cannot set a proxy: http.DefaultTransport has been replaced")
+	}
+	// connections opened without the proxy must not be reused
+	defer transport.CloseIdleConnections()
+	if proxy == "" {
+		transport.Proxy = http.ProxyFromEnvironment
+		return nil
+	}
+	u, err := ParseProxy(proxy)
+	if err != nil {
+		return err
+	}
+	transport.Proxy = http.ProxyURL(u)
+	return nil
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/tent/hawk-go"
+)
+
+// forwardingProxy returns an HTTP proxy forwarding requests to their URL, and
+// the list of the URLs it forwarded.
+func forwardingProxy() (*httptest.Server, *[]string) {
+	var forwarded []string
+	direct := &http.Transport{DisableKeepAlives: true}
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = append(forwarded, r.URL.String())
+		req, err := http.NewRequest(r.Method, r.URL.String(), r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		req.Header = r.Header.Clone()
+		res, err := direct.RoundTrip(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer res.Body.Close()
+		w.WriteHeader(res.StatusCode)
+		_, _ = io.Copy(w, res.Body)
+	}))
+	return proxy, &forwarded
+}
+
+func TestRequestThroughProxy(t *testing.T) {
+	assert := assert.New(t)
+
+	creds := &Credentials{ClientID: "tester", AccessToken: "no-secret"}
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the signature is valid for the request received by the service
+		auth, err := hawk.NewAuthFromRequest(r, func(c *hawk.Credentials) error {
+			c.Key = creds.AccessToken
+			c.Hash = sha256.New
+			return nil
+		}, nil)
+		if err == nil {
+			err = auth.Valid()
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer target.Close()
+
+	proxy, forwarded := forwardingProxy()
+	defer proxy.Close()
+	assert.NoError(UseProxy(proxy.URL))
+	defer func() { assert.NoError(UseProxy("")) }()
+
+	c := New(creds)
+	res, err := c.Request(context.Background(), "GET", target.URL+"/api/fake/v1/ping?x=1", nil)
+	assert.NoError(err)
+	assert.Equal(`{}`, string(res.Body))
+	assert.Equal([]string{target.URL + "/api/fake/v1/ping?x=1"}, *forwarded)
+}
+
+func TestParseProxy(t *testing.T) {
+	assert := assert.New(t)
+
+	u, err := ParseProxy("proxy.example.com:3128")
+	assert.NoError(err)
+	assert.Equal("http://proxy.example.com:3128", u.String())
+
+	u, err = ParseProxy("socks5://127.0.0.1:1080")
+	assert.NoError(err)
+	assert.Equal("socks5", u.Scheme)
+
+	_, err = ParseProxy("ftp://proxy.example.com")
+	assert.EqualError(err, "invalid proxy URL 'ftp://proxy.example.com', error: unsupported scheme 'ftp'")
+	_, err = ParseProxy("http://")
+	assert.EqualError(err, "invalid proxy URL 'http://', error: no host")
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiting the rate at which requests are
+// sent: it holds up to burst tokens, refilled at rate tokens per second, and
+// every attempt at a request takes one.  A RateLimiter may be shared by any
+// number of clients, and used concurrently.
+type RateLimiter struct {
+	rate  float64
+	burst int
+
+	mutex  sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing rate requests per second on
+// average, and up to burst requests at once; it starts full.  rate must be
+// positive, and burst is at least 1.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rate,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Rate returns the number of requests allowed per second.
+func (l *RateLimiter) Rate() float64 {
+	return l.rate
+}
+
+// Burst returns the number of requests allowed at once.
+func (l *RateLimiter) Burst() int {
+	return l.burst
+}
+
+// Wait blocks until a request may be sent, or until ctx is done, in which
+// case it returns the context's error without taking a token.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token, which may not be available yet, and returns how long
+// to wait until it is.  Waiting requests queue up, as each takes a token in
+// turn.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns the token of a request which was not sent.
+func (l *RateLimiter) cancel() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.tokens++
+}
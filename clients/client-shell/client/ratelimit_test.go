@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewRateLimiter(20, 3)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.NoError(l.Wait(context.Background()))
+	}
+	assert.True(time.Since(start) < 40*time.Millisecond, "the burst should not wait")
+
+	// the next token is refilled after 1/20th of a second
+	assert.NoError(l.Wait(context.Background()))
+	assert.True(time.Since(start) >= 40*time.Millisecond, "the rate limit was not applied")
+}
+
+func TestRateLimiterRespectsContext(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewRateLimiter(0.1, 1)
+	assert.NoError(l.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	assert.Equal(context.DeadlineExceeded, l.Wait(ctx))
+	assert.True(time.Since(start) < time.Second, "Wait did not return when the context was done")
+
+	// the token of the cancelled request is returned
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	assert.True(l.tokens > -0.5, "the token was not returned: %f", l.tokens)
+}
+
+func TestRequestRateLimited(t *testing.T) {
+	assert := assert.New(t)
+
+	server, count := flakyServer(1, http.StatusInternalServerError, nil)
+	defer server.Close()
+
+	// the retry after the failure waits for the limiter too
+	c := &Client{Retry: fastRetries, Limiter: NewRateLimiter(25, 1)}
+	start := time.Now()
+	_, err := c.Request(context.Background(), "GET", server.URL, nil)
+	assert.NoError(err)
+	_, err = c.Request(context.Background(), "GET", server.URL, nil)
+	assert.NoError(err)
+	assert.Equal(int32(3), atomic.LoadInt32(count))
+	assert.True(time.Since(start) >= 70*time.Millisecond, "requests were not rate limited")
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+// signatureTestCase is a request signed with known-good Hawk credentials.  The
+// expected headers in testdata/signatures.json were computed independently of
+// this package, building `ext` the same way as the JS client.
+type signatureTestCase struct {
+	Description string      `json:"description"`
+	Credentials Credentials `json:"credentials"`
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	Payload     *string     `json:"payload"`
+	Timestamp   int64       `json:"timestamp"`
+	Nonce       string      `json:"nonce"`
+	Header      string      `json:"header"`
+}
+
+func TestCredentialsSignatures(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/signatures.json")
+	assert.NoError(t, err)
+	var testCases []signatureTestCase
+	assert.NoError(t, json.Unmarshal(data, &testCases))
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var auth, err = tc.Credentials.newAuth(tc.Method, tc.URL, nil)
+			if tc.Payload != nil {
+				h := PayloadHash("application/json")
+				_, _ = h.Write([]byte(*tc.Payload))
+				auth, err = tc.Credentials.newAuth(tc.Method, tc.URL, h)
+			}
+			assert.NoError(err)
+
+			auth.Timestamp = time.Unix(tc.Timestamp, 0)
+			auth.Nonce = tc.Nonce
+			assert.Equal(tc.Header, auth.RequestHeader())
+		})
+	}
+}
+
+func TestCredentialsInvalidCertificate(t *testing.T) {
+	creds := &Credentials{
+		ClientID:    "tester",
+		AccessToken: "no-secret",
+		Certificate: "not a certificate",
+	}
+	_, err := creds.SignHeader("GET", "https://tc.example.com/api/queue/v1/ping", nil)
+	assert.Error(t, err)
+}
+
+// signedURLTestCase is a URL signed with known-good credentials.  The expected
+// URLs in testdata/signed-urls.json were computed independently of this
+// package, in the same way as the JS client's buildSignedUrl.
+type signedURLTestCase struct {
+	Description string      `json:"description"`
+	Credentials Credentials `json:"credentials"`
+	URL         string      `json:"url"`
+	Expires     int64       `json:"expires"`
+	SignedURL   string      `json:"signedUrl"`
+}
+
+func TestCredentialsSignedURLs(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/signed-urls.json")
+	assert.NoError(t, err)
+	var testCases []signedURLTestCase
+	assert.NoError(t, json.Unmarshal(data, &testCases))
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Description, func(t *testing.T) {
+			signed, err := tc.Credentials.signURL(tc.URL, time.Unix(tc.Expires, 0))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.SignedURL, signed)
+		})
+	}
+}
+
+func TestCredentialsSignURLExpiry(t *testing.T) {
+	assert := assert.New(t)
+
+	creds := &Credentials{ClientID: "tester", AccessToken: "no-secret"}
+	before := time.Now().Add(15 * time.Minute).Unix()
+	signed, err := creds.SignURL("https://tc.example.com/api/queue/v1/task/abc123", 15*time.Minute)
+	assert.NoError(err)
+
+	u, err := url.Parse(signed)
+	assert.NoError(err)
+	bewit, err := base64.RawURLEncoding.DecodeString(u.Query().Get("bewit"))
+	assert.NoError(err)
+	parts := strings.Split(string(bewit), `\`)
+	assert.Len(parts, 4)
+	assert.Equal("tester", parts[0])
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	assert.NoError(err)
+	assert.True(expires >= before && expires <= before+5, "bewit expires at %d, not in 15 minutes", expires)
+}
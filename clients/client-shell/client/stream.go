@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+)
+
+// maxErrorBody bounds how much of the body of an error response to a
+// streaming request is read into the APICallError.
+const maxErrorBody = 1 << 20
+
+// Stream sends a request like Request, but streams the request body from
+// body (nil for none), and returns the response without reading its body, so
+// that neither needs to fit in memory.  The caller must close the response
+// body.
+//
+// As the request body cannot be sent twice, only idempotent requests without
+// a body are retried.  If the final response is not a success, the error is
+// an *APICallError.
+func (c *Client) Stream(ctx context.Context, method, url string, body io.Reader) (*http.Response, error) {
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = defaultHTTPClient
+	}
+
+	maxAttempts := c.Retry.MaxAttempts
+	if maxAttempts < 1 || !isIdempotent(method) || body != nil {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		if err := c.wait(ctx); err != nil {
+			return nil, err
+		}
+		c.tracef("Attempt %d of %d: %s %s", attempt, maxAttempts, method, url)
+		start := time.Now()
+		res, err := c.send(ctx, httpClient, method, url, body)
+		c.tracef("Attempt %d finished after %s", attempt, time.Since(start))
+		if err == nil && res.StatusCode/100 == 2 {
+			return res, nil
+		}
+
+		var errRes *Response
+		if err == nil {
+			errRes, err = readErrorResponse(res)
+		}
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if attempt >= maxAttempts || (err == nil && !isTransient(errRes.StatusCode)) {
+			if err != nil {
+				return nil, err
+			}
+			return nil, newAPICallError(errRes, attempt, c.TraceID)
+		}
+
+		delay := c.Retry.delay(attempt, errRes)
+		if err != nil {
+			c.tracef("Retrying in %s, after error: %s", delay, err)
+		} else {
+			c.tracef("Retrying in %s, after status %d", delay, errRes.StatusCode)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// send makes a single attempt at a streaming request, returning the response
+// with its body unread.
+func (c *Client) send(ctx context.Context, httpClient *http.Client, method, url string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+		// send the length of regular files, rather than a chunked body
+		if f, ok := body.(*os.File); ok {
+			if info, err := f.Stat(); err == nil && info.Mode().IsRegular() {
+				req.ContentLength = info.Size()
+			}
+		}
+	}
+	if c.TraceID != "" {
+		req.Header.Set(TraceHeader, c.TraceID)
+	}
+
+	// the body is not hashed, as that would require reading it twice
+	if c.Credentials != nil {
+		if err := c.Credentials.SignRequest(req, nil); err != nil {
+			return nil, fmt.Errorf("Failed to sign request, error: %s", err)
+		}
+	}
+
+	c.logRequest(req, nil)
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	c.logResponse(res, nil)
+	return res, nil
+}
+
+// readErrorResponse reads the start of the body of an unsuccessful response,
+// and closes it.
+func readErrorResponse(res *http.Response) (*Response, error) {
+	defer res.Body.Close()
+	data, err := ioutil.ReadAll(io.LimitReader(res.Body, maxErrorBody))
+	if err != nil {
+		return nil, err
+	}
+	return &Response{
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+		Body:       data,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestStreamRetriesDownloads(t *testing.T) {
+	assert := assert.New(t)
+
+	server, count := flakyServer(2, http.StatusServiceUnavailable, nil)
+	defer server.Close()
+
+	c := &Client{Retry: fastRetries}
+	res, err := c.Stream(context.Background(), "GET", server.URL, nil)
+	assert.NoError(err)
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	assert.NoError(err)
+	assert.Equal(`{}`, string(body))
+	assert.Equal(int32(3), atomic.LoadInt32(count))
+}
+
+func TestStreamDoesNotRetryUploads(t *testing.T) {
+	assert := assert.New(t)
+
+	server, count := flakyServer(2, http.StatusServiceUnavailable, nil)
+	defer server.Close()
+
+	c := &Client{Retry: fastRetries}
+	_, err := c.Stream(context.Background(), "PUT", server.URL, strings.NewReader("data"))
+	apiErr, ok := err.(*APICallError)
+	assert.True(ok, "expected an *APICallError, got %T", err)
+	assert.Equal(http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Equal(1, apiErr.Attempts)
+	assert.Equal(int32(1), atomic.LoadInt32(count))
+}
+
+func TestStreamUpload(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		_, _ = w.Write([]byte(strconv.Itoa(len(body))))
+	}))
+	defer server.Close()
+
+	c := &Client{Retry: fastRetries}
+	res, err := c.Stream(context.Background(), "PUT", server.URL, strings.NewReader(strings.Repeat("x", 1<<16)))
+	assert.NoError(err)
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	assert.NoError(err)
+	assert.Equal("65536", string(body))
+	assert.Equal("application/octet-stream", res.Header.Get("Content-Type"))
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// UseTLS configures the verification of the certificates of the services,
+// for all requests sent with http.DefaultTransport (see UseProxy).  caCert, if
+// set, is a file of PEM-encoded CA certificates to trust in addition to those
+// of the system, such as that of a deployment with a self-signed certificate.
+//
+// insecure disables the verification of certificates altogether, so that
+// anyone able to intercept the connections can read or alter them, and steal
+// the credentials; it is only meant for testing.
+func UseTLS(caCert string, insecure bool) error {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return errors.New("cannot configure TLS: http.DefaultTransport has been replaced")
+	}
+	// connections verified differently must not be reused
+	defer transport.CloseIdleConnections()
+
+	if caCert == "" && !insecure {
+		transport.TLSClientConfig = nil
+		return nil
+	}
+
+	config := &tls.Config{InsecureSkipVerify: insecure}
+	if caCert != "" {
+		pool, err := certPool(caCert)
+		if err != nil {
+			return err
+		}
+		config.RootCAs = pool
+	}
+	transport.TLSClientConfig = config
+	return nil
+}
+
+// certPool returns the system's certificate pool, with the certificates of
+// the PEM file caCert added.
+func certPool(caCert string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(caCert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificates, error: %s", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM-encoded certificates found in %s", caCert)
+	}
+	return pool, nil
+}
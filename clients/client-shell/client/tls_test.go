@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+// writeCert writes the certificate of server to a PEM file in dir.
+func writeCert(t *testing.T, dir string, server *httptest.Server) string {
+	file := filepath.Join(dir, "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	assert.NoError(t, ioutil.WriteFile(file, data, 0644))
+	return file
+}
+
+func TestUseTLS(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "tls")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	defer func() { assert.NoError(UseTLS("", false)) }()
+
+	c := &Client{}
+	request := func() error {
+		_, err := c.Request(context.Background(), "GET", server.URL, nil)
+		return err
+	}
+
+	// the certificate of the test server is self-signed
+	assert.Error(request())
+
+	assert.NoError(UseTLS(writeCert(t, dir, server), false))
+	assert.NoError(request())
+
+	assert.NoError(UseTLS("", true))
+	assert.NoError(request())
+
+	assert.NoError(UseTLS("", false))
+	assert.Error(request())
+}
+
+func TestUseTLSInvalidCACert(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "tls")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	defer func() { assert.NoError(UseTLS("", false)) }()
+
+	file := filepath.Join(dir, "ca.pem")
+	assert.NoError(ioutil.WriteFile(file, []byte("not a certificate"), 0644))
+	assert.EqualError(UseTLS(file, false), "no PEM-encoded certificates found in "+file)
+
+	err = UseTLS(filepath.Join(dir, "missing.pem"), false)
+	assert.Error(err)
+	assert.Contains(err.Error(), "failed to read CA certificates")
+}
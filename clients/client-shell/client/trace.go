@@ -0,0 +1,74 @@
+package client
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// redactedHeaders are never logged, as they carry credentials.
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+}
+
+// logEnabled returns true if the client's logger logs at the given level.
+func (c *Client) logEnabled(level logrus.Level) bool {
+	return c.Logger != nil && c.Logger.IsLevelEnabled(level)
+}
+
+// tracef logs timing and retry information, at trace level.
+func (c *Client) tracef(format string, args ...interface{}) {
+	if c.logEnabled(logrus.TraceLevel) {
+		c.Logger.Tracef(format, args...)
+	}
+}
+
+// logRequest logs a request about to be sent, at debug level.
+func (c *Client) logRequest(req *http.Request, body []byte) {
+	if !c.logEnabled(logrus.DebugLevel) {
+		return
+	}
+	c.Logger.Debugf("> %s %s", req.Method, req.URL)
+	c.logHeaders(">", req.Header)
+	if len(body) != 0 {
+		c.Logger.Debugf("> %s", body)
+	}
+}
+
+// logResponse logs a response received, at debug level.  Its status line
+// carries the statusCode, the traceId of the request and the requestId the
+// service gave it, if any, as fields.
+func (c *Client) logResponse(res *http.Response, body []byte) {
+	if !c.logEnabled(logrus.DebugLevel) {
+		return
+	}
+	fields := logrus.Fields{"statusCode": res.StatusCode}
+	if res.Request != nil && res.Request.Header.Get(TraceHeader) != "" {
+		fields["traceId"] = res.Request.Header.Get(TraceHeader)
+	}
+	if requestID := res.Header.Get("X-For-Request-Id"); requestID != "" {
+		fields["requestId"] = requestID
+	}
+	c.Logger.WithFields(fields).Debugf("< %s", res.Status)
+	c.logHeaders("<", res.Header)
+	if len(body) != 0 {
+		c.Logger.Debugf("< %s", body)
+	}
+}
+
+func (c *Client) logHeaders(prefix string, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			value = "<redacted>"
+		}
+		c.Logger.Debugf("%s %s: %s", prefix, name, value)
+	}
+}
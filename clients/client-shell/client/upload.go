@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// md5ETag matches the ETag of an object uploaded with a single PUT to S3,
+// which is the MD5 hash of its content; that of multipart uploads has a
+// `-<parts>` suffix, and is not checked.
+var md5ETag = regexp.MustCompile(`^"?([0-9a-fA-F]{32})"?$`)
+
+// ContentType returns the MIME type of the content of a file to upload: that
+// registered for the extension of filename, or else the type detected from
+// the start of the content by http.DetectContentType.  The content is read
+// from its start, and left there.
+func ContentType(filename string, content io.ReadSeeker) (string, error) {
+	if t := mime.TypeByExtension(filepath.Ext(filename)); t != "" {
+		return t, nil
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return http.DetectContentType(head[:n]), nil
+}
+
+// Upload PUTs size bytes of content to a signed URL, such as the `putUrl`
+// returned by the queue's createArtifact for an s3 artifact, with the given
+// Content-Type.  The URL is signed already, so the request is not signed with
+// the credentials, and is not subject to the Limiter; as it is not sent to a
+// Taskcluster service, it does not carry the TraceID either.
+//
+// As the content can be read again from its start, transient failures are
+// retried like those of Request.  If the response has an ETag which is an MD5
+// hash, as that of S3 does, it must match the content uploaded.  If the final
+// response is not a success, the error is an *APICallError.
+func (c *Client) Upload(ctx context.Context, url string, content io.ReadSeeker, size int64, contentType string) error {
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = defaultHTTPClient
+	}
+
+	maxAttempts := c.Retry.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		if _, err := content.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("Failed to rewind content, error: %s", err)
+		}
+		c.tracef("Attempt %d of %d: PUT %s", attempt, maxAttempts, url)
+		start := time.Now()
+		hash := md5.New()
+		res, err := c.put(ctx, httpClient, url, io.TeeReader(content, hash), size, contentType)
+		c.tracef("Attempt %d finished after %s", attempt, time.Since(start))
+		if err == nil && res.StatusCode/100 == 2 {
+			return checkETag(res.Header.Get("ETag"), hash.Sum(nil))
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if attempt >= maxAttempts || (err == nil && !isTransient(res.StatusCode)) {
+			if err != nil {
+				return err
+			}
+			return newAPICallError(res, attempt, "")
+		}
+
+		delay := c.Retry.delay(attempt, res)
+		if err != nil {
+			c.tracef("Retrying in %s, after error: %s", delay, err)
+		} else {
+			c.tracef("Retrying in %s, after status %d", delay, res.StatusCode)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// put makes a single attempt at an upload, reading the body of the response.
+func (c *Client) put(
+	ctx context.Context, httpClient *http.Client, url string, body io.Reader, size int64, contentType string,
+) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.ContentLength = size
+	if size == 0 {
+		// an empty body is sent with a Content-Length of zero, not chunked
+		req.Body = http.NoBody
+	}
+
+	c.logRequest(req, nil)
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	c.logResponse(res, nil)
+	if res.StatusCode/100 == 2 {
+		res.Body.Close()
+		return &Response{StatusCode: res.StatusCode, Header: res.Header}, nil
+	}
+	return readErrorResponse(res)
+}
+
+// checkETag returns an error if etag is an MD5 hash other than sum.
+func checkETag(etag string, sum []byte) error {
+	m := md5ETag.FindStringSubmatch(etag)
+	if m == nil {
+		return nil
+	}
+	if expected := hex.EncodeToString(sum); !strings.EqualFold(m[1], expected) {
+		return fmt.Errorf("Upload was corrupted: the ETag of the response is %s, but the MD5 hash of the content is %s", etag, expected)
+	}
+	return nil
+}
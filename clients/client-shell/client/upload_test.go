@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestContentType(t *testing.T) {
+	assert := assert.New(t)
+
+	// the extension is used if known, without reading the content
+	content := strings.NewReader("not json")
+	contentType, err := ContentType("artifact.json", content)
+	assert.NoError(err)
+	assert.Equal("application/json", contentType)
+	assert.Equal(int64(8), content.Size())
+
+	// otherwise, the content is sniffed, and rewound
+	content = strings.NewReader("<html><body>hello</body></html>")
+	contentType, err = ContentType("index", content)
+	assert.NoError(err)
+	assert.Equal("text/html; charset=utf-8", contentType)
+	data, err := ioutil.ReadAll(content)
+	assert.NoError(err)
+	assert.Equal("<html><body>hello</body></html>", string(data))
+
+	contentType, err = ContentType("empty", strings.NewReader(""))
+	assert.NoError(err)
+	assert.Equal("text/plain; charset=utf-8", contentType)
+}
+
+// uploadServer records the uploads it receives, failing the first failures of
+// them with a 503, and responds with the MD5 hash of their content as ETag,
+// or with etag if set.
+func uploadServer(failures int32, etag string) (*httptest.Server, *int32, *http.Request, *[]byte) {
+	var count int32
+	received := &http.Request{}
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		if atomic.AddInt32(&count, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		*received = *r
+		body = data
+		sum := md5.Sum(data)
+		if etag == "" {
+			w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+		} else {
+			w.Header().Set("ETag", etag)
+		}
+	}))
+	return server, &count, received, &body
+}
+
+func TestUpload(t *testing.T) {
+	assert := assert.New(t)
+
+	server, count, received, body := uploadServer(2, "")
+	defer server.Close()
+
+	// the URL is signed already, so the request is not
+	c := &Client{Retry: fastRetries, Credentials: &Credentials{ClientID: "tester", AccessToken: "no-secret"}}
+	content := strings.NewReader("some content")
+	assert.NoError(c.Upload(context.Background(), server.URL, content, content.Size(), "text/plain"))
+
+	// failed attempts are retried, sending the whole content every time
+	assert.Equal(int32(3), atomic.LoadInt32(count))
+	assert.Equal("some content", string(*body))
+	assert.Equal("PUT", received.Method)
+	assert.Equal("text/plain", received.Header.Get("Content-Type"))
+	assert.Equal(int64(12), received.ContentLength)
+	assert.Empty(received.Header.Get("Authorization"))
+}
+
+func TestUploadEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	server, _, received, body := uploadServer(0, "")
+	defer server.Close()
+
+	c := &Client{Retry: fastRetries}
+	assert.NoError(c.Upload(context.Background(), server.URL, strings.NewReader(""), 0, "text/plain"))
+	assert.Equal(int64(0), received.ContentLength)
+	assert.Empty(received.TransferEncoding)
+	assert.Empty(*body)
+}
+
+func TestUploadChecksETag(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &Client{Retry: fastRetries}
+
+	// an MD5 ETag must match the content
+	server, _, _, _ := uploadServer(0, `"00000000000000000000000000000000"`)
+	defer server.Close()
+	err := c.Upload(context.Background(), server.URL, strings.NewReader("data"), 4, "text/plain")
+	assert.Error(err)
+	assert.Contains(err.Error(), "Upload was corrupted")
+
+	// other ETags, such as those of multipart uploads, are not checked
+	other, _, _, _ := uploadServer(0, `"00000000000000000000000000000000-2"`)
+	defer other.Close()
+	assert.NoError(c.Upload(context.Background(), other.URL, strings.NewReader("data"), 4, "text/plain"))
+}
+
+func TestUploadFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	server, count, _, _ := uploadServer(10, "")
+	defer server.Close()
+
+	c := &Client{Retry: fastRetries}
+	err := c.Upload(context.Background(), server.URL, strings.NewReader("data"), 4, "text/plain")
+	apiErr, ok := err.(*APICallError)
+	assert.True(ok, "expected an *APICallError, got %T", err)
+	assert.Equal(http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Equal(5, apiErr.Attempts)
+	assert.Equal(int32(5), atomic.LoadInt32(count))
+}
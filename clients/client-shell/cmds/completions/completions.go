@@ -2,12 +2,17 @@
 package completions
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/root"
 )
 
 var (
 	defaultFilename = "bash_completion.sh"
+
+	// shells for which `taskcluster completion` can generate a script
+	shells = []string{"bash", "zsh", "fish"}
 )
 
 func init() {
@@ -21,11 +26,32 @@ To use, do one of the following:
 'source bash_completion.sh' to add to your current shell,
 Add 'source bash_completion.sh' to your bash login scripts
 On Linux you can also copy it to /etc/bash_completion.d/ so that future bash shells have it active.
+
+See also 'taskcluster completion' for zsh and fish.
         `,
 		RunE: genCompletion,
 		Use:  use,
 	}
 	root.Command.AddCommand(completionsCommand)
+
+	completionCommand := &cobra.Command{
+		Use:   "completion <bash|zsh|fish>",
+		Short: "Writes a completion script for the given shell to stdout.",
+		Long: `Writes a completion script for bash, zsh or fish to stdout.
+
+The script covers every command, including all of the 'taskcluster api'
+services and methods, along with their flags.
+
+To use, do one of the following:
+bash: 'source <(taskcluster completion bash)'
+zsh:  'taskcluster completion zsh > "${fpath[1]}/_taskcluster"', then start a new shell
+fish: 'taskcluster completion fish | source'
+`,
+		ValidArgs: shells,
+		Args:      cobra.ExactValidArgs(1),
+		RunE:      genShellCompletion,
+	}
+	root.Command.AddCommand(completionCommand)
 }
 
 func genCompletion(cmd *cobra.Command, args []string) error {
@@ -36,3 +62,18 @@ func genCompletion(cmd *cobra.Command, args []string) error {
 
 	return root.Command.GenBashCompletionFile(filename)
 }
+
+func genShellCompletion(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+
+	switch args[0] {
+	case "bash":
+		return root.Command.GenBashCompletion(out)
+	case "zsh":
+		return root.Command.GenZshCompletion(out)
+	case "fish":
+		return root.Command.GenFishCompletion(out, true)
+	}
+
+	return fmt.Errorf("unsupported shell '%s'", args[0])
+}
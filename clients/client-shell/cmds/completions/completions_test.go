@@ -0,0 +1,38 @@
+package completions
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/root"
+)
+
+func TestCompletionScripts(t *testing.T) {
+	// a fake subcommand, standing in for the generated api commands
+	fake := &cobra.Command{Use: "fake-service", Run: func(*cobra.Command, []string) {}}
+	fake.Flags().String("fake-flag", "", "")
+	root.Command.AddCommand(fake)
+	defer root.Command.RemoveCommand(fake)
+
+	for _, shell := range shells {
+		t.Run(shell, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			root.Command.SetOut(buf)
+			defer root.Command.SetOut(nil)
+			root.Command.SetArgs([]string{"completion", shell})
+			assert.NoError(t, root.Command.Execute())
+			assert.NotEmpty(t, buf.String())
+			if shell == "bash" {
+				assert.Contains(t, buf.String(), "fake-service")
+				assert.Contains(t, buf.String(), "--fake-flag")
+			}
+		})
+	}
+}
+
+func TestCompletionUnknownShell(t *testing.T) {
+	root.Command.SetArgs([]string{"completion", "tcsh"})
+	assert.Error(t, root.Command.Execute())
+}
@@ -19,7 +19,17 @@ var (
 	Command = &cobra.Command{
 		Use:   "config",
 		Short: "Get/set taskcluster shell client configuration options.",
-		RunE:  cmdConfig,
+		Long: `Get/set taskcluster shell client configuration options.
+
+Options are given as '<command>.<option>', such as 'config.rootUrl'.
+
+The options of the 'config' command (the root URL, timeouts, rate limit, TLS
+options and credentials) can also be set in named profiles, as
+'profiles.<profile>.<option>', such as 'profiles.staging.rootUrl'.  Select a
+profile with --profile or TASKCLUSTER_PROFILE; its options take precedence
+over the other configured values, but not over environment variables or
+flags such as --root-url and --timeout.`,
+		RunE: cmdConfig,
 	}
 )
 
@@ -37,8 +47,46 @@ func init() {
 			Description: "Root URL of the Taskcluster deployment",
 			Default:     "",
 			Env:         "TASKCLUSTER_ROOT_URL",
+			Validate:    isRootURL,
+		},
+		"timeout": config.OptionDefinition{
+			Description: "Overall deadline of each API request, including retries, such as '30s' or '5m'; '0' disables it",
+			Default:     config.DefaultTimeout.String(),
+			Env:         "TASKCLUSTER_TIMEOUT",
+			Validate:    isDuration,
+		},
+		"ioTimeout": config.OptionDefinition{
+			Description: "Overall deadline of each request uploading or downloading an artifact, replacing 'timeout'; '0' disables it",
+			Default:     "0s",
+			Env:         "TASKCLUSTER_IO_TIMEOUT",
+			Validate:    isDuration,
+		},
+		"rateLimit": config.OptionDefinition{
+			Description: "Maximum average number of API requests per second, including retries; '0' disables the limit",
+			Default:     0,
+			Env:         "TASKCLUSTER_RATE_LIMIT",
+			Parse:       true,
+			Validate:    isRate,
+		},
+		"rateBurst": config.OptionDefinition{
+			Description: "Number of API requests which can be sent at once, before 'rateLimit' applies",
+			Default:     config.DefaultRateBurst,
+			Env:         "TASKCLUSTER_RATE_BURST",
+			Parse:       true,
+			Validate:    isBurst,
+		},
+		"caCert": config.OptionDefinition{
+			Description: "File of PEM-encoded CA certificates to trust, in addition to those of the system, such as that of a deployment with a self-signed certificate",
+			Default:     "",
+			Env:         "TASKCLUSTER_CA_CERT",
 			Validate:    isString,
 		},
+		"insecure": config.OptionDefinition{
+			Description: "DANGEROUS: if true, do not verify the TLS certificates of the services, so that connections and credentials can be intercepted; only for testing",
+			Default:     false,
+			Parse:       true,
+			Validate:    isBool,
+		},
 		"clientId": config.OptionDefinition{
 			Description: "ClientId to be used for authenticating requests",
 			Default:     "",
@@ -71,8 +119,7 @@ func init() {
 			Description: `Set of scopes to be used for authorizing requests, defaults to all the scopes you have.`,
 			Parse:       true,
 			Validate: func(value interface{}) error {
-				_, ok := value.([]string)
-				if !ok {
+				if _, ok := config.StringList(value); !ok {
 					return errors.New("Must be a list of strings")
 				}
 				return nil
@@ -111,7 +158,14 @@ func cmdConfig(cmd *cobra.Command, args []string) error {
 	}
 
 	// write output
-	if _, err := cmd.OutOrStdout().Write(formatter(config.Configuration)); err != nil {
+	all := make(map[string]interface{}, len(config.Configuration)+1)
+	for command, options := range config.Configuration {
+		all[command] = options
+	}
+	if len(config.Profiles) > 0 {
+		all[config.ProfilesKey] = config.Profiles
+	}
+	if _, err := cmd.OutOrStdout().Write(formatter(all)); err != nil {
 		return fmt.Errorf("error writing result, error: %s", err)
 	}
 
@@ -37,7 +37,7 @@ func cmdReset(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		config.Configuration[command][option] = definition.Default
+		resetOption(command, option, definition)
 		fmt.Fprintf(cmd.OutOrStdout(), "Reset %s.%s to default value.\n", command, option)
 	}
 
@@ -63,7 +63,7 @@ func cmdSet(cmd *cobra.Command, args []string) error {
 
 	// Save option
 	if dry, _ := cmd.Flags().GetBool("dry-run"); !dry {
-		config.Configuration[command][option] = value
+		setOption(command, option, value)
 		if err := config.Save(config.Configuration); err != nil {
 			return fmt.Errorf("failed to save configuration file, error: %s", err)
 		}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
@@ -37,6 +38,19 @@ func parseKey(key string) (string, string, error) {
 // getOption retrieves the definition, value of a config option
 // use if you don't need to parse the command and option
 func getOption(command string, option string) (config.OptionDefinition, interface{}, error) {
+	// options of profiles are given as `profiles.<profile>.<option>`
+	if command == config.ProfilesKey {
+		name, profileOption, err := parseProfileOption(option)
+		if err != nil {
+			return config.OptionDefinition{}, "", err
+		}
+		definition, ok := config.OptionsDefinitions["config"][profileOption]
+		if !ok {
+			return config.OptionDefinition{}, "", fmt.Errorf("configuration option '%s' cannot be set in a profile", profileOption)
+		}
+		return definition, config.Profiles[name][profileOption], nil
+	}
+
 	// find map of options for specified command
 	options, ok := config.OptionsDefinitions[command]
 	if !ok {
@@ -52,6 +66,40 @@ func getOption(command string, option string) (config.OptionDefinition, interfac
 	return definition, config.Configuration[command][option], nil
 }
 
+// parseProfileOption parses the `<profile>.<option>` part of a profile key
+func parseProfileOption(option string) (string, string, error) {
+	parts := strings.SplitN(option, ".", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", fmt.Errorf("invalid key format '%s.%s', profile keys must be on the form '%s.<profile>.<option>'", config.ProfilesKey, option, config.ProfilesKey)
+	}
+	return parts[0], parts[1], nil
+}
+
+// setOption sets a config option, or an option of a profile, creating the
+// profile if necessary
+func setOption(command, option string, value interface{}) {
+	if command == config.ProfilesKey {
+		name, profileOption, _ := parseProfileOption(option)
+		if config.Profiles[name] == nil {
+			config.Profiles[name] = make(map[string]interface{})
+		}
+		config.Profiles[name][profileOption] = value
+		return
+	}
+	config.Configuration[command][option] = value
+}
+
+// resetOption resets a config option to its default value, or removes an
+// option from a profile
+func resetOption(command, option string, definition config.OptionDefinition) {
+	if command == config.ProfilesKey {
+		name, profileOption, _ := parseProfileOption(option)
+		delete(config.Profiles[name], profileOption)
+		return
+	}
+	config.Configuration[command][option] = definition.Default
+}
+
 func pad(s string, length int) string {
 	p := length - len(s)
 	if p < 0 {
@@ -76,9 +124,45 @@ func formatJSON(value interface{}) []byte {
 	return data
 }
 
+func isRootURL(value interface{}) error {
+	s, ok := value.(string)
+	if !ok {
+		return errors.New("Must be a string")
+	}
+	return config.ValidateRootURL(s)
+}
+
 func isString(value interface{}) error {
 	if _, ok := value.(string); !ok {
 		return errors.New("Must be a string")
 	}
 	return nil
 }
+
+func isBool(value interface{}) error {
+	if _, ok := value.(bool); !ok {
+		return errors.New("Must be true or false")
+	}
+	return nil
+}
+
+func isRate(value interface{}) error {
+	if _, ok := config.Number(value); !ok {
+		return errors.New("Must be a non-negative number of requests per second")
+	}
+	return nil
+}
+
+func isBurst(value interface{}) error {
+	if n, ok := config.Number(value); !ok || n < 1 || n != math.Trunc(n) {
+		return errors.New("Must be a positive integer")
+	}
+	return nil
+}
+
+func isDuration(value interface{}) error {
+	if _, ok := config.Duration(value); !ok {
+		return errors.New("Must be a non-negative duration, such as '30s' or '5m'")
+	}
+	return nil
+}
@@ -1,7 +1,6 @@
 package group
 
 import (
-	"context"
 	"fmt"
 	"html/template"
 	"io"
@@ -21,10 +20,15 @@ func init() {
 	cancelCmd := &cobra.Command{
 		Use:   "cancel <taskGroupId>",
 		Short: "Cancel a whole group by taskGroupId.",
-		RunE:  executeHelperE(runCancel),
+		Long: `Cancels every unscheduled, pending or running task of a group, after
+confirmation, reporting whether each cancellation succeeded.  The exit status
+is non-zero if any of them failed.`,
+		RunE: executeHelperE(runCancel),
 	}
 	cancelCmd.Flags().StringP("worker-type", "w", "", "Only cancel tasks with a certain worker type.")
 	cancelCmd.Flags().BoolP("force", "f", false, "Skip cancellation confirmation.")
+	cancelCmd.Flags().IntP("concurrency", "j", 10, "Maximum number of cancellations to run at a time.")
+	cancelCmd.Flags().Bool("dry-run", false, "List the tasks which would be cancelled, without cancelling them.")
 
 	Command.AddCommand(cancelCmd)
 
@@ -63,12 +67,18 @@ func makeQueue(credentials *tcclient.Credentials) *tcqueue.Queue {
 //
 // It first fetches the list of all tasks associated with the given group,
 // then filters for only cancellable tasks (unscheduled, pending, running),
-// and finally runs all cancellations concurrently, because they are
-// independent of each other.
+// and finally runs the cancellations concurrently, because they are
+// independent of each other.  A failed cancellation does not stop the
+// others; the error reports how many failed.
 func runCancel(credentials *tcclient.Credentials, args []string, out io.Writer, flags *pflag.FlagSet) error {
 	q := makeQueue(credentials)
 	groupID := args[0]
 
+	concurrency, _ := flags.GetInt("concurrency")
+	if concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d, at least one cancellation must run at a time", concurrency)
+	}
+
 	// Because the list of tasks can be arbitrarily long, we have to loop until
 	// we are told not to.
 	tasks := make([]string, 0)
@@ -102,71 +112,58 @@ func runCancel(credentials *tcclient.Credentials, args []string, out io.Writer,
 		return nil
 	}
 
+	// only list the tasks for a dry run
+	if dryRun, _ := flags.GetBool("dry-run"); dryRun {
+		fmt.Fprintf(out, "The following %d tasks would be cancelled:\n", len(tasks))
+		listTasks(tasks, tasksNames, out)
+		return nil
+	}
+
 	// ask for confirmation before cancellation
 	if force, _ := flags.GetBool("force"); !force && !confirmCancellation(tasks, tasksNames, out) {
 		fmt.Fprintln(out, "Cancellation of tasks aborted.")
 		return nil
 	}
 
-	// Here we use a waitgroup to ensure that we return once all the tasks have
-	// been completed.
-	wg := &sync.WaitGroup{}
-	// The context allows us to exit early if any of the cancellation fails.
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	// errChan allows the first request that panics to propagate the error message
-	// up to us.
-	errChan := make(chan error, 1)
+	if failed := cancelTasks(q, tasks, concurrency, out); failed > 0 {
+		return fmt.Errorf("could not cancel %d of %d tasks", failed, len(tasks))
+	}
+	return nil
+}
+
+// cancelTasks cancels the given tasks, running at most concurrency
+// cancellations at a time, and reports the outcome of each as it completes.
+// It returns the number of cancellations which failed.
+func cancelTasks(q *tcqueue.Queue, tasks []string, concurrency int, out io.Writer) int {
+	var (
+		wg     sync.WaitGroup
+		mutex  sync.Mutex
+		failed int
+	)
+	// a slot is taken by each running cancellation
+	slots := make(chan struct{}, concurrency)
 
 	for _, taskID := range tasks {
 		wg.Add(1)
+		slots <- struct{}{}
 		go func(taskID string) {
-			// If we paniced on the way out, abort all the other tasks.
-			defer func() {
-				//recover from panic and abort
-				if err := recover(); err != nil {
-					if e, ok := err.(error); ok {
-						errChan <- e
-					} else {
-						errChan <- fmt.Errorf("%v", err)
-					}
-					cancel()
-				}
-			}()
-
-			fmt.Fprintf(out, "cancelling task %s\n", taskID)
-			c := make(chan error, 1)
-			go func() { _, err := q.CancelTask(taskID); c <- err }()
-
-			// we select the first that returns or closes:
-			// - ctx.Done() if we aborted;
-			// - c if we got a completed cancellation.
-			select {
-			case <-ctx.Done():
-				// nothing because we can't cancel the existing requests.
-			case err := <-c:
-				if err != nil {
-					panic(fmt.Errorf("could not cancel task %s: %v", taskID, err))
-				}
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			_, err := q.CancelTask(taskID)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				failed++
+				fmt.Fprintf(out, "failed to cancel task %s: %v\n", taskID, err)
+				return
 			}
-			// if we exited normally, we indicate that we completed.
-			wg.Done()
+			fmt.Fprintf(out, "cancelled task %s\n", taskID)
 		}(taskID)
 	}
-	// change the semantics of waitgroup to close a channel instead of blocking
-	// the main thread.
-	regularExit := make(chan bool)
-	go func() { wg.Wait(); close(regularExit) }()
-
-	// We select the first that closes:
-	// - ctx.Done() if we aborted;
-	// - regularExit if all the goroutine exited manually.
-	select {
-	case <-ctx.Done():
-		return fmt.Errorf("could not cancel all tasks: %v", <-errChan)
-	case <-regularExit:
-		return nil
-	}
+	wg.Wait()
+	return failed
 }
 
 // filterTask takes a task and returns whether or not this task should be
@@ -192,12 +189,8 @@ func filterTask(status tcqueue.TaskStatusStructure, flags *pflag.FlagSet) bool {
 
 // confirmCancellation lists the tasks to be cancelled and prompts to confirm cancellation
 func confirmCancellation(ids []string, names []string, out io.Writer) bool {
-	// list tasks
 	fmt.Fprintf(out, "The following %d tasks will be cancelled:\n", len(ids))
-
-	for n, id := range ids {
-		fmt.Fprintf(out, "\tTask %s: %s\n", id, names[n])
-	}
+	listTasks(ids, names, out)
 
 	for {
 		fmt.Fprint(out, "Are you sure you want to cancel these tasks? [y/n] ")
@@ -214,6 +207,13 @@ func confirmCancellation(ids []string, names []string, out io.Writer) bool {
 	}
 }
 
+// listTasks lists the given tasks, one per line.
+func listTasks(ids []string, names []string, out io.Writer) {
+	for n, id := range ids {
+		fmt.Fprintf(out, "\tTask %s: %s\n", id, names[n])
+	}
+}
+
 // runStatus displays the status summary of tasks in a group.
 //
 // It first fetches the list of all tasks associated with the given group,
@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -17,18 +18,26 @@ import (
 const fakeTaskID = "ANnmjMocTymeTID0tlNJAw"
 const fakeGroupID = "e4WPAAeSdaSdKxeWzDCBA"
 const badGroupID = "AAAAAAAAAAAAAAAAAAAAA"
+const failingTaskID = "BBnmjMocTymeTID0tlNJAw"
+const failingGroupID = "f4WPAAeSdaSdKxeWzDCBA"
 
 type FakeServerSuite struct {
 	suite.Suite
 	testServer *httptest.Server
+	cancelled  int32
 }
 
 func (suite *FakeServerSuite) SetupSuite() {
 	// set up a fake server that knows how to answer the `task()` method
 	handler := http.NewServeMux()
 
-	handler.HandleFunc("/api/queue/v1/task/"+fakeTaskID+"/cancel", cancelHandler)
+	handler.HandleFunc("/api/queue/v1/task/"+fakeTaskID+"/cancel", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&suite.cancelled, 1)
+		cancelHandler(w, r)
+	})
+	handler.HandleFunc("/api/queue/v1/task/"+failingTaskID+"/cancel", failingCancelHandler)
 	handler.HandleFunc("/api/queue/v1/task-group/"+fakeGroupID+"/list", listTaskGroupHandler)
+	handler.HandleFunc("/api/queue/v1/task-group/"+failingGroupID+"/list", listFailingTaskGroupHandler)
 
 	suite.testServer = httptest.NewServer(handler)
 
@@ -63,6 +72,24 @@ func cancelHandler(w http.ResponseWriter, _ *http.Request) {
 	_, _ = io.WriteString(w, status)
 }
 
+// refuses to cancel the task, as the queue does for resolved tasks
+func failingCancelHandler(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusConflict)
+	_, _ = io.WriteString(w, `{"code": "RequestConflict", "message": "Task is resolved"}`)
+}
+
+// lists a pending task which can be cancelled, and one which cannot
+func listFailingTaskGroupHandler(w http.ResponseWriter, _ *http.Request) {
+	list := `{
+			  "taskGroupId": "f4WPAAeSdaSdKxeWzDCBA",
+			  "tasks": [
+			    {"status": {"taskId": "ANnmjMocTymeTID0tlNJAw", "state": "pending"}, "task": {"metadata": {"name": "ok"}}},
+			    {"status": {"taskId": "BBnmjMocTymeTID0tlNJAw", "state": "running"}, "task": {"metadata": {"name": "resolving"}}}
+			  ]
+			}`
+	_, _ = io.WriteString(w, list)
+}
+
 func listTaskGroupHandler(w http.ResponseWriter, _ *http.Request) {
 	list := `{
 			  "taskGroupId": "e4WPAAeSdaSdKxeWzDCBA",
@@ -113,16 +140,58 @@ func setUpCommand() (*bytes.Buffer, *cobra.Command) {
 	return buf, cmd
 }
 
-func (suite *FakeServerSuite) TestRunCancel() {
-	// set up to run a command and capture output
+// setUpCancelCommand sets up the flags of the cancel command, skipping
+// confirmation.
+func setUpCancelCommand(dryRun bool) (*bytes.Buffer, *cobra.Command) {
 	buf, cmd := setUpCommand()
 	cmd.Flags().Bool("force", true, "")
+	cmd.Flags().Int("concurrency", 2, "")
+	cmd.Flags().Bool("dry-run", dryRun, "")
+	return buf, cmd
+}
+
+func (suite *FakeServerSuite) TestRunCancel() {
+	// set up to run a command and capture output
+	buf, cmd := setUpCancelCommand(false)
 
 	// run the command
 	args := []string{fakeGroupID}
 	assert.NoError(suite.T(), runCancel(&tcclient.Credentials{}, args, cmd.OutOrStdout(), cmd.Flags()))
 
-	suite.Equal("cancelling task ANnmjMocTymeTID0tlNJAw\n", buf.String())
+	suite.Equal("cancelled task ANnmjMocTymeTID0tlNJAw\n", buf.String())
+}
+
+func (suite *FakeServerSuite) TestRunCancelDryRun() {
+	buf, cmd := setUpCancelCommand(true)
+	before := atomic.LoadInt32(&suite.cancelled)
+
+	args := []string{fakeGroupID}
+	assert.NoError(suite.T(), runCancel(&tcclient.Credentials{}, args, cmd.OutOrStdout(), cmd.Flags()))
+
+	suite.Equal("The following 1 tasks would be cancelled:\n\tTask ANnmjMocTymeTID0tlNJAw: test-framework-task/opt\n", buf.String())
+	suite.Equal(before, atomic.LoadInt32(&suite.cancelled), "no task should be cancelled")
+}
+
+func (suite *FakeServerSuite) TestRunCancelReportsFailures() {
+	buf, cmd := setUpCancelCommand(false)
+
+	// the other cancellation is not stopped by the failure
+	args := []string{failingGroupID}
+	err := runCancel(&tcclient.Credentials{}, args, cmd.OutOrStdout(), cmd.Flags())
+	suite.EqualError(err, "could not cancel 1 of 2 tasks")
+
+	suite.Contains(buf.String(), "cancelled task ANnmjMocTymeTID0tlNJAw\n")
+	suite.Contains(buf.String(), "failed to cancel task BBnmjMocTymeTID0tlNJAw: ")
+	suite.Contains(buf.String(), "Task is resolved")
+}
+
+func (suite *FakeServerSuite) TestRunCancelInvalidConcurrency() {
+	_, cmd := setUpCancelCommand(false)
+	suite.NoError(cmd.Flags().Set("concurrency", "0"))
+
+	args := []string{fakeGroupID}
+	err := runCancel(&tcclient.Credentials{}, args, cmd.OutOrStdout(), cmd.Flags())
+	suite.EqualError(err, "invalid concurrency 0, at least one cancellation must run at a time")
 }
 
 func (suite *FakeServerSuite) TestRunStatus() {
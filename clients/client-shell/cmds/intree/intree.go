@@ -0,0 +1,230 @@
+// Package intree implements the intree command.
+package intree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/root"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/jsone"
+
+	"github.com/spf13/cobra"
+	sluglib "github.com/taskcluster/slugid-go/slugid"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// defaultFile is the file rendered when none is given.
+const defaultFile = ".taskcluster.yml"
+
+func init() {
+	root.Command.AddCommand(newCommand())
+}
+
+// newCommand returns the intree command, with its flags.
+func newCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "intree [<file>]",
+		Short: "Render the tasks of a .taskcluster.yml without creating them.",
+		Long: `Render the tasks of a .taskcluster.yml without creating them.
+
+The file, .taskcluster.yml in the current directory by default, is rendered
+as JSON-e with the context the GitHub service gives it, and the resulting task
+definitions are printed as JSON.  The context has:
+
+  tasks_for             set with --tasks-for (default github-push)
+  event                 the GitHub event, read from the JSON or YAML file
+                        given with --event (default {})
+  now                   set with --now (default the current time)
+  ownTaskId             set with --own-task-id (default a new slugid)
+  as_slugid(name)       a slugid, the same for each name
+  taskcluster_root_url  the configured root URL, if any
+
+Other values of the context are set with --context key.path=value, where the
+value is parsed as JSON if it can be, and taken as a string otherwise, such as
+--context event.pull_request.number=3.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: intree,
+	}
+	cmd.Flags().String("event", "", "File holding the event, as JSON or YAML.")
+	cmd.Flags().String("tasks-for", "github-push", "The kind of event the tasks are rendered for.")
+	cmd.Flags().String("now", "", "The time of the event, as an ISO 8601 timestamp.")
+	cmd.Flags().String("own-task-id", "", "The taskId of the decision task.")
+	cmd.Flags().StringArrayP("context", "c", nil, "Set a value of the context, as key.path=value.")
+	return cmd
+}
+
+func intree(cmd *cobra.Command, args []string) error {
+	file := defaultFile
+	if len(args) > 0 {
+		file = args[0]
+	}
+	template, err := readFile(file)
+	if err != nil {
+		return err
+	}
+	if tcyml, ok := template.(map[string]interface{}); !ok || tcyml["version"] != float64(1) {
+		return fmt.Errorf("%s: only version 1 of .taskcluster.yml is supported", file)
+	}
+
+	context, err := buildContext(cmd)
+	if err != nil {
+		return err
+	}
+	rendered, err := jsone.Render(template, context)
+	if err != nil {
+		return fmt.Errorf("%s: %s", file, err)
+	}
+
+	tasks := []interface{}{}
+	if tcyml, ok := rendered.(map[string]interface{}); ok && tcyml["tasks"] != nil {
+		if tasks, ok = tcyml["tasks"].([]interface{}); !ok {
+			return fmt.Errorf("%s: tasks must render to a list", file)
+		}
+	}
+	out, err := json.MarshalIndent(tasks, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(out))
+	return nil
+}
+
+// buildContext returns the context given by the flags of cmd.
+func buildContext(cmd *cobra.Command) (map[string]interface{}, error) {
+	flags := cmd.Flags()
+	tasksFor, _ := flags.GetString("tasks-for")
+	now, _ := flags.GetString("now")
+	ownTaskID, _ := flags.GetString("own-task-id")
+	eventFile, _ := flags.GetString("event")
+	values, _ := flags.GetStringArray("context")
+
+	if ownTaskID == "" {
+		ownTaskID = sluglib.Nice()
+	}
+	slugids := map[string]interface{}{}
+	context := map[string]interface{}{
+		"tasks_for": tasksFor,
+		"event":     map[string]interface{}{},
+		"ownTaskId": ownTaskID,
+		"as_slugid": jsone.Function(func(args ...interface{}) (interface{}, error) {
+			if len(args) != 1 {
+				return nil, errors.New("as_slugid expects one argument")
+			}
+			name := fmt.Sprint(args[0])
+			if _, ok := slugids[name]; !ok {
+				slugids[name] = sluglib.Nice()
+			}
+			return slugids[name], nil
+		}),
+	}
+	if config.HasRootURL() {
+		context["taskcluster_root_url"] = config.RootURL()
+	}
+	if now != "" {
+		t, err := time.Parse(time.RFC3339Nano, now)
+		if err != nil {
+			return nil, fmt.Errorf("--now must be an ISO 8601 timestamp, not '%s'", now)
+		}
+		context["now"] = t.UTC().Format("2006-01-02T15:04:05.000Z")
+	}
+	if eventFile != "" {
+		event, err := readFile(eventFile)
+		if err != nil {
+			return nil, err
+		}
+		context["event"] = event
+	}
+	for _, value := range values {
+		if err := setContextValue(context, value); err != nil {
+			return nil, err
+		}
+	}
+	return context, nil
+}
+
+// setContextValue sets a value of the context given as `key.path=value`,
+// creating the objects on the path as needed.
+func setContextValue(context map[string]interface{}, assignment string) error {
+	parts := strings.SplitN(assignment, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return fmt.Errorf("--context must be given key.path=value, not '%s'", assignment)
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(parts[1]), &value); err != nil {
+		value = parts[1]
+	}
+
+	keys := strings.Split(parts[0], ".")
+	object := context
+	for i, key := range keys[:len(keys)-1] {
+		next, ok := object[key].(map[string]interface{})
+		if !ok {
+			if _, exists := object[key]; exists {
+				return fmt.Errorf("--context %s: %s is not an object", assignment, strings.Join(keys[:i+1], "."))
+			}
+			next = map[string]interface{}{}
+			object[key] = next
+		}
+		object = next
+	}
+	object[keys[len(keys)-1]] = value
+	return nil
+}
+
+// readFile reads a JSON or YAML file as JSON values.
+func readFile(file string) (interface{}, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("%s: %s", file, err)
+	}
+	return jsonValue(value)
+}
+
+// jsonValue converts a value decoded by yaml to the values decoded by
+// encoding/json, with objects keyed by strings and numbers as float64.
+func jsonValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			name, ok := key.(string)
+			if !ok {
+				name = fmt.Sprint(key)
+			}
+			converted, err := jsonValue(item)
+			if err != nil {
+				return nil, err
+			}
+			object[name] = converted
+		}
+		return object, nil
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			converted, err := jsonValue(item)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = converted
+		}
+		return list, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case float64, string, bool, nil:
+		return v, nil
+	}
+	return nil, fmt.Errorf("unsupported value %v", value)
+}
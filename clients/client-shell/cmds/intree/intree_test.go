@@ -0,0 +1,105 @@
+package intree
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func runIntree(t *testing.T, args ...string) ([]map[string]interface{}, error) {
+	buf := &bytes.Buffer{}
+	cmd := newCommand()
+	cmd.SetOutput(buf)
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		return nil, err
+	}
+	var tasks []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &tasks))
+	return tasks, nil
+}
+
+func TestIntreePush(t *testing.T) {
+	assert := assert.New(t)
+
+	tasks, err := runIntree(t, "testdata/.taskcluster.yml", "--event", "testdata/push.yml",
+		"--now", "2020-01-01T00:00:00Z", "--own-task-id", "ownTaskIdAAAAAAAAAAAAA")
+	assert.NoError(err)
+	assert.Len(tasks, 2)
+
+	decision := tasks[0]
+	assert.Equal("ownTaskIdAAAAAAAAAAAAA", decision["taskGroupId"])
+	assert.Equal("2020-01-01T00:00:00.000Z", decision["created"])
+	assert.Equal("2020-01-02T00:00:00.000Z", decision["deadline"])
+	assert.Equal(map[string]interface{}{
+		"maxRunTime": float64(3600),
+		"command":    []interface{}{"git checkout abc123"},
+	}, decision["payload"])
+	assert.Equal("dev@example.com", decision["metadata"].(map[string]interface{})["owner"])
+
+	// as_slugid gives the same slugid for the same name
+	assert.NotEqual(decision["taskId"], tasks[1]["taskId"])
+	assert.Equal([]interface{}{decision["taskId"]}, tasks[1]["dependencies"])
+}
+
+func TestIntreeContext(t *testing.T) {
+	assert := assert.New(t)
+
+	tasks, err := runIntree(t, "testdata/.taskcluster.yml", "--tasks-for", "github-pull-request",
+		"-c", "event.pull_request.head.sha=def456", "-c", "event.pusher.email=someone", "-c", "event.repository.url=https://example.com")
+	assert.NoError(err)
+	assert.Len(tasks, 1)
+	assert.Equal([]interface{}{"git checkout def456"}, tasks[0]["payload"].(map[string]interface{})["command"])
+
+	// tasks for other events are not rendered
+	tasks, err = runIntree(t, "testdata/.taskcluster.yml", "--tasks-for", "github-release", "-c", "event.pull_request.head.sha=def456")
+	assert.NoError(err)
+	assert.Len(tasks, 0)
+
+	// values which parse as JSON are JSON
+	context := map[string]interface{}{"event": "x"}
+	assert.NoError(setContextValue(context, "a.b=[1, 2]"))
+	assert.NoError(setContextValue(context, "a.c=true"))
+	assert.NoError(setContextValue(context, "d=not json"))
+	assert.Equal(map[string]interface{}{
+		"event": "x",
+		"a":     map[string]interface{}{"b": []interface{}{float64(1), float64(2)}, "c": true},
+		"d":     "not json",
+	}, context)
+	assert.EqualError(setContextValue(context, "event.x=1"), "--context event.x=1: event is not an object")
+	assert.EqualError(setContextValue(context, "a.b"), "--context must be given key.path=value, not 'a.b'")
+}
+
+func TestIntreeErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "intree")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	write := func(content string) string {
+		file := filepath.Join(dir, ".taskcluster.yml")
+		assert.NoError(ioutil.WriteFile(file, []byte(content), 0644))
+		return file
+	}
+
+	file := write("version: 0\ntasks: []\n")
+	_, err = runIntree(t, file)
+	assert.EqualError(err, file+": only version 1 of .taskcluster.yml is supported")
+
+	file = write("version: 1\ntasks: [{$eval: missing}]\n")
+	_, err = runIntree(t, file)
+	assert.EqualError(err, file+": InterpreterError at template.tasks[0]: unknown context value missing")
+
+	file = write("version: 1\ntasks: {a: 1}\n")
+	_, err = runIntree(t, file)
+	assert.EqualError(err, file+": tasks must render to a list")
+
+	_, err = runIntree(t, file, "--now", "yesterday")
+	assert.EqualError(err, "--now must be an ISO 8601 timestamp, not 'yesterday'")
+}
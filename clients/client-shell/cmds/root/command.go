@@ -2,7 +2,13 @@
 package root
 
 import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	sluglib "github.com/taskcluster/slugid-go/slugid"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/client"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
 )
 
 var (
@@ -18,12 +24,94 @@ func setUpRootCmd() *cobra.Command {
 		Long:  "A shell interface to Taskcluster",
 	}
 
-	verbose := rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
+	verbose := rootCmd.PersistentFlags().CountP("verbose", "v", "verbose output, including HTTP requests and responses; repeat (-vv) to include timing and retries")
+	logFormat := rootCmd.PersistentFlags().String("log-format", "text", "Format of the messages written to stderr: 'text', or 'json' for one JSON object per line")
+
+	profile := rootCmd.PersistentFlags().String("profile", "", "Configuration profile to use, overriding TASKCLUSTER_PROFILE")
+	rootURL := rootCmd.PersistentFlags().String("root-url", "", "Root URL of the Taskcluster deployment, overriding TASKCLUSTER_ROOT_URL and the configuration")
+	timeout := rootCmd.PersistentFlags().Duration("timeout", config.DefaultTimeout, "Overall deadline of each API request, including retries, overriding TASKCLUSTER_TIMEOUT and the configuration; 0 disables it")
+	ioTimeout := rootCmd.PersistentFlags().Duration("io-timeout", 0, "Deadline of each request uploading or downloading an artifact, replacing --timeout, overriding TASKCLUSTER_IO_TIMEOUT and the configuration; 0 disables it")
+	rateLimit := rootCmd.PersistentFlags().Float64("rate-limit", 0, "Maximum average number of API requests per second, including retries, overriding TASKCLUSTER_RATE_LIMIT and the configuration; 0 disables it")
+	rateBurst := rootCmd.PersistentFlags().Int("rate-burst", config.DefaultRateBurst, "Number of API requests which can be sent at once before --rate-limit applies, overriding TASKCLUSTER_RATE_BURST and the configuration")
+	proxy := rootCmd.PersistentFlags().String("proxy", "", "Proxy to send all requests through, such as 'http://proxy:3128', overriding HTTP_PROXY, HTTPS_PROXY and NO_PROXY")
+	caCert := rootCmd.PersistentFlags().String("ca-cert", "", "File of PEM-encoded CA certificates to trust in addition to the system's, overriding TASKCLUSTER_CA_CERT and the configuration")
+	insecure := rootCmd.PersistentFlags().Bool("insecure", false, "DANGEROUS: do not verify the TLS certificates of the services, so that connections and credentials can be intercepted; only for testing")
+	traceID := rootCmd.PersistentFlags().String("trace-id", "", "ID sent with every API request as the "+client.TraceHeader+" header, to correlate them in the logs of the services; a new slugid by default")
 
 	// function to run before every subcommand
-	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
-		setUpLogs(*verbose)
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := setUpLogs(*verbose, *logFormat); err != nil {
+			return err
+		}
+		if jsonLogs {
+			// errors are reported as log entries by Execute, without usage
+			cmd.Root().SilenceErrors = true
+			cmd.Root().SilenceUsage = true
+		}
+		if *profile != "" {
+			if err := config.UseProfile(*profile); err != nil {
+				return err
+			}
+		}
+		if *rootURL != "" {
+			if err := config.ValidateRootURL(*rootURL); err != nil {
+				return err
+			}
+			config.SetRootURL(*rootURL)
+		}
+		if cmd.Flags().Changed("timeout") {
+			config.Timeout = *timeout
+		}
+		if cmd.Flags().Changed("io-timeout") {
+			config.IOTimeout = *ioTimeout
+		}
+		if cmd.Flags().Changed("rate-limit") {
+			if *rateLimit < 0 {
+				return errors.New("--rate-limit cannot be negative")
+			}
+			config.RateLimit = *rateLimit
+		}
+		if cmd.Flags().Changed("rate-burst") {
+			if *rateBurst < 1 {
+				return errors.New("--rate-burst must be at least 1")
+			}
+			config.RateBurst = *rateBurst
+		}
+		if *caCert != "" {
+			config.CACert = *caCert
+		}
+		if cmd.Flags().Changed("insecure") {
+			config.Insecure = *insecure
+		}
+		if err := client.UseTLS(config.CACert, config.Insecure); err != nil {
+			return err
+		}
+		if config.Insecure {
+			Report(cmd.ErrOrStderr(), logrus.WarnLevel, nil, "WARNING: TLS certificates are not verified (--insecure); connections to Taskcluster, and your credentials, can be intercepted")
+		}
+		if *proxy != "" {
+			if err := client.UseProxy(*proxy); err != nil {
+				return err
+			}
+		}
+		config.TraceID = *traceID
+		if config.TraceID == "" {
+			config.TraceID = sluglib.Nice()
+		}
+		Logger.WithField("traceId", config.TraceID).Debugf("Trace ID: %s", config.TraceID)
+		return nil
 	}
 
 	return rootCmd
 }
+
+// Execute runs the command tree.  With `--log-format json`, the error of the
+// command run, if any, is written to stderr as a log entry, unless the
+// command has reported it already.
+func Execute() error {
+	cmd, err := Command.ExecuteC()
+	if err != nil && jsonLogs && (cmd == Command || !cmd.SilenceErrors) {
+		Report(cmd.ErrOrStderr(), logrus.ErrorLevel, ErrorFields(err), err.Error())
+	}
+	return err
+}
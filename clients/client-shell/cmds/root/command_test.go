@@ -0,0 +1,35 @@
+package root
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+func TestTraceID(t *testing.T) {
+	assert := assert.New(t)
+
+	_, reset := withLogs(t, "text")
+	defer reset()
+	defer func() { config.TraceID = "" }()
+
+	noop := &cobra.Command{Use: "noop", Run: func(cmd *cobra.Command, args []string) {}}
+	Command.AddCommand(noop)
+	defer Command.RemoveCommand(noop)
+
+	// --trace-id is used as it is
+	Command.SetArgs([]string{"noop", "--trace-id", "my-trace"})
+	assert.NoError(Execute())
+	assert.Equal("my-trace", config.TraceID)
+
+	// by default, a new slugid is used for each run
+	assert.NoError(Command.PersistentFlags().Set("trace-id", ""))
+	Command.SetArgs([]string{"noop"})
+	assert.NoError(Execute())
+	first := config.TraceID
+	assert.Len(first, 22)
+	assert.NoError(Execute())
+	assert.NotEqual(first, config.TraceID)
+}
@@ -0,0 +1,20 @@
+package root
+
+import (
+	"errors"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/client"
+)
+
+// ExitCode returns the exit status for an error returned by a command: the
+// class of the HTTP status (3, 4 or 5) for errors from Taskcluster APIs, and
+// 1 for anything else.
+func ExitCode(err error) int {
+	var apiErr *client.APICallError
+	if errors.As(err, &apiErr) {
+		if class := apiErr.StatusCode / 100; class >= 3 && class <= 5 {
+			return class
+		}
+	}
+	return 1
+}
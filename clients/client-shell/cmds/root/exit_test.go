@@ -0,0 +1,20 @@
+package root
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/client"
+)
+
+func TestExitCode(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(1, ExitCode(errors.New("Insufficient arguments given")))
+	assert.Equal(4, ExitCode(&client.APICallError{StatusCode: 404}))
+	assert.Equal(5, ExitCode(&client.APICallError{StatusCode: 503}))
+	assert.Equal(3, ExitCode(&client.APICallError{StatusCode: 304}))
+	assert.Equal(4, ExitCode(fmt.Errorf("wrapped: %w", &client.APICallError{StatusCode: 403})))
+}
@@ -1,9 +1,14 @@
 package root
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/client"
 )
 
 var (
@@ -14,12 +19,73 @@ var (
 		Hooks:     make(logrus.LevelHooks),
 		Level:     logrus.InfoLevel,
 	}
+
+	// jsonLogs is set by `--log-format json`
+	jsonLogs bool
 )
 
-// setup log output based on --verbose flag
-func setUpLogs(enable bool) {
-	Logger.Formatter.(*logrus.TextFormatter).DisableTimestamp = true
-	if enable {
+// setup log output based on the number of --verbose flags, and on the
+// --log-format
+func setUpLogs(verbosity int, format string) error {
+	switch format {
+	case "text":
+		jsonLogs = false
+		Logger.Formatter = &logrus.TextFormatter{DisableTimestamp: true}
+	case "json":
+		// one object per line, for automation to ingest
+		jsonLogs = true
+		Logger.Formatter = &logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339Nano,
+			FieldMap: logrus.FieldMap{
+				logrus.FieldKeyTime: "timestamp",
+				logrus.FieldKeyMsg:  "message",
+			},
+		}
+	default:
+		return fmt.Errorf("invalid --log-format '%s', expected 'text' or 'json'", format)
+	}
+	switch {
+	case verbosity >= 2:
+		Logger.SetLevel(logrus.TraceLevel)
+	case verbosity == 1:
 		Logger.SetLevel(logrus.DebugLevel)
 	}
+	return nil
+}
+
+// ErrorFields returns the fields to log err with: the requestId, traceId and
+// statusCode of the errors of API calls.
+func ErrorFields(err error) logrus.Fields {
+	fields := logrus.Fields{}
+	var apiErr *client.APICallError
+	if errors.As(err, &apiErr) {
+		fields["statusCode"] = apiErr.StatusCode
+		if apiErr.RequestID != "" {
+			fields["requestId"] = apiErr.RequestID
+		}
+		if apiErr.TraceID != "" {
+			fields["traceId"] = apiErr.TraceID
+		}
+	}
+	return fields
+}
+
+// Report writes a message for the user, such as a warning or an error, to w.
+// It is written as it is with `--log-format text`, and as a log entry of the
+// given level, with the given fields, with `--log-format json`.
+func Report(w io.Writer, level logrus.Level, fields logrus.Fields, msg string) {
+	if !jsonLogs {
+		fmt.Fprintln(w, msg)
+		return
+	}
+	entry := logrus.NewEntry(Logger).WithFields(fields)
+	entry.Time = time.Now()
+	entry.Level = level
+	entry.Message = msg
+	line, err := Logger.Formatter.Format(entry)
+	if err != nil {
+		fmt.Fprintln(w, msg)
+		return
+	}
+	_, _ = w.Write(line)
 }
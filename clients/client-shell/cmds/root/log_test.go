@@ -0,0 +1,130 @@
+package root
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/client"
+)
+
+// withLogs sets up the logs as with the given --log-format, writing them to
+// the returned buffer, until the returned function is called.
+func withLogs(t *testing.T, format string) (*bytes.Buffer, func()) {
+	buf := &bytes.Buffer{}
+	out, level := Logger.Out, Logger.Level
+	Logger.Out = buf
+	assert.NoError(t, setUpLogs(0, format))
+	return buf, func() {
+		Logger.Out = out
+		Logger.SetLevel(level)
+		assert.NoError(t, setUpLogs(0, "text"))
+	}
+}
+
+// logLines decodes the lines of JSON logs.
+func logLines(t *testing.T, logs string) []map[string]interface{} {
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(logs), "\n") {
+		var entry map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestJSONLogs(t *testing.T) {
+	assert := assert.New(t)
+
+	buf, reset := withLogs(t, "json")
+	defer reset()
+
+	Logger.WithField("requestId", "abc").Warnf("something %s", "odd")
+	Logger.Debug("not shown")
+	lines := logLines(t, buf.String())
+	assert.Len(lines, 1)
+	assert.Equal("warning", lines[0]["level"])
+	assert.Equal("something odd", lines[0]["message"])
+	assert.Equal("abc", lines[0]["requestId"])
+	_, err := time.Parse(time.RFC3339Nano, lines[0]["timestamp"].(string))
+	assert.NoError(err)
+
+	// messages reported to the user are log entries too
+	out := &bytes.Buffer{}
+	Report(out, logrus.ErrorLevel, ErrorFields(&client.APICallError{StatusCode: 404, RequestID: "req"}), "ResourceNotFound: no such task")
+	lines = logLines(t, out.String())
+	assert.Len(lines, 1)
+	assert.Equal("error", lines[0]["level"])
+	assert.Equal("ResourceNotFound: no such task", lines[0]["message"])
+	assert.Equal("req", lines[0]["requestId"])
+	assert.Equal(404.0, lines[0]["statusCode"])
+}
+
+func TestTextLogs(t *testing.T) {
+	assert := assert.New(t)
+
+	buf, reset := withLogs(t, "text")
+	defer reset()
+
+	Logger.Warn("something odd")
+	assert.Equal("level=warning msg=\"something odd\"\n", buf.String())
+
+	// messages reported to the user are written as they are
+	out := &bytes.Buffer{}
+	Report(out, logrus.ErrorLevel, ErrorFields(&client.APICallError{StatusCode: 404}), "ResourceNotFound: no such task")
+	assert.Equal("ResourceNotFound: no such task\n", out.String())
+
+	assert.EqualError(setUpLogs(0, "xml"), "invalid --log-format 'xml', expected 'text' or 'json'")
+}
+
+func TestErrorFields(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(logrus.Fields{}, ErrorFields(errors.New("oops")))
+	assert.Equal(logrus.Fields{"statusCode": 500}, ErrorFields(&client.APICallError{StatusCode: 500}))
+	assert.Equal(logrus.Fields{"statusCode": 403, "requestId": "req"}, ErrorFields(&client.APICallError{StatusCode: 403, RequestID: "req"}))
+	assert.Equal(logrus.Fields{"statusCode": 403, "requestId": "req", "traceId": "trace"},
+		ErrorFields(&client.APICallError{StatusCode: 403, RequestID: "req", TraceID: "trace"}))
+}
+
+func TestExecuteJSONLogs(t *testing.T) {
+	assert := assert.New(t)
+
+	_, reset := withLogs(t, "text")
+	defer reset()
+	defer func() {
+		Command.SilenceErrors = false
+		Command.SilenceUsage = false
+	}()
+
+	failing := &cobra.Command{
+		Use: "failing",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return &client.APICallError{StatusCode: 404, Code: "ResourceNotFound", Message: "no such task", RequestID: "req"}
+		},
+	}
+	Command.AddCommand(failing)
+	defer Command.RemoveCommand(failing)
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	Command.SetOut(stdout)
+	Command.SetErr(stderr)
+	defer Command.SetOut(nil)
+	defer Command.SetErr(nil)
+
+	// the error is a single log entry on stderr, without cobra's usage
+	Command.SetArgs([]string{"failing", "--log-format", "json"})
+	assert.Error(Execute())
+	assert.Empty(stdout.String())
+	lines := logLines(t, stderr.String())
+	assert.Len(lines, 1)
+	assert.Equal("error", lines[0]["level"])
+	assert.Equal("ResourceNotFound: no such task", lines[0]["message"])
+	assert.Equal("req", lines[0]["requestId"])
+	assert.Equal(404.0, lines[0]["statusCode"])
+}
@@ -0,0 +1,166 @@
+// Package scope implements the scope subcommands.
+package scope
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	tcclient "github.com/taskcluster/taskcluster/v31/clients/client-go"
+	"github.com/taskcluster/taskcluster/v31/clients/client-go/tcauth"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/root"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+	"github.com/taskcluster/taskcluster/v31/internal/scopes"
+)
+
+var (
+	// Command is the root of the scope subtree.
+	Command = &cobra.Command{
+		Use:   "scope",
+		Short: "Checks and expands Taskcluster scopes.",
+		Long: `Checks and expands Taskcluster scopes.
+
+Scopes are given as JSON: either a single scope as a string, or a list of
+scopes.  A given scope satisfies a required scope if they are equal, or if the
+given scope ends with '*' and the required scope starts with the rest of it;
+so 'queue:*' satisfies 'queue:create-task:highest'.`,
+	}
+
+	// errNotSatisfied is returned by satisfies when the scopes are not
+	// satisfied, so that the exit status reflects the result.
+	errNotSatisfied = errors.New("scopes not satisfied")
+)
+
+// authExpander returns the scope expander used by expand and by satisfies
+// with --expand; it is a variable so that tests can replace it.
+var authExpander = func() scopes.ScopeExpander {
+	var creds *tcclient.Credentials
+	if config.Credentials != nil {
+		creds = config.Credentials.ToClientCredentials()
+	}
+	return tcauth.New(creds, config.RootURL())
+}
+
+func init() {
+	satisfies := &cobra.Command{
+		Use:   "satisfies <given> <required>",
+		Short: "Checks whether the given scopes satisfy the required scopes.",
+		Long: `Checks whether the given scopes satisfy the required scopes, printing true
+or false; the exit status is 0 only if they do.
+
+<required> is a single scope, a list of scopes which are all required, or a
+list of such lists, any one of which is sufficient (for example,
+'[["a", "b"], ["c"]]' requires either both a and b, or c).
+
+Only the scopes themselves are matched, unless --expand is given, in which case
+any 'assume:' scopes are first expanded into the scopes of the roles they
+assume, by the auth service.`,
+		Example: `  taskcluster scope satisfies '["queue:*"]' queue:create-task:highest:proj/ci`,
+		Args:    cobra.ExactArgs(2),
+		RunE:    runSatisfies,
+	}
+	satisfies.Flags().Bool("expand", false, "Expand 'assume:' scopes in <given> with the auth service first.")
+
+	expand := &cobra.Command{
+		Use:   "expand <scopes>",
+		Short: "Expands scopes into the scopes of the roles they assume.",
+		Long: `Expands the given scopes, adding the scopes of every role they assume, and
+prints the resulting scopes one per line.  Roles are defined by the auth
+service, which performs the expansion.`,
+		Example: `  taskcluster scope expand '["assume:repo:github.com/taskcluster/taskcluster:*"]'`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runExpand,
+	}
+
+	Command.AddCommand(satisfies, expand)
+
+	// Add the scope subtree to the root.
+	root.Command.AddCommand(Command)
+}
+
+func runSatisfies(cmd *cobra.Command, args []string) error {
+	given, err := parseGiven(args[0])
+	if err != nil {
+		return err
+	}
+	required, err := parseRequired(args[1])
+	if err != nil {
+		return err
+	}
+
+	var expander scopes.ScopeExpander = noExpansion{}
+	if expand, _ := cmd.Flags().GetBool("expand"); expand {
+		expander = authExpander()
+	}
+
+	satisfied, err := given.Satisfies(required, expander)
+	if err != nil {
+		return fmt.Errorf("failed to expand scopes: %s", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), satisfied)
+	if !satisfied {
+		// the result has been printed; only the exit status remains
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return errNotSatisfied
+	}
+	return nil
+}
+
+func runExpand(cmd *cobra.Command, args []string) error {
+	given, err := parseGiven(args[0])
+	if err != nil {
+		return err
+	}
+
+	expanded, err := authExpander().ExpandScopes(&tcauth.SetOfScopes{Scopes: given})
+	if err != nil {
+		return fmt.Errorf("failed to expand scopes: %s", err)
+	}
+	for _, scope := range expanded.Scopes {
+		fmt.Fprintln(cmd.OutOrStdout(), scope)
+	}
+	return nil
+}
+
+// noExpansion is a scopes.ScopeExpander which leaves scopes as they are, so
+// that matching does not need the auth service.
+type noExpansion struct{}
+
+func (noExpansion) ExpandScopes(given *tcauth.SetOfScopes) (*tcauth.SetOfScopes, error) {
+	return given, nil
+}
+
+// parseGiven parses a scope, or a JSON list of scopes.
+func parseGiven(arg string) (scopes.Given, error) {
+	if !strings.HasPrefix(strings.TrimSpace(arg), "[") {
+		return scopes.Given{arg}, nil
+	}
+	var given scopes.Given
+	if err := json.Unmarshal([]byte(arg), &given); err != nil {
+		return nil, fmt.Errorf("invalid scopes '%s': expected a scope or a JSON list of scopes", arg)
+	}
+	return given, nil
+}
+
+// parseRequired parses a scope, a JSON list of scopes which are all required,
+// or a JSON list of such lists.
+func parseRequired(arg string) (scopes.Required, error) {
+	if !strings.HasPrefix(strings.TrimSpace(arg), "[") {
+		return scopes.Required{{arg}}, nil
+	}
+	var all []string
+	if err := json.Unmarshal([]byte(arg), &all); err == nil {
+		return scopes.Required{all}, nil
+	}
+	var required scopes.Required
+	if err := json.Unmarshal([]byte(arg), &required); err != nil {
+		return nil, fmt.Errorf(
+			"invalid required scopes '%s': expected a scope, a JSON list of scopes, or a JSON list of lists of scopes",
+			arg,
+		)
+	}
+	return required, nil
+}
@@ -0,0 +1,122 @@
+package scope
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/spf13/cobra"
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-go/tcauth"
+	"github.com/taskcluster/taskcluster/v31/internal/scopes"
+)
+
+// vector is a test case from testdata/satisfies.json; the cases are those of
+// the scope-matching tests of the other Taskcluster clients.
+type vector struct {
+	Description string          `json:"description"`
+	Given       []string        `json:"given"`
+	Required    scopes.Required `json:"required"`
+	Satisfied   bool            `json:"satisfied"`
+}
+
+func setUpCommand() (*bytes.Buffer, *cobra.Command) {
+	buf := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("expand", false, "")
+	cmd.SetOutput(buf)
+
+	return buf, cmd
+}
+
+func TestSatisfiesVectors(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := ioutil.ReadFile("testdata/satisfies.json")
+	assert.NoError(err)
+	var vectors []vector
+	assert.NoError(json.Unmarshal(data, &vectors))
+
+	for _, v := range vectors {
+		given, err := json.Marshal(v.Given)
+		assert.NoError(err)
+		required, err := json.Marshal(v.Required)
+		assert.NoError(err)
+
+		buf, cmd := setUpCommand()
+		err = runSatisfies(cmd, []string{string(given), string(required)})
+		if v.Satisfied {
+			assert.NoError(err, v.Description)
+			assert.Equal("true\n", buf.String(), v.Description)
+		} else {
+			assert.Equal(errNotSatisfied, err, v.Description)
+			assert.Equal("false\n", buf.String(), v.Description)
+		}
+	}
+}
+
+func TestParseScopes(t *testing.T) {
+	assert := assert.New(t)
+
+	given, err := parseGiven("queue:*")
+	assert.NoError(err)
+	assert.Equal(scopes.Given{"queue:*"}, given)
+
+	given, err = parseGiven(`["a", "b"]`)
+	assert.NoError(err)
+	assert.Equal(scopes.Given{"a", "b"}, given)
+
+	_, err = parseGiven(`["a", `)
+	assert.Error(err)
+
+	required, err := parseRequired("queue:route:x")
+	assert.NoError(err)
+	assert.Equal(scopes.Required{{"queue:route:x"}}, required)
+
+	required, err = parseRequired(`["a", "b"]`)
+	assert.NoError(err)
+	assert.Equal(scopes.Required{{"a", "b"}}, required)
+
+	required, err = parseRequired(`[["a", "b"], ["c"]]`)
+	assert.NoError(err)
+	assert.Equal(scopes.Required{{"a", "b"}, {"c"}}, required)
+
+	_, err = parseRequired(`[1]`)
+	assert.Error(err)
+}
+
+// fakeExpander expands `assume:role` into `queue:*`.
+type fakeExpander struct{}
+
+func (fakeExpander) ExpandScopes(given *tcauth.SetOfScopes) (*tcauth.SetOfScopes, error) {
+	expanded := &tcauth.SetOfScopes{}
+	for _, scope := range given.Scopes {
+		expanded.Scopes = append(expanded.Scopes, scope)
+		if scope == "assume:role" {
+			expanded.Scopes = append(expanded.Scopes, "queue:*")
+		}
+	}
+	return expanded, nil
+}
+
+func TestExpand(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func(old func() scopes.ScopeExpander) { authExpander = old }(authExpander)
+	authExpander = func() scopes.ScopeExpander { return fakeExpander{} }
+
+	buf, cmd := setUpCommand()
+	assert.NoError(runExpand(cmd, []string{`["assume:role", "x"]`}))
+	assert.Equal("assume:role\nqueue:*\nx\n", buf.String())
+
+	// roles are only expanded with --expand
+	buf, cmd = setUpCommand()
+	assert.Equal(errNotSatisfied, runSatisfies(cmd, []string{"assume:role", "queue:route:x"}))
+	assert.Equal("false\n", buf.String())
+
+	buf, cmd = setUpCommand()
+	assert.NoError(cmd.Flags().Set("expand", "true"))
+	assert.NoError(runSatisfies(cmd, []string{"assume:role", "queue:route:x"}))
+	assert.Equal("true\n", buf.String())
+}
@@ -41,11 +41,22 @@ tc-signin() { eval ` + "`" + `taskcluster signin "${@}"` + "`" + `; }
 
 This will set environment variables in your shell session containing the credentials.
 Note that the JS and Python client recognize the same environment variables, so any
-tools using those libraries can also benefit from this signin method.`,
+tools using those libraries can also benefit from this signin method.
+
+Alternatively, use --save to write the credentials to the configuration file, where
+they are used by all future invocations of this tool:
+
+$ taskcluster signin --save
+
+With --profile, the credentials are saved in that profile instead.
+
+If no browser can be opened, the sign-in URL is printed so that it can be opened
+manually.`,
 
 		RunE: cmdSignin,
 	}
 	cmd.Flags().Bool("check", false, "Check whether you are already signed in")
+	cmd.Flags().Bool("save", false, "Save the credentials to the configuration file, instead of outputting environment variables")
 	cmd.Flags().Bool("csh", false, "Output csh-style environment variables (default is Bourne shell)")
 	cmd.Flags().StringP("name", "n", "cli", "Name of the credential to create/reset.")
 	cmd.Flags().String("expires", "1d", "Lifetime for this client (keep it short to avoid risk from accidental disclosure).")
@@ -75,9 +86,20 @@ func cmdSignin(cmd *cobra.Command, _ []string) error {
 	// Handle callback
 	s.Server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		qs := r.URL.Query()
+		clientID := qs.Get("clientId")
+		accessToken := qs.Get("accessToken")
+		save, _ := cmd.Flags().GetBool("save")
 		csh, _ := cmd.Flags().GetBool("csh")
 		rootURL := config.RootURL()
-		if csh {
+		if save {
+			if err := saveCredentials(rootURL, clientID, accessToken); err != nil {
+				log.Errorf("Failed to save credentials: %s", err)
+				http.Error(w, "Failed to save credentials", http.StatusInternalServerError)
+				s.Stop(50 * time.Millisecond)
+				return
+			}
+			log.Infoln("Credentials saved to the configuration file")
+		} else if csh {
 			fmt.Fprintln(cmd.OutOrStdout(), "setenv TASKCLUSTER_CLIENT_ID '"+qs.Get("clientId")+"'")
 			fmt.Fprintln(cmd.OutOrStdout(), "setenv TASKCLUSTER_ACCESS_TOKEN '"+qs.Get("accessToken")+"'")
 			fmt.Fprintln(cmd.OutOrStdout(), "setenv TASKCLUSTER_ROOT_URL '"+rootURL+"'")
@@ -86,7 +108,10 @@ func cmdSignin(cmd *cobra.Command, _ []string) error {
 			fmt.Fprintln(cmd.OutOrStdout(), "export TASKCLUSTER_ACCESS_TOKEN='"+qs.Get("accessToken")+"'")
 			fmt.Fprintln(cmd.OutOrStdout(), "export TASKCLUSTER_ROOT_URL='"+rootURL+"'")
 		}
-		log.Infoln("Credentials output as environment variables")
+		if !save {
+			log.Infoln("Credentials output as environment variables")
+		}
+		printSignedIn(cmd, rootURL, clientID, accessToken)
 
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte(`
@@ -152,7 +177,8 @@ func cmdSignin(cmd *cobra.Command, _ []string) error {
 	// Open browser
 	err = browser.OpenURL(loginURL)
 	if err != nil {
-		return fmt.Errorf("failed to open browser, error: %s", err)
+		log.Debugf("Failed to open browser, error: %s", err)
+		fmt.Fprintf(cmd.ErrOrStderr(), "Could not open a browser; to sign in, open this URL:\n\n  %s\n\n", loginURL)
 	}
 
 	// Start serving
@@ -164,6 +190,46 @@ func cmdSignin(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// saveCredentials replaces the credentials in the configuration file, in the
+// selected profile if any.
+func saveCredentials(rootURL, clientID, accessToken string) error {
+	var options map[string]interface{}
+	if profile := config.Profile(); profile != "" {
+		if config.Profiles[profile] == nil {
+			config.Profiles[profile] = make(map[string]interface{})
+		}
+		options = config.Profiles[profile]
+	} else {
+		if config.Configuration["config"] == nil {
+			config.Configuration["config"] = make(map[string]interface{})
+		}
+		options = config.Configuration["config"]
+	}
+	options["rootUrl"] = rootURL
+	options["clientId"] = clientID
+	options["accessToken"] = accessToken
+	// these credentials are not temporary, nor restricted
+	delete(options, "certificate")
+	delete(options, "authorizedScopes")
+	return config.Save(config.Configuration)
+}
+
+// printSignedIn reports the clientId of the new credentials, along with their
+// expiry if it can be looked up.
+func printSignedIn(cmd *cobra.Command, rootURL, clientID, accessToken string) {
+	auth := tcauth.New(&tcclient.Credentials{
+		ClientID:    clientID,
+		AccessToken: accessToken,
+	}, rootURL)
+	client, err := auth.Client(clientID)
+	if err != nil {
+		log.Debugf("Failed to look up client %s, error: %s", clientID, err)
+		fmt.Fprintf(cmd.ErrOrStderr(), "Signed in as %s\n", clientID)
+		return
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Signed in as %s; credentials expire %s\n", clientID, time.Time(client.Expires).Format(time.RFC3339))
+}
+
 // Return an appropriate exit code based on whether we have credentials.
 // Useful for shell scripting around 'taskcluster signin' calls.
 func checkSignin() error {
@@ -0,0 +1,64 @@
+package signin
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+func TestSaveCredentialsProfile(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "signin")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	defer os.Setenv("XDG_CONFIG_HOME", os.Getenv("XDG_CONFIG_HOME"))
+	assert.NoError(os.Setenv("XDG_CONFIG_HOME", dir))
+
+	config.RegisterOptions("config", map[string]config.OptionDefinition{
+		"rootUrl":     {Default: ""},
+		"clientId":    {Default: ""},
+		"accessToken": {Default: ""},
+		"certificate": {Default: ""},
+	})
+	defer delete(config.OptionsDefinitions, "config")
+
+	defer func(configuration, profiles map[string]map[string]interface{}) {
+		config.Configuration, config.Profiles = configuration, profiles
+		_ = config.UseProfile("")
+	}(config.Configuration, config.Profiles)
+	config.Configuration = map[string]map[string]interface{}{
+		"config": {"rootUrl": "https://prod.example.com", "clientId": "prod-client", "accessToken": "prod-token"},
+	}
+	config.Profiles = map[string]map[string]interface{}{
+		"staging": {"rootUrl": "https://staging.example.com", "certificate": "{}"},
+	}
+	assert.NoError(config.UseProfile("staging"))
+
+	// the credentials go to the selected profile, leaving the others alone
+	assert.NoError(saveCredentials("https://staging.example.com", "staging-client", "staging-token"))
+	profiles, err := config.LoadProfiles()
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{
+		"rootUrl":     "https://staging.example.com",
+		"clientId":    "staging-client",
+		"accessToken": "staging-token",
+	}, profiles["staging"])
+	configuration, err := config.Load()
+	assert.NoError(err)
+	assert.Equal("prod-client", configuration["config"]["clientId"])
+	assert.Equal("prod-token", configuration["config"]["accessToken"])
+
+	// without a profile, the default credentials are replaced
+	assert.NoError(config.UseProfile(""))
+	assert.NoError(saveCredentials("https://prod.example.com", "new-client", "new-token"))
+	configuration, err = config.Load()
+	assert.NoError(err)
+	assert.Equal("new-client", configuration["config"]["clientId"])
+	profiles, err = config.LoadProfiles()
+	assert.NoError(err)
+	assert.Equal("staging-client", profiles["staging"]["clientId"])
+}
@@ -51,6 +51,19 @@ func init() {
 	// add commands
 	Command.AddCommand(
 		generate,
+		// shorthands for generate
+		&cobra.Command{
+			Use:   "v4",
+			Short: "Generate a V4 UUID and output its slug.",
+			Args:  cobra.NoArgs,
+			Run:   generateV4,
+		},
+		&cobra.Command{
+			Use:   "nice",
+			Short: "Generate a V4 UUID and output its 'nice' slug, which never starts with '-'.",
+			Args:  cobra.NoArgs,
+			Run:   generateNice,
+		},
 		// decode
 		&cobra.Command{
 			Use:   "decode <slug>",
@@ -80,6 +93,16 @@ func generate(cmd *cobra.Command, args []string) {
 	}
 }
 
+// generateV4 generates the slug of a v4 uuid, like generate
+func generateV4(cmd *cobra.Command, args []string) {
+	fmt.Fprintln(cmd.OutOrStdout(), sluglib.V4())
+}
+
+// generateNice generates a nice slug, like generate --nice
+func generateNice(cmd *cobra.Command, args []string) {
+	fmt.Fprintln(cmd.OutOrStdout(), sluglib.Nice())
+}
+
 // decode decodes a slug into a uuid
 func decode(cmd *cobra.Command, args []string) error {
 	if len(args) < 1 {
@@ -117,3 +117,48 @@ func TestValidEncode(t *testing.T) {
 	assert.NoError(err, "Error encoding test uuid.")
 	assert.Equal(buf.String(), expected, "Got wrong output when encoding uuid.")
 }
+
+func TestSlugidShorthands(t *testing.T) {
+	assert := assert.New(t)
+
+	buf, cmd := setUpCommand()
+
+	for i := 0; i < 1000; i++ {
+		buf.Reset()
+		generateV4(cmd, []string{})
+		slug := buf.String()
+		slug = slug[0 : len(slug)-1]
+		assert.True(RegexpSlugV4.MatchString(slug), fmt.Sprintf("Slug '%q' generated by v4 is invalid.", slug))
+
+		buf.Reset()
+		generateNice(cmd, []string{})
+		slug = buf.String()
+		slug = slug[0 : len(slug)-1]
+		assert.True(RegexpSlugNice.MatchString(slug), fmt.Sprintf("Slug '%q' generated by nice is invalid.", slug))
+	}
+}
+
+// slugVectors are the encodings used in the tests of the other slugid
+// implementations, including non-nice uuids whose first bit is set.
+var slugVectors = []struct {
+	uuid, slug string
+}{
+	{"796220c0-c831-49f7-9743-7ea23db3b189", "eWIgwMgxSfeXQ36iPbOxiQ"},
+	{"804f3fc8-dfcb-4b06-89fb-aefad5e18754", "gE8_yN_LSwaJ-6761eGHVA"},
+	{"fbefbefb-efbe-43ef-bfff-fffffffffffd", "--------Q--__________Q"},
+}
+
+func TestSlugVectors(t *testing.T) {
+	assert := assert.New(t)
+
+	buf, cmd := setUpCommand()
+	for _, v := range slugVectors {
+		buf.Reset()
+		assert.NoError(encode(cmd, []string{v.uuid}))
+		assert.Equal(v.slug+"\n", buf.String())
+
+		buf.Reset()
+		assert.NoError(decode(cmd, []string{v.slug}))
+		assert.Equal(v.uuid+"\n", buf.String())
+	}
+}
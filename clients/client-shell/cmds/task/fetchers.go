@@ -3,12 +3,14 @@ package task
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 	tcurls "github.com/taskcluster/taskcluster-lib-urls"
@@ -21,16 +23,12 @@ func makeQueue(credentials *tcclient.Credentials) *tcqueue.Queue {
 	return tcqueue.New(credentials, config.RootURL())
 }
 
-// runStatus gets the status of run(s) of a given task.
+// runStatus gets the status of run(s) of a given task.  With --wait, it
+// first waits for the task to be resolved, and returns errUnsuccessful if it
+// was not completed.
 func runStatus(credentials *tcclient.Credentials, args []string, out io.Writer, flagSet *pflag.FlagSet) error {
-	q := makeQueue(credentials)
 	taskID := args[0]
 
-	s, err := q.Status(taskID)
-	if err != nil {
-		return fmt.Errorf("could not get the status of the task %s: %v", taskID, err)
-	}
-
 	allRuns, _ := flagSet.GetBool("all-runs")
 	runID, _ := flagSet.GetInt("run")
 
@@ -38,6 +36,42 @@ func runStatus(credentials *tcclient.Credentials, args []string, out io.Writer,
 		return fmt.Errorf("can't specify both all-runs and a specific run")
 	}
 
+	var s *tcqueue.TaskStatusResponse
+	var err error
+	wait, _ := flagSet.GetBool("wait")
+	if wait {
+		interval, _ := flagSet.GetDuration("poll-interval")
+		if interval <= 0 {
+			return fmt.Errorf("invalid poll interval %s: must be positive", interval)
+		}
+		// an explicit --timeout bounds the whole wait, not only each poll
+		var timeout time.Duration
+		if flag := flagSet.Lookup("timeout"); flag != nil && flag.Changed {
+			timeout, _ = flagSet.GetDuration("timeout")
+		}
+		s, err = waitForTask(makeQueue(credentials), taskID, interval, timeout)
+		if err != nil {
+			return err
+		}
+	} else {
+		s, err = makeQueue(credentials).Status(taskID)
+		if err != nil {
+			return fmt.Errorf("could not get the status of the task %s: %v", taskID, err)
+		}
+	}
+
+	if err := printStatus(out, s, allRuns, runID); err != nil {
+		return err
+	}
+	if wait && s.Status.State != "completed" {
+		return errUnsuccessful
+	}
+	return nil
+}
+
+// printStatus prints the status of all runs of a task, or that of the given
+// run (-1 for the latest).
+func printStatus(out io.Writer, s *tcqueue.TaskStatusResponse, allRuns bool, runID int) error {
 	if allRuns {
 		for _, r := range s.Status.Runs {
 			fmt.Fprintf(out, "Run #%d: %s\n", r.RunID, getRunStatusString(r.State, r.ReasonResolved))
@@ -213,7 +247,18 @@ func runLog(credentials *tcclient.Credentials, args []string, out io.Writer, fla
 
 	path := tcurls.API(config.RootURL(), "queue", "v1", "task/"+taskID+"/artifacts/public/logs/live.log")
 
-	resp, err := http.Get(path)
+	// the log is an artifact, so only --io-timeout applies
+	ctx := context.Background()
+	if config.IOTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.IOTimeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return fmt.Errorf("Error making request to %v: %v", path, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("Error making request to %v: %v", path, err)
 	}
@@ -2,6 +2,9 @@
 package task
 
 import (
+	"errors"
+	"time"
+
 	"github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/root"
 
 	"github.com/spf13/cobra"
@@ -16,7 +19,15 @@ var (
 	statusCmd = &cobra.Command{
 		Use:   "status <taskId>",
 		Short: "Get the status of a task.",
-		RunE:  executeHelperE(runStatus),
+		Long: `Get the status of a task.
+
+With --wait, the status is polled until the task is resolved (completed,
+failed or exception), waiting --poll-interval between the first polls and
+backing off to at most a minute between later ones.  The final status is then
+printed, and the exit status is 0 only if the task completed.  If --timeout is
+given, it bounds the whole wait as well as each poll: the command fails if the
+task is not resolved by then.`,
+		RunE: runStatusE,
 	}
 	artifactsCmd = &cobra.Command{
 		Use:   "artifacts <taskId>",
@@ -49,9 +60,22 @@ var (
 
 var log = root.Logger
 
+// runStatusE runs the status command; unsuccessful tasks waited for are not
+// reported as errors, as their status has been printed.
+func runStatusE(cmd *cobra.Command, args []string) error {
+	err := executeHelperE(runStatus)(cmd, args)
+	if errors.Is(err, errUnsuccessful) {
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+	}
+	return err
+}
+
 func init() {
 	statusCmd.Flags().BoolP("all-runs", "a", false, "Check all runs of the task.")
 	statusCmd.Flags().IntP("run", "r", -1, "Specifies which run to consider.")
+	statusCmd.Flags().BoolP("wait", "w", false, "Wait for the task to be resolved, exiting with a non-zero status unless it completed.")
+	statusCmd.Flags().Duration("poll-interval", 5*time.Second, "Initial delay between polls of the status with --wait; it doubles after each poll.")
 
 	artifactsCmd.Flags().IntP("run", "r", -1, "Specifies which run to consider.")
 
@@ -0,0 +1,81 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-go/tcqueue"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+// maxPollInterval caps the delay between two polls of the status of a task,
+// unless the initial interval is longer.
+const maxPollInterval = time.Minute
+
+// errUnsuccessful is returned by `status --wait` when the task it waited for
+// failed or had an exception, so that the exit status reflects the result.
+var errUnsuccessful = errors.New("task did not complete successfully")
+
+// resolved returns true for the states of a task which will not change
+// unless the task is rerun.
+func resolved(state string) bool {
+	return state == "completed" || state == "failed" || state == "exception"
+}
+
+// waitForTask polls the status of a task until it is resolved, and returns
+// that status.  Each poll, including its retries, is bounded by the
+// configured timeout, through the Context of queue; between polls, it waits for interval, doubling it (with +/- 25%
+// jitter) after each poll, up to maxPollInterval.  If timeout is not 0, the
+// whole wait is bounded by it, polling one last time when it expires.
+func waitForTask(queue *tcqueue.Queue, taskID string, interval, timeout time.Duration) (*tcqueue.TaskStatusResponse, error) {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	for polls := 1; ; polls++ {
+		s, err := pollStatus(queue, taskID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get the status of the task %s: %v", taskID, err)
+		}
+		if resolved(s.Status.State) {
+			return s, nil
+		}
+
+		delay := pollDelay(interval, polls)
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return nil, fmt.Errorf("task %s was still %s after %s", taskID, s.Status.State, timeout)
+			}
+			if delay > remaining {
+				delay = remaining
+			}
+		}
+		log.Debugf("Task %s is %s, polling again in %s", taskID, s.Status.State, delay)
+		time.Sleep(delay)
+	}
+}
+
+// pollStatus gets the status of a task, aborting the request once the
+// configured timeout expires, if any.
+func pollStatus(queue *tcqueue.Queue, taskID string) (*tcqueue.TaskStatusResponse, error) {
+	if config.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+		defer cancel()
+		queue.Context = ctx
+		defer func() { queue.Context = nil }()
+	}
+	return queue.Status(taskID)
+}
+
+// pollDelay returns how long to wait after the given number of polls.
+func pollDelay(interval time.Duration, polls int) time.Duration {
+	max := math.Max(float64(maxPollInterval), float64(interval))
+	d := math.Pow(2, float64(polls-1)) * float64(interval)
+	d *= 1 + 0.25*(rand.Float64()*2-1)
+	return time.Duration(math.Min(d, max))
+}
@@ -0,0 +1,158 @@
+package task
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	tcclient "github.com/taskcluster/taskcluster/v31/clients/client-go"
+	"github.com/taskcluster/taskcluster/v31/clients/client-go/tcqueue"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+// stateServer serves the given states of a task, one per request for its
+// status, then 404s; calls returns the number of requests served.
+func stateServer(states []string) (queue *tcqueue.Queue, calls func() int, stop func()) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/queue/v1/task/"+fakeTaskID+"/status" || requests >= len(states) {
+			http.NotFound(w, r)
+			return
+		}
+		state := states[requests]
+		requests++
+		_, _ = io.WriteString(w, fmt.Sprintf(`{"status": {"taskId": "%s", "state": "%s", "runs": []}}`, fakeTaskID, state))
+	}))
+	return tcqueue.New(nil, server.URL), func() int { return requests }, server.Close
+}
+
+func TestWaitForTask(t *testing.T) {
+	assert := assert.New(t)
+
+	q, calls, closeServer := stateServer([]string{"unscheduled", "pending", "running", "failed", "completed"})
+	defer closeServer()
+	s, err := waitForTask(q, fakeTaskID, time.Millisecond, 0)
+	assert.NoError(err)
+	assert.Equal("failed", s.Status.State)
+	assert.Equal(4, calls())
+
+	// errors getting the status end the wait
+	q, _, closeServer = stateServer([]string{"pending"})
+	defer closeServer()
+	_, err = waitForTask(q, fakeTaskID, time.Millisecond, 0)
+	assert.Error(err)
+	assert.Contains(err.Error(), "could not get the status of the task "+fakeTaskID)
+
+	// the wait ends with an error if the task is not resolved in time
+	q, calls, closeServer = stateServer([]string{"pending", "pending", "running", "running", "running", "running", "completed"})
+	defer closeServer()
+	_, err = waitForTask(q, fakeTaskID, 20*time.Millisecond, 30*time.Millisecond)
+	assert.EqualError(err, "task "+fakeTaskID+" was still running after 30ms")
+	assert.Equal(3, calls(), "the task was not polled when the deadline expired")
+}
+
+func TestWaitForTaskPollTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	// the server does not respond until the test is over
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	defer func(timeout time.Duration) { config.Timeout = timeout }(config.Timeout)
+	config.Timeout = 50 * time.Millisecond
+
+	start := time.Now()
+	_, err := waitForTask(tcqueue.New(nil, server.URL), fakeTaskID, time.Millisecond, 0)
+	assert.Error(err)
+	assert.Contains(err.Error(), "could not get the status of the task "+fakeTaskID)
+	assert.True(time.Since(start) < 5*time.Second, "the poll was not aborted")
+}
+
+func TestPollDelay(t *testing.T) {
+	assert := assert.New(t)
+
+	// the delay doubles, with jitter, up to maxPollInterval
+	for polls, expected := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second} {
+		d := pollDelay(time.Second, polls)
+		assert.True(d >= expected*3/4 && d <= expected*5/4, "delay %s after %d polls", d, polls)
+	}
+	assert.Equal(maxPollInterval, pollDelay(time.Second, 20))
+
+	// an initial interval longer than maxPollInterval is not shortened
+	assert.Equal(2*time.Minute, pollDelay(2*time.Minute, 20))
+}
+
+func TestStatusWait(t *testing.T) {
+	assert := assert.New(t)
+
+	// the task is pending, then running, then resolved with the given state
+	var resolvedAs string
+	requests := 0
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/queue/v1/task/"+fakeTaskID+"/status", func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		state, reason := resolvedAs, resolvedAs
+		switch requests {
+		case 1:
+			state, reason = "pending", ""
+		case 2:
+			state, reason = "running", ""
+		}
+		_, _ = io.WriteString(w, fmt.Sprintf(
+			`{"status": {"taskId": "%s", "state": "%s", "runs": [{"runId": 0, "state": "%s", "reasonResolved": "%s"}]}}`,
+			fakeTaskID, state, state, reason,
+		))
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	config.SetRootURL(server.URL)
+	defer config.SetRootURL("")
+
+	for _, resolvedAs = range []string{"completed", "failed", "exception"} {
+		requests = 0
+		buf, cmd := setUpCommand()
+		cmd.Flags().Int("run", -1, "")
+		cmd.Flags().Bool("wait", true, "")
+		cmd.Flags().Duration("poll-interval", time.Millisecond, "")
+
+		err := runStatus(&tcclient.Credentials{}, []string{fakeTaskID}, cmd.OutOrStdout(), cmd.Flags())
+		if resolvedAs == "completed" {
+			assert.NoError(err)
+		} else {
+			assert.Equal(errUnsuccessful, err)
+		}
+		assert.Equal(fmt.Sprintf("%s '%s'\n", resolvedAs, resolvedAs), buf.String())
+		assert.Equal(3, requests)
+	}
+
+	// an explicit --timeout bounds the whole wait, here of a task that is
+	// never resolved
+	resolvedAs = "pending"
+	requests = 0
+	_, cmd := setUpCommand()
+	cmd.Flags().Int("run", -1, "")
+	cmd.Flags().Bool("wait", true, "")
+	cmd.Flags().Duration("poll-interval", time.Millisecond, "")
+	cmd.Flags().Duration("timeout", 0, "")
+	assert.NoError(cmd.Flags().Set("timeout", "50ms"))
+	start := time.Now()
+	err := runStatus(&tcclient.Credentials{}, []string{fakeTaskID}, cmd.OutOrStdout(), cmd.Flags())
+	assert.Error(err)
+	assert.NotEqual(errUnsuccessful, err)
+	assert.Contains(err.Error(), "was still pending after 50ms")
+	assert.True(time.Since(start) < 5*time.Second, "the wait was not bounded")
+
+	// the poll interval must be positive
+	_, cmd = setUpCommand()
+	cmd.Flags().Bool("wait", true, "")
+	cmd.Flags().Duration("poll-interval", 0, "")
+	assert.Error(runStatus(&tcclient.Credentials{}, []string{fakeTaskID}, cmd.OutOrStdout(), cmd.Flags()))
+}
@@ -10,6 +10,7 @@ import (
 	s "strings"
 
 	"github.com/spf13/cobra"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis"
 	"github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/root"
 )
 
@@ -50,11 +51,16 @@ var (
 var log = root.Logger
 
 func init() {
+	Command.Flags().Bool("references", false, "Print the version of the API references the API commands were generated from")
 	root.Command.AddCommand(Command)
 	root.Command.AddCommand(Updcommand)
 }
 
 func printVersion(cmd *cobra.Command, _ []string) {
+	if references, _ := cmd.Flags().GetBool("references"); references {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", apis.ReferencesVersion)
+		return
+	}
 	fmt.Fprintf(cmd.OutOrStdout(), "taskcluster version %s\n", VersionNumber)
 }
 
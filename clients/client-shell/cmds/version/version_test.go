@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis"
 )
 
 func setUpCommand() (*bytes.Buffer, *cobra.Command) {
@@ -26,6 +27,17 @@ func TestVersionCommand(t *testing.T) {
 	assert.Contains(buf.String(), VersionNumber, "VersionNumber not found in version output")
 }
 
+func TestVersionReferences(t *testing.T) {
+	assert := assert.New(t)
+
+	buf, cmd := setUpCommand()
+	cmd.Flags().Bool("references", true, "")
+
+	printVersion(cmd, nil)
+
+	assert.Equal(apis.ReferencesVersion+"\n", buf.String())
+}
+
 func TestUpdateCommand(t *testing.T) {
 	assert := assert.New(t)
 
@@ -0,0 +1,92 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/iancoleman/strcase"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// Aliases maps former names of API methods to their current names, by
+// service, so that the commands of renamed methods keep working.  Services
+// are named as in IncludeServices, such as `purge-cache` or `PurgeCache`.
+type Aliases map[string]map[string]string
+
+// LoadAliases reads Aliases from a JSON file such as
+//
+//	{"queue": {"oldName": "currentName"}}
+//
+// It returns no aliases if the file does not exist.
+func LoadAliases(filename string) (Aliases, error) {
+	data, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var aliases Aliases
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", filename, err)
+	}
+	return aliases, nil
+}
+
+// forService returns the aliases of the service with the given camel-case
+// name.
+func (a Aliases) forService(camelName string) map[string]string {
+	for name, aliases := range a {
+		if strcase.ToCamel(name) == camelName {
+			return aliases
+		}
+	}
+	return nil
+}
+
+// addAliases sets the Aliases of the entries of svc, the service defined by
+// the reference refName, to the given former names.  Each must refer to a
+// method of the service, and must not be the name of one.
+func addAliases(refName string, svc *definitions.Service, aliases map[string]string) error {
+	entries := map[string]int{}
+	for i, entry := range svc.Entries {
+		entries[entry.Name] = i
+	}
+
+	// add the aliases in a stable order
+	names := make([]string, 0, len(aliases))
+	for alias := range aliases {
+		names = append(names, alias)
+	}
+	sort.Strings(names)
+
+	for _, alias := range names {
+		target := aliases[alias]
+		if _, ok := entries[alias]; ok {
+			return fmt.Errorf("%s: alias '%s' of service '%s' is the name of a method; remove the alias", refName, alias, svc.ServiceName)
+		}
+		i, ok := entries[target]
+		if !ok {
+			return fmt.Errorf("%s: alias '%s' of service '%s' refers to the unknown method '%s'", refName, alias, svc.ServiceName, target)
+		}
+		svc.Entries[i].Aliases = append(svc.Entries[i].Aliases, alias)
+	}
+	return nil
+}
+
+// warnUnknownAliases warns of aliases given for services which are not known.
+func (g *Generator) warnUnknownAliases(known map[string]bool) {
+	names := make([]string, 0, len(g.Aliases))
+	for name := range g.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !known[strcase.ToCamel(name)] {
+			g.Warnings = append(g.Warnings, fmt.Sprintf("aliases given for the unknown service '%s'", name))
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package codegen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestGenerateAliases(t *testing.T) {
+	assert := assert.New(t)
+
+	gen := &Generator{Aliases: Aliases{"fake": {"pingThing": "ping", "getThings": "listThings", "queryThings": "listThings"}}}
+	assert.NoError(Generate(loadFixture(t), gen))
+	source, err := gen.Format()
+	assert.NoError(err)
+	assert.Regexp(`Aliases: \[\]string\{\s+"getThings",\s+"queryThings",\s+\}`, string(source))
+	assert.Regexp(`Aliases: \[\]string\{\s+"pingThing",\s+\}`, string(source))
+	assert.Empty(gen.Warnings)
+
+	// aliases do not generate Go methods
+	assert.NotContains(string(source), "PingThing")
+}
+
+func TestGenerateAliasErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	err := Generate(loadFixture(t), &Generator{Aliases: Aliases{"Fake": {"oldPing": "pong"}}})
+	assert.EqualError(err, "/references/fake/v1/api.json: alias 'oldPing' of service 'fake' refers to the unknown method 'pong'")
+
+	// once a method is named like an alias, the alias must go
+	err = Generate(loadFixture(t), &Generator{Aliases: Aliases{"Fake": {"createThing": "ping"}}})
+	assert.EqualError(err, "/references/fake/v1/api.json: alias 'createThing' of service 'fake' is the name of a method; remove the alias")
+
+	gen := &Generator{Aliases: Aliases{"missing": {"old": "new"}}}
+	assert.NoError(Generate(loadFixture(t), gen))
+	assert.Equal([]string{"aliases given for the unknown service 'missing'"}, gen.Warnings)
+}
+
+func TestLoadAliases(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "aliases")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	// the file is optional
+	aliases, err := LoadAliases(filepath.Join(dir, "aliases.json"))
+	assert.NoError(err)
+	assert.Nil(aliases)
+
+	file := filepath.Join(dir, "aliases.json")
+	assert.NoError(ioutil.WriteFile(file, []byte(`{"queue": {"oldName": "newName"}}`), 0644))
+	aliases, err = LoadAliases(file)
+	assert.NoError(err)
+	assert.Equal(Aliases{"queue": {"oldName": "newName"}}, aliases)
+
+	assert.NoError(ioutil.WriteFile(file, []byte(`{"queue": ["oldName"]}`), 0644))
+	_, err = LoadAliases(file)
+	assert.Error(err)
+}
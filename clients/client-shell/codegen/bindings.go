@@ -0,0 +1,65 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// printBindings prints, for the exchanges of each of the named services, a
+// function per exchange, such as QueueTaskCompleted, taking a parameter per
+// routing-key component that is not constant, and returning the binding for
+// the messages whose routing keys have the given values.  Components given as
+// "" match any word, or any number of words for multiple-word components.
+func (g *Generator) printBindings(names []string, exchanges map[string]definitions.Exchanges) {
+	for _, name := range names {
+		x, ok := exchanges[name]
+		if !ok {
+			continue
+		}
+		for _, exchange := range x.Entries {
+			heading := exchange.Name
+			if exchange.Title != "" {
+				heading += " (" + exchange.Title + ")"
+			}
+			g.Print(formatComment(fmt.Sprintf(
+				"%s returns the binding for the messages of the %s exchange of the %s service whose routing keys "+
+					"have the given values; components given as \"\" match any value.",
+				bindingName(name, exchange), heading, name,
+			)))
+			components, params := bindingParams(exchange)
+			g.Printf("func %s(%s) (definitions.Binding, error) {\n", bindingName(name, exchange), params)
+			g.Printf("return bindingFor(%q, %q, %s)\n", name, exchange.Name, components)
+			g.Print("}\n\n")
+		}
+	}
+}
+
+// bindingName returns the name of the binding function of an exchange of
+// the named service.
+func bindingName(name string, exchange definitions.Exchange) string {
+	return name + identifier(exchange.Name)
+}
+
+// bindingParams returns an expression for the map of the routing-key
+// components of an exchange which are not constant, by name, and the
+// parameter list giving them.
+func bindingParams(exchange definitions.Exchange) (string, string) {
+	var args []string
+	for _, element := range exchange.RoutingKey {
+		if element.Constant == "" {
+			args = append(args, element.Name)
+		}
+	}
+	if len(args) == 0 {
+		return "nil", ""
+	}
+
+	names := paramNames(definitions.Entry{Args: args})
+	pairs := make([]string, 0, len(args))
+	for i, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%q: %s", args[i], name))
+	}
+	return "map[string]string{" + strings.Join(pairs, ", ") + "}", strings.Join(names, ", ") + " string"
+}
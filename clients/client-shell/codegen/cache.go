@@ -0,0 +1,124 @@
+package codegen
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// referencesCacheDir returns the directory holding the decoded references
+// documents of LoadReferencesCached.
+var referencesCacheDir = func() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "taskcluster", "codegen"), nil
+}
+
+// referencesCache is the content of a cache file: the decoded references of
+// a source file, along with what identifies the version of the source they
+// were decoded from.
+type referencesCache struct {
+	ModTime time.Time
+	Size    int64
+	Hash    string
+	Data    []Reference
+}
+
+// LoadReferencesCached loads a references document from the given local
+// file, as LoadReferencesFrom does, but keeps the decoded document in an
+// on-disk cache, so that loading the same document again, such as when
+// regenerating repeatedly, skips parsing and validating it.  The cache is only
+// used while the file has the modification time, size and SHA-256 hash it
+// had when cached; failing to read or write the cache is not an error.
+func LoadReferencesCached(path string) (*References, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(file)
+	hash := hex.EncodeToString(sum[:])
+
+	cacheFile, err := referencesCacheFile(path)
+	if err != nil {
+		return parseReferences(file)
+	}
+	if cached, err := readReferencesCache(cacheFile); err == nil &&
+		cached.ModTime.Equal(info.ModTime()) && cached.Size == info.Size() && cached.Hash == hash {
+		return &References{data: cached.Data}, nil
+	}
+
+	r, err := parseReferences(file)
+	if err != nil {
+		return nil, err
+	}
+	_ = writeReferencesCache(cacheFile, &referencesCache{
+		ModTime: info.ModTime(),
+		Size:    info.Size(),
+		Hash:    hash,
+		Data:    r.data,
+	})
+	return r, nil
+}
+
+// referencesCacheFile returns the cache file of the references document at
+// path, named after the hash of its absolute path.
+func referencesCacheFile(path string) (string, error) {
+	dir, err := referencesCacheDir()
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(dir, hex.EncodeToString(sum[:8])+".gob"), nil
+}
+
+func readReferencesCache(filename string) (*referencesCache, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	cached := &referencesCache{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(cached); err != nil {
+		return nil, err
+	}
+	return cached, nil
+}
+
+// writeReferencesCache writes the cache file through a temporary file, so
+// that concurrent generators never read a partial one.
+func writeReferencesCache(filename string, cached *referencesCache) error {
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(cached); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(filename), "references-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
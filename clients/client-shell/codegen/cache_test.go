@@ -0,0 +1,154 @@
+package codegen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+// withCacheDir makes LoadReferencesCached use a new temporary directory,
+// returning it and a function to remove it.
+func withCacheDir(t testing.TB) (string, func()) {
+	dir, err := ioutil.TempDir("", "codegen-cache")
+	assert.NoError(t, err)
+	previous := referencesCacheDir
+	referencesCacheDir = func() (string, error) { return dir, nil }
+	return dir, func() {
+		referencesCacheDir = previous
+		os.RemoveAll(dir)
+	}
+}
+
+// poisonCache replaces the data cached for the references at path, so that
+// tests can tell whether it is served.
+func poisonCache(t *testing.T, path string) {
+	cacheFile, err := referencesCacheFile(path)
+	assert.NoError(t, err)
+	cached, err := readReferencesCache(cacheFile)
+	assert.NoError(t, err)
+	cached.Data = []Reference{{Filename: "poisoned", Content: []byte(`{}`)}}
+	assert.NoError(t, writeReferencesCache(cacheFile, cached))
+}
+
+func TestLoadReferencesCached(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, cleanup := withCacheDir(t)
+	defer cleanup()
+	fixture, err := ioutil.ReadFile(fixturePath)
+	assert.NoError(err)
+	path := filepath.Join(dir, "references.json")
+	assert.NoError(ioutil.WriteFile(path, fixture, 0644))
+
+	expected, err := LoadReferencesFrom(path)
+	assert.NoError(err)
+
+	// the first load parses the document, and caches it
+	refs, err := LoadReferencesCached(path)
+	assert.NoError(err)
+	assert.Equal(expected, refs)
+	cacheFile, err := referencesCacheFile(path)
+	assert.NoError(err)
+	assert.FileExists(cacheFile)
+
+	// later loads of the unchanged document are served from the cache
+	poisonCache(t, path)
+	refs, err = LoadReferencesCached(path)
+	assert.NoError(err)
+	assert.Equal("poisoned", refs.data[0].Filename)
+}
+
+func TestLoadReferencesCachedInvalidation(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, cleanup := withCacheDir(t)
+	defer cleanup()
+	fixture, err := ioutil.ReadFile(fixturePath)
+	assert.NoError(err)
+	path := filepath.Join(dir, "references.json")
+	assert.NoError(ioutil.WriteFile(path, fixture, 0644))
+	info, err := os.Stat(path)
+	assert.NoError(err)
+
+	_, err = LoadReferencesCached(path)
+	assert.NoError(err)
+
+	// a change of the modification time alone invalidates the cache
+	poisonCache(t, path)
+	later := info.ModTime().Add(time.Second)
+	assert.NoError(os.Chtimes(path, later, later))
+	refs, err := LoadReferencesCached(path)
+	assert.NoError(err)
+	assert.Equal("references/manifest.json", refs.data[0].Filename)
+
+	// as does a change of the content keeping the size and modification time
+	poisonCache(t, path)
+	changed := append([]byte(nil), fixture...)
+	copy(changed, "[ ")
+	assert.Len(changed, len(fixture))
+	assert.NotEqual(fixture, changed)
+	assert.NoError(ioutil.WriteFile(path, changed, 0644))
+	assert.NoError(os.Chtimes(path, later, later))
+	refs, err = LoadReferencesCached(path)
+	assert.NoError(err)
+	assert.Equal("references/manifest.json", refs.data[0].Filename)
+
+	// invalid documents are errors, and are not cached
+	assert.NoError(ioutil.WriteFile(path, []byte(`[{"filename": "", "content": 42}]`), 0644))
+	_, err = LoadReferencesCached(path)
+	assert.Error(err)
+	_, err = LoadReferencesCached(path)
+	assert.Error(err)
+}
+
+func TestLoadReferencesCachedUnusableCache(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, cleanup := withCacheDir(t)
+	defer cleanup()
+
+	// a corrupt cache file is ignored, and replaced
+	cacheFile, err := referencesCacheFile(fixturePath)
+	assert.NoError(err)
+	assert.NoError(ioutil.WriteFile(cacheFile, []byte("not gob"), 0644))
+	refs, err := LoadReferencesCached(fixturePath)
+	assert.NoError(err)
+	assert.Equal("references/manifest.json", refs.data[0].Filename)
+	_, err = readReferencesCache(cacheFile)
+	assert.NoError(err)
+
+	// as is a cache directory which cannot be written
+	referencesCacheDir = func() (string, error) { return filepath.Join(dir, "references.json", "x"), nil }
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, "references.json"), []byte("a file"), 0644))
+	refs, err = LoadReferencesCached(fixturePath)
+	assert.NoError(err)
+	assert.Equal("references/manifest.json", refs.data[0].Filename)
+}
+
+// The bundled references, parsed every time...
+func BenchmarkLoadReferences(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := LoadReferencesFrom(DefaultReferencesPath); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// ...and served from the cache.
+func BenchmarkLoadReferencesCached(b *testing.B) {
+	_, cleanup := withCacheDir(b)
+	defer cleanup()
+	if _, err := LoadReferencesCached(DefaultReferencesPath); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := LoadReferencesCached(DefaultReferencesPath); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,32 +1,219 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/taskcluster/taskcluster/v31/clients/client-shell/codegen"
 )
 
+// fetchTimeout bounds how long we wait for a remote references document.
+const fetchTimeout = 30 * time.Second
+
 func main() {
-	references, err := codegen.LoadReferences()
+	refs := flag.String("references", "", "path or http(s) URL of the references document (default: the bundled generated/references.json)")
+	dryRun := flag.Bool("dry-run", false, "print a diff against the existing services.go instead of writing it; exits non-zero if they differ")
+	typed := flag.Bool("typed-payloads", false, "also generate Go types for the input and output schemas of each API method, and typed service clients using them")
+	withContext := flag.Bool("context", false, "generate the service client methods with a leading ctx context.Context parameter")
+	simplify := flag.Bool("simplify", false, "simplify the generated code, as gofmt -s does")
+	split := flag.Bool("split", false, "write a file per service, and client.go, instead of services.go")
+	groupImports := flag.Bool("group-imports", false, "sort and group the imports of the generated code, as goimports does")
+	cache := flag.Bool("cache", false, "cache the parsed references document on disk, to skip parsing it again while it is unchanged; ignored for URLs")
+	smokeTests := flag.Bool("smoke-tests", false, "also generate "+codegen.SmokeTestFile+", with a smoke test per API method, run against TASKCLUSTER_ROOT_URL with -run Smoke")
+	aliasesFile := flag.String("aliases", "aliases.json", "JSON file mapping former names of API methods to their current names, by service, e.g. {\"queue\": {\"oldName\": \"newName\"}}; ignored if it does not exist")
+	var include, exclude serviceList
+	flag.Var(&include, "include", "only generate the given service (repeatable)")
+	flag.Var(&exclude, "exclude", "do not generate the given service (repeatable)")
+	flag.Parse()
+
+	references, err := loadReferences(*refs, *cache)
 	if err != nil {
 		log.Fatalln("error: failed to load references.json: ", err)
 	}
 
-	gen := &codegen.Generator{}
+	aliases, err := codegen.LoadAliases(*aliasesFile)
+	if err != nil {
+		log.Fatalln("error: failed to load aliases: ", err)
+	}
+
+	gen := &codegen.Generator{
+		TypedPayloads:   *typed,
+		EmitSmokeTests:  *smokeTests,
+		ContextMethods:  *withContext,
+		IncludeServices: include,
+		ExcludeServices: exclude,
+		Aliases:         aliases,
+		FormatOptions: codegen.FormatOptions{
+			Simplify:     *simplify,
+			GroupImports: *groupImports,
+		},
+	}
 
-	err = codegen.Generate(references, gen)
+	files, err := generate(references, gen, *split)
 	if err != nil {
-		log.Fatalln("error: failed to generate services.go: ", err)
+		log.Fatalln("error:", err)
+	}
+	for _, warning := range gen.Warnings {
+		log.Println("warning:", warning)
 	}
 
+	// generated files which are no longer generated, such as services.go
+	// after switching to -split
+	stale, err := staleFiles(files)
+	if err != nil {
+		log.Fatalln("error: failed to list generated files: ", err)
+	}
+
+	if *dryRun {
+		changed := false
+		for _, filename := range sortedFilenames(files) {
+			fileChanged, err := printDiff(filename, files[filename])
+			if err != nil {
+				log.Fatalln("error: failed to diff "+filename+": ", err)
+			}
+			changed = changed || fileChanged
+		}
+		for _, filename := range stale {
+			fmt.Printf("would remove %s\n", filename)
+			changed = true
+		}
+		if changed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	for _, filename := range sortedFilenames(files) {
+		if current, err := ioutil.ReadFile(filename); err == nil && !codegen.IsGenerated(current) {
+			log.Fatalln("error: refusing to overwrite " + filename + ", which was not generated")
+		}
+		err = ioutil.WriteFile(filename, files[filename], 0664)
+		if err != nil {
+			log.Fatalln("error: failed to save "+filename+": ", err)
+		}
+	}
+	for _, filename := range stale {
+		err = os.Remove(filename)
+		if err != nil {
+			log.Fatalln("error: failed to remove "+filename+": ", err)
+		}
+	}
+}
+
+// generate generates services.go, or with split, a file per service.
+func generate(references *codegen.References, gen *codegen.Generator, split bool) (map[string][]byte, error) {
+	if split {
+		files, err := codegen.GenerateFiles(references, gen)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate the service files: %s", err)
+		}
+		return files, nil
+	}
+
+	err := codegen.Generate(references, gen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate services.go: %s", err)
+	}
 	source, err := gen.Format()
 	if err != nil {
-		log.Fatalln("error: failed to format services.go: ", err)
+		return nil, fmt.Errorf("failed to format services.go: %s", err)
+	}
+	files := map[string][]byte{"services.go": source}
+	if gen.SmokeTests != nil {
+		files[codegen.SmokeTestFile] = gen.SmokeTests
 	}
+	return files, nil
+}
+
+// staleFiles returns the generated Go files of the current directory which
+// are not among files.
+func staleFiles(files map[string][]byte) ([]string, error) {
+	matches, err := filepath.Glob("*.go")
+	if err != nil {
+		return nil, err
+	}
+	var stale []string
+	for _, filename := range matches {
+		if _, ok := files[filename]; ok {
+			continue
+		}
+		source, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		if codegen.IsGenerated(source) {
+			stale = append(stale, filename)
+		}
+	}
+	return stale, nil
+}
+
+func sortedFilenames(files map[string][]byte) []string {
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	return filenames
+}
+
+// serviceList is a flag.Value collecting the values of a repeated flag.
+type serviceList []string
 
-	err = ioutil.WriteFile("services.go", source, 0664)
+func (l *serviceList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *serviceList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func loadReferences(source string, cache bool) (*codegen.References, error) {
+	switch {
+	case source == "" && cache:
+		return codegen.LoadReferencesCached(codegen.DefaultReferencesPath)
+	case source == "":
+		return codegen.LoadReferences()
+	case strings.HasPrefix(source, "http://"), strings.HasPrefix(source, "https://"):
+		ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+		defer cancel()
+		return codegen.LoadReferencesFromURL(ctx, source)
+	case cache:
+		return codegen.LoadReferencesCached(source)
+	default:
+		return codegen.LoadReferencesFrom(source)
+	}
+}
+
+// printDiff writes a unified diff between the file at filename and source to
+// stdout, returning true if they differ.  A missing file is treated as empty.
+func printDiff(filename string, source []byte) (bool, error) {
+	current, err := ioutil.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(current)),
+		B:        difflib.SplitLines(string(source)),
+		FromFile: filename,
+		ToFile:   filename + " (generated)",
+		Context:  3,
+	})
 	if err != nil {
-		log.Fatalln("error: failed to save services.go: ", err)
+		return false, err
 	}
+
+	fmt.Print(diff)
+	return diff != "", nil
 }
@@ -0,0 +1,48 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// checkCollisions returns an error if two entries of svc would generate the
+// same Go method of its service interface, such as `listThings` and
+// `list_things`, or `getThing` and `getThingSignURL` if the former supports
+// signed URLs; the generated code would not compile.
+func checkCollisions(refName string, svc definitions.Service) error {
+	methods := map[string]definitions.Entry{}
+	for _, entry := range svc.Entries {
+		for _, method := range methodNames(entry) {
+			if other, ok := methods[method]; ok {
+				return fmt.Errorf(
+					"%s: methods '%s' (%s) and '%s' (%s) of service '%s' both generate the Go method %s",
+					refName, other.Name, describeRoute(other), entry.Name, describeRoute(entry), svc.ServiceName, method,
+				)
+			}
+			methods[method] = entry
+		}
+	}
+	return nil
+}
+
+// methodNames returns the names of the Go methods generated for an entry.
+func methodNames(entry definitions.Entry) []string {
+	name := identifier(entry.Name)
+	names := []string{name}
+	if entry.SignedURL {
+		names = append(names, name+"SignURL")
+	}
+	if entry.Download || entry.Upload {
+		names = append(names, name+"Stream")
+	}
+	if entry.PutURL {
+		names = append(names, name+"Upload")
+	}
+	return names
+}
+
+func describeRoute(entry definitions.Entry) string {
+	return strings.ToUpper(entry.Method) + " " + entry.Route
+}
@@ -0,0 +1,96 @@
+package codegen
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// commentWidth is the maximum width of the text of a generated comment line,
+// including the leading `// `.
+const commentWidth = 80
+
+// commentFor returns the comment to emit above a value when pretty-printing
+// it, or an empty string if it has none.
+func commentFor(data interface{}) string {
+	switch v := data.(type) {
+	case definitions.Entry:
+		return headedComment(v.Name, v.Title, v.Description)
+	case definitions.Exchange:
+		return headedComment(v.Name, v.Title, v.Description)
+	}
+	return ""
+}
+
+// headedComment returns a comment made of a heading, from the name and title
+// of a value, followed by its description.
+func headedComment(name, title, description string) string {
+	heading := name
+	if title != "" {
+		heading += ": " + title
+	}
+	if description == "" {
+		return formatComment(heading)
+	}
+	return formatComment(heading + "\n\n" + description)
+}
+
+// formatComment renders text as a block of `//` comment lines, wrapping long
+// lines at commentWidth.  Existing line breaks are preserved, so that lists
+// and other markdown formatting in the reference descriptions survive.
+func formatComment(text string) string {
+	buf := &strings.Builder{}
+	text = strings.TrimRightFunc(sanitizeComment(text), unicode.IsSpace)
+	for _, line := range strings.Split(text, "\n") {
+		for _, wrapped := range wrapLine(strings.TrimRightFunc(line, unicode.IsSpace), commentWidth-3) {
+			if wrapped == "" {
+				buf.WriteString("//\n")
+			} else {
+				buf.WriteString("// " + wrapped + "\n")
+			}
+		}
+	}
+	return buf.String()
+}
+
+// sanitizeComment replaces any control characters that would end or corrupt
+// a line comment.
+func sanitizeComment(text string) string {
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	return strings.Map(func(r rune) rune {
+		if r == '\n' {
+			return r
+		}
+		if r == '\t' || unicode.IsControl(r) || r == '\u2028' || r == '\u2029' {
+			return ' '
+		}
+		return r
+	}, text)
+}
+
+// wrapLine splits line into lines no longer than width, breaking at spaces
+// and repeating the line's indentation on continuation lines.  Words longer
+// than width are left intact.
+func wrapLine(line string, width int) []string {
+	if len(line) <= width {
+		return []string{line}
+	}
+
+	trimmed := strings.TrimLeft(line, " ")
+	indent := line[:len(line)-len(trimmed)]
+
+	var lines []string
+	current := indent
+	for _, word := range strings.Fields(trimmed) {
+		if len(current) > len(indent) && len(current)+1+len(word) > width {
+			lines = append(lines, current)
+			current = indent
+		}
+		if len(current) > len(indent) {
+			current += " "
+		}
+		current += word
+	}
+	return append(lines, current)
+}
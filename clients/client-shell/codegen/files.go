@@ -0,0 +1,182 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// MainFile is the file holding what GenerateFiles does not generate per
+// service: ReferencesVersion, the maps of all services, exchanges and
+// schemas, and the payload types.
+const MainFile = "client.go"
+
+// GenerateFiles generates the same code as Generate, but split into a file
+// per service, named after the service (such as `queue.go` or
+// `purge_cache.go`), and MainFile.  It returns the formatted files, by name.
+func GenerateFiles(references *References, gen *Generator) (map[string][]byte, error) {
+	out, err := collect(references, gen)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for name := range out.rendered {
+		names[name] = true
+	}
+	for name := range out.renderedExchanges {
+		names[name] = true
+	}
+
+	sources := map[string]*Generator{}
+	services := map[string][]byte{}
+	exchanges := map[string][]byte{}
+	for name := range names {
+		filename := serviceFile(name)
+		if filename == MainFile || strings.HasSuffix(filename, "_test.go") {
+			return nil, fmt.Errorf("service %s cannot be generated in a file named %s", name, filename)
+		}
+
+		file := &Generator{ContextMethods: gen.ContextMethods, FormatOptions: gen.FormatOptions}
+		var selected map[string]definitions.Service
+		if svc, ok := out.selected[name]; ok {
+			selected = map[string]definitions.Service{name: svc}
+		}
+		file.printHeader(false)
+		file.printImports(out.imports(selected, false))
+		if rendered, ok := out.rendered[name]; ok {
+			variable := strcase.ToLowerCamel(name) + "Service"
+			file.Printf("// %s is the definition of the %s service.\n", variable, name)
+			file.Printf("var %s = ", variable)
+			_, _ = file.Write(rendered)
+			file.Print("\n\n")
+			services[name] = []byte(variable)
+		}
+		if rendered, ok := out.renderedExchanges[name]; ok {
+			variable := strcase.ToLowerCamel(name) + "Exchanges"
+			file.Printf("// %s is the definition of the Pulse exchanges of the %s service.\n", variable, name)
+			file.Printf("var %s = ", variable)
+			_, _ = file.Write(rendered)
+			file.Print("\n\n")
+			exchanges[name] = []byte(variable)
+		}
+		file.printInterfaces([]string{name}, selected)
+		file.printBindings([]string{name}, out.selectedExchanges)
+		if gen.TypedPayloads {
+			file.printTypedClients([]string{name}, selected, out.payloadTypes, out.types)
+		}
+		sources[filename] = file
+	}
+
+	// the maps refer to the variables of the service files
+	body := &Generator{}
+	body.Print("var services = ")
+	body.printRendered("definitions.Service", services)
+	body.Print("\n")
+	body.printExchangesAndSchemas(out, exchanges)
+	out.types.Print(body)
+
+	// ReferencesVersion is the same as that generated by Generate
+	mainFile := &Generator{ContextMethods: gen.ContextMethods, FormatOptions: gen.FormatOptions}
+	mainFile.printHeader(true)
+	mainFile.printImports(out.imports(nil, true))
+	mainFile.printReferencesVersion(out.body(gen))
+	_, _ = mainFile.Write(body.buf.Bytes())
+	sources[MainFile] = mainFile
+
+	files := map[string][]byte{}
+	for filename, source := range sources {
+		formatted, err := source.Format()
+		if err != nil {
+			return nil, fmt.Errorf("formatting %s: %s", filename, err)
+		}
+		files[filename] = formatted
+	}
+	if gen.EmitSmokeTests {
+		smokeTests, err := out.generateSmokeTests(gen)
+		if err != nil {
+			return nil, fmt.Errorf("formatting %s: %s", SmokeTestFile, err)
+		}
+		files[SmokeTestFile] = smokeTests
+	}
+	if err := checkDeclarations(files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// IsGenerated returns true if source is a file written by Generate or
+// GenerateFiles, which can be overwritten.
+func IsGenerated(source []byte) bool {
+	if len(source) > 512 {
+		source = source[:512]
+	}
+	return bytes.Contains(source, []byte(generatedHeader))
+}
+
+// serviceFile returns the name of the file generated for the named service.
+func serviceFile(name string) string {
+	return strcase.ToSnake(name) + ".go"
+}
+
+// checkDeclarations returns an error if two of the files declare the same
+// package-level identifier.
+func checkDeclarations(files map[string][]byte) error {
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	declared := map[string]string{}
+	fset := token.NewFileSet()
+	for _, filename := range filenames {
+		file, err := parser.ParseFile(fset, filename, files[filename], 0)
+		if err != nil {
+			return err
+		}
+		for _, name := range declaredNames(file) {
+			if name == "_" {
+				continue
+			}
+			if other, ok := declared[name]; ok {
+				return fmt.Errorf("%s is declared in both %s and %s", name, other, filename)
+			}
+			declared[name] = filename
+		}
+	}
+	return nil
+}
+
+// declaredNames returns the package-level identifiers declared in file;
+// methods are not package-level, and so are not included.
+func declaredNames(file *ast.File) []string {
+	var names []string
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				names = append(names, d.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch sp := spec.(type) {
+				case *ast.TypeSpec:
+					names = append(names, sp.Name.Name)
+				case *ast.ValueSpec:
+					for _, name := range sp.Names {
+						names = append(names, name.Name)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
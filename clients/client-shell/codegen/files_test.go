@@ -0,0 +1,68 @@
+package codegen
+
+import (
+	"go/parser"
+	"go/token"
+	"regexp"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestGenerateFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	files, err := GenerateFiles(loadFixture(t), &Generator{})
+	assert.NoError(err)
+
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	assert.ElementsMatch([]string{"client.go", "fake.go", "other.go"}, filenames)
+
+	fset := token.NewFileSet()
+	for filename, source := range files {
+		_, err := parser.ParseFile(fset, filename, source, 0)
+		assert.NoError(err, filename)
+		assert.True(IsGenerated(source), filename)
+	}
+
+	client := string(files[MainFile])
+	assert.Contains(client, "//go:generate")
+	assert.Contains(client, `"Fake":  fakeService,`)
+	assert.Contains(client, `"Fake": fakeExchanges,`)
+	assert.Contains(client, "var schemas = ")
+	assert.NotContains(client, "type Fake interface")
+
+	fake := string(files["fake.go"])
+	assert.Contains(fake, "var fakeService = definitions.Service{")
+	assert.Contains(fake, "var fakeExchanges = definitions.Exchanges{")
+	assert.Contains(fake, "type Fake interface {")
+	assert.NotContains(fake, "//go:generate")
+	assert.NotContains(fake, "Other")
+}
+
+func TestGenerateFilesReferencesVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	version := regexp.MustCompile(`const ReferencesVersion = "sha256:[0-9a-f]{64}"`)
+	files, err := GenerateFiles(loadFixture(t), &Generator{})
+	assert.NoError(err)
+	expected := version.FindString(string(generateFixture(t, loadFixture(t))))
+	assert.NotEmpty(expected)
+	assert.Equal(expected, version.FindString(string(files[MainFile])))
+}
+
+func TestCheckDeclarations(t *testing.T) {
+	assert := assert.New(t)
+
+	files := map[string][]byte{
+		"a.go": []byte("package apis\n\nvar _ = 1\n\nfunc (x) helper() {}\n\ntype x struct{}\n"),
+		"b.go": []byte("package apis\n\nvar _ = 2\n\nfunc (y) helper() {}\n\ntype y struct{}\n"),
+	}
+	assert.NoError(checkDeclarations(files))
+
+	files["c.go"] = []byte("package apis\n\nfunc helper() {}\n\nvar x = 1\n")
+	assert.EqualError(checkDeclarations(files), "x is declared in both a.go and c.go")
+}
@@ -1,6 +1,14 @@
 package codegen
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
 	"github.com/iancoleman/strcase"
 	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
 )
@@ -22,55 +30,519 @@ type metadata struct {
 	Version int    `json:"version"`
 }
 
+// payloads is the subset of an API reference describing the request and
+// response schemas of its entries.
+type payloads struct {
+	Entries []struct {
+		Name   string `json:"name"`
+		Input  string `json:"input"`
+		Output string `json:"output"`
+	} `json:"entries"`
+}
+
+// scoped is the subset of an API reference giving the scopes required by its
+// entries.
+type scoped struct {
+	Entries []struct {
+		Name   string          `json:"name"`
+		Scopes json.RawMessage `json:"scopes"`
+	} `json:"entries"`
+}
+
+// service is the result of processing a single reference.
+type service struct {
+	// the name of the service in the generated `services` or `exchanges`
+	// map, or empty if the reference is of a kind we do not recognize
+	name string
+	// set for exchanges references, rather than API references
+	exchanges bool
+	svc       definitions.Service
+	// the exchanges definition, for exchanges references
+	x definitions.Exchanges
+	// the pretty-printed service or exchanges definition
+	rendered []byte
+	// the schemas needed to validate the service's payloads
+	schemas map[string]string
+	err     error
+}
+
 func Generate(references *References, gen *Generator) error {
-	gen.Print("//go:generate go run ../codegen/cmd/gen-services\n")
-	gen.Print("// Code generated by `go generate ./apis`; DO NOT EDIT\n")
-	gen.Print("package apis\n")
-	gen.Print("\n")
-	gen.Print("import \"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions\"\n")
-	gen.Print("\n")
+	out, err := collect(references, gen)
+	if err != nil {
+		return err
+	}
+
+	// render the definitions first, so that they can be hashed
+	body := out.body(gen)
+
+	gen.printHeader(true)
+	gen.printImports(out.imports(out.selected, true))
+	gen.printReferencesVersion(body)
+	_, _ = gen.Write(body)
+
+	if gen.EmitSmokeTests {
+		gen.SmokeTests, err = out.generateSmokeTests(gen)
+		if err != nil {
+			return fmt.Errorf("formatting %s: %s", SmokeTestFile, err)
+		}
+	}
+	return nil
+}
+
+// generated is what Generate and GenerateFiles collect from the references,
+// before rendering it.
+type generated struct {
+	// the pretty-printed definitions of the selected services and
+	// exchanges, by Go name
+	rendered          map[string][]byte
+	renderedExchanges map[string][]byte
+	selected          map[string]definitions.Service
+	selectedExchanges map[string]definitions.Exchanges
+	schemas           map[string]string
+	types             *typeGenerator
+	// the types of the payloads and responses of the selected services'
+	// entries, by Go name and entry name, if TypedPayloads is set
+	payloadTypes map[string]map[string]payloadType
+}
+
+// collect processes the references, returning the services selected by gen.
+func collect(references *References, gen *Generator) (*generated, error) {
+	if err := gen.checkServiceFilters(); err != nil {
+		return nil, err
+	}
 
 	var manifest manifest
 	err := references.get("references/manifest.json", &manifest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	services := map[string]definitions.Service{}
+	// process the references concurrently; results are collected in manifest
+	// order, so that the output does not depend on scheduling
+	results := make([]service, len(manifest.References))
+	workers := gen.workers()
+	sem := make(chan struct{}, workers)
+	wg := &sync.WaitGroup{}
+	for i, refName := range manifest.References {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, refName string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = loadService(references, refName, gen.Aliases)
+		}(i, refName)
+	}
+	wg.Wait()
 
-	for _, refName := range manifest.References {
-		// fetch the reference file, just getting its $schema property to start
-		var ws withSchema
-		err = references.get(refName, &ws)
-		if err != nil {
-			return err
+	out := &generated{
+		rendered:          map[string][]byte{},
+		renderedExchanges: map[string][]byte{},
+		selected:          map[string]definitions.Service{},
+		selectedExchanges: map[string]definitions.Exchanges{},
+		schemas:           map[string]string{},
+		types:             newTypeGenerator(references),
+		payloadTypes:      map[string]map[string]payloadType{},
+	}
+	known := map[string]bool{}
+	// the reference defining each service (and exchanges), by Go name
+	defined := map[string]string{}
+	for i, result := range results {
+		if result.err != nil {
+			return nil, result.err
+		}
+		if result.name == "" {
+			continue
+		}
+		known[result.name] = true
+		key := result.name
+		if result.exchanges {
+			key += " exchanges"
+		}
+		if other, ok := defined[key]; ok {
+			return nil, fmt.Errorf(
+				"%s and %s both define the service %s, after conversion to a Go name",
+				other, manifest.References[i], result.name,
+			)
+		}
+		defined[key] = manifest.References[i]
+		if !gen.selectsService(result.name) {
+			continue
+		}
+		if result.exchanges {
+			out.renderedExchanges[result.name] = result.rendered
+			out.selectedExchanges[result.name] = result.x
+			continue
 		}
 
-		// fetch that schema..
-		var sch schema
-		err = references.get(ws.Schema[:len(ws.Schema)-1], &sch)
-		if err != nil {
-			return err
+		// type generation assigns names as it goes, so it runs sequentially
+		if gen.TypedPayloads {
+			out.payloadTypes[result.name], err = addPayloadTypes(references, manifest.References[i], result.svc.ServiceName, out.types)
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		// and check its name and version; we only recognize api references at v0
-		if sch.Metadata.Name != "api" || sch.Metadata.Version != 0 {
-			continue
+		out.rendered[result.name] = result.rendered
+		out.selected[result.name] = result.svc
+		for file, schema := range result.schemas {
+			out.schemas[file] = schema
 		}
+	}
 
-		var svc definitions.Service
-		err = references.get(refName, &svc)
-		if err != nil {
-			return err
+	gen.warnUnknownServices(known)
+	gen.warnUnknownAliases(known)
+	return out, nil
+}
+
+// body renders everything Generate writes after the header: the definitions,
+// the service interfaces, the exchange bindings, and the payload types and
+// typed clients, if TypedPayloads is set.
+func (out *generated) body(gen *Generator) []byte {
+	body := &Generator{ContextMethods: gen.ContextMethods}
+	body.Print("var services = ")
+	body.printRendered("definitions.Service", out.rendered)
+	body.Print("\n")
+	body.printExchangesAndSchemas(out, out.renderedExchanges)
+	body.printInterfaces(sortedNames(out.rendered), out.selected)
+	body.printBindings(sortedNames(out.renderedExchanges), out.selectedExchanges)
+	if gen.TypedPayloads {
+		body.printTypedClients(sortedNames(out.rendered), out.selected, out.payloadTypes, out.types)
+	}
+	out.types.Print(body)
+	return body.buf.Bytes()
+}
+
+// printExchangesAndSchemas prints the exchanges map, whose values are given
+// by exchanges (rendered definitions, or the names of the variables holding
+// them), and the schemas map.
+func (g *Generator) printExchangesAndSchemas(out *generated, exchanges map[string][]byte) {
+	g.Print("// exchanges holds the Pulse exchanges of the services, from which bindings\n")
+	g.Print("// are built by ListenFor and the binding functions of the exchanges.\n")
+	g.Print("var exchanges = ")
+	g.printRendered("definitions.Exchanges", exchanges)
+	g.Print("\n")
+	g.Print("// schemas holds the schemas of the API methods' payloads and responses,\n")
+	g.Print("// and the schemas they refer to, by path; they are used to validate payloads\n")
+	g.Print("// before they are sent, and shown by --schema.\n")
+	g.Print("var schemas = ")
+	g.PrettyPrint(out.schemas)
+	g.Print("\n")
+	g.Print("\n")
+}
+
+// imports returns the standard library packages used by the code generated
+// for the given services, and by the payload types if withTypes is set.
+func (out *generated) imports(services map[string]definitions.Service, withTypes bool) []string {
+	var imports []string
+	// the service clients pass a context to each call
+	if len(services) > 0 {
+		imports = append(imports, "context")
+	}
+	if (withTypes && out.types.usesJSON) || out.typedClientsUseJSON(services) {
+		imports = append(imports, "encoding/json")
+	}
+	if usesStreams(services) {
+		imports = append(imports, "io")
+	}
+	if usesSignedURLs(services) {
+		imports = append(imports, "time")
+	}
+	return imports
+}
+
+// printHeader prints the header of a generated file, up to the package
+// clause; only the main file of the package has the go:generate directive.
+func (g *Generator) printHeader(goGenerate bool) {
+	if goGenerate {
+		g.Print("//go:generate go run ../codegen/cmd/gen-services\n")
+	}
+	g.Print(generatedHeader + "\n")
+	if g.ContextMethods {
+		g.Print("//\n")
+		g.Print("// Generated with -context: the methods of the service clients take a leading\n")
+		g.Print("// ctx context.Context, which aborts the request when cancelled.  To migrate\n")
+		g.Print("// code written without it, pass a context as the first argument, e.g.\n")
+		g.Print("// NewQueue().Task(taskId) becomes NewQueue().Task(ctx, taskId); use\n")
+		g.Print("// context.Background() to keep the previous behaviour.\n")
+	}
+	// keep the header detached from the package clause, so that it is not
+	// treated (and reformatted) as a package doc comment
+	g.Print("\n")
+	g.Print("package apis\n")
+	g.Print("\n")
+}
+
+// generatedHeader marks the files written by the generator.
+const generatedHeader = "// Code generated by `go generate ./apis`; DO NOT EDIT"
+
+// printImports prints the import declaration of a generated file: the given
+// standard library packages, then the definitions package.
+func (g *Generator) printImports(imports []string) {
+	g.Print("import (\n")
+	for _, imp := range imports {
+		g.Printf("\t%q\n", imp)
+	}
+	g.Print("\n")
+	g.Print("\t\"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions\"\n")
+	g.Print(")\n")
+	g.Print("\n")
+}
+
+// printReferencesVersion prints the ReferencesVersion constant, hashing the
+// given definitions.  No generation timestamp is printed alongside it: the
+// generated code must be identical whenever it is generated from the same
+// references, or `-dry-run` would never find it in sync, and the references
+// document carries no date of its own to derive one from.
+func (g *Generator) printReferencesVersion(definitions []byte) {
+	g.Print("// ReferencesVersion identifies the API references this file was generated\n")
+	g.Print("// from.  It is a hash of the generated definitions, so it changes only when\n")
+	g.Print("// the generated code does; there is deliberately no generation timestamp,\n")
+	g.Print("// which would change every time.\n")
+	g.Printf("const ReferencesVersion = %q\n", referencesVersion(definitions))
+	g.Print("\n")
+}
+
+// referencesVersion computes the value of ReferencesVersion for the given
+// generated definitions.
+func referencesVersion(definitions []byte) string {
+	sum := sha256.Sum256(definitions)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// printRendered prints a map from names to already pretty-printed values of
+// type typ, sorted by name; this is equivalent to PrettyPrint of the map of
+// values.
+func (g *Generator) printRendered(typ string, rendered map[string][]byte) {
+	g.Printf("map[string]%s{\n", typ)
+	for _, name := range sortedNames(rendered) {
+		g.Printf("%#v: ", name)
+		_, _ = g.Write(rendered[name])
+		g.Print(",\n")
+	}
+	g.Print("}\n")
+}
+
+func sortedNames(rendered map[string][]byte) []string {
+	names := make([]string, 0, len(rendered))
+	for name := range rendered {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadService reads the reference refName and renders its service or
+// exchanges definition.  It does not modify references, so it is safe to
+// call concurrently.
+func loadService(references *References, refName string, aliases Aliases) service {
+	// fetch the reference file, just getting its $schema property to start
+	var ws withSchema
+	err := references.get(refName, &ws)
+	if err != nil {
+		return service{err: err}
+	}
+	if ws.Schema == "" {
+		return service{err: fmt.Errorf("%s does not have a $schema property", refName)}
+	}
+
+	// fetch that schema..
+	var sch schema
+	err = references.get(ws.Schema[:len(ws.Schema)-1], &sch)
+	if err != nil {
+		return service{err: err}
+	}
+
+	// and check its name and version; we only recognize api and exchanges
+	// references at v0
+	if sch.Metadata.Version != 0 {
+		return service{}
+	}
+	switch sch.Metadata.Name {
+	case "api":
+		return loadAPI(references, refName, aliases)
+	case "exchanges":
+		return loadExchanges(references, refName)
+	}
+	return service{}
+}
+
+// loadAPI renders the service definition of the API reference refName, with
+// the given aliases of its methods.
+func loadAPI(references *References, refName string, aliases Aliases) service {
+	var svc definitions.Service
+	err := references.get(refName, &svc)
+	if err != nil {
+		return service{err: err}
+	}
+
+	// emit entries in a stable order, regardless of their order in the
+	// reference document
+	sort.SliceStable(svc.Entries, func(i, j int) bool {
+		return svc.Entries[i].Name < svc.Entries[j].Name
+	})
+	var sc scoped
+	err = references.get(refName, &sc)
+	if err != nil {
+		return service{err: err}
+	}
+	requiresScopes := map[string]bool{}
+	for _, entry := range sc.Entries {
+		requiresScopes[entry.Name] = len(entry.Scopes) > 0 && string(entry.Scopes) != "null"
+	}
+
+	// the output schemas are embedded too, to be shown by --schema=output
+	payloadSchemas := make([]string, 0, 2*len(svc.Entries))
+	for _, entry := range svc.Entries {
+		payloadSchemas = append(payloadSchemas, entry.Input, entry.Output)
+	}
+	schemas, err := collectSchemas(references, svc.ServiceName, payloadSchemas)
+	if err != nil {
+		return service{err: fmt.Errorf("%s: collecting payload schemas: %s", refName, err)}
+	}
+
+	camelName := strcase.ToCamel(svc.ServiceName)
+	for i := range svc.Entries {
+		svc.Entries[i].Paginated = isPaginated(svc.Entries[i])
+		svc.Entries[i].SignedURL = supportsSignedURL(svc.Entries[i], requiresScopes[svc.Entries[i].Name])
+		svc.Entries[i].Download, svc.Entries[i].Upload = isArtifactIO(svc.Entries[i])
+		svc.Entries[i].PutURL = returnsPutURL(schemas, svc.ServiceName, svc.Entries[i])
+		svc.Entries[i].Usage = entryUsage(svc.Entries[i])
+		svc.Entries[i].Example = entryExample(camelName, svc.Entries[i])
+	}
+	if err := checkCollisions(refName, svc); err != nil {
+		return service{err: err}
+	}
+	if err := addAliases(refName, &svc, aliases.forService(camelName)); err != nil {
+		return service{err: err}
+	}
+
+	fragment := &Generator{}
+	fragment.PrettyPrint(svc)
+	return service{name: camelName, svc: svc, rendered: fragment.buf.Bytes(), schemas: schemas}
+}
+
+// loadExchanges renders the exchanges definition of the exchanges reference
+// refName.
+func loadExchanges(references *References, refName string) service {
+	var x definitions.Exchanges
+	err := references.get(refName, &x)
+	if err != nil {
+		return service{err: err}
+	}
+
+	sort.SliceStable(x.Entries, func(i, j int) bool {
+		return x.Entries[i].Name < x.Entries[j].Name
+	})
+
+	fragment := &Generator{}
+	fragment.PrettyPrint(x)
+	return service{name: strcase.ToCamel(x.ServiceName), exchanges: true, x: x, rendered: fragment.buf.Bytes()}
+}
+
+// isPaginated returns true if the entry takes a `continuationToken` query
+// parameter, and so returns its results a page at a time.
+func isPaginated(entry definitions.Entry) bool {
+	for _, q := range entry.Query {
+		if q == "continuationToken" {
+			return true
 		}
+	}
+	return false
+}
 
-		camelName := strcase.ToCamel(svc.ServiceName)
-		services[camelName] = svc
+// supportsSignedURL returns true if the entry can usefully be called with a
+// signed URL: it must be a GET, and, as with the Go client, require scopes, as
+// otherwise an unsigned URL works just as well.  auth.testAuthenticateGet
+// requires no scopes of its own, but exists to test signed URLs.
+func supportsSignedURL(entry definitions.Entry, requiresScopes bool) bool {
+	if strings.ToLower(entry.Method) != "get" {
+		return false
 	}
+	return requiresScopes || entry.Name == "testAuthenticateGet"
+}
 
-	gen.Print("var services = ")
-	gen.PrettyPrint(services)
-	gen.Print("\n")
+// isArtifactIO returns whether the entry downloads or uploads the content of
+// an artifact: the artifacts of a task are at `.../artifacts/<name>`, and
+// their content is neither a JSON response (for a GET) nor a JSON payload.
+func isArtifactIO(entry definitions.Entry) (download, upload bool) {
+	if !strings.HasSuffix(entry.Route, "/artifacts/<name>") {
+		return false, false
+	}
+	switch strings.ToLower(entry.Method) {
+	case "get":
+		return entry.Output == "", false
+	case "put", "post":
+		return false, entry.Input == ""
+	}
+	return false, false
+}
 
-	return nil
+// returnsPutURL returns whether the output schema of entry, in schemas,
+// allows a `putUrl` property, to which the content of an artifact is to be
+// uploaded, as queue's createArtifact does for s3 artifacts.  The schema may
+// be one of several alternatives, with `oneOf` or `anyOf`.
+func returnsPutURL(schemas map[string]string, serviceName string, entry definitions.Entry) bool {
+	if entry.Output == "" {
+		return false
+	}
+	file := "/" + strings.SplitN("schemas/"+serviceName+"/"+entry.Output, "#", 2)[0]
+	var doc interface{}
+	if err := json.Unmarshal([]byte(schemas[file]), &doc); err != nil {
+		return false
+	}
+
+	var hasPutURL func(schema interface{}) bool
+	hasPutURL = func(schema interface{}) bool {
+		node, ok := schema.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if props, ok := node["properties"].(map[string]interface{}); ok && props["putUrl"] != nil {
+			return true
+		}
+		for _, key := range []string{"oneOf", "anyOf"} {
+			alternatives, _ := node[key].([]interface{})
+			for _, alternative := range alternatives {
+				if hasPutURL(alternative) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return hasPutURL(doc)
+}
+
+// addPayloadTypes generates types for the input and output schemas of each
+// entry in the given API reference, returning them by entry name.
+func addPayloadTypes(references *References, refName, serviceName string, types *typeGenerator) (map[string]payloadType, error) {
+	var p payloads
+	err := references.get(refName, &p)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(p.Entries, func(i, j int) bool {
+		return p.Entries[i].Name < p.Entries[j].Name
+	})
+
+	entryTypes := map[string]payloadType{}
+	for _, entry := range p.Entries {
+		var typ payloadType
+		for _, schema := range []struct {
+			location string
+			typ      *string
+		}{{entry.Input, &typ.input}, {entry.Output, &typ.output}} {
+			if schema.location == "" {
+				continue
+			}
+			*schema.typ, err = types.AddSchema("schemas/" + serviceName + "/" + schema.location)
+			if err != nil {
+				return nil, fmt.Errorf("%s: generating types for %s: %s", refName, entry.Name, err)
+			}
+		}
+		entryTypes[entry.Name] = typ
+	}
+
+	return entryTypes, nil
 }
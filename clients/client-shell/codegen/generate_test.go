@@ -0,0 +1,600 @@
+package codegen
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// generateFixture runs Generate over the references and returns the
+// formatted source.
+func generateFixture(t *testing.T, refs *References) []byte {
+	gen := &Generator{}
+	assert.NoError(t, Generate(refs, gen))
+	source, err := gen.Format()
+	assert.NoError(t, err)
+	return source
+}
+
+func loadFixture(t *testing.T) *References {
+	refs, err := LoadReferencesFrom(fixturePath)
+	assert.NoError(t, err)
+	return refs
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	assert := assert.New(t)
+
+	first := generateFixture(t, loadFixture(t))
+	second := generateFixture(t, loadFixture(t))
+	assert.Equal(string(first), string(second))
+}
+
+func TestGenerateIgnoresReferenceOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	expected := generateFixture(t, loadFixture(t))
+
+	// reverse the order of the documents and of the entries within each
+	// service; the output should not change
+	refs := loadFixture(t)
+	for i, j := 0, len(refs.data)-1; i < j; i, j = i+1, j-1 {
+		refs.data[i], refs.data[j] = refs.data[j], refs.data[i]
+	}
+	for i := range refs.data {
+		if refs.data[i].Filename == "references/fake/v1/api.json" {
+			refs.data[i].Content = reverseEntries(t, refs.data[i].Content)
+		}
+	}
+
+	assert.Equal(string(expected), string(generateFixture(t, refs)))
+}
+
+func TestGenerateSortsEntries(t *testing.T) {
+	assert := assert.New(t)
+
+	source := string(generateFixture(t, loadFixture(t)))
+	create := indexOf(t, source, `Name:        "createThing"`)
+	list := indexOf(t, source, `Name:        "listThings"`)
+	ping := indexOf(t, source, `Name:        "ping"`)
+	assert.True(create < list && list < ping, "entries are not sorted by name")
+
+	fake := indexOf(t, source, `"Fake": definitions.Service{`)
+	other := indexOf(t, source, `"Other": definitions.Service{`)
+	assert.True(fake < other, "services are not sorted by name")
+}
+
+func indexOf(t *testing.T, s, substr string) int {
+	i := strings.Index(s, substr)
+	assert.True(t, i >= 0, "%q not found in generated source", substr)
+	return i
+}
+
+func reverseEntries(t *testing.T, content json.RawMessage) json.RawMessage {
+	var doc map[string]interface{}
+	assert.NoError(t, json.Unmarshal(content, &doc))
+	entries := doc["entries"].([]interface{})
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	data, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	return data
+}
+
+func TestGenerateEntryComments(t *testing.T) {
+	assert := assert.New(t)
+
+	source := string(generateFixture(t, loadFixture(t)))
+
+	// title and description of a documented entry
+	assert.Contains(source, "// createThing: Create Thing\n")
+	assert.Contains(source, "// Create a new thing with the given `thingId`.\n")
+
+	// long lines are wrapped, but list items are preserved
+	assert.Contains(source, "// List all things. By default this end-point will try to return up to 1000\n")
+	assert.Contains(source, "//   * Use `continuationToken` to fetch the next page,\n")
+	for _, line := range strings.Split(source, "\n") {
+		if trimmed := strings.TrimLeft(line, "\t"); strings.HasPrefix(trimmed, "//") {
+			assert.True(len(trimmed) <= commentWidth, "comment line too long: %q", trimmed)
+		}
+	}
+
+	// entries without a description fall back to the method name
+	assert.Contains(source, "// reset\n")
+}
+
+func TestFormatCommentSanitizes(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("// a b\n//\n// c d\n", formatComment("a\rb\r\n\r\nc\td"))
+}
+
+func TestGenerateMarksPaginatedEntries(t *testing.T) {
+	assert := assert.New(t)
+
+	source := string(generateFixture(t, loadFixture(t)))
+	list := indexOf(t, source, `Name:        "listThings"`)
+	ping := indexOf(t, source, `Name:        "ping"`)
+	paginated := regexp.MustCompile(`Paginated:\s+true`)
+
+	// only listThings takes a continuationToken
+	matches := paginated.FindAllStringIndex(source, -1)
+	assert.Len(matches, 1)
+	assert.True(matches[0][0] > list && matches[0][0] < ping, "listThings is not marked as paginated")
+}
+
+func TestGenerateReferencesVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	source := string(generateFixture(t, loadFixture(t)))
+	version := regexp.MustCompile(`const ReferencesVersion = "sha256:[0-9a-f]{64}"`).FindString(source)
+	assert.NotEmpty(version, "ReferencesVersion not found")
+
+	// any change to the definitions changes the version
+	refs := loadFixture(t)
+	for i := range refs.data {
+		if refs.data[i].Filename == "references/fake/v1/api.json" {
+			refs.data[i].Content = reverseEntries(t, renameFirstEntry(t, refs.data[i].Content))
+		}
+	}
+	assert.NotContains(string(generateFixture(t, refs)), version)
+}
+
+func renameFirstEntry(t *testing.T, content json.RawMessage) json.RawMessage {
+	var doc map[string]interface{}
+	assert.NoError(t, json.Unmarshal(content, &doc))
+	entry := doc["entries"].([]interface{})[0].(map[string]interface{})
+	entry["title"] = entry["title"].(string) + " (renamed)"
+	data, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	return data
+}
+
+func TestGenerateConcurrencyIsIdentical(t *testing.T) {
+	assert := assert.New(t)
+
+	refs, err := LoadReferences()
+	assert.NoError(err)
+
+	generate := func(workers int) string {
+		gen := &Generator{TypedPayloads: true, Workers: workers}
+		assert.NoError(Generate(refs, gen))
+		return gen.String()
+	}
+
+	sequential := generate(1)
+	for _, workers := range []int{2, 8, 64} {
+		assert.Equal(sequential, generate(workers), "output differs with %d workers", workers)
+	}
+}
+
+// BenchmarkGenerate compares sequential and concurrent generation from the
+// bundled references; run with `go test -bench Generate ./codegen`.
+func BenchmarkGenerate(b *testing.B) {
+	refs, err := LoadReferences()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, bm := range []struct {
+		name    string
+		workers int
+	}{
+		{"sequential", 1},
+		{"concurrent", 0},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				gen := &Generator{Workers: bm.workers}
+				if err := Generate(refs, gen); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateEmbedsPayloadSchemas(t *testing.T) {
+	assert := assert.New(t)
+
+	source := string(generateFixture(t, loadFixture(t)))
+
+	// the input schema, and the schemas it refers to, are embedded
+	assert.Contains(source, `"/schemas/fake/v1/create-thing-request.json": "{`)
+	assert.Contains(source, `"/schemas/fake/v1/thing-owner.json":`)
+	// so are output schemas, for --schema=output
+	assert.Contains(source, `"/schemas/fake/v1/list-things-response.json": "{`)
+	assert.Regexp(`Output: +"v1/list-things-response.json#",`, source)
+}
+
+func TestGenerateExchanges(t *testing.T) {
+	assert := assert.New(t)
+
+	source := string(generateFixture(t, loadFixture(t)))
+	indexOf(t, source, "var exchanges = map[string]definitions.Exchanges{")
+	indexOf(t, source, `"Fake": definitions.Exchanges{`)
+	assert.Contains(source, `ExchangePrefix: "exchange/taskcluster-fake/v1/"`)
+	assert.Contains(source, "// thingUpdated: Thing Updated\n")
+
+	// exchanges are sorted by name, and are not API services
+	created := indexOf(t, source, `Name:        "thingCreated"`)
+	updated := indexOf(t, source, `Name:        "thingUpdated"`)
+	assert.True(created < updated, "exchanges are not sorted by name")
+	assert.NotContains(source, `"Fake": definitions.Service{
+		APIVersion:  "v1",
+		ServiceName: "fake",
+		Title:       "Fake Exchanges"`)
+}
+
+func TestExchangeBindings(t *testing.T) {
+	assert := assert.New(t)
+
+	var x definitions.Exchanges
+	assert.NoError(loadFixture(t).get("references/fake/v1/exchanges.json", &x))
+
+	binding, err := x.Binding("thingUpdated", nil)
+	assert.NoError(err)
+	assert.Equal("exchange/taskcluster-fake/v1/thing-updated", binding.Exchange)
+	assert.Equal("primary.*.*.#", binding.RoutingKeyPattern)
+
+	binding, err = x.Binding("thingUpdated", map[string]string{
+		"thingId":  "abc",
+		"reserved": "x.y",
+	})
+	assert.NoError(err)
+	assert.Equal("primary.abc.*.x.y", binding.RoutingKeyPattern)
+
+	_, err = x.Binding("thingUpdated", map[string]string{"color": "red"})
+	assert.EqualError(err, "exchange 'thingUpdated' has no routing-key component 'color'")
+
+	_, err = x.Binding("thingUpdated", map[string]string{"owner": "a.b"})
+	assert.Error(err)
+
+	_, err = x.Binding("thingUpdated", map[string]string{"routingKeyKind": "route"})
+	assert.Error(err)
+
+	_, err = x.Binding("thingDeleted", nil)
+	assert.EqualError(err, "unknown exchange 'thingDeleted'")
+}
+
+func TestGenerateBindingFunctions(t *testing.T) {
+	assert := assert.New(t)
+
+	// each exchange has a function taking its routing-key components which
+	// are not constant
+	source := string(generateFixture(t, loadFixture(t)))
+	assert.Contains(source, "func FakeThingUpdated(thingId, owner, reserved string) (definitions.Binding, error) {\n"+
+		"\treturn bindingFor(\"Fake\", \"thingUpdated\", map[string]string{\"thingId\": thingId, \"owner\": owner, \"reserved\": reserved})\n}\n")
+	assert.Contains(source, "// FakeThingUpdated returns the binding for the messages of the thingUpdated\n")
+	assert.Contains(source, "func FakeThingCreated(")
+
+	// and the same functions are in the service's file when split
+	files, err := GenerateFiles(loadFixture(t), &Generator{})
+	assert.NoError(err)
+	assert.Contains(string(files["fake.go"]), "func FakeThingUpdated(thingId, owner, reserved string) (definitions.Binding, error) {")
+	assert.NotContains(string(files[MainFile]), "func FakeThingUpdated(")
+}
+
+func TestBindingParams(t *testing.T) {
+	assert := assert.New(t)
+
+	components, params := bindingParams(definitions.Exchange{RoutingKey: []definitions.RoutingKeyElement{
+		{Name: "routingKeyKind", Constant: "primary"},
+		{Name: "type"},
+		{Name: "c"},
+	}})
+	assert.Equal(`map[string]string{"type": typeArg, "c": cArg}`, components)
+	assert.Equal("typeArg, cArg string", params)
+
+	// exchanges whose routing keys are constant take no parameters
+	components, params = bindingParams(definitions.Exchange{RoutingKey: []definitions.RoutingKeyElement{{Name: "kind", Constant: "primary"}}})
+	assert.Equal("nil", components)
+	assert.Equal("", params)
+}
+
+func TestGenerateServiceFilters(t *testing.T) {
+	assert := assert.New(t)
+
+	// only the included services, and their exchanges and schemas, are
+	// generated; names can be given as in the references or in camel case
+	gen := &Generator{IncludeServices: []string{"other"}}
+	assert.NoError(Generate(loadFixture(t), gen))
+	source, err := gen.Format()
+	assert.NoError(err)
+	assert.Contains(string(source), `"Other": definitions.Service{`)
+	assert.NotContains(string(source), `"Fake": definitions.Service{`)
+	assert.NotContains(string(source), `"Fake": definitions.Exchanges{`)
+	assert.NotContains(string(source), `"/schemas/fake/`)
+	assert.Empty(gen.Warnings)
+
+	gen = &Generator{ExcludeServices: []string{"Other"}}
+	assert.NoError(Generate(loadFixture(t), gen))
+	source, err = gen.Format()
+	assert.NoError(err)
+	assert.Contains(string(source), `"Fake": definitions.Service{`)
+	assert.Contains(string(source), `"Fake": definitions.Exchanges{`)
+	assert.NotContains(string(source), `"Other": definitions.Service{`)
+
+	// unknown services are warned about
+	gen = &Generator{ExcludeServices: []string{"fake", "missing"}}
+	assert.NoError(Generate(loadFixture(t), gen))
+	assert.Equal([]string{"unknown service 'missing' in the service filters"}, gen.Warnings)
+
+	gen = &Generator{IncludeServices: []string{"fake"}, ExcludeServices: []string{"other"}}
+	assert.Error(Generate(loadFixture(t), gen))
+}
+
+func TestGenerateServiceInterfaces(t *testing.T) {
+	assert := assert.New(t)
+
+	source := string(generateFixture(t, loadFixture(t)))
+	assert.Contains(source, "type Fake interface {\n")
+	assert.Contains(source, "\tCreateThing(thingId string, payload []byte) ([]byte, error)\n")
+	assert.Contains(source, "\tListThings(query map[string]string) ([]byte, error)\n")
+	assert.Contains(source, "\tPing() ([]byte, error)\n")
+	assert.Contains(source, "func NewFake() Fake {\n\treturn fakeClient{}\n}\n")
+	assert.Contains(source, "type fakeClient struct {\n\tconn *connection\n}\n")
+	assert.Contains(source, "func NewFakeFromEnv() (Fake, error) {\n\tconn, err := connectionFromEnv()\n"+
+		"\tif err != nil {\n\t\treturn nil, err\n\t}\n\treturn fakeClient{conn: conn}, nil\n}\n")
+	assert.Contains(source, "func (c fakeClient) CreateThing(thingId string, payload []byte) ([]byte, error) {\n"+
+		"\treturn call(context.Background(), c.conn, \"Fake\", \"createThing\", map[string]string{\"thingId\": thingId}, nil, payload)\n}\n")
+	assert.Contains(source, "func NewOther() Other {\n")
+}
+
+func TestParamName(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("taskId", paramName("taskId"))
+	assert.Equal("typeArg", paramName("type"))
+	assert.Equal("payloadArg", paramName("payload"))
+	assert.Equal("bodyArg", paramName("body"))
+	assert.Equal("stringArg", paramName("string"))
+	assert.Equal("callArg", paramName("call"))
+	assert.Equal("cArg", paramName("c"))
+	assert.Equal("contextArg", paramName("context"))
+	assert.Equal("thingid", paramName("thing-id"))
+	assert.Equal("arg2nd", paramName("2nd"))
+
+	// the names of the parameters of an entry are distinct
+	assert.Equal([]string{"typeArg", "typeArgArg", "name"}, paramNames(definitions.Entry{Args: []string{"type", "typeArg", "name"}}))
+}
+
+func TestGenerateReservedNames(t *testing.T) {
+	assert := assert.New(t)
+
+	// the fixture's methods and arguments are named like Go keywords,
+	// predeclared identifiers and the names used by the generated methods
+	refs, err := LoadReferencesFrom("testdata/reserved-references.json")
+	assert.NoError(err)
+	for _, contextMethods := range []bool{false, true} {
+		gen := &Generator{ContextMethods: contextMethods}
+		assert.NoError(Generate(refs, gen))
+		formatted, err := gen.Format()
+		assert.NoError(err)
+		_, err = parser.ParseFile(token.NewFileSet(), "services.go", formatted, parser.AllErrors)
+		assert.NoError(err)
+
+		source := string(formatted)
+		assert.Contains(source, "\tType(")
+		assert.Contains(source, "\tRange(")
+		assert.Contains(source, "\tGo(")
+		assert.Contains(source, "Func(")
+		// the arguments are renamed consistently in the signature and body
+		assert.Contains(source, "func (c reservedClient) Range(")
+		assert.Contains(source, "funcArg string, stringArg string, query map[string]string) ([]byte, error) {\n")
+		assert.Contains(source, `map[string]string{"func": funcArg, "string": stringArg}`)
+		assert.Contains(source, "contextArg string, callArg string, typeArg string, typeArgArg string")
+		assert.Contains(source, `map[string]string{"context": contextArg, "call": callArg, "type": typeArg, "typeArg": typeArgArg}`)
+		assert.Contains(source, `map[string]string{"2nd": arg2nd, "thing-id": thingid}`)
+	}
+}
+
+func TestGenerateContextMethods(t *testing.T) {
+	assert := assert.New(t)
+
+	// without ContextMethods, the clients call with a background context
+	source := string(generateFixture(t, loadFixture(t)))
+	assert.Contains(source, "\tPing() ([]byte, error)\n")
+	assert.Contains(source, "return call(context.Background(), c.conn, \"Fake\", \"ping\", nil, nil, nil)\n")
+	assert.NotContains(source, "Generated with -context")
+
+	gen := &Generator{ContextMethods: true}
+	assert.NoError(Generate(loadFixture(t), gen))
+	formatted, err := gen.Format()
+	assert.NoError(err)
+	source = string(formatted)
+	assert.Contains(source, "// Generated with -context: the methods of the service clients take a leading\n")
+	assert.Contains(source, "\tCreateThing(ctx context.Context, thingId string, payload []byte) ([]byte, error)\n")
+	assert.Contains(source, "\tPing(ctx context.Context) ([]byte, error)\n")
+	assert.Contains(source, "return call(ctx, c.conn, \"Fake\", \"ping\", nil, nil, nil)\n")
+}
+
+func TestGenerateSignURLMethods(t *testing.T) {
+	assert := assert.New(t)
+
+	source := string(generateFixture(t, loadFixture(t)))
+
+	// only GET entries requiring scopes can be signed
+	signed := regexp.MustCompile(`SignedURL:\s+true`)
+	matches := signed.FindAllStringIndex(source, -1)
+	assert.Len(matches, 1)
+	list := indexOf(t, source, `Name:        "listThings"`)
+	ping := indexOf(t, source, `Name:        "ping"`)
+	assert.True(matches[0][0] > list && matches[0][0] < ping, "listThings is not marked as supporting signed URLs")
+
+	assert.Contains(source, "\t\"time\"\n")
+	assert.Contains(source, "\tListThingsSignURL(duration time.Duration, query map[string]string) (string, error)\n")
+	assert.Contains(source, "func (c fakeClient) ListThingsSignURL(duration time.Duration, query map[string]string) (string, error) {\n"+
+		"\treturn signURL(c.conn, \"Fake\", \"listThings\", duration, nil, query)\n}\n")
+	assert.NotContains(source, "PingSignURL")
+	assert.Contains(source, `Usage:     "listThings [--continuationToken <continuationToken>] [--limit <limit>] [--all] [--sign-url <duration>]"`)
+}
+
+func TestArtifactIO(t *testing.T) {
+	assert := assert.New(t)
+
+	get := definitions.Entry{Method: "get", Route: "/task/<taskId>/runs/<runId>/artifacts/<name>"}
+	download, upload := isArtifactIO(get)
+	assert.True(download)
+	assert.False(upload)
+
+	// createArtifact takes and returns JSON; the content is uploaded elsewhere
+	create := definitions.Entry{Method: "post", Route: get.Route, Input: "v1/post-artifact-request.json#", Output: "v1/post-artifact-response.json#"}
+	download, upload = isArtifactIO(create)
+	assert.False(download)
+	assert.False(upload)
+
+	put := definitions.Entry{Method: "put", Route: get.Route}
+	download, upload = isArtifactIO(put)
+	assert.False(download)
+	assert.True(upload)
+
+	list := definitions.Entry{Method: "get", Route: "/task/<taskId>/runs/<runId>/artifacts", Output: "v1/list-artifacts-response.json#"}
+	download, upload = isArtifactIO(list)
+	assert.False(download)
+	assert.False(upload)
+}
+
+func TestReturnsPutURL(t *testing.T) {
+	assert := assert.New(t)
+
+	schemas := map[string]string{
+		// the putUrl is in one of the alternatives, as for createArtifact
+		"/schemas/fake/v1/create-response.json": `{"oneOf": [
+			{"properties": {"storageType": {"enum": ["s3"]}, "putUrl": {"type": "string"}}},
+			{"properties": {"storageType": {"enum": ["reference"]}}}
+		]}`,
+		"/schemas/fake/v1/thing.json": `{"properties": {"url": {"type": "string"}}}`,
+	}
+	assert.True(returnsPutURL(schemas, "fake", definitions.Entry{Output: "v1/create-response.json#"}))
+	assert.False(returnsPutURL(schemas, "fake", definitions.Entry{Output: "v1/thing.json#"}))
+	assert.False(returnsPutURL(schemas, "fake", definitions.Entry{Output: "v1/unknown.json#"}))
+	assert.False(returnsPutURL(schemas, "fake", definitions.Entry{}))
+}
+
+func TestGenerateUploadMethods(t *testing.T) {
+	assert := assert.New(t)
+
+	gen := &Generator{}
+	gen.Print("package apis\n\n")
+	gen.printInterfaces([]string{"Fake"}, map[string]definitions.Service{
+		"Fake": definitions.Service{
+			Entries: []definitions.Entry{
+				definitions.Entry{
+					Name: "createData", Method: "post", Route: "/things/<thingId>/artifacts/<name>", Args: []string{"thingId", "name"},
+					Input: "v1/create-request.json#", Output: "v1/create-response.json#", PutURL: true,
+				},
+			},
+		},
+	})
+	formatted, err := gen.Format()
+	assert.NoError(err)
+	source := string(formatted)
+
+	assert.Contains(source, "\tCreateDataUpload(thingId string, name string, payload []byte, filename string) ([]byte, error)\n")
+	assert.Contains(source, "func (c fakeClient) CreateDataUpload(thingId string, name string, payload []byte, filename string) ([]byte, error) {\n"+
+		"\treturn callUpload(context.Background(), c.conn, \"Fake\", \"createData\", map[string]string{\"thingId\": thingId, \"name\": name}, nil, payload, filename)\n}\n")
+}
+
+func TestGenerateStreamMethods(t *testing.T) {
+	assert := assert.New(t)
+
+	gen := &Generator{}
+	gen.Print("package apis\n\n")
+	gen.printInterfaces([]string{"Fake"}, map[string]definitions.Service{
+		"Fake": definitions.Service{
+			Entries: []definitions.Entry{
+				definitions.Entry{Name: "getData", Method: "get", Route: "/things/<thingId>/artifacts/<name>", Args: []string{"thingId", "name"}, Download: true},
+				definitions.Entry{Name: "putData", Method: "put", Route: "/things/<thingId>/artifacts/<name>", Args: []string{"thingId", "name"}, Upload: true},
+			},
+		},
+	})
+	formatted, err := gen.Format()
+	assert.NoError(err)
+	source := string(formatted)
+
+	assert.Contains(source, "\tGetDataStream(thingId string, name string) (io.ReadCloser, error)\n")
+	assert.Contains(source, "\tPutDataStream(thingId string, name string, body io.Reader) (io.ReadCloser, error)\n")
+	assert.Contains(source, "func (c fakeClient) GetDataStream(thingId string, name string) (io.ReadCloser, error) {\n"+
+		"\treturn callStream(context.Background(), c.conn, \"Fake\", \"getData\", map[string]string{\"thingId\": thingId, \"name\": name}, nil, nil)\n}\n")
+	assert.Contains(source, "\treturn callStream(context.Background(), c.conn, \"Fake\", \"putData\", map[string]string{\"thingId\": thingId, \"name\": name}, nil, body)\n")
+
+	// the fixture has no artifacts, so io is not imported
+	assert.NotContains(string(generateFixture(t, loadFixture(t))), "\t\"io\"\n")
+}
+
+// addEntry adds an entry to the fake service of the fixture.
+func addEntry(t *testing.T, refs *References, entry map[string]interface{}) {
+	for i := range refs.data {
+		if refs.data[i].Filename == "references/fake/v1/api.json" {
+			var doc map[string]interface{}
+			assert.NoError(t, json.Unmarshal(refs.data[i].Content, &doc))
+			doc["entries"] = append(doc["entries"].([]interface{}), entry)
+			data, err := json.Marshal(doc)
+			assert.NoError(t, err)
+			refs.data[i].Content = data
+		}
+	}
+}
+
+func TestGenerateMethodCollision(t *testing.T) {
+	assert := assert.New(t)
+
+	// list_things and listThings are both ListThings in Go
+	refs := loadFixture(t)
+	addEntry(t, refs, map[string]interface{}{
+		"type":   "function",
+		"name":   "list_things",
+		"method": "get",
+		"route":  "/things/all",
+		"args":   []string{},
+		"query":  []string{},
+	})
+	err := Generate(refs, &Generator{})
+	assert.EqualError(err, "/references/fake/v1/api.json: methods 'listThings' (GET /things) and 'list_things' (GET /things/all) "+
+		"of service 'fake' both generate the Go method ListThings")
+
+	// listThings supports signed URLs, so it has a ListThingsSignURL method
+	refs = loadFixture(t)
+	addEntry(t, refs, map[string]interface{}{
+		"type":   "function",
+		"name":   "listThingsSignURL",
+		"method": "post",
+		"route":  "/things/sign",
+		"args":   []string{},
+		"query":  []string{},
+	})
+	err = Generate(refs, &Generator{})
+	assert.Error(err)
+	assert.Contains(err.Error(), "both generate the Go method ListThingsSignURL")
+}
+
+func TestGenerateServiceCollision(t *testing.T) {
+	assert := assert.New(t)
+
+	// a second reference for a service named like the fake one
+	refs := loadFixture(t)
+	for i := range refs.data {
+		switch refs.data[i].Filename {
+		case "references/manifest.json":
+			var manifest map[string]interface{}
+			assert.NoError(json.Unmarshal(refs.data[i].Content, &manifest))
+			manifest["references"] = append(manifest["references"].([]interface{}), "/references/fake/v2/api.json")
+			data, err := json.Marshal(manifest)
+			assert.NoError(err)
+			refs.data[i].Content = data
+		case "references/fake/v1/api.json":
+			refs.data = append(refs.data, Reference{Filename: "references/fake/v2/api.json", Content: refs.data[i].Content})
+		}
+	}
+
+	err := Generate(refs, &Generator{})
+	assert.EqualError(err, "/references/fake/v1/api.json and /references/fake/v2/api.json both define the service Fake, after conversion to a Go name")
+}
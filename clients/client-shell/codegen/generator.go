@@ -3,16 +3,119 @@ package codegen
 import (
 	"bytes"
 	"fmt"
+	"go/ast"
 	"go/format"
+	"go/parser"
+	"go/token"
 	"reflect"
+	"runtime"
 	"sort"
+
+	"github.com/iancoleman/strcase"
+	"golang.org/x/tools/imports"
 )
 
-// Generator holds a buffer of the output that will be generated.
+// Generator holds a buffer of the output that will be generated, along with
+// options controlling what is generated.
 type Generator struct {
+	// TypedPayloads, if set, additionally generates Go types for the input
+	// and output schemas of every API entry, and typed service clients (such
+	// as apis.TypedQueue, returned by apis.NewTypedQueue) whose methods take
+	// and return them, marshalling and unmarshalling around the raw calls.
+	// The `api` commands send and receive raw JSON, and do not use them.
+	TypedPayloads bool
+	// ContextMethods, if set, generates the methods of the service clients
+	// (such as apis.Queue) with a leading `ctx context.Context` parameter,
+	// which aborts the request when cancelled.  This changes the signature of
+	// every method, so it must be asked for.
+	ContextMethods bool
+	// Workers is the number of API references processed concurrently; if
+	// zero, it defaults to GOMAXPROCS.  The output does not depend on it.
+	Workers int
+	// IncludeServices, if set, limits the generated services to those
+	// named; ExcludeServices, if set, omits the services named.  At most one
+	// of them can be set.  Services are named as in the references (e.g.
+	// `purge-cache`) or in camel case (e.g. `PurgeCache` or `purgeCache`).
+	IncludeServices []string
+	ExcludeServices []string
+	// Aliases, if set, generates commands for the former names of renamed
+	// API methods, which run the current methods with a deprecation
+	// warning.
+	Aliases Aliases
+	// EmitSmokeTests, if set, additionally generates SmokeTestFile, with a
+	// smoke test per API method which calls it on the deployment given by
+	// TASKCLUSTER_ROOT_URL when run with `-run Smoke`.  Generate sets
+	// SmokeTests to its source, and GenerateFiles returns it with the other
+	// files.
+	EmitSmokeTests bool
+	SmokeTests     []byte
+	// Warnings is set by Generate, and lists anything suspicious about the
+	// options, such as service names matching no service.
+	Warnings []string
+	// FormatOptions controls the formatting applied by Format, beyond that of
+	// `gofmt`.
+	FormatOptions FormatOptions
+
 	buf bytes.Buffer
 }
 
+// FormatOptions are the optional formatting passes of Generator.Format.
+type FormatOptions struct {
+	// Simplify simplifies the code as `gofmt -s` does, such as by omitting
+	// the types of the elements of composite literals.
+	Simplify bool
+	// GroupImports sorts the imports and groups them, with the standard
+	// library first, as goimports does.
+	GroupImports bool
+}
+
+// workers returns the number of API references to process concurrently.
+func (g *Generator) workers() int {
+	if g.Workers > 0 {
+		return g.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// checkServiceFilters checks that IncludeServices and ExcludeServices are not
+// both set.
+func (g *Generator) checkServiceFilters() error {
+	if len(g.IncludeServices) > 0 && len(g.ExcludeServices) > 0 {
+		return fmt.Errorf("only one of IncludeServices and ExcludeServices can be set")
+	}
+	return nil
+}
+
+// selectsService returns true if the service with the given camel-case name
+// is to be generated, according to IncludeServices and ExcludeServices.
+func (g *Generator) selectsService(camelName string) bool {
+	if len(g.IncludeServices) > 0 {
+		return containsService(g.IncludeServices, camelName)
+	}
+	return !containsService(g.ExcludeServices, camelName)
+}
+
+// warnUnknownServices adds a warning for each service named in
+// IncludeServices or ExcludeServices that is not among the known services.
+func (g *Generator) warnUnknownServices(known map[string]bool) {
+	for _, names := range [][]string{g.IncludeServices, g.ExcludeServices} {
+		for _, name := range names {
+			if !known[strcase.ToCamel(name)] {
+				g.Warnings = append(g.Warnings, fmt.Sprintf("unknown service '%s' in the service filters", name))
+			}
+		}
+	}
+}
+
+func containsService(names []string, camelName string) bool {
+	for _, name := range names {
+		if strcase.ToCamel(name) == camelName {
+			return true
+		}
+	}
+	return false
+}
+
 // Write writes arbitrary bytes to the buffer. This meets the requirements for
 // the io.Writer interface.
 func (g *Generator) Write(p []byte) (n int, err error) {
@@ -33,7 +136,8 @@ func (g *Generator) Print(a ...interface{}) {
 //
 // There are special rules for some composite types to ensure we have verbose
 // output, but simple types such as strings and numbers are printed using the
-// built-in `%#v` format filter.
+// built-in `%#v` format filter.  Slice elements which have documentation (see
+// commentFor) are preceded by a comment.
 func (g *Generator) PrettyPrint(data interface{}) {
 	v := reflect.ValueOf(data)
 	t := v.Type()
@@ -47,6 +151,7 @@ func (g *Generator) PrettyPrint(data interface{}) {
 		}
 		g.Print("{\n")
 		for i := 0; i < v.Len(); i++ {
+			g.Print(commentFor(v.Index(i).Interface()))
 			g.PrettyPrint(v.Index(i).Interface())
 			g.Print(",\n")
 		}
@@ -82,9 +187,11 @@ func (g *Generator) PrettyPrint(data interface{}) {
 				g.Print(",\n")
 			}
 		} else {
-			// If the keys are not strings, we don't sort them for now.
-			for i := 0; i < v.Len(); i++ {
-				k := keys[i]
+			// Otherwise, sort by the keys' printed representation.
+			sort.Slice(keys, func(i, j int) bool {
+				return fmt.Sprintf("%#v", keys[i].Interface()) < fmt.Sprintf("%#v", keys[j].Interface())
+			})
+			for _, k := range keys {
 				g.Printf("%#v: ", k)
 				g.PrettyPrint(v.MapIndex(k).Interface())
 				g.Print(",\n")
@@ -96,9 +203,35 @@ func (g *Generator) PrettyPrint(data interface{}) {
 	}
 }
 
-// Format returns the formated contents of the Generator's buffer.
+// Format returns the formated contents of the Generator's buffer, with the
+// passes selected by FormatOptions.  If the contents cannot be parsed, it
+// returns them unformatted, along with the error, so that they can be
+// inspected.
 func (g *Generator) Format() ([]byte, error) {
-	return format.Source(g.buf.Bytes())
+	source := g.buf.Bytes()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "services.go", source, parser.ParseComments)
+	if err != nil {
+		return append([]byte(nil), source...), err
+	}
+	ast.SortImports(fset, file)
+	if g.FormatOptions.Simplify {
+		simplify(file)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := format.Node(buf, fset, file); err != nil {
+		return append([]byte(nil), source...), err
+	}
+	if !g.FormatOptions.GroupImports {
+		return buf.Bytes(), nil
+	}
+	return imports.Process("services.go", buf.Bytes(), &imports.Options{
+		FormatOnly: true,
+		Comments:   true,
+		TabIndent:  true,
+		TabWidth:   8,
+	})
 }
 
 // String returns a string representation of the Generator's buffer.
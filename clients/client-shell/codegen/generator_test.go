@@ -0,0 +1,76 @@
+package codegen
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+const unformatted = `package apis
+
+import (
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+	"time"
+	"context"
+)
+
+var entries = map[string][]definitions.Entry{
+	"a": []definitions.Entry{definitions.Entry{Name: "a"}},
+}
+
+var pointers = []*definitions.Entry{&definitions.Entry{Name: "b"}}
+
+func f(ctx context.Context, s []string, d time.Duration) []string {
+	for _ = range s {
+	}
+	for i, _ := range s {
+		_ = i
+	}
+	return s[1:len(s)]
+}
+`
+
+func formatSource(t *testing.T, options FormatOptions, source string) string {
+	gen := &Generator{FormatOptions: options}
+	gen.Print(source)
+	formatted, err := gen.Format()
+	assert.NoError(t, err)
+	return string(formatted)
+}
+
+func TestFormatDefault(t *testing.T) {
+	assert := assert.New(t)
+
+	source := formatSource(t, FormatOptions{}, unformatted)
+	assert.Contains(source, "\t\"context\"\n\t\"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions\"\n\t\"time\"\n")
+	assert.Contains(source, `"a": []definitions.Entry{definitions.Entry{Name: "a"}},`)
+	assert.Contains(source, "return s[1:len(s)]")
+}
+
+func TestFormatSimplify(t *testing.T) {
+	assert := assert.New(t)
+
+	source := formatSource(t, FormatOptions{Simplify: true}, unformatted)
+	assert.Contains(source, `"a": {{Name: "a"}},`)
+	assert.Contains(source, `var pointers = []*definitions.Entry{{Name: "b"}}`)
+	assert.Contains(source, "\tfor range s {\n")
+	assert.Contains(source, "\tfor i := range s {\n")
+	assert.Contains(source, "return s[1:]")
+}
+
+func TestFormatGroupImports(t *testing.T) {
+	assert := assert.New(t)
+
+	source := formatSource(t, FormatOptions{GroupImports: true}, unformatted)
+	assert.Contains(source, "import (\n\t\"context\"\n\t\"time\"\n\n\t\"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions\"\n)\n")
+}
+
+func TestFormatInvalidSource(t *testing.T) {
+	assert := assert.New(t)
+
+	gen := &Generator{}
+	gen.Print("package apis\n\nvar x = {\n")
+	source, err := gen.Format()
+	assert.Error(err)
+	assert.Equal("package apis\n\nvar x = {\n", string(source))
+}
@@ -0,0 +1,301 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+
+	"github.com/iancoleman/strcase"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// printInterfaces prints, for each of the named services, an interface with
+// a method per API entry, an unexported struct implementing it by calling
+// the entry, and constructors returning the interface: one using the
+// configured root URL and credentials, and one reading them from the
+// environment.  Code depending on the interface can then be given a fake in
+// its tests.
+func (g *Generator) printInterfaces(names []string, services map[string]definitions.Service) {
+	for _, name := range names {
+		svc := services[name]
+		impl := strcase.ToLowerCamel(name) + "Client"
+
+		g.Print(formatComment(fmt.Sprintf(
+			"%s is the interface of the methods of the %s service, as implemented by the client returned by New%s.",
+			name, name, name,
+		)))
+		g.Printf("type %s interface {\n", name)
+		for _, entry := range svc.Entries {
+			heading := entry.Name
+			if entry.Title != "" {
+				heading += ": " + entry.Title
+			}
+			g.Print(formatComment(fmt.Sprintf("%s calls %s", identifier(entry.Name), heading)))
+			g.Printf("%s(%s) ([]byte, error)\n", identifier(entry.Name), g.methodParams(entry))
+			if entry.SignedURL {
+				g.Print(formatComment(fmt.Sprintf(
+					"%sSignURL returns a URL for %s, signed with the credentials and valid for the given duration, without calling it",
+					identifier(entry.Name), entry.Name,
+				)))
+				g.Printf("%sSignURL(%s) (string, error)\n", identifier(entry.Name), signURLParams(entry))
+			}
+			if entry.Download || entry.Upload {
+				g.Print(formatComment(streamComment(entry)))
+				g.Printf("%sStream(%s) (io.ReadCloser, error)\n", identifier(entry.Name), g.streamParams(entry))
+			}
+			if entry.PutURL {
+				g.Print(formatComment(fmt.Sprintf(
+					"%sUpload calls %s, then uploads the content of the named file to the putUrl of the response, with the Content-Type of the payload (which is detected from the file if not given).",
+					identifier(entry.Name), entry.Name,
+				)))
+				g.Printf("%sUpload(%s) ([]byte, error)\n", identifier(entry.Name), g.uploadParams(entry))
+			}
+		}
+		g.Print("}\n\n")
+
+		g.Printf("type %s struct {\nconn *connection\n}\n\n", impl)
+		g.Print(formatComment(fmt.Sprintf(
+			"New%s returns a client for the %s service, using the configured root URL and credentials.",
+			name, name,
+		)))
+		g.Printf("func New%s() %s {\n", name, name)
+		g.Printf("return %s{}\n", impl)
+		g.Print("}\n\n")
+		g.Print(formatComment(fmt.Sprintf(
+			"New%sFromEnv returns a client for the %s service, using the root URL and credentials given by "+
+				"TASKCLUSTER_ROOT_URL, TASKCLUSTER_CLIENT_ID, TASKCLUSTER_ACCESS_TOKEN and, for temporary credentials, "+
+				"TASKCLUSTER_CERTIFICATE. The error lists the variables which are missing, if any.",
+			name, name,
+		)))
+		g.Printf("func New%sFromEnv() (%s, error) {\n", name, name)
+		g.Print("conn, err := connectionFromEnv()\nif err != nil {\nreturn nil, err\n}\n")
+		g.Printf("return %s{conn: conn}, nil\n", impl)
+		g.Print("}\n\n")
+
+		for _, entry := range svc.Entries {
+			g.Printf("func (c %s) %s(%s) ([]byte, error) {\n", impl, identifier(entry.Name), g.methodParams(entry))
+			g.Printf(
+				"return call(%s, c.conn, %q, %q, %s, %s, %s)\n",
+				g.ctxArg(), name, entry.Name, argsMap(entry), queryParam(entry), payloadParam(entry),
+			)
+			g.Print("}\n\n")
+
+			if entry.SignedURL {
+				g.Printf("func (c %s) %sSignURL(%s) (string, error) {\n", impl, identifier(entry.Name), signURLParams(entry))
+				g.Printf("return signURL(c.conn, %q, %q, duration, %s, %s)\n", name, entry.Name, argsMap(entry), queryParam(entry))
+				g.Print("}\n\n")
+			}
+
+			if entry.Download || entry.Upload {
+				g.Printf("func (c %s) %sStream(%s) (io.ReadCloser, error) {\n", impl, identifier(entry.Name), g.streamParams(entry))
+				g.Printf(
+					"return callStream(%s, c.conn, %q, %q, %s, %s, %s)\n",
+					g.ctxArg(), name, entry.Name, argsMap(entry), queryParam(entry), bodyParam(entry),
+				)
+				g.Print("}\n\n")
+			}
+
+			if entry.PutURL {
+				g.Printf("func (c %s) %sUpload(%s) ([]byte, error) {\n", impl, identifier(entry.Name), g.uploadParams(entry))
+				g.Printf(
+					"return callUpload(%s, c.conn, %q, %q, %s, %s, %s, filename)\n",
+					g.ctxArg(), name, entry.Name, argsMap(entry), queryParam(entry), payloadParam(entry),
+				)
+				g.Print("}\n\n")
+			}
+		}
+	}
+}
+
+// usesSignedURLs returns true if any of the services has an entry with a
+// SignURL method, which takes a time.Duration.
+func usesSignedURLs(services map[string]definitions.Service) bool {
+	for _, svc := range services {
+		for _, entry := range svc.Entries {
+			if entry.SignedURL {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// usesStreams returns true if any of the services has an entry with a Stream
+// method, which uses io.
+func usesStreams(services map[string]definitions.Service) bool {
+	for _, svc := range services {
+		for _, entry := range svc.Entries {
+			if entry.Download || entry.Upload {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// methodParams returns the parameter list of the method for an entry: the
+// context if ContextMethods is set, its URL arguments, then the query-string
+// parameters and the payload, if any.
+func (g *Generator) methodParams(entry definitions.Entry) string {
+	params := make([]string, 0, len(entry.Args)+3)
+	if g.ContextMethods {
+		params = append(params, "ctx context.Context")
+	}
+	for _, name := range paramNames(entry) {
+		params = append(params, name+" string")
+	}
+	if len(entry.Query) > 0 {
+		params = append(params, "query map[string]string")
+	}
+	if entry.Input != "" {
+		params = append(params, "payload []byte")
+	}
+	return strings.Join(params, ", ")
+}
+
+// signURLParams returns the parameter list of the SignURL method for an
+// entry: the duration for which the URL is valid, then its URL arguments and
+// the query-string parameters, if any.
+func signURLParams(entry definitions.Entry) string {
+	params := make([]string, 0, len(entry.Args)+2)
+	params = append(params, "duration time.Duration")
+	for _, name := range paramNames(entry) {
+		params = append(params, name+" string")
+	}
+	if len(entry.Query) > 0 {
+		params = append(params, "query map[string]string")
+	}
+	return strings.Join(params, ", ")
+}
+
+// streamParams returns the parameter list of the Stream method for an entry:
+// that of its method, without the payload, and with the body to upload, for
+// Upload entries.
+func (g *Generator) streamParams(entry definitions.Entry) string {
+	entry.Input = ""
+	params := g.methodParams(entry)
+	if entry.Upload {
+		if params != "" {
+			params += ", "
+		}
+		params += "body io.Reader"
+	}
+	return params
+}
+
+// uploadParams returns the parameter list of the Upload method for an entry:
+// that of its method, and the name of the file to upload.
+func (g *Generator) uploadParams(entry definitions.Entry) string {
+	params := g.methodParams(entry)
+	if params != "" {
+		params += ", "
+	}
+	return params + "filename string"
+}
+
+// streamComment returns the comment of the Stream method for an entry.
+func streamComment(entry definitions.Entry) string {
+	if entry.Upload {
+		return fmt.Sprintf(
+			"%sStream calls %s, streaming the content of the artifact from body; the caller must close the response body.",
+			identifier(entry.Name), entry.Name,
+		)
+	}
+	return fmt.Sprintf(
+		"%sStream calls %s, returning the content of the artifact as a stream, which the caller must close.",
+		identifier(entry.Name), entry.Name,
+	)
+}
+
+// ctxArg returns the context to pass to call.
+func (g *Generator) ctxArg() string {
+	if g.ContextMethods {
+		return "ctx"
+	}
+	return "context.Background()"
+}
+
+// argsMap returns an expression for the map of the entry's URL arguments.
+func argsMap(entry definitions.Entry) string {
+	if len(entry.Args) == 0 {
+		return "nil"
+	}
+	pairs := make([]string, 0, len(entry.Args))
+	for i, name := range paramNames(entry) {
+		pairs = append(pairs, fmt.Sprintf("%q: %s", entry.Args[i], name))
+	}
+	return "map[string]string{" + strings.Join(pairs, ", ") + "}"
+}
+
+func queryParam(entry definitions.Entry) string {
+	if len(entry.Query) == 0 {
+		return "nil"
+	}
+	return "query"
+}
+
+func bodyParam(entry definitions.Entry) string {
+	if !entry.Upload {
+		return "nil"
+	}
+	return "body"
+}
+
+func payloadParam(entry definitions.Entry) string {
+	if entry.Input == "" {
+		return "nil"
+	}
+	return "payload"
+}
+
+// reservedNames are the names which a parameter for a URL argument must not
+// take, besides Go keywords and predeclared identifiers such as `string`:
+// those of the other parameters, and the package-level names, imported
+// packages and local variables used in the signatures and bodies of the
+// generated methods and binding functions, including the receiver `c` of the
+// methods.
+var reservedNames = map[string]bool{
+	"c": true, "ctx": true, "duration": true, "query": true, "payload": true, "body": true, "filename": true,
+	"result": true, "err": true,
+	"call": true, "callStream": true, "callUpload": true, "callTyped": true, "signURL": true, "bindingFor": true,
+	"context": true, "io": true, "time": true, "definitions": true,
+}
+
+// paramName returns the name of the parameter for a URL argument: the
+// argument without any characters not allowed in Go identifiers, suffixed with
+// `Arg` if it is a Go keyword, a predeclared identifier or one of
+// reservedNames.
+func paramName(arg string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, arg)
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "arg" + name
+	}
+	if token.Lookup(name).IsKeyword() || types.Universe.Lookup(name) != nil || reservedNames[name] {
+		return name + "Arg"
+	}
+	return name
+}
+
+// paramNames returns the names of the parameters for the URL arguments of an
+// entry, as given by paramName, further suffixed with `Arg` where two of them
+// would otherwise have the same name (such as `type` and `typeArg`).
+func paramNames(entry definitions.Entry) []string {
+	names := make([]string, 0, len(entry.Args))
+	seen := make(map[string]bool, len(entry.Args))
+	for _, arg := range entry.Args {
+		name := paramName(arg)
+		for seen[name] {
+			name += "Arg"
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
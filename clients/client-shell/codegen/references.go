@@ -1,11 +1,34 @@
 package codegen
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
 )
 
+// DefaultReferencesPath is the location of `generated/references.json`,
+// relative to the `apis` directory where `go generate` runs.
+const DefaultReferencesPath = "../../../generated/references.json"
+
+// referencesSchema is the JSON schema that a references document must
+// satisfy before we attempt to generate anything from it.
+const referencesSchema = `{
+	"type": "array",
+	"items": {
+		"type": "object",
+		"properties": {
+			"filename": {"type": "string", "minLength": 1},
+			"content": {"type": "object"}
+		},
+		"required": ["filename", "content"]
+	}
+}`
+
 // References represents `generated/references.json`
 type References struct {
 	data []Reference
@@ -17,14 +40,66 @@ type Reference struct {
 	Filename string          `json:"filename"`
 }
 
+// LoadReferences loads the bundled `generated/references.json`.
 func LoadReferences() (*References, error) {
-	file, err := ioutil.ReadFile("../../../generated/references.json")
+	return LoadReferencesFrom(DefaultReferencesPath)
+}
+
+// LoadReferencesFrom loads a references document from the given local file.
+func LoadReferencesFrom(path string) (*References, error) {
+	file, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseReferences(file)
+}
+
+// LoadReferencesFromURL fetches a references document from the given URL.
+// The request is aborted if ctx is cancelled or its deadline expires.
+func LoadReferencesFromURL(ctx context.Context, url string) (*References, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("fetching %s returned status %s", url, res.Status)
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
 		return nil, err
 	}
 
+	return parseReferences(body)
+}
+
+// parseReferences validates data against referencesSchema and decodes it.
+func parseReferences(data []byte) (*References, error) {
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewStringLoader(referencesSchema),
+		gojsonschema.NewBytesLoader(data),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("references document is not valid JSON: %s", err)
+	}
+	if !result.Valid() {
+		problems := make([]string, 0, len(result.Errors()))
+		for _, desc := range result.Errors() {
+			problems = append(problems, desc.String())
+		}
+		return nil, fmt.Errorf("references document failed schema validation:\n  %s", strings.Join(problems, "\n  "))
+	}
+
 	r := &References{}
-	err = json.Unmarshal([]byte(file), &r.data)
+	err = json.Unmarshal(data, &r.data)
 	if err != nil {
 		return nil, err
 	}
@@ -33,9 +108,7 @@ func LoadReferences() (*References, error) {
 }
 
 func (r *References) get(filename string, v interface{}) error {
-	if filename[0] == '/' {
-		filename = filename[1:]
-	}
+	filename = strings.TrimPrefix(filename, "/")
 	for _, ref := range r.data {
 		if ref.Filename == filename {
 			return json.Unmarshal(ref.Content, v)
@@ -0,0 +1,81 @@
+package codegen
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+const fixturePath = "testdata/references.json"
+
+func TestLoadReferencesFrom(t *testing.T) {
+	assert := assert.New(t)
+
+	refs, err := LoadReferencesFrom(fixturePath)
+	assert.NoError(err)
+
+	var m manifest
+	assert.NoError(refs.get("/references/manifest.json", &m))
+	assert.Equal([]string{"/references/fake/v1/api.json", "/references/other/v1/api.json", "/references/fake/v1/exchanges.json"}, m.References)
+}
+
+func TestLoadReferencesFromInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "codegen")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "references.json")
+
+	// well-formed JSON that doesn't match the expected shape
+	assert.NoError(ioutil.WriteFile(path, []byte(`[{"filename": "", "content": 42}]`), 0644))
+	_, err = LoadReferencesFrom(path)
+	assert.Error(err)
+	assert.Contains(err.Error(), "failed schema validation")
+
+	// not JSON at all
+	assert.NoError(ioutil.WriteFile(path, []byte(`[{`), 0644))
+	_, err = LoadReferencesFrom(path)
+	assert.Error(err)
+	assert.Contains(err.Error(), "not valid JSON")
+}
+
+func TestLoadReferencesFromURL(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, fixturePath)
+	}))
+	defer server.Close()
+
+	refs, err := LoadReferencesFromURL(context.Background(), server.URL)
+	assert.NoError(err)
+
+	var m manifest
+	assert.NoError(refs.get("references/manifest.json", &m))
+	assert.Len(m.References, 3)
+}
+
+func TestLoadReferencesFromURLTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := LoadReferencesFromURL(ctx, server.URL)
+	assert.Error(err)
+}
@@ -0,0 +1,80 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// collectSchemas finds the schema documents of the payloads and responses of
+// the entries of a service: each of the given input and output schemas, and
+// every schema it refers to, directly or indirectly.  They are returned compacted, by absolute path (such
+// as `/schemas/queue/v1/create-task-request.json`), which is also how the
+// schemas refer to one another.
+func collectSchemas(references *References, serviceName string, payloads []string) (map[string]string, error) {
+	schemas := map[string]string{}
+
+	var add func(file string) error
+	add = func(file string) error {
+		if _, ok := schemas["/"+file]; ok {
+			return nil
+		}
+
+		var content json.RawMessage
+		if err := references.get(file, &content); err != nil {
+			return err
+		}
+		buf := &bytes.Buffer{}
+		if err := json.Compact(buf, content); err != nil {
+			return err
+		}
+		schemas["/"+file] = buf.String()
+
+		var doc interface{}
+		if err := json.Unmarshal(content, &doc); err != nil {
+			return err
+		}
+		for _, ref := range schemaRefs(doc) {
+			// references to external documents, such as the JSON schema
+			// meta-schemas, are not needed for validation
+			if strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://") {
+				continue
+			}
+			refFile, _ := resolveRef(file, ref)
+			if err := add(refFile); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, payload := range payloads {
+		if payload == "" {
+			continue
+		}
+		file := strings.SplitN("schemas/"+serviceName+"/"+payload, "#", 2)[0]
+		if err := add(file); err != nil {
+			return nil, err
+		}
+	}
+	return schemas, nil
+}
+
+// schemaRefs returns the values of all of the `$ref` properties in doc.
+func schemaRefs(doc interface{}) []string {
+	var refs []string
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		if ref, ok := node["$ref"].(string); ok {
+			refs = append(refs, ref)
+		}
+		for _, value := range node {
+			refs = append(refs, schemaRefs(value)...)
+		}
+	case []interface{}:
+		for _, value := range node {
+			refs = append(refs, schemaRefs(value)...)
+		}
+	}
+	return refs
+}
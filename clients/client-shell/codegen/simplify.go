@@ -0,0 +1,110 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// simplify applies the simplifications of `gofmt -s` to file: it omits the
+// types of elements of composite literals where they are implied, the high
+// bound of slice expressions `s[a:len(s)]`, and blank variables of range
+// clauses.
+func simplify(file *ast.File) {
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.CompositeLit:
+			simplifyCompositeLit(n, n.Type)
+		case *ast.SliceExpr:
+			if call, ok := n.High.(*ast.CallExpr); ok && !n.Slice3 && isLenOf(call, n.X) {
+				n.High = nil
+			}
+		case *ast.RangeStmt:
+			if isBlank(n.Value) {
+				n.Value = nil
+			}
+			if isBlank(n.Key) && n.Value == nil {
+				n.Key = nil
+				n.Tok = token.ILLEGAL
+			}
+		}
+		return true
+	})
+}
+
+// simplifyCompositeLit omits the type of the elements (and, for maps, keys)
+// of a composite literal of type typ, an array, slice or map type, where they
+// are composite literals of the element type, or pointers to them.  The type
+// of lit itself may already be omitted.
+func simplifyCompositeLit(lit *ast.CompositeLit, typ ast.Expr) {
+	var keyType, elemType ast.Expr
+	switch t := typ.(type) {
+	case *ast.ArrayType:
+		elemType = t.Elt
+	case *ast.MapType:
+		keyType = t.Key
+		elemType = t.Value
+	default:
+		return
+	}
+
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if keyType != nil {
+				kv.Key = simplifyElement(kv.Key, keyType)
+			}
+			kv.Value = simplifyElement(kv.Value, elemType)
+			continue
+		}
+		lit.Elts = replaceExpr(lit.Elts, elt, simplifyElement(elt, elemType))
+	}
+}
+
+// simplifyElement returns elt without its type, if it is implied by typ,
+// simplifying its own elements first, while its type is known.
+func simplifyElement(elt, typ ast.Expr) ast.Expr {
+	if inner, ok := elt.(*ast.CompositeLit); ok && sameType(inner.Type, typ) {
+		simplifyCompositeLit(inner, inner.Type)
+		inner.Type = nil
+		return inner
+	}
+	if star, ok := typ.(*ast.StarExpr); ok {
+		if addr, ok := elt.(*ast.UnaryExpr); ok && addr.Op == token.AND {
+			if inner, ok := addr.X.(*ast.CompositeLit); ok && sameType(inner.Type, star.X) {
+				simplifyCompositeLit(inner, inner.Type)
+				inner.Type = nil
+				return inner
+			}
+		}
+	}
+	return elt
+}
+
+func replaceExpr(exprs []ast.Expr, old, new ast.Expr) []ast.Expr {
+	for i := range exprs {
+		if exprs[i] == old {
+			exprs[i] = new
+		}
+	}
+	return exprs
+}
+
+func sameType(a, b ast.Expr) bool {
+	return a != nil && b != nil && types.ExprString(a) == types.ExprString(b)
+}
+
+// isLenOf returns true if call is `len(x)`, for an identifier x.
+func isLenOf(call *ast.CallExpr, x ast.Expr) bool {
+	fun, ok := call.Fun.(*ast.Ident)
+	if !ok || fun.Name != "len" || len(call.Args) != 1 {
+		return false
+	}
+	arg, ok1 := call.Args[0].(*ast.Ident)
+	id, ok2 := x.(*ast.Ident)
+	return ok1 && ok2 && arg.Name == id.Name
+}
+
+func isBlank(expr ast.Expr) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == "_"
+}
@@ -0,0 +1,229 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// SmokeTestFile is the file generated alongside the services when
+// Generator.EmitSmokeTests is set.
+const SmokeTestFile = "services_smoke_test.go"
+
+// maxPayloadDepth bounds the nesting of the payloads built by minimalValue,
+// so that recursive schemas fail rather than loop.
+const maxPayloadDepth = 16
+
+// sideEffectFreeMethods lists, as service.method, the API methods which are
+// not GET requests but only read, such as those taking a list of scopes to
+// check; their smoke tests call them like GET methods, with a minimal payload.
+var sideEffectFreeMethods = map[string]bool{
+	"auth.authenticateHawk": true,
+	"auth.expandScopes":     true,
+	"auth.testAuthenticate": true,
+}
+
+// smokeHelpers are the functions used by the generated smoke tests.
+const smokeHelpers = `// smokeTest skips the test unless TASKCLUSTER_ROOT_URL is set and the smoke
+// tests are selected with -run Smoke, then uses the root URL, and the
+// credentials given by TASKCLUSTER_CLIENT_ID and TASKCLUSTER_ACCESS_TOKEN if
+// any.
+func smokeTest(t *testing.T) {
+	t.Helper()
+	rootURL := os.Getenv("TASKCLUSTER_ROOT_URL")
+	if rootURL == "" {
+		t.Skip("TASKCLUSTER_ROOT_URL is not set")
+	}
+	if run := flag.Lookup("test.run"); run == nil || !strings.Contains(run.Value.String(), "Smoke") {
+		t.Skip("smoke tests only run with -run Smoke")
+	}
+	config.SetRootURL(rootURL)
+	config.Credentials = nil
+	if clientID := os.Getenv("TASKCLUSTER_CLIENT_ID"); clientID != "" {
+		config.Credentials = &client.Credentials{
+			ClientID:    clientID,
+			AccessToken: os.Getenv("TASKCLUSTER_ACCESS_TOKEN"),
+			Certificate: os.Getenv("TASKCLUSTER_CERTIFICATE"),
+		}
+	}
+}
+
+// checkSmokeResponse fails the test if the call failed, or if its response
+// is not JSON.
+func checkSmokeResponse(t *testing.T, res []byte, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(res, &value); err != nil {
+		t.Fatalf("response does not parse: %s", err)
+	}
+}
+`
+
+// generateSmokeTests renders SmokeTestFile for the selected services: a test
+// per API method, named `TestSmoke<Service><Method>`, which is skipped unless
+// TASKCLUSTER_ROOT_URL is set and the tests are selected with `-run Smoke`.
+// The tests of GET methods, and of those in sideEffectFreeMethods, which take
+// no arguments (for which there is no safe default), call them with a minimal
+// payload built from the input schema if any, and check that the response
+// parses; the others are skipped with the reason.
+func (out *generated) generateSmokeTests(gen *Generator) ([]byte, error) {
+	file := &Generator{ContextMethods: gen.ContextMethods, FormatOptions: gen.FormatOptions}
+	file.Print(generatedHeader + "\n\n")
+	file.Print("package apis\n\n")
+	file.Print("import (\n")
+	if gen.ContextMethods {
+		file.Print("\t\"context\"\n")
+	}
+	file.Print("\t\"encoding/json\"\n\t\"flag\"\n\t\"os\"\n\t\"strings\"\n\t\"testing\"\n\n")
+	file.Print("\t\"github.com/taskcluster/taskcluster/v31/clients/client-shell/client\"\n")
+	file.Print("\t\"github.com/taskcluster/taskcluster/v31/clients/client-shell/config\"\n")
+	file.Print(")\n\n")
+
+	_, _ = file.Write([]byte(smokeHelpers))
+
+	for _, name := range sortedNames(out.rendered) {
+		svc := out.selected[name]
+		for _, entry := range svc.Entries {
+			call, skip := out.smokeCall(gen, name, svc.ServiceName, entry)
+			file.Printf("\nfunc TestSmoke%s%s(t *testing.T) {\n", name, identifier(entry.Name))
+			file.Print("smokeTest(t)\n")
+			if skip != "" {
+				file.Printf("t.Skip(%q)\n", skip)
+			} else {
+				file.Printf("res, err := %s\n", call)
+				file.Print("checkSmokeResponse(t, res, err)\n")
+			}
+			file.Print("}\n")
+		}
+	}
+	return file.Format()
+}
+
+// smokeCall returns the call of the client method for entry made by its smoke
+// test, or the reason the test is skipped.
+func (out *generated) smokeCall(gen *Generator, name, serviceName string, entry definitions.Entry) (string, string) {
+	switch {
+	case entry.Method != "get" && !sideEffectFreeMethods[serviceName+"."+entry.Name]:
+		return "", fmt.Sprintf("%s is a %s request, which may have side effects", entry.Name, strings.ToUpper(entry.Method))
+	case len(entry.Args) > 0:
+		return "", fmt.Sprintf("%s needs the %s arguments, which have no safe defaults", entry.Name, strings.Join(entry.Args, ", "))
+	case entry.Download || entry.Upload:
+		return "", fmt.Sprintf("%s streams artifact content, which is not JSON", entry.Name)
+	}
+
+	var args []string
+	if gen.ContextMethods {
+		args = append(args, "context.Background()")
+	}
+	if len(entry.Query) > 0 {
+		args = append(args, "nil")
+	}
+	if entry.Input != "" {
+		file := "schemas/" + serviceName + "/" + strings.SplitN(entry.Input, "#", 2)[0]
+		schema, err := out.types.resolve(file, "")
+		if err != nil {
+			return "", fmt.Sprintf("the input schema of %s cannot be read: %s", entry.Name, err)
+		}
+		payload, err := out.types.minimalValue(file, schema, "payload", 0)
+		if err != nil {
+			return "", fmt.Sprintf("no minimal payload for %s is known to be valid: %s", entry.Name, err)
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return "", fmt.Sprintf("no minimal payload for %s is known to be valid: %s", entry.Name, err)
+		}
+		args = append(args, fmt.Sprintf("[]byte(%q)", data))
+	}
+	return fmt.Sprintf("New%s().%s(%s)", name, identifier(entry.Name), strings.Join(args, ", ")), ""
+}
+
+// minimalValue returns the smallest value valid against schema, which appears
+// in file and is found at path of the payload: objects have only their
+// required properties, lists are empty, and scalars are zero, their default,
+// or the first of their enum.  It fails for the schemas for which no such
+// value is known to be valid, such as strings with a format or a pattern.
+func (tg *typeGenerator) minimalValue(file string, schema map[string]interface{}, path string, depth int) (interface{}, error) {
+	if depth > maxPayloadDepth {
+		return nil, fmt.Errorf("%s: the schema is recursive", path)
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		refFile, pointer := resolveRef(file, ref)
+		resolved, err := tg.resolve(refFile, pointer)
+		if err != nil {
+			return nil, err
+		}
+		return tg.minimalValue(refFile, resolved, path, depth+1)
+	}
+	if value, ok := schema["default"]; ok {
+		return value, nil
+	}
+	if values, ok := schema["enum"].([]interface{}); ok && len(values) > 0 {
+		return values[0], nil
+	}
+	for _, combinator := range []string{"oneOf", "anyOf"} {
+		if alternatives, ok := schema[combinator].([]interface{}); ok && len(alternatives) > 0 {
+			if first, ok := alternatives[0].(map[string]interface{}); ok {
+				return tg.minimalValue(file, first, path, depth+1)
+			}
+		}
+	}
+
+	typ := schema["type"]
+	if types, ok := typ.([]interface{}); ok && len(types) > 0 {
+		typ = types[0]
+	}
+	switch typ {
+	case "object":
+		value := map[string]interface{}{}
+		properties, _ := schema["properties"].(map[string]interface{})
+		required, _ := schema["required"].([]interface{})
+		for _, r := range required {
+			prop, _ := r.(string)
+			propSchema, ok := properties[prop].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s.%s: the required property has no schema", path, prop)
+			}
+			propValue, err := tg.minimalValue(file, propSchema, path+"."+prop, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			value[prop] = propValue
+		}
+		return value, nil
+	case "array":
+		if minItems, ok := schema["minItems"].(float64); ok && minItems > 0 {
+			return nil, fmt.Errorf("%s: the list cannot be empty", path)
+		}
+		return []interface{}{}, nil
+	case "string":
+		for _, constraint := range []string{"format", "pattern", "minLength"} {
+			if _, ok := schema[constraint]; ok {
+				return nil, fmt.Errorf("%s: the string has a %s", path, constraint)
+			}
+		}
+		return "", nil
+	case "integer", "number":
+		for _, constraint := range []string{"exclusiveMinimum", "exclusiveMaximum", "multipleOf"} {
+			if _, ok := schema[constraint]; ok {
+				return nil, fmt.Errorf("%s: the number has %s", path, constraint)
+			}
+		}
+		if minimum, ok := schema["minimum"].(float64); ok && minimum > 0 {
+			return minimum, nil
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && maximum < 0 {
+			return maximum, nil
+		}
+		return 0, nil
+	case "boolean":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	return nil, fmt.Errorf("%s: the schema has no type", path)
+}
@@ -0,0 +1,162 @@
+package codegen
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+func TestGenerateSmokeTests(t *testing.T) {
+	assert := assert.New(t)
+
+	gen := &Generator{EmitSmokeTests: true}
+	assert.NoError(Generate(loadFixture(t), gen))
+	_, err := parser.ParseFile(token.NewFileSet(), SmokeTestFile, gen.SmokeTests, parser.AllErrors)
+	assert.NoError(err)
+	source := string(gen.SmokeTests)
+	assert.Contains(source, generatedHeader)
+	assert.Contains(source, "func smokeTest(t *testing.T) {")
+
+	// methods without side effects or arguments are called
+	assert.Contains(source, "func TestSmokeFakePing(t *testing.T) {\n\tsmokeTest(t)\n\tres, err := NewFake().Ping()\n\tcheckSmokeResponse(t, res, err)\n}\n")
+	assert.Contains(source, "\tres, err := NewFake().ListThings(nil)\n")
+	assert.Contains(source, "func TestSmokeOtherPing(t *testing.T) {")
+
+	// and the others are skipped with the reason
+	assert.Contains(source, "func TestSmokeFakeCreateThing(t *testing.T) {\n\tsmokeTest(t)\n\tt.Skip(\"createThing is a PUT request, which may have side effects\")\n}\n")
+	assert.Contains(source, "t.Skip(\"reset is a POST request, which may have side effects\")")
+
+	// the main output is the same as without the smoke tests
+	formatted, err := gen.Format()
+	assert.NoError(err)
+	assert.Equal(string(generateFixture(t, loadFixture(t))), string(formatted))
+}
+
+func TestGenerateSmokeTestsWithContext(t *testing.T) {
+	assert := assert.New(t)
+
+	gen := &Generator{EmitSmokeTests: true, ContextMethods: true}
+	assert.NoError(Generate(loadFixture(t), gen))
+	source := string(gen.SmokeTests)
+	assert.Contains(source, "\t\"context\"\n")
+	assert.Contains(source, "\tres, err := NewFake().ListThings(context.Background(), nil)\n")
+}
+
+func TestGenerateFilesSmokeTests(t *testing.T) {
+	assert := assert.New(t)
+
+	files, err := GenerateFiles(loadFixture(t), &Generator{})
+	assert.NoError(err)
+	assert.NotContains(files, SmokeTestFile)
+
+	files, err = GenerateFiles(loadFixture(t), &Generator{EmitSmokeTests: true})
+	assert.NoError(err)
+	assert.Contains(string(files[SmokeTestFile]), "func TestSmokeFakePing(t *testing.T) {")
+
+	gen := &Generator{}
+	assert.NoError(Generate(loadFixture(t), gen))
+	assert.Nil(gen.SmokeTests)
+}
+
+func TestSmokeCallSideEffectFree(t *testing.T) {
+	assert := assert.New(t)
+
+	gen := &Generator{}
+	out, err := collect(loadFixture(t), gen)
+	assert.NoError(err)
+	entry := definitions.Entry{Name: "findThings", Method: "post", Args: []string{}, Query: []string{}, Input: "v1/create-thing-request.json#"}
+
+	// POST methods are skipped, unless they are known to only read
+	_, skip := out.smokeCall(gen, "Fake", "fake", entry)
+	assert.Equal("findThings is a POST request, which may have side effects", skip)
+
+	sideEffectFreeMethods["fake.findThings"] = true
+	defer delete(sideEffectFreeMethods, "fake.findThings")
+	call, skip := out.smokeCall(gen, "Fake", "fake", entry)
+	assert.Equal("", skip)
+	assert.Equal(`NewFake().FindThings([]byte("{\"name\":\"\"}"))`, call)
+
+}
+
+// The smoke tests of the bundled references build a minimal payload for the
+// side-effect-free methods that can have one.
+func TestGenerateSmokeTestsPayloads(t *testing.T) {
+	assert := assert.New(t)
+
+	refs, err := LoadReferences()
+	assert.NoError(err)
+	gen := &Generator{EmitSmokeTests: true}
+	assert.NoError(Generate(refs, gen))
+	source := string(gen.SmokeTests)
+
+	assert.Contains(source, "\tres, err := NewAuth().ExpandScopes([]byte(\"{\\\"scopes\\\":[]}\"))\n")
+	assert.Contains(source, "\tres, err := NewAuth().TestAuthenticate([]byte(")
+	// they are skipped for want of a valid payload, not for side effects
+	assert.Contains(source, "t.Skip(\"no minimal payload for authenticateHawk is known to be valid: payload.host: the string has a format\")")
+	assert.Contains(source, "t.Skip(\"createTask is a PUT request, which may have side effects\")")
+}
+
+func TestMinimalValue(t *testing.T) {
+	assert := assert.New(t)
+
+	tg := newTypeGenerator(loadFixture(t))
+	file := "schemas/fake/v1/create-thing-request.json"
+	schema, err := tg.resolve(file, "")
+	assert.NoError(err)
+
+	// only the required properties are given
+	value, err := tg.minimalValue(file, schema, "payload", 0)
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{"name": ""}, value)
+
+	// through $refs, too
+	value, err = tg.minimalValue(file, map[string]interface{}{"$ref": "thing-owner.json#"}, "payload", 0)
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{"email": ""}, value)
+
+	// defaults, enums and the first alternatives are used
+	value, err = tg.minimalValue(file, map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"a", "b", "c", "d", "e", "f"},
+		"properties": map[string]interface{}{
+			"a": map[string]interface{}{"type": "string", "format": "date-time", "default": "2020-01-01T00:00:00.000Z"},
+			"b": map[string]interface{}{"type": "string", "enum": []interface{}{"low", "high"}},
+			"c": map[string]interface{}{"oneOf": []interface{}{map[string]interface{}{"type": "boolean"}}},
+			"d": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			"e": map[string]interface{}{"type": "integer", "minimum": 1.0},
+			"f": map[string]interface{}{"type": []interface{}{"null", "string"}},
+		},
+	}, "payload", 0)
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{"a": "2020-01-01T00:00:00.000Z", "b": "low", "c": false, "d": []interface{}{}, "e": 1.0, "f": nil}, value)
+
+	// and schemas without a known valid value fail, naming the path
+	for _, unsafe := range []map[string]interface{}{
+		{"type": "string", "pattern": "^[a-z]+$"},
+		{"type": "string", "format": "date-time"},
+		{"type": "array", "minItems": 1.0},
+		{"type": "number", "exclusiveMinimum": 0.0},
+		{"description": "anything"},
+	} {
+		_, err = tg.minimalValue(file, map[string]interface{}{
+			"type":       "object",
+			"required":   []interface{}{"x"},
+			"properties": map[string]interface{}{"x": unsafe},
+		}, "payload", 0)
+		assert.Error(err)
+		assert.Contains(err.Error(), "payload.x: ")
+	}
+
+	// as do recursive ones
+	tg.documents["schemas/fake/v1/loop.json"] = map[string]interface{}{
+		"type":       "object",
+		"required":   []interface{}{"next"},
+		"properties": map[string]interface{}{"next": map[string]interface{}{"$ref": "#"}},
+	}
+	_, err = tg.minimalValue("schemas/fake/v1/loop.json", tg.documents["schemas/fake/v1/loop.json"], "payload", 0)
+	assert.Error(err)
+	assert.Contains(err.Error(), "the schema is recursive")
+}
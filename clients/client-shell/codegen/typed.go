@@ -0,0 +1,147 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// payloadType holds the Go types generated for the payload and the response
+// of an API entry, or "" where it has none.
+type payloadType struct {
+	input, output string
+}
+
+// printTypedClients prints, for each of the named services, an interface
+// such as TypedQueue, with a method per API entry taking and returning the
+// generated payload types, an unexported struct implementing it by
+// marshalling the payload, calling the entry and unmarshalling the response,
+// and constructors like those printed by printInterfaces.  Entries
+// transferring the content of artifacts have no typed methods, as their
+// bodies are not JSON.
+func (g *Generator) printTypedClients(names []string, services map[string]definitions.Service, payloads map[string]map[string]payloadType, tg *typeGenerator) {
+	for _, name := range names {
+		typed := "Typed" + name
+		impl := strcase.ToLowerCamel(typed) + "Client"
+
+		var entries []definitions.Entry
+		for _, entry := range services[name].Entries {
+			if !entry.Download && !entry.Upload {
+				entries = append(entries, entry)
+			}
+		}
+
+		g.Print(formatComment(fmt.Sprintf(
+			"%s is the interface of the methods of the %s service, taking and returning the Go types of their "+
+				"payloads and responses, as implemented by the client returned by New%s.",
+			typed, name, typed,
+		)))
+		g.Printf("type %s interface {\n", typed)
+		for _, entry := range entries {
+			heading := entry.Name
+			if entry.Title != "" {
+				heading += ": " + entry.Title
+			}
+			p := payloads[name][entry.Name]
+			g.Print(formatComment(fmt.Sprintf("%s calls %s", identifier(entry.Name), heading)))
+			g.Printf("%s(%s) %s\n", identifier(entry.Name), g.typedParams(entry, p, tg), typedResults(p, tg))
+		}
+		g.Print("}\n\n")
+
+		g.Printf("type %s struct {\nconn *connection\n}\n\n", impl)
+		g.Print(formatComment(fmt.Sprintf(
+			"New%s returns a client for the %s service, with typed payloads and responses, using the configured "+
+				"root URL and credentials.",
+			typed, name,
+		)))
+		g.Printf("func New%s() %s {\n", typed, typed)
+		g.Printf("return %s{}\n", impl)
+		g.Print("}\n\n")
+		g.Print(formatComment(fmt.Sprintf(
+			"New%sFromEnv returns a client for the %s service, with typed payloads and responses, using the root "+
+				"URL and credentials given by the environment, as New%sFromEnv does.",
+			typed, name, name,
+		)))
+		g.Printf("func New%sFromEnv() (%s, error) {\n", typed, typed)
+		g.Print("conn, err := connectionFromEnv()\nif err != nil {\nreturn nil, err\n}\n")
+		g.Printf("return %s{conn: conn}, nil\n", impl)
+		g.Print("}\n\n")
+
+		for _, entry := range entries {
+			p := payloads[name][entry.Name]
+			g.Printf("func (c %s) %s(%s) %s {\n", impl, identifier(entry.Name), g.typedParams(entry, p, tg), typedResults(p, tg))
+			call := fmt.Sprintf(
+				"callTyped(%s, c.conn, %q, %q, %s, %s, %s",
+				g.ctxArg(), name, entry.Name, argsMap(entry), queryParam(entry), typedPayloadParam(p),
+			)
+			switch {
+			case p.output == "":
+				g.Printf("return %s, nil)\n", call)
+			case tg.isStructType(p.output):
+				g.Printf("result := &%s{}\n", p.output)
+				g.Printf("if err := %s, result); err != nil {\nreturn nil, err\n}\n", call)
+				g.Print("return result, nil\n")
+			default:
+				g.Printf("var result %s\n", p.output)
+				g.Printf("err := %s, &result)\n", call)
+				g.Print("return result, err\n")
+			}
+			g.Print("}\n\n")
+		}
+	}
+}
+
+// typedParams returns the parameter list of the typed method for an entry:
+// that of its method, with the payload of the type generated for it.
+func (g *Generator) typedParams(entry definitions.Entry, p payloadType, tg *typeGenerator) string {
+	entry.Input = ""
+	params := g.methodParams(entry)
+	if p.input != "" {
+		if params != "" {
+			params += ", "
+		}
+		params += "payload " + typedRef(p.input, tg)
+	}
+	return params
+}
+
+// typedResults returns the results of the typed method for an entry: the
+// type generated for its response, if any, and an error.
+func typedResults(p payloadType, tg *typeGenerator) string {
+	if p.output == "" {
+		return "error"
+	}
+	return "(" + typedRef(p.output, tg) + ", error)"
+}
+
+// typedRef returns how the typed methods refer to a generated type: by
+// pointer for struct types, and by value otherwise.
+func typedRef(typ string, tg *typeGenerator) string {
+	if tg.isStructType(typ) {
+		return "*" + typ
+	}
+	return typ
+}
+
+func typedPayloadParam(p payloadType) string {
+	if p.input == "" {
+		return "nil"
+	}
+	return "payload"
+}
+
+// typedClientsUseJSON returns true if the signatures of the typed methods of
+// any of the services refer to `json.RawMessage`.
+func (out *generated) typedClientsUseJSON(services map[string]definitions.Service) bool {
+	for name, svc := range services {
+		for _, entry := range svc.Entries {
+			p := out.payloadTypes[name][entry.Name]
+			if !entry.Download && !entry.Upload && strings.Contains(p.input+" "+p.output, "json.") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+// typedRoundTripTest is a test run in a copy of the apis package generated
+// from the fixture, calling echoThing (see addEchoEntry) and setThingState
+// (see addEnumEntry) through its typed client, against a server echoing the
+// payload.  %[1]s is the context argument of the methods, if any.
+const typedRoundTripTest = `package apis
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/config"
+)
+
+var _ = context.Background
+
+func TestTypedRoundTrip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(w, r.Body)
+	}))
+	defer server.Close()
+	config.SetRootURL(server.URL)
+
+	payload := &FakeCreateThingRequest{
+		Name:   "thing",
+		Tags:   []string{"a", "b"},
+		State:  FakeCreateThingRequestStateInProgress,
+		Owner:  &FakeThingOwner{Email: "owner@example.com"},
+		Limits: &FakeCreateThingRequestLimits{Max: 3},
+	}
+	var client TypedFake = NewTypedFake()
+	result, err := client.EchoThing(%[1]spayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(payload, result) {
+		t.Fatalf("sent %%#v, received %%#v", payload, result)
+	}
+	if err := client.CreateThing(%[1]s"abc", payload); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := client.SetThingState(%[1]s"abc", FakeThingStateDone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state != FakeThingStateDone {
+		t.Fatalf("sent %%q, received %%q", FakeThingStateDone, state)
+	}
+}
+`
+
+// addEchoEntry adds an entry to the fake service whose response has the
+// type of its payload.
+func addEchoEntry(t *testing.T, refs *References) {
+	addEntry(t, refs, map[string]interface{}{
+		"type": "function", "name": "echoThing", "title": "Echo Thing", "description": "",
+		"stability": "experimental", "method": "post", "route": "/echo", "args": []interface{}{},
+		"input": "v1/create-thing-request.json#", "output": "v1/create-thing-request.json#",
+	})
+}
+
+// addEnumEntry adds an entry to the fake service whose payload and response
+// are a string enum.
+func addEnumEntry(t *testing.T, refs *References) {
+	refs.data = append(refs.data, Reference{
+		Filename: "schemas/fake/v1/thing-state.json",
+		Content: json.RawMessage(`{"$id": "/schemas/fake/v1/thing-state.json#", "title": "Thing State", ` +
+			`"type": "string", "enum": ["pending", "done"]}`),
+	})
+	addEntry(t, refs, map[string]interface{}{
+		"type": "function", "name": "setThingState", "title": "Set Thing State", "description": "",
+		"stability": "experimental", "method": "put", "route": "/things/<thingId>/state", "args": []interface{}{"thingId"},
+		"input": "v1/thing-state.json#", "output": "v1/thing-state.json#",
+	})
+}
+
+// testGeneratedPackage writes files to a copy of the apis package in which
+// they replace the generated code, and runs its tests.
+func testGeneratedPackage(t *testing.T, files map[string][]byte) {
+	if testing.Short() {
+		t.Skip("building the generated package is slow")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not installed")
+	}
+
+	// the copy must be within the module, for its imports; the leading
+	// underscore keeps it out of ./...
+	dir, err := ioutil.TempDir("..", "_typed")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sources, err := filepath.Glob("../apis/*.go")
+	assert.NoError(t, err)
+	for _, source := range sources {
+		if strings.HasSuffix(source, "_test.go") {
+			continue
+		}
+		content, err := ioutil.ReadFile(source)
+		assert.NoError(t, err)
+		if IsGenerated(content) {
+			continue
+		}
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, filepath.Base(source)), content, 0644))
+	}
+	for filename, content := range files {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, filename), content, 0644))
+	}
+
+	cmd := exec.Command(goTool, "test", "-count=1", ".")
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(output))
+}
+
+func TestGenerateTypedClients(t *testing.T) {
+	assert := assert.New(t)
+
+	gen := &Generator{TypedPayloads: true}
+	assert.NoError(Generate(loadFixture(t), gen))
+	out := gen.String()
+
+	// the raw clients are unchanged
+	assert.Contains(out, "\nCreateThing(thingId string, payload []byte) ([]byte, error)\n")
+
+	// entries with payloads and responses take and return their types, by
+	// pointer for structs
+	assert.Contains(out, "type TypedFake interface {\n")
+	assert.Contains(out, "\nCreateThing(thingId string, payload *FakeCreateThingRequest) error\n")
+	assert.Contains(out, "\nListThings(query map[string]string) (*FakeListThingsResponse, error)\n")
+	assert.Contains(out, "\nPing() error\n")
+	assert.Contains(out, "func NewTypedFake() TypedFake {\n")
+	assert.Contains(out, "func NewTypedFakeFromEnv() (TypedFake, error) {\n")
+	assert.Contains(out, "type TypedOther interface {\n")
+	assert.Contains(out, "\nfunc (c typedFakeClient) ListThings(query map[string]string) (*FakeListThingsResponse, error) {\n"+
+		"result := &FakeListThingsResponse{}\n"+
+		"if err := callTyped(context.Background(), c.conn, \"Fake\", \"listThings\", nil, query, nil, result); err != nil {\n")
+
+	gen = &Generator{TypedPayloads: true, ContextMethods: true}
+	assert.NoError(Generate(loadFixture(t), gen))
+	assert.Contains(gen.String(), "\nCreateThing(ctx context.Context, thingId string, payload *FakeCreateThingRequest) error\n")
+
+	// without TypedPayloads, there are no typed clients
+	assert.NotContains(string(generateFixture(t, loadFixture(t))), "TypedFake")
+}
+
+func TestGenerateTypedClientsFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	files, err := GenerateFiles(loadFixture(t), &Generator{TypedPayloads: true})
+	assert.NoError(err)
+	assert.Contains(string(files["fake.go"]), "type TypedFake interface {")
+	assert.Contains(string(files["other.go"]), "type TypedOther interface {")
+	assert.Contains(string(files[MainFile]), "type FakeCreateThingRequest struct {")
+}
+
+func TestGenerateTypedClientsEnums(t *testing.T) {
+	assert := assert.New(t)
+
+	refs := loadFixture(t)
+	addEnumEntry(t, refs)
+	gen := &Generator{TypedPayloads: true}
+	assert.NoError(Generate(refs, gen))
+	out := gen.String()
+
+	// enum payloads and responses are taken and returned as their types, by
+	// value, and so are the enum fields of struct payloads
+	assert.Contains(out, "\nSetThingState(thingId string, payload FakeThingState) (FakeThingState, error)\n")
+	assert.Contains(out, "\nvar result FakeThingState\n")
+	assert.Contains(out, "type FakeThingState string\n")
+	assert.Contains(out, "\nFakeThingStateDone FakeThingState = \"done\"\n")
+	assert.Contains(out, "\nCreateThing(thingId string, payload *FakeCreateThingRequest) error\n")
+	assert.Contains(out, "\nState FakeCreateThingRequestState `json:\"state,omitempty\"`\n")
+}
+
+func TestTypedClientsRoundTrip(t *testing.T) {
+	refs := loadFixture(t)
+	addEchoEntry(t, refs)
+	addEnumEntry(t, refs)
+	gen := &Generator{TypedPayloads: true}
+	assert.NoError(t, Generate(refs, gen))
+	source, err := gen.Format()
+	assert.NoError(t, err)
+	testGeneratedPackage(t, map[string][]byte{
+		"services.go":   source,
+		"typed_test.go": []byte(fmt.Sprintf(typedRoundTripTest, "")),
+	})
+}
+
+func TestTypedClientsRoundTripFiles(t *testing.T) {
+	refs := loadFixture(t)
+	addEchoEntry(t, refs)
+	addEnumEntry(t, refs)
+	files, err := GenerateFiles(refs, &Generator{TypedPayloads: true, ContextMethods: true})
+	assert.NoError(t, err)
+	files["typed_test.go"] = []byte(fmt.Sprintf(typedRoundTripTest, "context.Background(), "))
+	testGeneratedPackage(t, files)
+}
@@ -0,0 +1,393 @@
+package codegen
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/iancoleman/strcase"
+)
+
+// typeGenerator converts the JSON schemas referenced by API entries into Go
+// type declarations.
+//
+// Objects with properties become named struct types, strings with an `enum`
+// become named string types with a constant for each value, arrays become
+// slices, and `$ref`s are resolved relative to the schema that contains them.  Schemas
+// which have no direct Go equivalent (`oneOf` and friends, or schemas without
+// a `type`) are represented as `json.RawMessage`.
+type typeGenerator struct {
+	references *References
+
+	// parsed schema documents, by filename
+	documents map[string]map[string]interface{}
+	// Go type expression for each schema location (`<filename>#<pointer>`)
+	locations map[string]string
+	// rendered declarations, by type name
+	decls map[string]string
+	// names of the enum types among decls, and of their constants
+	enums  map[string]bool
+	consts map[string]bool
+	// whether any of the types make use of `json.RawMessage`
+	usesJSON bool
+}
+
+func newTypeGenerator(references *References) *typeGenerator {
+	return &typeGenerator{
+		references: references,
+		documents:  map[string]map[string]interface{}{},
+		locations:  map[string]string{},
+		decls:      map[string]string{},
+		enums:      map[string]bool{},
+		consts:     map[string]bool{},
+	}
+}
+
+// AddSchema generates the types for the schema at the given location, such as
+// `schemas/auth/v1/create-client-request.json#`, and returns its Go type.
+func (tg *typeGenerator) AddSchema(location string) (string, error) {
+	parts := strings.SplitN(strings.TrimPrefix(location, "/"), "#", 2)
+	pointer := ""
+	if len(parts) == 2 {
+		pointer = parts[1]
+	}
+	return tg.locationType(parts[0], pointer)
+}
+
+// Print writes all of the generated type declarations, sorted by name.
+func (tg *typeGenerator) Print(gen *Generator) {
+	names := make([]string, 0, len(tg.decls))
+	for name := range tg.decls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		gen.Print("\n")
+		gen.Print(tg.decls[name])
+	}
+}
+
+// locationType returns the Go type for the schema at pointer within file.
+func (tg *typeGenerator) locationType(file, pointer string) (string, error) {
+	key := file + "#" + pointer
+	if typ, ok := tg.locations[key]; ok {
+		return typ, nil
+	}
+
+	schema, err := tg.resolve(file, pointer)
+	if err != nil {
+		return "", err
+	}
+
+	name := locationName(file, pointer)
+	if isStruct(schema) {
+		// reserve the name before descending, so that recursive references
+		// resolve to this type
+		name = tg.unique(name)
+		tg.locations[key] = name
+		return name, tg.structType(file, schema, name)
+	}
+
+	typ, err := tg.goType(file, schema, name)
+	if err != nil {
+		return "", err
+	}
+	tg.locations[key] = typ
+	return typ, nil
+}
+
+// goType returns the Go type for schema, which appears in file.  Any new
+// struct types are named after name.
+func (tg *typeGenerator) goType(file string, schema map[string]interface{}, name string) (string, error) {
+	if ref, ok := schema["$ref"].(string); ok {
+		refFile, pointer := resolveRef(file, ref)
+		return tg.locationType(refFile, pointer)
+	}
+
+	for _, combinator := range []string{"oneOf", "anyOf", "allOf"} {
+		if _, ok := schema[combinator]; ok {
+			tg.usesJSON = true
+			return "json.RawMessage", nil
+		}
+	}
+
+	if isStruct(schema) {
+		name = tg.unique(name)
+		return name, tg.structType(file, schema, name)
+	}
+
+	switch schema["type"] {
+	case "object":
+		if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+			typ, err := tg.goType(file, additional, name+"Value")
+			if err != nil {
+				return "", err
+			}
+			return "map[string]" + typ, nil
+		}
+		return "map[string]interface{}", nil
+	case "array":
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			typ, err := tg.goType(file, items, name+"Item")
+			if err != nil {
+				return "", err
+			}
+			return "[]" + typ, nil
+		}
+		return "[]interface{}", nil
+	case "string":
+		if values, ok := enumValues(schema); ok {
+			name = tg.unique(name)
+			tg.enumType(schema, name, values)
+			return name, nil
+		}
+		return "string", nil
+	case "integer":
+		return "int64", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	}
+
+	tg.usesJSON = true
+	return "json.RawMessage", nil
+}
+
+// structType renders a struct declaration called name for the object schema.
+func (tg *typeGenerator) structType(file string, schema map[string]interface{}, name string) error {
+	// register a placeholder straight away, so that field types can tell
+	// this is a struct
+	tg.decls[name] = ""
+
+	properties := schema["properties"].(map[string]interface{})
+	required := map[string]bool{}
+	if list, ok := schema["required"].([]interface{}); ok {
+		for _, r := range list {
+			if s, ok := r.(string); ok {
+				required[s] = true
+			}
+		}
+	}
+
+	props := make([]string, 0, len(properties))
+	for prop := range properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+
+	buf := &strings.Builder{}
+	buf.WriteString(formatComment(schemaDoc(name, schema)))
+	fmt.Fprintf(buf, "type %s struct {\n", name)
+	fields := map[string]bool{}
+	for _, prop := range props {
+		propSchema, ok := properties[prop].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: property %s of %s is not a schema", file, prop, name)
+		}
+
+		field := identifier(prop)
+		for i := 2; fields[field]; i++ {
+			field = identifier(prop) + strconv.Itoa(i)
+		}
+		fields[field] = true
+
+		typ, err := tg.goType(file, propSchema, name+field)
+		if err != nil {
+			return err
+		}
+
+		tag := prop
+		if !required[prop] {
+			tag += ",omitempty"
+			if tg.isStructType(typ) {
+				typ = "*" + typ
+			}
+		}
+
+		if desc, ok := propSchema["description"].(string); ok && desc != "" {
+			buf.WriteString(formatComment(desc))
+		}
+		fmt.Fprintf(buf, "%s %s `json:\"%s\"`\n", field, typ, tag)
+	}
+	buf.WriteString("}\n")
+
+	tg.decls[name] = buf.String()
+	return nil
+}
+
+// enumType renders a string type called name for the enum schema, with a
+// constant for each of its values, such as `QueueTaskPriorityHigh`.
+func (tg *typeGenerator) enumType(schema map[string]interface{}, name string, values []string) {
+	tg.enums[name] = true
+
+	buf := &strings.Builder{}
+	buf.WriteString(formatComment(schemaDoc(name, schema)))
+	fmt.Fprintf(buf, "type %s string\n\n", name)
+	fmt.Fprintf(buf, "// The values allowed for %s.\n", name)
+	buf.WriteString("const (\n")
+	for _, value := range values {
+		constant := name + identifier(value)
+		for i := 2; tg.consts[constant] || tg.isDeclared(constant); i++ {
+			constant = name + identifier(value) + strconv.Itoa(i)
+		}
+		tg.consts[constant] = true
+		fmt.Fprintf(buf, "%s %s = %s\n", constant, name, strconv.Quote(value))
+	}
+	buf.WriteString(")\n")
+
+	tg.decls[name] = buf.String()
+}
+
+// resolve finds the schema at pointer within file.
+func (tg *typeGenerator) resolve(file, pointer string) (map[string]interface{}, error) {
+	doc, ok := tg.documents[file]
+	if !ok {
+		if err := tg.references.get(file, &doc); err != nil {
+			return nil, err
+		}
+		tg.documents[file] = doc
+	}
+
+	var current interface{} = doc
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+		switch node := current.(type) {
+		case map[string]interface{}:
+			current = node[token]
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("%s#%s: invalid index %q", file, pointer, token)
+			}
+			current = node[i]
+		default:
+			current = nil
+		}
+		if current == nil {
+			return nil, fmt.Errorf("%s#%s does not exist", file, pointer)
+		}
+	}
+
+	schema, ok := current.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s#%s is not a schema", file, pointer)
+	}
+	return schema, nil
+}
+
+// unique returns name, or name with a numeric suffix if it is already taken.
+func (tg *typeGenerator) unique(name string) string {
+	candidate := name
+	for i := 2; ; i++ {
+		if !tg.isDeclared(candidate) {
+			return candidate
+		}
+		candidate = name + strconv.Itoa(i)
+	}
+}
+
+// isDeclared returns true if name is taken by a type or an enum constant.
+func (tg *typeGenerator) isDeclared(name string) bool {
+	_, taken := tg.decls[name]
+	return taken || tg.consts[name]
+}
+
+// isStructType returns true if typ is one of the generated struct types,
+// which optional fields and the typed client methods refer to by pointer.
+func (tg *typeGenerator) isStructType(typ string) bool {
+	_, declared := tg.decls[typ]
+	return declared && !tg.enums[typ]
+}
+
+// resolveRef resolves a `$ref` found in file to a filename and JSON pointer.
+func resolveRef(file, ref string) (string, string) {
+	parts := strings.SplitN(ref, "#", 2)
+	pointer := ""
+	if len(parts) == 2 {
+		pointer = parts[1]
+	}
+	switch {
+	case parts[0] == "":
+		return file, pointer
+	case strings.HasPrefix(parts[0], "/"):
+		return strings.TrimPrefix(parts[0], "/"), pointer
+	default:
+		return path.Join(path.Dir(file), parts[0]), pointer
+	}
+}
+
+// locationName derives a type name from a schema location, such as
+// `AuthCreateClientRequest` for `schemas/auth/v1/create-client-request.json`.
+func locationName(file, pointer string) string {
+	name := ""
+	parts := strings.Split(file, "/")
+	if len(parts) > 1 && parts[0] == "schemas" {
+		name = identifier(parts[1])
+	}
+	name += identifier(strings.TrimSuffix(path.Base(file), ".json"))
+	for _, token := range strings.Split(pointer, "/") {
+		switch token {
+		case "", "properties", "definitions", "items":
+		default:
+			name += identifier(token)
+		}
+	}
+	return name
+}
+
+// identifier converts s into an exported Go identifier.
+func identifier(s string) string {
+	id := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, strcase.ToCamel(s))
+	if id == "" || unicode.IsDigit(rune(id[0])) {
+		id = "X" + id
+	}
+	return id
+}
+
+// isStruct returns true if the schema describes an object with properties.
+func isStruct(schema map[string]interface{}) bool {
+	props, ok := schema["properties"].(map[string]interface{})
+	return ok && len(props) > 0 && (schema["type"] == "object" || schema["type"] == nil)
+}
+
+// enumValues returns the values of the `enum` of a string schema, if it has
+// one made only of strings.
+func enumValues(schema map[string]interface{}) ([]string, bool) {
+	list, ok := schema["enum"].([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, false
+	}
+	values := make([]string, 0, len(list))
+	for _, v := range list {
+		s, ok := v.(string)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, s)
+	}
+	return values, true
+}
+
+// schemaDoc builds the text of a type's doc comment from its schema.
+func schemaDoc(name string, schema map[string]interface{}) string {
+	doc := name
+	if title, ok := schema["title"].(string); ok && title != "" {
+		doc += ": " + title
+	}
+	if desc, ok := schema["description"].(string); ok && desc != "" {
+		doc += "\n\n" + desc
+	}
+	return doc
+}
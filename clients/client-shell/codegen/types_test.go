@@ -0,0 +1,101 @@
+package codegen
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestGenerateTypedPayloads(t *testing.T) {
+	assert := assert.New(t)
+
+	gen := &Generator{TypedPayloads: true}
+	assert.NoError(Generate(loadFixture(t), gen))
+	source, err := gen.Format()
+	assert.NoError(err)
+	_, err = parser.ParseFile(token.NewFileSet(), "services.go", source, parser.AllErrors)
+	assert.NoError(err)
+
+	// check the unformatted output, to avoid depending on field alignment
+	out := gen.String()
+	assert.Contains(out, "\"encoding/json\"")
+
+	// required and optional fields, with nested and $ref'd struct types
+	assert.Contains(out, "type FakeCreateThingRequest struct {\n")
+	assert.Contains(out, "\nName string `json:\"name\"`\n")
+	assert.Contains(out, "\nTags []string `json:\"tags,omitempty\"`\n")
+	assert.Contains(out, "\nOwner *FakeThingOwner `json:\"owner,omitempty\"`\n")
+	assert.Contains(out, "\nLimits *FakeCreateThingRequestLimits `json:\"limits,omitempty\"`\n")
+	assert.Contains(out, "\nExtra json.RawMessage `json:\"extra,omitempty\"`\n")
+	assert.Contains(out, "type FakeCreateThingRequestLimits struct {\nMax int64 `json:\"max\"`\n}\n")
+	assert.Contains(out, "type FakeThingOwner struct {\n")
+
+	// output schemas, with arrays of referenced types
+	assert.Contains(out, "\nThings []FakeCreateThingRequest `json:\"things\"`\n")
+
+	// string enums, with a constant for each value, which are not pointers
+	// even if optional
+	assert.Contains(out, "\nState FakeCreateThingRequestState `json:\"state,omitempty\"`\n")
+	assert.Contains(out, "type FakeCreateThingRequestState string\n")
+	assert.Contains(out, "\nFakeCreateThingRequestStatePending FakeCreateThingRequestState = \"pending\"\n")
+	assert.Contains(out, "\nFakeCreateThingRequestStateInProgress FakeCreateThingRequestState = \"in-progress\"\n")
+	assert.Contains(out, "\nFakeCreateThingRequestStateDone FakeCreateThingRequestState = \"done\"\n")
+}
+
+func TestEnumTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	tg := newTypeGenerator(nil)
+	typ, err := tg.goType("schemas/fake/v1/x.json", map[string]interface{}{
+		"type": "string",
+		"enum": []interface{}{"a-b", "A B", "3"},
+	}, "FakeXMode")
+	assert.NoError(err)
+	assert.Equal("FakeXMode", typ)
+
+	// values whose identifiers clash get distinct constants
+	decl := tg.decls["FakeXMode"]
+	assert.Contains(decl, "\nFakeXModeAB FakeXMode = \"a-b\"\n")
+	assert.Contains(decl, "\nFakeXModeAB2 FakeXMode = \"A B\"\n")
+	assert.Contains(decl, "\nFakeXModeX3 FakeXMode = \"3\"\n")
+
+	// and the type does not clash with them either
+	typ, err = tg.goType("schemas/fake/v1/x.json", map[string]interface{}{
+		"type": "string",
+		"enum": []interface{}{"b"},
+	}, "FakeXModeAB")
+	assert.NoError(err)
+	assert.Equal("FakeXModeAB3", typ)
+
+	// other enums are plain values
+	typ, err = tg.goType("schemas/fake/v1/x.json", map[string]interface{}{
+		"type": "integer",
+		"enum": []interface{}{1.0, 2.0},
+	}, "FakeXLevel")
+	assert.NoError(err)
+	assert.Equal("int64", typ)
+	typ, err = tg.goType("schemas/fake/v1/x.json", map[string]interface{}{
+		"type": "string",
+		"enum": []interface{}{"a", nil},
+	}, "FakeXOther")
+	assert.NoError(err)
+	assert.Equal("string", typ)
+}
+
+func TestGenerateWithoutTypedPayloads(t *testing.T) {
+	assert := assert.New(t)
+
+	source := string(generateFixture(t, loadFixture(t)))
+	assert.NotContains(source, "encoding/json")
+	assert.NotContains(source, "type FakeCreateThingRequest struct")
+}
+
+func TestLocationName(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("AuthCreateClientRequest", locationName("schemas/auth/v1/create-client-request.json", ""))
+	assert.Equal("QueueTaskPayload", locationName("schemas/queue/v1/task.json", "/properties/payload"))
+	assert.Equal("X0Empty", identifier("0-empty"))
+}
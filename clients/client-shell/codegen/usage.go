@@ -0,0 +1,56 @@
+package codegen
+
+import (
+	"path"
+	"strings"
+
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+// entryUsage builds the usage string of an entry's command: the route
+// parameters, which are required, are given in angle brackets, while the
+// query-string parameters and the payload (which is otherwise read from
+// stdin) are optional, in square brackets.
+func entryUsage(entry definitions.Entry) string {
+	parts := []string{entry.Name}
+	for _, arg := range entry.Args {
+		parts = append(parts, "<"+arg+">")
+	}
+	for _, q := range entry.Query {
+		parts = append(parts, "[--"+q+" <"+q+">]")
+	}
+	if entry.Input != "" {
+		parts = append(parts, "[--body <payload>]")
+	}
+	if isPaginated(entry) {
+		parts = append(parts, "[--all]")
+	}
+	if entry.Upload || entry.PutURL {
+		parts = append(parts, "[--input <file>]")
+	}
+	if entry.SignedURL {
+		parts = append(parts, "[--sign-url <duration>]")
+	}
+	return strings.Join(parts, " ")
+}
+
+// entryExample builds an example invocation of an entry's command, giving
+// only the required parameters, reading the payload from a file named after
+// its schema, and the content of artifacts from or to a <file>.
+func entryExample(serviceName string, entry definitions.Entry) string {
+	parts := []string{"taskcluster", "api", strings.ToLower(serviceName[0:1]) + serviceName[1:], entry.Name}
+	for _, arg := range entry.Args {
+		parts = append(parts, "<"+arg+">")
+	}
+	if entry.Input != "" {
+		schema := strings.SplitN(entry.Input, "#", 2)[0]
+		parts = append(parts, "--body", "@"+path.Base(schema))
+	}
+	if entry.Upload || entry.PutURL {
+		parts = append(parts, "--input", "<file>")
+	}
+	if entry.Download {
+		parts = append(parts, "--output", "<file>")
+	}
+	return "  " + strings.Join(parts, " ")
+}
@@ -0,0 +1,29 @@
+package codegen
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/taskcluster/taskcluster/v31/clients/client-shell/apis/definitions"
+)
+
+func TestEntryUsage(t *testing.T) {
+	assert := assert.New(t)
+
+	create := definitions.Entry{
+		Name:  "createThing",
+		Args:  []string{"thingId"},
+		Query: []string{},
+		Input: "v1/create-thing-request.json#",
+	}
+	assert.Equal("createThing <thingId> [--body <payload>]", entryUsage(create))
+	assert.Equal("  taskcluster api fake createThing <thingId> --body @create-thing-request.json", entryExample("Fake", create))
+
+	list := definitions.Entry{
+		Name:  "listThings",
+		Args:  []string{},
+		Query: []string{"continuationToken", "limit"},
+	}
+	assert.Equal("listThings [--continuationToken <continuationToken>] [--limit <limit>] [--all]", entryUsage(list))
+	assert.Equal("  taskcluster api workerManager listThings", entryExample("WorkerManager", list))
+}
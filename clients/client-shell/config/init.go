@@ -1,8 +1,12 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/taskcluster/taskcluster/v31/clients/client-shell/client"
 )
@@ -19,8 +23,38 @@ var (
 
 	// Credentials is the client credentials, if present.
 	Credentials *client.Credentials
+
+	// Timeout is the overall deadline of each API request, including any
+	// retries; zero means no deadline.
+	Timeout = DefaultTimeout
+
+	// IOTimeout replaces Timeout for requests uploading or downloading
+	// artifacts, which may take much longer; zero means no deadline.
+	IOTimeout time.Duration
+
+	// RateLimit is the maximum average rate of API requests, per second;
+	// zero means no limit.  RateBurst is the number of requests which can be
+	// sent at once, before the limit applies.
+	RateLimit float64
+	RateBurst = DefaultRateBurst
+
+	// CACert is a file of PEM-encoded CA certificates to trust, in addition
+	// to those of the system.  Insecure disables the verification of
+	// certificates altogether.
+	CACert   string
+	Insecure bool
+
+	// TraceID is sent with every API request, so that the requests of a
+	// command can be correlated in the logs of the services.
+	TraceID string
 )
 
+// DefaultTimeout is the value of Timeout unless configured otherwise.
+const DefaultTimeout = 30 * time.Second
+
+// DefaultRateBurst is the value of RateBurst unless configured otherwise.
+const DefaultRateBurst = 1
+
 // Defer erroring out on a missing RootURL until we actually need one..
 func RootURL() string {
 	if rootURL == "" {
@@ -30,9 +64,32 @@ func RootURL() string {
 	return rootURL
 }
 
-// set the root URL -- this is used only for testing
+// HasRootURL returns whether a root URL is configured, for the commands which
+// can do without one.
+func HasRootURL() bool {
+	return rootURL != ""
+}
+
+// set the root URL -- this is used by the --root-url flag, and for testing
 func SetRootURL(newRootURL string) {
-	rootURL = newRootURL
+	rootURL = strings.TrimRight(newRootURL, "/")
+}
+
+// ValidateRootURL checks that value is a valid root URL, such as
+// `https://tc.example.com`: an absolute http(s) URL, with no query or
+// fragment.
+func ValidateRootURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("invalid root URL '%s': %s", value, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid root URL '%s': must be an absolute http or https URL", value)
+	}
+	if u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return fmt.Errorf("invalid root URL '%s': must not contain credentials, a query or a fragment", value)
+	}
+	return nil
 }
 
 // Setup is to be called from main
@@ -49,25 +106,114 @@ func Setup() {
 		os.Exit(1)
 	}
 
-	// load root URL
-	rootURL = Configuration["config"]["rootUrl"].(string)
+	// load profiles, selecting one if TASKCLUSTER_PROFILE is set; --profile
+	// may still override it
+	Profiles, err = LoadProfiles()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load configuration file, error: %s\n", err)
+		os.Exit(1)
+	}
+	if err = UseProfile(os.Getenv("TASKCLUSTER_PROFILE")); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// apply sets the root URL, timeouts, rate limit, TLS options and credentials
+// from the configuration, and the selected profile, if any.  The values were
+// already validated by Load and LoadProfiles; --root-url and --timeout may
+// still override them.
+func apply() error {
+	rootURL, _ := option("rootUrl").(string)
+	SetRootURL(rootURL)
+
+	// load timeouts
+	Timeout = DefaultTimeout
+	if timeout, ok := Duration(option("timeout")); ok {
+		Timeout = timeout
+	}
+	IOTimeout, _ = Duration(option("ioTimeout"))
+
+	// load the rate limit
+	RateLimit, _ = Number(option("rateLimit"))
+	RateBurst = DefaultRateBurst
+	if burst, ok := Number(option("rateBurst")); ok && burst >= 1 {
+		RateBurst = int(burst)
+	}
+
+	// load the TLS options
+	CACert, _ = option("caCert").(string)
+	Insecure, _ = option("insecure").(bool)
 
 	// load credentials
-	clientID, ok1 := Configuration["config"]["clientId"].(string)
-	accessToken, ok2 := Configuration["config"]["accessToken"].(string)
+	Credentials = nil
+	clientID, ok1 := option("clientId").(string)
+	accessToken, ok2 := option("accessToken").(string)
 	if ok1 && ok2 {
-		certificate, _ := Configuration["config"]["certificate"].(string)
-		authorizedScopes, _ := Configuration["config"]["authorizedScopes"].([]string)
+		certificate, _ := option("certificate").(string)
+		authorizedScopes, _ := StringList(option("authorizedScopes"))
 		Credentials = &client.Credentials{
 			ClientID:         clientID,
 			AccessToken:      accessToken,
 			Certificate:      certificate,
 			AuthorizedScopes: authorizedScopes,
 		}
-		return
+		return nil
 	}
 	if ok1 || ok2 {
-		fmt.Fprintln(os.Stderr, "Either ClientID or Access Token not set")
-		os.Exit(1)
+		return errors.New("Either ClientID or Access Token not set")
+	}
+	return nil
+}
+
+// Duration converts a configuration value, such as `30s` or `5m`, to a
+// duration.  Negative durations are rejected.
+func Duration(value interface{}) (time.Duration, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return 0, false
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// Number converts a configuration value, parsed from YAML or JSON, to a
+// number.  Negative numbers are rejected.
+func Number(value interface{}) (float64, bool) {
+	var n float64
+	switch v := value.(type) {
+	case int:
+		n = float64(v)
+	case float64:
+		n = v
+	default:
+		return 0, false
+	}
+	if n < 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// StringList converts a configuration value to a list of strings.  Values
+// parsed from YAML or JSON are lists of interface{}, rather than of strings.
+func StringList(value interface{}) ([]string, bool) {
+	switch list := value.(type) {
+	case []string:
+		return list, true
+	case []interface{}:
+		result := make([]string, 0, len(list))
+		for _, item := range list {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			result = append(result, s)
+		}
+		return result, true
 	}
+	return nil, false
 }
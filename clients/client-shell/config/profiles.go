@@ -0,0 +1,97 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ProfilesKey is the top-level key of the configuration file under which
+// profiles are stored.
+const ProfilesKey = "profiles"
+
+var (
+	// Profiles contains the named profiles of the configuration file.  Each
+	// profile sets some of the options of the `config` command, such as
+	// `rootUrl` and the credentials, overriding the values set outside of
+	// any profile.
+	Profiles = make(map[string]map[string]interface{})
+
+	// profile is the name of the selected profile, if any
+	profile string
+)
+
+// Profile returns the name of the selected profile, or an empty string if no
+// profile is selected.
+func Profile() string {
+	return profile
+}
+
+// UseProfile selects the named profile, recomputing the root URL and
+// credentials.
+func UseProfile(name string) error {
+	if _, ok := Profiles[name]; !ok && name != "" {
+		return fmt.Errorf("no profile named '%s' in the configuration file %s", name, configFile())
+	}
+	profile = name
+	return apply()
+}
+
+// LoadProfiles loads the profiles from the configuration file, validating
+// their options.  It returns an empty set of profiles if there is no
+// configuration file.
+func LoadProfiles() (map[string]map[string]interface{}, error) {
+	var file struct {
+		Profiles map[string]map[string]interface{} `yaml:"profiles"`
+	}
+	configFile := configFile()
+	if data, err := ioutil.ReadFile(configFile); err == nil {
+		if err = yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf(
+				"read config file %s, but failed to parse YAML, error: %s",
+				configFile, err,
+			)
+		}
+	}
+
+	profiles := make(map[string]map[string]interface{})
+	for name, options := range file.Profiles {
+		for option, value := range options {
+			definition, ok := OptionsDefinitions["config"][option]
+			if !ok {
+				return nil, fmt.Errorf("unknown option '%s' in profile '%s'", option, name)
+			}
+			if definition.Validate != nil {
+				if err := definition.Validate(value); err != nil {
+					val, _ := json.Marshal(value)
+					return nil, fmt.Errorf(
+						"invalid value '%s' for option '%s' in profile '%s', error: %s",
+						val, option, name, err,
+					)
+				}
+			}
+		}
+		if options == nil {
+			options = make(map[string]interface{})
+		}
+		profiles[name] = options
+	}
+	return profiles, nil
+}
+
+// option returns the effective value of an option of the `config` command,
+// looking at the environment, then the selected profile, and finally the rest
+// of the configuration (which already includes the default values).
+func option(name string) interface{} {
+	definition := OptionsDefinitions["config"][name]
+	if definition.Env != "" && os.Getenv(definition.Env) != "" {
+		return Configuration["config"][name]
+	}
+	if value, ok := Profiles[profile][name]; ok {
+		return value
+	}
+	return Configuration["config"][name]
+}
@@ -0,0 +1,249 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+// setUpConfig registers the options of the `config` command, and points the
+// configuration file at a temporary directory containing data.
+func setUpConfig(t *testing.T, data string) func() {
+	dir, err := ioutil.TempDir("", "config")
+	assert.NoError(t, err)
+	oldXDG := os.Getenv("XDG_CONFIG_HOME")
+	assert.NoError(t, os.Setenv("XDG_CONFIG_HOME", dir))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "taskcluster.yml"), []byte(data), 0644))
+
+	RegisterOptions("config", map[string]OptionDefinition{
+		"rootUrl":     {Default: "", Env: "TASKCLUSTER_ROOT_URL"},
+		"clientId":    {Default: "", Env: "TASKCLUSTER_CLIENT_ID"},
+		"accessToken": {Default: "", Env: "TASKCLUSTER_ACCESS_TOKEN"},
+	})
+
+	return func() {
+		_ = os.Setenv("XDG_CONFIG_HOME", oldXDG)
+		_ = os.RemoveAll(dir)
+		delete(OptionsDefinitions, "config")
+		Profiles = make(map[string]map[string]interface{})
+		profile = ""
+		rootURL = ""
+		Credentials = nil
+	}
+}
+
+const profilesConfig = `
+config:
+  rootUrl: https://prod.example.com
+  clientId: prod-client
+  accessToken: prod-token
+profiles:
+  staging:
+    rootUrl: https://staging.example.com
+    clientId: staging-client
+    accessToken: staging-token
+  empty:
+`
+
+func TestProfilesPrecedence(t *testing.T) {
+	assert := assert.New(t)
+	defer setUpConfig(t, profilesConfig)()
+
+	var err error
+	Configuration, err = Load()
+	assert.NoError(err)
+	Profiles, err = LoadProfiles()
+	assert.NoError(err)
+	assert.Len(Profiles, 2)
+
+	// without a profile, the configured values are used
+	assert.NoError(UseProfile(""))
+	assert.Equal("https://prod.example.com", RootURL())
+	assert.Equal("prod-client", Credentials.ClientID)
+
+	// the profile takes precedence
+	assert.NoError(UseProfile("staging"))
+	assert.Equal("https://staging.example.com", RootURL())
+	assert.Equal("staging-client", Credentials.ClientID)
+	assert.Equal("staging-token", Credentials.AccessToken)
+
+	// an empty profile changes nothing
+	assert.NoError(UseProfile("empty"))
+	assert.Equal("https://prod.example.com", RootURL())
+
+	// unknown profiles are an error
+	assert.Error(UseProfile("missing"))
+}
+
+func TestProfilesEnvironmentPrecedence(t *testing.T) {
+	assert := assert.New(t)
+	defer setUpConfig(t, profilesConfig)()
+	assert.NoError(os.Setenv("TASKCLUSTER_ROOT_URL", "https://env.example.com"))
+	defer os.Unsetenv("TASKCLUSTER_ROOT_URL")
+
+	var err error
+	Configuration, err = Load()
+	assert.NoError(err)
+	Profiles, err = LoadProfiles()
+	assert.NoError(err)
+
+	// the environment takes precedence over the profile, option by option
+	assert.NoError(UseProfile("staging"))
+	assert.Equal("https://env.example.com", RootURL())
+	assert.Equal("staging-client", Credentials.ClientID)
+}
+
+func TestLoadProfilesInvalid(t *testing.T) {
+	defer setUpConfig(t, "profiles:\n  staging:\n    bogus: 1\n")()
+
+	_, err := LoadProfiles()
+	assert.Error(t, err)
+}
+
+func TestSaveProfiles(t *testing.T) {
+	assert := assert.New(t)
+	defer setUpConfig(t, "")()
+
+	Profiles["staging"] = map[string]interface{}{"clientId": "staging-client"}
+	assert.NoError(Save(map[string]map[string]interface{}{
+		"config": {"rootUrl": "https://prod.example.com"},
+	}))
+
+	// the file is only readable by its owner, as it holds credentials
+	info, err := os.Stat(configFile())
+	assert.NoError(err)
+	assert.Equal(os.FileMode(0600), info.Mode().Perm())
+
+	profiles, err := LoadProfiles()
+	assert.NoError(err)
+	assert.Equal(Profiles, profiles)
+	config, err := Load()
+	assert.NoError(err)
+	assert.Equal("https://prod.example.com", config["config"]["rootUrl"])
+	assert.NotContains(config, ProfilesKey)
+}
+
+func TestProfilesTimeout(t *testing.T) {
+	assert := assert.New(t)
+	defer setUpConfig(t, `
+profiles:
+  slow:
+    timeout: 5m
+    ioTimeout: 1h
+`)()
+	RegisterOptions("config", map[string]OptionDefinition{
+		"timeout":   {Default: "30s", Env: "TASKCLUSTER_TIMEOUT"},
+		"ioTimeout": {Default: "0s", Env: "TASKCLUSTER_IO_TIMEOUT"},
+	})
+	defer func() { Timeout, IOTimeout = DefaultTimeout, 0 }()
+
+	var err error
+	Configuration, err = Load()
+	assert.NoError(err)
+	Profiles, err = LoadProfiles()
+	assert.NoError(err)
+
+	assert.NoError(UseProfile(""))
+	assert.Equal(DefaultTimeout, Timeout)
+	assert.Equal(time.Duration(0), IOTimeout)
+
+	assert.NoError(UseProfile("slow"))
+	assert.Equal(5*time.Minute, Timeout)
+	assert.Equal(time.Hour, IOTimeout)
+}
+
+func TestDuration(t *testing.T) {
+	assert := assert.New(t)
+
+	d, ok := Duration("90s")
+	assert.True(ok)
+	assert.Equal(90*time.Second, d)
+
+	d, ok = Duration("0")
+	assert.True(ok)
+	assert.Equal(time.Duration(0), d)
+
+	for _, invalid := range []interface{}{"-1s", "soon", 30, nil} {
+		_, ok = Duration(invalid)
+		assert.False(ok, "%v", invalid)
+	}
+}
+
+func TestProfilesRateLimit(t *testing.T) {
+	assert := assert.New(t)
+	defer setUpConfig(t, `
+profiles:
+  gentle:
+    rateLimit: 2.5
+    rateBurst: 10
+`)()
+	RegisterOptions("config", map[string]OptionDefinition{
+		"rateLimit": {Default: 0, Env: "TASKCLUSTER_RATE_LIMIT", Parse: true},
+		"rateBurst": {Default: DefaultRateBurst, Env: "TASKCLUSTER_RATE_BURST", Parse: true},
+	})
+	defer func() { RateLimit, RateBurst = 0, DefaultRateBurst }()
+
+	var err error
+	Configuration, err = Load()
+	assert.NoError(err)
+	Profiles, err = LoadProfiles()
+	assert.NoError(err)
+
+	assert.NoError(UseProfile(""))
+	assert.Equal(float64(0), RateLimit)
+	assert.Equal(DefaultRateBurst, RateBurst)
+
+	assert.NoError(UseProfile("gentle"))
+	assert.Equal(2.5, RateLimit)
+	assert.Equal(10, RateBurst)
+}
+
+func TestNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	n, ok := Number(3)
+	assert.True(ok)
+	assert.Equal(float64(3), n)
+
+	n, ok = Number(0.5)
+	assert.True(ok)
+	assert.Equal(0.5, n)
+
+	for _, invalid := range []interface{}{-1, "5", nil} {
+		_, ok = Number(invalid)
+		assert.False(ok, "%v", invalid)
+	}
+}
+
+func TestProfilesTLS(t *testing.T) {
+	assert := assert.New(t)
+	defer setUpConfig(t, `
+profiles:
+  staging:
+    caCert: /etc/staging-ca.pem
+    insecure: true
+`)()
+	RegisterOptions("config", map[string]OptionDefinition{
+		"caCert":   {Default: "", Env: "TASKCLUSTER_CA_CERT"},
+		"insecure": {Default: false, Parse: true},
+	})
+	defer func() { CACert, Insecure = "", false }()
+
+	var err error
+	Configuration, err = Load()
+	assert.NoError(err)
+	Profiles, err = LoadProfiles()
+	assert.NoError(err)
+
+	assert.NoError(UseProfile(""))
+	assert.Equal("", CACert)
+	assert.False(Insecure, "certificates must be verified by default")
+
+	assert.NoError(UseProfile("staging"))
+	assert.Equal("/etc/staging-ca.pem", CACert)
+	assert.True(Insecure)
+}
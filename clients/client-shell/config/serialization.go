@@ -46,6 +46,9 @@ func Load() (map[string]map[string]interface{}, error) {
 		}
 	}
 
+	// Profiles are loaded separately, by LoadProfiles
+	delete(config, ProfilesKey)
+
 	// Populate missing config fields with default values
 	for command, options := range OptionsDefinitions {
 		if _, ok := config[command]; !ok {
@@ -126,7 +129,8 @@ func Load() (map[string]map[string]interface{}, error) {
 	return config, nil
 }
 
-// Save will save configuration.
+// Save will save configuration, along with the profiles in Profiles.  As it
+// may contain credentials, the file is only readable by its owner.
 func Save(config map[string]map[string]interface{}) error {
 	result := make(map[string]map[string]interface{})
 
@@ -160,6 +164,14 @@ func Save(config map[string]map[string]interface{}) error {
 		}
 	}
 
+	// Add the profiles, which were validated as they were set
+	if len(Profiles) > 0 {
+		result[ProfilesKey] = make(map[string]interface{})
+		for name, options := range Profiles {
+			result[ProfilesKey][name] = options
+		}
+	}
+
 	// Serialize the config data
 	data, err := yaml.Marshal(result)
 	if err != nil {
@@ -169,10 +181,14 @@ func Save(config map[string]map[string]interface{}) error {
 	// Write config file
 	configFile := configFile()
 	// Attempt to create config folder if it doesn't exist... (ignore errors)
-	_ = os.MkdirAll(filepath.Dir(configFile), 0664)
-	if err = ioutil.WriteFile(configFile, data, 0664); err != nil {
+	_ = os.MkdirAll(filepath.Dir(configFile), 0755)
+	if err = ioutil.WriteFile(configFile, data, 0600); err != nil {
 		return fmt.Errorf("Failed to write config file: %s, error: %s", configFile, err)
 	}
+	// WriteFile only sets the mode of new files
+	if err = os.Chmod(configFile, 0600); err != nil {
+		return fmt.Errorf("Failed to set permissions of config file: %s, error: %s", configFile, err)
+	}
 
 	return nil
 }
@@ -0,0 +1,292 @@
+package jsone
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeNow is the clock used for `now` when the context does not give it.
+var timeNow = time.Now
+
+// timeFormat is the format of the times produced by fromNow and `now`.
+const timeFormat = "2006-01-02T15:04:05.000Z"
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeFormat)
+}
+
+// offsetPattern matches the offsets of fromNow, such as `2 days 3 hours` or
+// `-1h`.
+var offsetPattern = regexp.MustCompile(`^(\s*(-|\+))?` +
+	`(\s*(\d+)\s*y((ears?)|r)?)?` +
+	`(\s*(\d+)\s*mo(nths?)?)?` +
+	`(\s*(\d+)\s*w((eeks?)|k)?)?` +
+	`(\s*(\d+)\s*d(ays?)?)?` +
+	`(\s*(\d+)\s*h((ours?)|r)?)?` +
+	`(\s*(\d+)\s*m(in(utes?)?)?)?` +
+	`(\s*(\d+)\s*s(ec(onds?)?)?)?\s*$`)
+
+// offsetUnits are the groups of offsetPattern holding numbers, and their
+// units; as in JSON-e, years are 365 days and months 30 days.
+var offsetUnits = []struct {
+	group int
+	unit  time.Duration
+}{
+	{4, 365 * 24 * time.Hour},
+	{8, 30 * 24 * time.Hour},
+	{11, 7 * 24 * time.Hour},
+	{15, 24 * time.Hour},
+	{18, time.Hour},
+	{22, time.Minute},
+	{26, time.Second},
+}
+
+// fromNow returns the time offset from reference, which is a time as
+// produced by formatTime.
+func fromNow(offset string, reference interface{}) (interface{}, error) {
+	from, ok := reference.(string)
+	if !ok {
+		return nil, interpreterError("fromNow expects the reference time to be a string")
+	}
+	t, err := time.Parse(time.RFC3339Nano, from)
+	if err != nil {
+		return nil, interpreterError("fromNow cannot parse the reference time '%s'", from)
+	}
+	match := offsetPattern.FindStringSubmatch(offset)
+	if match == nil {
+		return nil, interpreterError("'%s' is not a valid time offset", offset)
+	}
+	var d time.Duration
+	for _, u := range offsetUnits {
+		if match[u.group] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(match[u.group])
+		if err != nil {
+			return nil, interpreterError("'%s' is not a valid time offset", offset)
+		}
+		d += time.Duration(n) * u.unit
+	}
+	if match[2] == "-" {
+		d = -d
+	}
+	return formatTime(t.Add(d)), nil
+}
+
+// builtins returns the functions available in every context.
+func builtins() map[string]interface{} {
+	return map[string]interface{}{
+		"abs":   mathFunction("abs", math.Abs),
+		"ceil":  mathFunction("ceil", math.Ceil),
+		"floor": mathFunction("floor", math.Floor),
+		"sqrt":  mathFunction("sqrt", math.Sqrt),
+		"max":   extremum("max", func(a, b float64) bool { return a > b }),
+		"min":   extremum("min", func(a, b float64) bool { return a < b }),
+
+		"lowercase": stringFunction("lowercase", strings.ToLower),
+		"uppercase": stringFunction("uppercase", strings.ToUpper),
+		"strip":     stringFunction("strip", strings.TrimSpace),
+		"lstrip": stringFunction("lstrip", func(s string) string {
+			return strings.TrimLeft(s, " \t\n\r\v\f")
+		}),
+		"rstrip": stringFunction("rstrip", func(s string) string {
+			return strings.TrimRight(s, " \t\n\r\v\f")
+		}),
+
+		"len": Function(func(args ...interface{}) (interface{}, error) {
+			if len(args) != 1 {
+				return nil, interpreterError("len expects one argument")
+			}
+			switch v := args[0].(type) {
+			case string:
+				return float64(len([]rune(v))), nil
+			case []interface{}:
+				return float64(len(v)), nil
+			}
+			return nil, interpreterError("len expects a string or an array, not %s", typeOf(args[0]))
+		}),
+
+		"str": Function(func(args ...interface{}) (interface{}, error) {
+			if len(args) != 1 {
+				return nil, interpreterError("str expects one argument")
+			}
+			switch args[0].(type) {
+			case []interface{}, map[string]interface{}:
+				return nil, interpreterError("str expects a string, number, boolean or null, not %s", typeOf(args[0]))
+			}
+			return stringify(args[0])
+		}),
+
+		"number": Function(func(args ...interface{}) (interface{}, error) {
+			s, ok := oneString(args)
+			if !ok {
+				return nil, interpreterError("number expects one string")
+			}
+			n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+			if err != nil {
+				return nil, interpreterError("'%s' is not a number", s)
+			}
+			return n, nil
+		}),
+
+		"split": Function(func(args ...interface{}) (interface{}, error) {
+			if len(args) != 2 {
+				return nil, interpreterError("split expects a string and a separator")
+			}
+			s, ok := args[0].(string)
+			if !ok {
+				return nil, interpreterError("split expects a string, not %s", typeOf(args[0]))
+			}
+			separator, err := stringify(args[1])
+			if err != nil {
+				return nil, err
+			}
+			result := []interface{}{}
+			if s == "" {
+				return append(result, ""), nil
+			}
+			for _, part := range strings.Split(s, separator) {
+				result = append(result, part)
+			}
+			return result, nil
+		}),
+
+		"join": Function(func(args ...interface{}) (interface{}, error) {
+			if len(args) != 2 {
+				return nil, interpreterError("join expects an array and a separator")
+			}
+			items, ok := args[0].([]interface{})
+			if !ok {
+				return nil, interpreterError("join expects an array, not %s", typeOf(args[0]))
+			}
+			separator, err := stringify(args[1])
+			if err != nil {
+				return nil, err
+			}
+			parts := make([]string, len(items))
+			for i, item := range items {
+				switch item.(type) {
+				case string, float64:
+				default:
+					return nil, interpreterError("join expects an array of strings or numbers")
+				}
+				if parts[i], err = stringify(item); err != nil {
+					return nil, err
+				}
+			}
+			return strings.Join(parts, separator), nil
+		}),
+
+		"range": Function(func(args ...interface{}) (interface{}, error) {
+			if len(args) < 2 || len(args) > 3 {
+				return nil, interpreterError("range expects a start, an end and optionally a step")
+			}
+			bounds := make([]int, 3)
+			bounds[2] = 1
+			for i, arg := range args {
+				n, ok := arg.(float64)
+				if !ok || n != math.Trunc(n) {
+					return nil, interpreterError("range expects integers")
+				}
+				bounds[i] = int(n)
+			}
+			start, end, step := bounds[0], bounds[1], bounds[2]
+			if step == 0 {
+				return nil, interpreterError("the step of range cannot be zero")
+			}
+			result := []interface{}{}
+			for i := start; (step > 0 && i < end) || (step < 0 && i > end); i += step {
+				result = append(result, float64(i))
+			}
+			return result, nil
+		}),
+
+		"typeof": Function(func(args ...interface{}) (interface{}, error) {
+			if len(args) != 1 {
+				return nil, interpreterError("typeof expects one argument")
+			}
+			return typeOf(args[0]), nil
+		}),
+
+		"fromNow": contextFunction(func(ctx map[string]interface{}, args ...interface{}) (interface{}, error) {
+			if len(args) < 1 || len(args) > 2 {
+				return nil, interpreterError("fromNow expects an offset and optionally a reference time")
+			}
+			offset, ok := args[0].(string)
+			if !ok {
+				return nil, interpreterError("fromNow expects a string offset")
+			}
+			reference := ctx["now"]
+			if len(args) == 2 {
+				reference = args[1]
+			}
+			return fromNow(offset, reference)
+		}),
+
+		"defined": contextFunction(func(ctx map[string]interface{}, args ...interface{}) (interface{}, error) {
+			name, ok := oneString(args)
+			if !ok {
+				return nil, interpreterError("defined expects one string")
+			}
+			_, defined := ctx[name]
+			return defined, nil
+		}),
+	}
+}
+
+func oneString(args []interface{}) (string, bool) {
+	if len(args) != 1 {
+		return "", false
+	}
+	s, ok := args[0].(string)
+	return s, ok
+}
+
+func mathFunction(name string, f func(float64) float64) Function {
+	return func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, interpreterError("%s expects one number", name)
+		}
+		n, ok := args[0].(float64)
+		if !ok {
+			return nil, interpreterError("%s expects a number, not %s", name, typeOf(args[0]))
+		}
+		result := f(n)
+		if math.IsNaN(result) {
+			return nil, interpreterError("%s(%v) is not a number", name, n)
+		}
+		return result, nil
+	}
+}
+
+func extremum(name string, better func(a, b float64) bool) Function {
+	return func(args ...interface{}) (interface{}, error) {
+		if len(args) == 0 {
+			return nil, interpreterError("%s expects at least one number", name)
+		}
+		var result float64
+		for i, arg := range args {
+			n, ok := arg.(float64)
+			if !ok {
+				return nil, interpreterError("%s expects numbers, not %s", name, typeOf(arg))
+			}
+			if i == 0 || better(n, result) {
+				result = n
+			}
+		}
+		return result, nil
+	}
+}
+
+func stringFunction(name string, f func(string) string) Function {
+	return func(args ...interface{}) (interface{}, error) {
+		s, ok := oneString(args)
+		if !ok {
+			return nil, interpreterError("%s expects one string", name)
+		}
+		return f(s), nil
+	}
+}
@@ -0,0 +1,889 @@
+package jsone
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// tokenKind is the kind of a token of an expression.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdentifier
+	tokenOperator
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	// offset of the token in the source
+	offset int
+}
+
+// operators are the operators and punctuation of expressions, longest first
+// so that `**` is not read as two `*`.
+var operators = []string{
+	"**", "==", "!=", "<=", ">=", "&&", "||",
+	"+", "-", "*", "/", "<", ">", "!", "(", ")", "[", "]", "{", "}", ",", ":", ".",
+}
+
+// tokenizer splits an expression into tokens, ending at the end of the source
+// or, for interpolations, at the `}` closing them.
+type tokenizer struct {
+	source string
+	offset int
+}
+
+func (tz *tokenizer) next() (token, error) {
+	for tz.offset < len(tz.source) && strings.ContainsRune(" \t\r\n", rune(tz.source[tz.offset])) {
+		tz.offset++
+	}
+	start := tz.offset
+	if start >= len(tz.source) {
+		return token{kind: tokenEOF, offset: start}, nil
+	}
+
+	c := tz.source[start]
+	switch {
+	case c >= '0' && c <= '9':
+		end := start
+		for end < len(tz.source) && tz.source[end] >= '0' && tz.source[end] <= '9' {
+			end++
+		}
+		if end+1 < len(tz.source) && tz.source[end] == '.' && tz.source[end+1] >= '0' && tz.source[end+1] <= '9' {
+			end++
+			for end < len(tz.source) && tz.source[end] >= '0' && tz.source[end] <= '9' {
+				end++
+			}
+		}
+		tz.offset = end
+		return token{kind: tokenNumber, value: tz.source[start:end], offset: start}, nil
+	case c == '"' || c == '\'':
+		// strings have no escape sequences
+		end := strings.IndexByte(tz.source[start+1:], c)
+		if end < 0 {
+			return token{}, syntaxError("unterminated string in '%s'", tz.source)
+		}
+		tz.offset = start + 1 + end + 1
+		return token{kind: tokenString, value: tz.source[start+1 : start+1+end], offset: start}, nil
+	case isIdentifierStart(c):
+		end := start
+		for end < len(tz.source) && (isIdentifierStart(tz.source[end]) || (tz.source[end] >= '0' && tz.source[end] <= '9')) {
+			end++
+		}
+		tz.offset = end
+		return token{kind: tokenIdentifier, value: tz.source[start:end], offset: start}, nil
+	}
+
+	for _, op := range operators {
+		if strings.HasPrefix(tz.source[start:], op) {
+			tz.offset = start + len(op)
+			return token{kind: tokenOperator, value: op, offset: start}, nil
+		}
+	}
+	return token{}, syntaxError("unexpected character '%c' in '%s'", c, tz.source)
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isIdentifier returns true if s can be used as the name of a context value.
+func isIdentifier(s string) bool {
+	if s == "" || !isIdentifierStart(s[0]) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if !isIdentifierStart(s[i]) && (s[i] < '0' || s[i] > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// node is a parsed expression, which evaluates to a value in a context.
+type node func(ctx map[string]interface{}) (interface{}, error)
+
+// parser parses expressions by precedence climbing, from the lowest
+// precedence (`||`) to the highest (unary operators, then property access,
+// indexing and calls).
+type parser struct {
+	tz      *tokenizer
+	current token
+}
+
+func newParser(source string, offset int) (*parser, error) {
+	p := &parser{tz: &tokenizer{source: source, offset: offset}}
+	return p, p.advance()
+}
+
+func (p *parser) advance() error {
+	tok, err := p.tz.next()
+	if err != nil {
+		return err
+	}
+	p.current = tok
+	return nil
+}
+
+// accept consumes the current token if it is the given operator.
+func (p *parser) accept(op string) (bool, error) {
+	if p.current.kind != tokenOperator || p.current.value != op {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) expect(op string) error {
+	ok, err := p.accept(op)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return p.unexpected()
+	}
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	if p.current.kind == tokenEOF {
+		return syntaxError("unexpected end of input in '%s'", p.tz.source)
+	}
+	return syntaxError("unexpected '%s' in '%s'", p.current.value, p.tz.source)
+}
+
+// binaryLevels are the binary operators, by increasing precedence; `**` is
+// handled separately, as it is right-associative.
+var binaryLevels = [][]string{
+	{"||"},
+	{"&&"},
+	{"in"},
+	{"==", "!="},
+	{"<", ">", "<=", ">="},
+	{"+", "-"},
+	{"*", "/"},
+}
+
+func (p *parser) isBinary(ops []string) (string, bool) {
+	if p.current.kind != tokenOperator && !(p.current.kind == tokenIdentifier && p.current.value == "in") {
+		return "", false
+	}
+	for _, op := range ops {
+		if p.current.value == op {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) parseExpression() (node, error) {
+	return p.parseLevel(0)
+}
+
+func (p *parser) parseLevel(level int) (node, error) {
+	if level == len(binaryLevels) {
+		return p.parsePower()
+	}
+	left, err := p.parseLevel(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.isBinary(binaryLevels[level])
+		if !ok {
+			return left, nil
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseLevel(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = binary(op, left, right)
+	}
+}
+
+func (p *parser) parsePower() (node, error) {
+	base, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	ok, err := p.accept("**")
+	if err != nil || !ok {
+		return base, err
+	}
+	exponent, err := p.parsePower()
+	if err != nil {
+		return nil, err
+	}
+	return binary("**", base, exponent), nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	for _, op := range []string{"!", "-", "+"} {
+		ok, err := p.accept(op)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unary(op, operand), nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	value, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.current.kind == tokenOperator && p.current.value == ".":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if p.current.kind != tokenIdentifier {
+				return nil, p.unexpected()
+			}
+			name := p.current.value
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			value = property(value, name)
+		case p.current.kind == tokenOperator && p.current.value == "[":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			value, err = p.parseIndex(value)
+			if err != nil {
+				return nil, err
+			}
+		case p.current.kind == tokenOperator && p.current.value == "(":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			args, err := p.parseList(")")
+			if err != nil {
+				return nil, err
+			}
+			value = call(value, args)
+		default:
+			return value, nil
+		}
+	}
+}
+
+// parseIndex parses what follows the `[` of `value[index]` or
+// `value[start:end]`, either bound of a slice being optional.
+func (p *parser) parseIndex(value node) (node, error) {
+	var start, end node
+	var err error
+	if p.current.kind != tokenOperator || p.current.value != ":" {
+		if start, err = p.parseExpression(); err != nil {
+			return nil, err
+		}
+	}
+	isSlice, err := p.accept(":")
+	if err != nil {
+		return nil, err
+	}
+	if isSlice && (p.current.kind != tokenOperator || p.current.value != "]") {
+		if end, err = p.parseExpression(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect("]"); err != nil {
+		return nil, err
+	}
+	if isSlice {
+		return slice(value, start, end), nil
+	}
+	return index(value, start), nil
+}
+
+// parseList parses comma-separated expressions up to the closing operator.
+func (p *parser) parseList(closing string) ([]node, error) {
+	var items []node
+	for {
+		ok, err := p.accept(closing)
+		if err != nil || ok {
+			return items, err
+		}
+		if len(items) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		item, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.current
+	switch tok.kind {
+	case tokenNumber:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		n, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, syntaxError("invalid number '%s'", tok.value)
+		}
+		return constant(n), nil
+	case tokenString:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return constant(tok.value), nil
+	case tokenIdentifier:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		switch tok.value {
+		case "true":
+			return constant(true), nil
+		case "false":
+			return constant(false), nil
+		case "null":
+			return constant(nil), nil
+		case "in":
+			return nil, syntaxError("unexpected 'in' in '%s'", p.tz.source)
+		}
+		return variable(tok.value), nil
+	case tokenOperator:
+		switch tok.value {
+		case "(":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			inner, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			return inner, p.expect(")")
+		case "[":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			items, err := p.parseList("]")
+			if err != nil {
+				return nil, err
+			}
+			return arrayLiteral(items), nil
+		case "{":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			return p.parseObject()
+		}
+	}
+	return nil, p.unexpected()
+}
+
+// parseObject parses what follows the `{` of an object literal, whose keys
+// are identifiers or strings.
+func (p *parser) parseObject() (node, error) {
+	var keys []string
+	var values []node
+	for {
+		ok, err := p.accept("}")
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return objectLiteral(keys, values), nil
+		}
+		if len(keys) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		if p.current.kind != tokenIdentifier && p.current.kind != tokenString {
+			return nil, p.unexpected()
+		}
+		keys = append(keys, p.current.value)
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+}
+
+// parse parses the whole of source as an expression.
+func parse(source string) (node, error) {
+	p, err := newParser(source, 0)
+	if err != nil {
+		return nil, err
+	}
+	expr, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if p.current.kind != tokenEOF {
+		return nil, p.unexpected()
+	}
+	return expr, nil
+}
+
+// parseInterpolation parses the expression of an interpolation starting at
+// offset of source, just after its `${`, returning it and the offset just
+// after the closing `}`.
+func parseInterpolation(source string, offset int) (node, int, error) {
+	p, err := newParser(source, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	expr, err := p.parseExpression()
+	if err != nil {
+		return nil, 0, err
+	}
+	if p.current.kind != tokenOperator || p.current.value != "}" {
+		return nil, 0, p.unexpected()
+	}
+	return expr, p.current.offset + 1, nil
+}
+
+// evaluate evaluates the expression source in ctx.
+func evaluate(source string, ctx map[string]interface{}) (interface{}, error) {
+	expr, err := parse(source)
+	if err != nil {
+		return nil, err
+	}
+	return expr(ctx)
+}
+
+func constant(value interface{}) node {
+	return func(map[string]interface{}) (interface{}, error) {
+		return value, nil
+	}
+}
+
+func variable(name string) node {
+	return func(ctx map[string]interface{}) (interface{}, error) {
+		value, ok := ctx[name]
+		if !ok {
+			return nil, interpreterError("unknown context value %s", name)
+		}
+		return value, nil
+	}
+}
+
+func arrayLiteral(items []node) node {
+	return func(ctx map[string]interface{}) (interface{}, error) {
+		array := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			value, err := item(ctx)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		return array, nil
+	}
+}
+
+func objectLiteral(keys []string, values []node) node {
+	return func(ctx map[string]interface{}) (interface{}, error) {
+		object := make(map[string]interface{}, len(keys))
+		for i, key := range keys {
+			value, err := values[i](ctx)
+			if err != nil {
+				return nil, err
+			}
+			object[key] = value
+		}
+		return object, nil
+	}
+}
+
+func unary(op string, operand node) node {
+	return func(ctx map[string]interface{}) (interface{}, error) {
+		value, err := operand(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if op == "!" {
+			return !truthy(value), nil
+		}
+		n, ok := value.(float64)
+		if !ok {
+			return nil, interpreterError("%s expects a number", op)
+		}
+		if op == "-" {
+			return -n, nil
+		}
+		return n, nil
+	}
+}
+
+func binary(op string, left, right node) node {
+	return func(ctx map[string]interface{}) (interface{}, error) {
+		l, err := left(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// the logical operators do not evaluate their right operand if
+		// the left one decides the result
+		switch op {
+		case "||":
+			if truthy(l) {
+				return true, nil
+			}
+		case "&&":
+			if !truthy(l) {
+				return false, nil
+			}
+		}
+		r, err := right(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		switch op {
+		case "||", "&&":
+			return truthy(r), nil
+		case "==":
+			return equal(l, r), nil
+		case "!=":
+			return !equal(l, r), nil
+		case "in":
+			return contains(r, l)
+		case "+":
+			if ls, ok := l.(string); ok {
+				if rs, ok := r.(string); ok {
+					return ls + rs, nil
+				}
+			}
+		case "<", ">", "<=", ">=":
+			return compare(op, l, r)
+		}
+
+		ln, lok := l.(float64)
+		rn, rok := r.(float64)
+		if !lok || !rok {
+			if op == "+" {
+				return nil, interpreterError("+ expects two numbers or two strings")
+			}
+			return nil, interpreterError("%s expects numbers", op)
+		}
+		switch op {
+		case "+":
+			return ln + rn, nil
+		case "-":
+			return ln - rn, nil
+		case "*":
+			return ln * rn, nil
+		case "/":
+			if rn == 0 {
+				return nil, interpreterError("division by zero")
+			}
+			return ln / rn, nil
+		}
+		return math.Pow(ln, rn), nil
+	}
+}
+
+func compare(op string, l, r interface{}) (interface{}, error) {
+	var cmp int
+	switch lv := l.(type) {
+	case float64:
+		rv, ok := r.(float64)
+		if !ok {
+			return nil, interpreterError("%s expects two numbers or two strings", op)
+		}
+		switch {
+		case lv < rv:
+			cmp = -1
+		case lv > rv:
+			cmp = 1
+		}
+	case string:
+		rv, ok := r.(string)
+		if !ok {
+			return nil, interpreterError("%s expects two numbers or two strings", op)
+		}
+		cmp = strings.Compare(lv, rv)
+	default:
+		return nil, interpreterError("%s expects two numbers or two strings", op)
+	}
+	switch op {
+	case "<":
+		return cmp < 0, nil
+	case ">":
+		return cmp > 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	}
+	return cmp >= 0, nil
+}
+
+// contains implements `needle in haystack`: the membership of an array, the
+// keys of an object, or the substrings of a string.
+func contains(haystack, needle interface{}) (interface{}, error) {
+	switch h := haystack.(type) {
+	case []interface{}:
+		for _, item := range h {
+			if equal(item, needle) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case map[string]interface{}:
+		key, ok := needle.(string)
+		if !ok {
+			return nil, interpreterError("in of an object expects a string key")
+		}
+		_, ok = h[key]
+		return ok, nil
+	case string:
+		sub, ok := needle.(string)
+		if !ok {
+			return nil, interpreterError("in of a string expects a string")
+		}
+		return strings.Contains(h, sub), nil
+	}
+	return nil, interpreterError("in expects an array, an object or a string")
+}
+
+func property(value node, name string) node {
+	return func(ctx map[string]interface{}) (interface{}, error) {
+		v, err := value(ctx)
+		if err != nil {
+			return nil, err
+		}
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, interpreterError("cannot access property %s of %s", name, typeOf(v))
+		}
+		return object[name], nil
+	}
+}
+
+func index(value, at node) node {
+	return func(ctx map[string]interface{}) (interface{}, error) {
+		v, err := value(ctx)
+		if err != nil {
+			return nil, err
+		}
+		i, err := at(ctx)
+		if err != nil {
+			return nil, err
+		}
+		switch container := v.(type) {
+		case map[string]interface{}:
+			key, ok := i.(string)
+			if !ok {
+				return nil, interpreterError("object keys must be strings")
+			}
+			return container[key], nil
+		case []interface{}:
+			n, err := position(i, len(container))
+			if err != nil {
+				return nil, err
+			}
+			return container[n], nil
+		case string:
+			runes := []rune(container)
+			n, err := position(i, len(runes))
+			if err != nil {
+				return nil, err
+			}
+			return string(runes[n]), nil
+		}
+		return nil, interpreterError("cannot index %s", typeOf(v))
+	}
+}
+
+// position returns the offset of index i in a sequence of the given length,
+// counting from the end if i is negative.
+func position(i interface{}, length int) (int, error) {
+	n, ok := i.(float64)
+	if !ok || n != math.Trunc(n) {
+		return 0, interpreterError("indexes must be integers")
+	}
+	at := int(n)
+	if at < 0 {
+		at += length
+	}
+	if at < 0 || at >= length {
+		return 0, interpreterError("index %d out of bounds", int(n))
+	}
+	return at, nil
+}
+
+func slice(value, start, end node) node {
+	return func(ctx map[string]interface{}) (interface{}, error) {
+		v, err := value(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var length int
+		switch container := v.(type) {
+		case []interface{}:
+			length = len(container)
+		case string:
+			length = utf8.RuneCountInString(container)
+		default:
+			return nil, interpreterError("cannot slice %s", typeOf(v))
+		}
+		from, err := sliceBound(ctx, start, 0, length)
+		if err != nil {
+			return nil, err
+		}
+		to, err := sliceBound(ctx, end, length, length)
+		if err != nil {
+			return nil, err
+		}
+		if to < from {
+			to = from
+		}
+		if array, ok := v.([]interface{}); ok {
+			return append([]interface{}{}, array[from:to]...), nil
+		}
+		return string([]rune(v.(string))[from:to]), nil
+	}
+}
+
+// sliceBound evaluates a bound of a slice, clamped to the sequence; without
+// one, it is def.
+func sliceBound(ctx map[string]interface{}, bound node, def, length int) (int, error) {
+	if bound == nil {
+		return def, nil
+	}
+	v, err := bound(ctx)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := v.(float64)
+	if !ok || n != math.Trunc(n) {
+		return 0, interpreterError("slice bounds must be integers")
+	}
+	at := int(n)
+	if at < 0 {
+		at += length
+	}
+	if at < 0 {
+		at = 0
+	}
+	if at > length {
+		at = length
+	}
+	return at, nil
+}
+
+func call(function node, args []node) node {
+	return func(ctx map[string]interface{}) (interface{}, error) {
+		f, err := function(ctx)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]interface{}, 0, len(args))
+		for _, arg := range args {
+			value, err := arg(ctx)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		switch fn := f.(type) {
+		case Function:
+			return fn(values...)
+		case contextFunction:
+			return fn(ctx, values...)
+		}
+		return nil, interpreterError("%s is not callable", typeOf(f))
+	}
+}
+
+// truthy returns the truth of a value: null, false, 0, and empty strings,
+// arrays and objects are false.
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+// equal compares values deeply; functions are never equal.
+func equal(a, b interface{}) bool {
+	if isFunction(a) || isFunction(b) {
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func isFunction(value interface{}) bool {
+	switch value.(type) {
+	case Function, contextFunction:
+		return true
+	}
+	return false
+}
+
+// typeOf returns the JSON-e type of a value, as the typeof builtin does.
+func typeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case Function, contextFunction:
+		return "function"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// sortedKeys returns the keys of an object, sorted.
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,663 @@
+// Package jsone renders JSON-e templates, such as the `.taskcluster.yml` files
+// of repositories, as the Taskcluster services do.
+//
+// Templates and contexts are JSON values as decoded by encoding/json, with
+// numbers as float64, and functions of the context as Function.  See
+// https://json-e.js.org for the language.
+package jsone
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Function is a function of the context, which expressions can call.
+type Function func(args ...interface{}) (interface{}, error)
+
+// contextFunction is a builtin which needs the context it is called in, such
+// as `defined`.
+type contextFunction func(ctx map[string]interface{}, args ...interface{}) (interface{}, error)
+
+// Error is an error rendering a template: a SyntaxError in an expression, an
+// InterpreterError evaluating one, or a TemplateError in the use of the
+// operators.
+type Error struct {
+	Kind    string
+	Message string
+	// Location is the path within the template of the value being
+	// rendered, such as `template.tasks[0]`.
+	Location string
+}
+
+func (e *Error) Error() string {
+	if e.Location == "" {
+		return fmt.Sprintf("%s: %s", e.Kind, e.Message)
+	}
+	return fmt.Sprintf("%s at %s: %s", e.Kind, e.Location, e.Message)
+}
+
+func syntaxError(format string, args ...interface{}) error {
+	return &Error{Kind: "SyntaxError", Message: fmt.Sprintf(format, args...)}
+}
+
+func interpreterError(format string, args ...interface{}) error {
+	return &Error{Kind: "InterpreterError", Message: fmt.Sprintf(format, args...)}
+}
+
+func templateError(format string, args ...interface{}) error {
+	return &Error{Kind: "TemplateError", Message: fmt.Sprintf(format, args...)}
+}
+
+// deleted is the result of templates which produce no value, such as an
+// `$if` without `else` whose condition is false; it is omitted from the
+// arrays and objects containing it.
+type deletedMarker struct{}
+
+var deleted = deletedMarker{}
+
+// Render renders template in the given context.  The builtin functions, and
+// `now` if the context does not give it, are added to the context.  A
+// template which produces no value at all renders as nil.
+func Render(template interface{}, context map[string]interface{}) (interface{}, error) {
+	ctx := builtins()
+	for key, value := range context {
+		if !isIdentifier(key) {
+			return nil, templateError("top level keys of the context must be identifiers, not '%s'", key)
+		}
+		ctx[key] = value
+	}
+	if _, ok := ctx["now"]; !ok {
+		ctx["now"] = formatTime(timeNow())
+	}
+
+	result, err := render(template, ctx, "template")
+	if err != nil {
+		return nil, err
+	}
+	if result == deleted {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// operatorProperties are the operators of templates, with the properties
+// they allow besides their own; `each(...)` and `by(...)` are matched by
+// prefix.
+var operatorProperties = map[string][]string{
+	"$eval":        nil,
+	"$flatten":     nil,
+	"$flattenDeep": nil,
+	"$fromNow":     {"from"},
+	"$if":          {"then", "else"},
+	"$json":        nil,
+	"$let":         {"in"},
+	"$map":         {"each("},
+	"$match":       nil,
+	"$merge":       nil,
+	"$mergeDeep":   nil,
+	"$reverse":     nil,
+	"$sort":        {"by("},
+	"$switch":      nil,
+}
+
+// reservedPattern matches the keys reserved for operators.
+var reservedPattern = regexp.MustCompile(`^\$[a-zA-Z][a-zA-Z0-9]*$`)
+
+// render renders template in ctx; location is its path, for errors.
+func render(template interface{}, ctx map[string]interface{}, location string) (interface{}, error) {
+	result, err := renderValue(template, ctx, location)
+	if e, ok := err.(*Error); ok && e.Location == "" {
+		e.Location = location
+	}
+	return result, err
+}
+
+func renderValue(template interface{}, ctx map[string]interface{}, location string) (interface{}, error) {
+	switch t := template.(type) {
+	case string:
+		return interpolate(t, ctx)
+	case []interface{}:
+		result := make([]interface{}, 0, len(t))
+		for i, item := range t {
+			value, err := render(item, ctx, fmt.Sprintf("%s[%d]", location, i))
+			if err != nil {
+				return nil, err
+			}
+			if value != deleted {
+				result = append(result, value)
+			}
+		}
+		return result, nil
+	case map[string]interface{}:
+		return renderObject(t, ctx, location)
+	}
+	return template, nil
+}
+
+func renderObject(t map[string]interface{}, ctx map[string]interface{}, location string) (interface{}, error) {
+	var operator string
+	for _, key := range sortedKeys(t) {
+		if _, ok := operatorProperties[key]; ok {
+			if operator != "" {
+				return nil, templateError("only one operator is allowed, found %s and %s", operator, key)
+			}
+			operator = key
+		} else if reservedPattern.MatchString(key) {
+			return nil, templateError("%s is reserved; use $%s", key, key)
+		}
+	}
+	if operator != "" {
+		if err := checkProperties(operator, t); err != nil {
+			return nil, err
+		}
+		return renderOperator(operator, t, ctx, location)
+	}
+
+	result := make(map[string]interface{}, len(t))
+	for _, key := range sortedKeys(t) {
+		value, err := render(t[key], ctx, location+"."+key)
+		if err != nil {
+			return nil, err
+		}
+		if value == deleted {
+			continue
+		}
+		name := key
+		if strings.HasPrefix(name, "$$") {
+			name = name[1:]
+		} else if name, err = interpolateString(key, ctx); err != nil {
+			return nil, err
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+// checkProperties checks that the object of an operator has only the
+// properties it allows.
+func checkProperties(operator string, t map[string]interface{}) error {
+	var undefined []string
+	for key := range t {
+		if key == operator {
+			continue
+		}
+		allowed := false
+		for _, property := range operatorProperties[operator] {
+			if key == property || (strings.HasSuffix(property, "(") && strings.HasPrefix(key, property)) {
+				allowed = true
+			}
+		}
+		if !allowed {
+			undefined = append(undefined, key)
+		}
+	}
+	if len(undefined) > 0 {
+		sort.Strings(undefined)
+		return templateError("%s has undefined properties: %s", operator, strings.Join(undefined, " "))
+	}
+	return nil
+}
+
+// interpolate renders a string, replacing each `${expression}` with the
+// value of the expression, and `$${` with `${`.
+func interpolate(s string, ctx map[string]interface{}) (interface{}, error) {
+	return interpolateString(s, ctx)
+}
+
+func interpolateString(s string, ctx map[string]interface{}) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+	b := &strings.Builder{}
+	for i := 0; i < len(s); {
+		switch {
+		case strings.HasPrefix(s[i:], "$${"):
+			b.WriteString("${")
+			i += 3
+		case strings.HasPrefix(s[i:], "${"):
+			expr, end, err := parseInterpolation(s, i+2)
+			if err != nil {
+				return "", err
+			}
+			value, err := expr(ctx)
+			if err != nil {
+				return "", err
+			}
+			switch value.(type) {
+			case []interface{}, map[string]interface{}:
+				return "", interpreterError("interpolation of '%s' produced an array or object", s[i+2:end-1])
+			}
+			str, err := stringify(value)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(str)
+			i = end
+		default:
+			b.WriteByte(s[i])
+			i++
+		}
+	}
+	return b.String(), nil
+}
+
+// stringify converts a value to a string, as the str builtin does.
+func stringify(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "null", nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case string:
+		return v, nil
+	}
+	if isFunction(value) {
+		return "", interpreterError("cannot convert a function to a string")
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", interpreterError("cannot convert %s to a string: %s", typeOf(value), err)
+	}
+	return string(data), nil
+}
+
+func renderOperator(operator string, t map[string]interface{}, ctx map[string]interface{}, location string) (interface{}, error) {
+	where := location + "." + operator
+	switch operator {
+	case "$eval":
+		expr, ok := t["$eval"].(string)
+		if !ok {
+			return nil, templateError("$eval must be given a string expression")
+		}
+		return evaluate(expr, ctx)
+
+	case "$if":
+		expr, ok := t["$if"].(string)
+		if !ok {
+			return nil, templateError("$if must be given a string expression")
+		}
+		condition, err := evaluate(expr, ctx)
+		if err != nil {
+			return nil, err
+		}
+		branch := "else"
+		if truthy(condition) {
+			branch = "then"
+		}
+		value, ok := t[branch]
+		if !ok {
+			return deleted, nil
+		}
+		return render(value, ctx, location+"."+branch)
+
+	case "$json":
+		value, err := render(t["$json"], ctx, where)
+		if err != nil {
+			return nil, err
+		}
+		if containsFunction(value) {
+			return nil, templateError("$json cannot encode functions")
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, templateError("$json cannot encode the value: %s", err)
+		}
+		return string(data), nil
+
+	case "$let":
+		bindings, err := render(t["$let"], ctx, where)
+		if err != nil {
+			return nil, err
+		}
+		object, ok := bindings.(map[string]interface{})
+		if !ok {
+			return nil, templateError("$let value must be an object")
+		}
+		in, ok := t["in"]
+		if !ok {
+			return nil, templateError("$let operator requires an `in` clause")
+		}
+		inner := make(map[string]interface{}, len(ctx)+len(object))
+		for key, value := range ctx {
+			inner[key] = value
+		}
+		for key, value := range object {
+			if !isIdentifier(key) {
+				return nil, templateError("top level keys of $let must be identifiers, not '%s'", key)
+			}
+			inner[key] = value
+		}
+		return render(in, inner, location+".in")
+
+	case "$fromNow":
+		value, err := render(t["$fromNow"], ctx, where)
+		if err != nil {
+			return nil, err
+		}
+		offset, ok := value.(string)
+		if !ok {
+			return nil, templateError("$fromNow expects a string")
+		}
+		reference := ctx["now"]
+		if from, ok := t["from"]; ok {
+			if reference, err = render(from, ctx, location+".from"); err != nil {
+				return nil, err
+			}
+		}
+		return fromNow(offset, reference)
+
+	case "$flatten", "$flattenDeep", "$reverse", "$merge", "$mergeDeep", "$sort":
+		value, err := render(t[operator], ctx, where)
+		if err != nil {
+			return nil, err
+		}
+		array, ok := value.([]interface{})
+		if !ok {
+			return nil, templateError("%s value must evaluate to an array", operator)
+		}
+		return arrayOperator(operator, array, t, ctx)
+
+	case "$map":
+		return renderMap(t, ctx, location)
+
+	case "$match", "$switch":
+		cases, ok := t[operator].(map[string]interface{})
+		if !ok {
+			return nil, templateError("%s can evaluate objects only", operator)
+		}
+		return renderCases(operator, cases, ctx, where)
+	}
+	return nil, templateError("%s is not implemented", operator)
+}
+
+func arrayOperator(operator string, array []interface{}, t, ctx map[string]interface{}) (interface{}, error) {
+	switch operator {
+	case "$flatten":
+		result := []interface{}{}
+		for _, item := range array {
+			if inner, ok := item.([]interface{}); ok {
+				result = append(result, inner...)
+			} else {
+				result = append(result, item)
+			}
+		}
+		return result, nil
+	case "$flattenDeep":
+		return flattenDeep(array, []interface{}{}), nil
+	case "$reverse":
+		result := make([]interface{}, len(array))
+		for i, item := range array {
+			result[len(array)-1-i] = item
+		}
+		return result, nil
+	case "$merge", "$mergeDeep":
+		result := map[string]interface{}{}
+		for _, item := range array {
+			object, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, templateError("%s value must evaluate to an array of objects", operator)
+			}
+			if operator == "$merge" {
+				for key, value := range object {
+					result[key] = value
+				}
+			} else {
+				result = mergeDeep(result, object).(map[string]interface{})
+			}
+		}
+		return result, nil
+	}
+	return sortArray(array, t, ctx)
+}
+
+func flattenDeep(array, into []interface{}) []interface{} {
+	for _, item := range array {
+		if inner, ok := item.([]interface{}); ok {
+			into = flattenDeep(inner, into)
+		} else {
+			into = append(into, item)
+		}
+	}
+	return into
+}
+
+// mergeDeep merges b into a: objects are merged recursively, arrays are
+// concatenated, and other values of b replace those of a.
+func mergeDeep(a, b interface{}) interface{} {
+	switch bv := b.(type) {
+	case map[string]interface{}:
+		av, ok := a.(map[string]interface{})
+		if !ok {
+			return bv
+		}
+		result := make(map[string]interface{}, len(av)+len(bv))
+		for key, value := range av {
+			result[key] = value
+		}
+		for key, value := range bv {
+			if existing, ok := result[key]; ok {
+				result[key] = mergeDeep(existing, value)
+			} else {
+				result[key] = value
+			}
+		}
+		return result
+	case []interface{}:
+		if av, ok := a.([]interface{}); ok {
+			return append(append([]interface{}{}, av...), bv...)
+		}
+	}
+	return b
+}
+
+// sortArray implements `$sort`, of numbers or strings, optionally by the
+// value of an expression given as `by(x)`.
+func sortArray(array []interface{}, t, ctx map[string]interface{}) (interface{}, error) {
+	keys := append([]interface{}{}, array...)
+	for property, value := range t {
+		if !strings.HasPrefix(property, "by(") {
+			continue
+		}
+		names, err := parseEach(property, "by")
+		if err != nil {
+			return nil, err
+		}
+		if len(names) != 1 {
+			return nil, templateError("$sort requires by(identifier) for sorting arrays of objects")
+		}
+		expr, ok := value.(string)
+		if !ok {
+			return nil, templateError("$sort must be given a string expression with by(...)")
+		}
+		for i, item := range array {
+			inner := withBindings(ctx, names[0], item)
+			if keys[i], err = evaluate(expr, inner); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	order := make([]int, len(array))
+	for i := range order {
+		order[i] = i
+	}
+	if len(keys) > 0 {
+		kind := typeOf(keys[0])
+		for _, key := range keys {
+			if typeOf(key) != kind || (kind != "number" && kind != "string") {
+				return nil, templateError("$sort requires all sorted values have the same type, either numbers or strings")
+			}
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if a, ok := keys[order[i]].(float64); ok {
+			return a < keys[order[j]].(float64)
+		}
+		return keys[order[i]].(string) < keys[order[j]].(string)
+	})
+	result := make([]interface{}, len(array))
+	for i, at := range order {
+		result[i] = array[at]
+	}
+	return result, nil
+}
+
+var eachPattern = regexp.MustCompile(`^(each|by)\(\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*(?:,\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*)?\)$`)
+
+// parseEach returns the names bound by a property such as `each(x,i)`.
+func parseEach(property, function string) ([]string, error) {
+	match := eachPattern.FindStringSubmatch(property)
+	if match == nil || match[1] != function {
+		return nil, templateError("invalid %s(...) property '%s'", function, property)
+	}
+	if match[3] == "" {
+		return []string{match[2]}, nil
+	}
+	return []string{match[2], match[3]}, nil
+}
+
+func withBindings(ctx map[string]interface{}, pairs ...interface{}) map[string]interface{} {
+	inner := make(map[string]interface{}, len(ctx)+len(pairs)/2)
+	for key, value := range ctx {
+		inner[key] = value
+	}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		inner[pairs[i].(string)] = pairs[i+1]
+	}
+	return inner
+}
+
+// renderMap implements `$map`, of arrays with `each(x)` or `each(x,i)`, and
+// of objects with `each(y)`, binding `{key, val}`, or `each(v,k)`.
+func renderMap(t, ctx map[string]interface{}, location string) (interface{}, error) {
+	value, err := render(t["$map"], ctx, location+".$map")
+	if err != nil {
+		return nil, err
+	}
+	var property string
+	for key := range t {
+		if key != "$map" {
+			property = key
+		}
+	}
+	if property == "" {
+		return nil, templateError("$map requires exactly one other property, each(..)")
+	}
+	names, err := parseEach(property, "each")
+	if err != nil {
+		return nil, err
+	}
+	each := t[property]
+	where := location + "." + property
+
+	switch v := value.(type) {
+	case []interface{}:
+		result := []interface{}{}
+		for i, item := range v {
+			bindings := []interface{}{names[0], item}
+			if len(names) == 2 {
+				bindings = append(bindings, names[1], float64(i))
+			}
+			rendered, err := render(each, withBindings(ctx, bindings...), where)
+			if err != nil {
+				return nil, err
+			}
+			if rendered != deleted {
+				result = append(result, rendered)
+			}
+		}
+		return result, nil
+	case map[string]interface{}:
+		result := map[string]interface{}{}
+		for _, key := range sortedKeys(v) {
+			bindings := []interface{}{names[0], map[string]interface{}{"key": key, "val": v[key]}}
+			if len(names) == 2 {
+				bindings = []interface{}{names[0], v[key], names[1], key}
+			}
+			rendered, err := render(each, withBindings(ctx, bindings...), where)
+			if err != nil {
+				return nil, err
+			}
+			if rendered == deleted {
+				continue
+			}
+			object, ok := rendered.(map[string]interface{})
+			if !ok {
+				return nil, templateError("$map on objects expects each(..) to evaluate to an object")
+			}
+			for k, val := range object {
+				result[k] = val
+			}
+		}
+		return result, nil
+	}
+	return nil, templateError("$map value must evaluate to an array or object")
+}
+
+// renderCases implements `$match`, rendering the values of all of the true
+// conditions, in the order of the conditions, and `$switch`, rendering the
+// value of the only true condition, or of `$default`.
+func renderCases(operator string, cases, ctx map[string]interface{}, location string) (interface{}, error) {
+	var matched []string
+	for _, condition := range sortedKeys(cases) {
+		if operator == "$switch" && condition == "$default" {
+			continue
+		}
+		value, err := evaluate(condition, ctx)
+		if err != nil {
+			return nil, err
+		}
+		if truthy(value) {
+			matched = append(matched, condition)
+		}
+	}
+
+	if operator == "$match" {
+		result := []interface{}{}
+		for _, condition := range matched {
+			value, err := render(cases[condition], ctx, location+"."+condition)
+			if err != nil {
+				return nil, err
+			}
+			if value != deleted {
+				result = append(result, value)
+			}
+		}
+		return result, nil
+	}
+
+	switch len(matched) {
+	case 0:
+		if value, ok := cases["$default"]; ok {
+			return render(value, ctx, location+".$default")
+		}
+		return deleted, nil
+	case 1:
+		return render(cases[matched[0]], ctx, location+"."+matched[0])
+	}
+	return nil, templateError("$switch can only have one truthy condition, found %s", strings.Join(matched, ", "))
+}
+
+func containsFunction(value interface{}) bool {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if containsFunction(item) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			if containsFunction(item) {
+				return true
+			}
+		}
+	}
+	return isFunction(value)
+}
@@ -0,0 +1,249 @@
+package jsone
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func renderJSON(t *testing.T, template, context string) (interface{}, error) {
+	var tmpl interface{}
+	assert.NoError(t, json.Unmarshal([]byte(template), &tmpl))
+	ctx := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(context), &ctx))
+	return Render(tmpl, ctx)
+}
+
+func assertRenders(t *testing.T, expected, template, context string) {
+	t.Helper()
+	result, err := renderJSON(t, template, context)
+	assert.NoError(t, err, template)
+	data, err := json.Marshal(result)
+	assert.NoError(t, err)
+	assert.JSONEq(t, expected, string(data), template)
+}
+
+func TestExpressions(t *testing.T) {
+	context := `{"a": 2, "s": "abc", "l": [1, 2, 3], "o": {"x": {"y": "z"}}, "t": true}`
+	for expr, expected := range map[string]string{
+		"1 + 2 * 3":                     `7`,
+		"(1 + 2) * 3":                   `9`,
+		"2 ** 3 ** 2":                   `512`,
+		"-a + 10 / 4":                   `0.5`,
+		"s + 'def'":                     `"abcdef"`,
+		"a > 1 && !(s == 'x')":          `true`,
+		"a < 1 || t":                    `true`,
+		"'b' in s":                      `true`,
+		"4 in l":                        `false`,
+		"'x' in o":                      `true`,
+		"o.x.y":                         `"z"`,
+		"o['x'].y":                      `"z"`,
+		"o.missing":                     `null`,
+		"l[-1]":                         `3`,
+		"l[1:]":                         `[2, 3]`,
+		"s[:-1]":                        `"ab"`,
+		"[a, s, {k: 1}]":                `[2, "abc", {"k": 1}]`,
+		"len(l) + len(s)":               `6`,
+		"max(1, a, -3)":                 `2`,
+		"uppercase(s)":                  `"ABC"`,
+		"join(split('a-b', '-'), '+')":  `"a+b"`,
+		"str(1.5) + str(null)":          `"1.5null"`,
+		"number('12')":                  `12`,
+		"range(0, 6, 2)":                `[0, 2, 4]`,
+		"typeof(o) + typeof(typeof)":    `"objectfunction"`,
+		"defined('a') && !defined('b')": `true`,
+		"strip('  x ')":                 `"x"`,
+	} {
+		assertRenders(t, expected, `{"$eval": "`+expr+`"}`, context)
+	}
+}
+
+func TestExpressionErrors(t *testing.T) {
+	for expr, message := range map[string]string{
+		"1 +":         "SyntaxError",
+		"a":           "InterpreterError",
+		"l[5]":        "InterpreterError",
+		"1 / 0":       "InterpreterError",
+		"'a' + 1":     "InterpreterError",
+		"s()":         "InterpreterError",
+		"'unclosed":   "SyntaxError",
+		"len(1, 2)":   "InterpreterError",
+		"number('x')": "InterpreterError",
+	} {
+		_, err := renderJSON(t, `{"$eval": "`+expr+`"}`, `{"l": [], "s": "x"}`)
+		assert.Error(t, err, expr)
+		assert.Contains(t, err.Error(), message, expr)
+	}
+}
+
+func TestInterpolation(t *testing.T) {
+	assertRenders(t, `"task abc-2 true null"`, `"task ${s}-${a} ${a > 1} ${n}"`, `{"s": "abc", "a": 2, "n": null}`)
+	assertRenders(t, `"${s} 1"`, `"$${s} ${ {'a': 1}.a }"`, `{}`)
+	assertRenders(t, `"${a} ${s}"`, `"${'$'}{a} $${s}"`, `{"a": 1}`)
+	assertRenders(t, `{"key-abc": 1, "$k": 2}`, `{"key-${s}": 1, "$$k": 2}`, `{"s": "abc"}`)
+
+	_, err := renderJSON(t, `"${l}"`, `{"l": [1]}`)
+	assert.EqualError(t, err, "InterpreterError at template: interpolation of 'l' produced an array or object")
+	_, err = renderJSON(t, `"${s"`, `{"s": ""}`)
+	assert.Error(t, err)
+}
+
+func TestOperators(t *testing.T) {
+	for template, expected := range map[string]string{
+		`{"$if": "a > 1", "then": "yes", "else": "no"}`:                          `"yes"`,
+		`[{"$if": "a > 5", "then": "yes"}, 1]`:                                   `[1]`,
+		`{"k": {"$if": "false", "then": 1}, "j": 2}`:                             `{"j": 2}`,
+		`{"$json": {"a": [1, "${a}"]}}`:                                          `"{\"a\":[1,\"2\"]}"`,
+		`{"$let": {"x": {"$eval": "a + 1"}}, "in": "${x}"}`:                      `"3"`,
+		`{"$map": [1, 2], "each(x)": {"$eval": "x * a"}}`:                        `[2, 4]`,
+		`{"$map": ["a", "b"], "each(x,i)": "${i}${x}"}`:                          `["0a", "1b"]`,
+		`{"$map": {"a": 1}, "each(y)": {"${y.key}x": "${y.val}"}}`:               `{"ax": "1"}`,
+		`{"$map": {"a": 1}, "each(v,k)": {"${k}": {"$eval": "v + 1"}}}`:          `{"a": 2}`,
+		`{"$match": {"a == 2": "two", "a > 0": "pos", "a < 0": "neg"}}`:          `["two", "pos"]`,
+		`{"$switch": {"a == 1": "one", "a == 2": "two"}}`:                        `"two"`,
+		`{"$switch": {"a == 3": "three", "$default": "other"}}`:                  `"other"`,
+		`[{"$switch": {"a == 3": "three"}}]`:                                     `[]`,
+		`{"$merge": [{"a": 1, "b": 1}, {"b": 2}]}`:                               `{"a": 1, "b": 2}`,
+		`{"$mergeDeep": [{"a": {"x": 1}, "l": [1]}, {"a": {"y": 2}, "l": [2]}]}`: `{"a": {"x": 1, "y": 2}, "l": [1, 2]}`,
+		`{"$flatten": [[1, 2], 3, [[4]]]}`:                                       `[1, 2, 3, [4]]`,
+		`{"$flattenDeep": [[1, [2]], [[[3]]]]}`:                                  `[1, 2, 3]`,
+		`{"$reverse": [1, 2, 3]}`:                                                `[3, 2, 1]`,
+		`{"$sort": [3, 1, 2]}`:                                                   `[1, 2, 3]`,
+		`{"$sort": [{"n": "b"}, {"n": "a"}], "by(x)": "x.n"}`:                    `[{"n": "a"}, {"n": "b"}]`,
+		`{"$fromNow": "1 day 2 hours", "from": "2020-01-01T00:00:00.000Z"}`:      `"2020-01-02T02:00:00.000Z"`,
+		`{"$eval": "fromNow('-1 minute', '2020-01-01T00:00:00.000Z')"}`:          `"2019-12-31T23:59:00.000Z"`,
+		`{"$fromNow": "1 year 1 mo 1 week"}`:                                     `"2020-02-07T00:00:00.000Z"`,
+	} {
+		assertRenders(t, expected, template, `{"a": 2, "now": "2019-01-01T00:00:00.000Z"}`)
+	}
+}
+
+func TestOperatorErrors(t *testing.T) {
+	for template, message := range map[string]string{
+		`{"$if": "true", "then": 1, "other": 2}`: "TemplateError at template: $if has undefined properties: other",
+		`{"$unknown": 1}`:                        "TemplateError at template: $unknown is reserved; use $$unknown",
+		`{"$eval": "1", "$json": 1}`:             "TemplateError at template: only one operator is allowed, found $eval and $json",
+		`{"$let": {"x": 1}}`:                     "TemplateError at template: $let operator requires an `in` clause",
+		`{"$let": {"a-b": 1}, "in": 1}`:          "TemplateError at template: top level keys of $let must be identifiers, not 'a-b'",
+		`{"$map": [1], "each(x": 1}`:             "TemplateError at template: invalid each(...) property 'each(x'",
+		`{"$merge": [1]}`:                        "TemplateError at template: $merge value must evaluate to an array of objects",
+		`{"$sort": [1, "a"]}`:                    "TemplateError at template: $sort requires all sorted values have the same type, either numbers or strings",
+		`{"$switch": {"true": 1, "1": 2}}`:       "TemplateError at template: $switch can only have one truthy condition, found 1, true",
+		`{"tasks": [1, {"$eval": "x"}]}`:         "InterpreterError at template.tasks[1]: unknown context value x",
+	} {
+		_, err := renderJSON(t, template, `{}`)
+		assert.EqualError(t, err, message, template)
+	}
+}
+
+func TestRenderContext(t *testing.T) {
+	defer func(now func() time.Time) { timeNow = now }(timeNow)
+	timeNow = func() time.Time { return time.Date(2020, 5, 6, 7, 8, 9, 10e6, time.FixedZone("x", 3600)) }
+
+	// now is the current time, unless the context gives it
+	assertRenders(t, `["2020-05-06T06:08:09.010Z", "2020-05-06T06:08:19.010Z"]`, `["${now}", {"$fromNow": "10s"}]`, `{}`)
+	assertRenders(t, `"then"`, `"${now}"`, `{"now": "then"}`)
+
+	// functions of the context can be called
+	result, err := Render("${greet('world')}", map[string]interface{}{
+		"greet": Function(func(args ...interface{}) (interface{}, error) { return "hello " + args[0].(string), nil }),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", result)
+
+	// the keys of the context are identifiers
+	_, err = Render(1, map[string]interface{}{"a-b": 1})
+	assert.EqualError(t, err, "TemplateError: top level keys of the context must be identifiers, not 'a-b'")
+
+	// templates which produce nothing render as nil
+	result, err = renderJSON(t, `{"$if": "false", "then": 1}`, `{}`)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+// specCase is a document of testdata/specification.yml, which is in the
+// format of the conformance suite of JSON-e: either a section heading, or a
+// case whose error is true or a message starting with the kind of error.
+type specCase struct {
+	Section  string      `yaml:"section"`
+	Title    string      `yaml:"title"`
+	Context  interface{} `yaml:"context"`
+	Template interface{} `yaml:"template"`
+	Result   interface{} `yaml:"result"`
+	Error    interface{} `yaml:"error"`
+}
+
+func TestSpecification(t *testing.T) {
+	file, err := os.Open("testdata/specification.yml")
+	assert.NoError(t, err)
+	defer file.Close()
+
+	section := ""
+	cases := 0
+	decoder := yaml.NewDecoder(file)
+	for {
+		var c specCase
+		err := decoder.Decode(&c)
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		if c.Section != "" {
+			section = c.Section
+			continue
+		}
+		cases++
+
+		t.Run(section+"/"+c.Title, func(t *testing.T) {
+			context := map[string]interface{}{}
+			if c.Context != nil {
+				var ok bool
+				context, ok = jsonValue(c.Context).(map[string]interface{})
+				assert.True(t, ok, "the context is not an object")
+			}
+			result, err := Render(jsonValue(c.Template), context)
+
+			if c.Error != nil {
+				assert.Error(t, err, "rendered %#v", result)
+				if message, ok := c.Error.(string); ok {
+					e, ok := err.(*Error)
+					assert.True(t, ok, "%s is not a JSON-e error", err)
+					assert.Equal(t, strings.SplitN(message, ":", 2)[0], e.Kind, err.Error())
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, jsonValue(c.Result), result)
+		})
+	}
+	assert.NotZero(t, cases)
+}
+
+// jsonValue converts a value decoded by yaml to the values decoded by
+// encoding/json, with objects keyed by strings and numbers as float64.
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			object[fmt.Sprint(key)] = jsonValue(item)
+		}
+		return object
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = jsonValue(item)
+		}
+		return list
+	case int:
+		return float64(v)
+	}
+	return value
+}
@@ -9,6 +9,8 @@ import (
 	_ "github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/config"
 	_ "github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/from-now"
 	_ "github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/group"
+	_ "github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/intree"
+	_ "github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/scope"
 	_ "github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/signin"
 	_ "github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/slugid"
 	_ "github.com/taskcluster/taskcluster/v31/clients/client-shell/cmds/task"